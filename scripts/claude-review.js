@@ -0,0 +1,158 @@
+#!/usr/bin/env node
+
+/**
+ * claude-review CLI
+ * 워크플로우 밖(로컬 셸, self-hosted 러너)에서 액션 도구를 실행하는 명령행 진입점
+ *
+ * 사용법:
+ *   claude-review doctor                                   # 환경 점검 체크리스트 출력
+ *   claude-review doctor --input review_type=security      # 점검할 액션 입력값 지정 (여러 번 지정 가능)
+ *   claude-review memory record <지문> --outcome disputed --reason "내부 전용 API라 입력 검증 불필요" --report report.json
+ *   claude-review memory list                              # 저장된 과거 리뷰 결정 목록
+ *   claude-review memory similar src/db.js                 # 이 파일 리뷰 시 프롬프트에 들어갈 사례 미리 보기
+ *
+ * 액션 입력값은 --input 옵션, INPUT_<이름> 환경 변수, action.yml 기본값 순으로 사용합니다.
+ * anthropic_api_key와 github_token은 ANTHROPIC_API_KEY, GITHUB_TOKEN 환경 변수도 읽습니다.
+ */
+
+const fs = require('fs');
+const { readInputDefaults } = require('./simulate');
+
+const COMMANDS = {
+  doctor: async args => {
+    const overrides = parseInputs(args);
+    const inputs = { ...readInputDefaults(), ...overrides };
+    if (process.env.ANTHROPIC_API_KEY) {
+      inputs.anthropic_api_key = inputs.anthropic_api_key || process.env.ANTHROPIC_API_KEY;
+    }
+    if (process.env.GITHUB_TOKEN) {
+      inputs.github_token = inputs.github_token || process.env.GITHUB_TOKEN;
+    }
+
+    // @actions/core는 INPUT_<이름> 환경 변수에서 입력값을 읽으므로, 이미 설정된 값은 유지
+    Object.entries(inputs).forEach(([name, value]) => {
+      const key = `INPUT_${name.replace(/ /g, '_').toUpperCase()}`;
+      if (process.env[key] === undefined || name in overrides) {
+        process.env[key] = value;
+      }
+    });
+
+    const { runDoctor } = require('../src/index');
+    const checks = await runDoctor();
+    return checks.some(check => check.status === 'fail') ? 1 : 0;
+  },
+
+  memory: async ([subcommand, ...args]) => {
+    const ReviewMemory = require('../src/review-memory');
+    const { positional, flags } = parseFlags(args);
+    const memoryPath = flags.path || ReviewMemory.DEFAULT_MEMORY_PATH;
+    const memory = await ReviewMemory.loadFile(memoryPath);
+
+    if (subcommand === 'record') {
+      const [fingerprint] = positional;
+      if (!fingerprint || !flags.outcome) {
+        throw new Error('Usage: claude-review memory record <fingerprint> --outcome <fixed|dismissed|disputed> [--reason text] [--report report.json] [--file path --title text --type type] [--by name]');
+      }
+      // JSON 리포트가 있으면 지문으로 파일, 제목, 타입, 설명을 채움
+      const finding = flags.report ? findInReport(flags.report, fingerprint) : {};
+      memory.record({
+        fingerprint,
+        file: flags.file || finding.file || '',
+        type: flags.type || finding.type || 'general',
+        title: flags.title || finding.title || '',
+        summary: finding.description || '',
+        outcome: flags.outcome,
+        reason: flags.reason || '',
+        by: flags.by || process.env.USER || ''
+      });
+      await memory.saveFile(memoryPath);
+      console.log(`Recorded ${flags.outcome} for ${fingerprint} in ${memoryPath} (commit the file to share it with the team)`);
+      return 0;
+    }
+    if (subcommand === 'list') {
+      memory.entries.forEach(entry => console.log(`${entry.fingerprint}  ${entry.outcome.padEnd(9)}  ${entry.type}: ${entry.title} (${entry.file})${entry.reason ? ` — ${entry.reason}` : ''}`));
+      console.log(`${memory.entries.length} past decision(s) in ${memoryPath}`);
+      return 0;
+    }
+    if (subcommand === 'similar') {
+      const [file] = positional;
+      if (!file) {
+        throw new Error('Usage: claude-review memory similar <file> [--limit n]');
+      }
+      const cases = memory.findSimilar(file, fs.readFileSync(file, 'utf8'), parseInt(flags.limit || '3', 10));
+      console.log(ReviewMemory.buildInstruction(cases).trim() || 'No similar past decisions');
+      return 0;
+    }
+    throw new Error('Usage: claude-review memory <record|list|similar>');
+  }
+};
+
+/**
+ * --이름 값 형식 옵션과 위치 인자 분리
+ * @param {Array<string>} args - 명령행 인자
+ * @returns {Object} { positional, flags }
+ */
+function parseFlags(args) {
+  const positional = [];
+  const flags = {};
+  for (let i = 0; i < args.length; i++) {
+    if (args[i].startsWith('--')) {
+      flags[args[i].slice(2)] = args[++i];
+    } else {
+      positional.push(args[i]);
+    }
+  }
+  return { positional, flags };
+}
+
+/**
+ * JSON 리포트에서 지문으로 이슈 찾기
+ * @param {string} reportPath - report_path 출력값의 파일
+ * @param {string} fingerprint - 이슈 지문
+ * @returns {Object} { file, type, title, description }
+ */
+function findInReport(reportPath, fingerprint) {
+  const report = JSON.parse(fs.readFileSync(reportPath, 'utf8'));
+  for (const result of report.files) {
+    const issue = result.issues.find(item => item.fingerprint === fingerprint);
+    if (issue) {
+      return { file: result.file, type: issue.type, title: issue.title, description: issue.description };
+    }
+  }
+  throw new Error(`Finding ${fingerprint} is not in ${reportPath}`);
+}
+
+/**
+ * --input key=value 옵션 파싱
+ * @param {Array<string>} args - 명령행 인자
+ * @returns {Object} { 입력 이름: 값 }
+ */
+function parseInputs(args) {
+  const inputs = {};
+  for (let i = 0; i < args.length; i += 2) {
+    const value = args[i + 1] || '';
+    const separator = value.indexOf('=');
+    if (args[i] !== '--input' || separator === -1) {
+      throw new Error(`Unknown option ${args[i]} ${value} (expected --input key=value)`);
+    }
+    inputs[value.substring(0, separator)] = value.substring(separator + 1);
+  }
+  return inputs;
+}
+
+async function main([command, ...args]) {
+  if (!COMMANDS[command]) {
+    console.log(`Usage: claude-review <command>\n\nCommands:\n  ${Object.keys(COMMANDS).join('\n  ')}`);
+    return command ? 1 : 0;
+  }
+  return COMMANDS[command](args);
+}
+
+if (require.main === module) {
+  main(process.argv.slice(2)).then(code => {
+    process.exitCode = code;
+  }, error => {
+    console.error(error.message);
+    process.exitCode = 1;
+  });
+}
@@ -0,0 +1,312 @@
+#!/usr/bin/env node
+
+/**
+ * Local Event Simulation
+ * 저장된 웹훅 이벤트 JSON과 로컬 저장소로 전체 리뷰 파이프라인을 실행하는 스크립트
+ *
+ * 테스트 저장소에 푸시하지 않고도 이벤트 처리 과정을 디버깅할 수 있습니다.
+ * GitHub 쓰기 요청(댓글, 리뷰, 파일 커밋)과 외부 알림은 실제로 보내지 않고 출력만 합니다 (dry-run).
+ *
+ * 사용법:
+ *   npm run simulate -- --event ./pr-event.json --repo ../my-service
+ *   npm run simulate -- --event ./push.json --repo . --input review_type=security --input language=ko
+ *
+ * 옵션:
+ *   --event <file>        웹훅 이벤트 JSON (필수)
+ *   --repo <dir>          리뷰할 로컬 저장소 (기본: 현재 디렉터리)
+ *   --event-name <name>   이벤트 이름 (기본: 페이로드로 추론, pull_request 또는 push)
+ *   --base <ref>          PR 비교 기준 (기본: 이벤트의 base.sha, 로컬에 없으면 HEAD~1)
+ *   --head <ref>          PR 비교 대상 (기본: 이벤트의 head.sha, 로컬에 없으면 HEAD)
+ *   --input <key=value>   액션 입력값 (여러 번 지정 가능, 나머지는 action.yml 기본값)
+ *
+ * Claude API는 ANTHROPIC_API_KEY로 실제 호출하거나,
+ * ANTHROPIC_BASE_URL을 test/mock-claude-server.js 주소로 지정해 모의 응답을 사용할 수 있습니다.
+ */
+
+const fs = require('fs');
+const os = require('os');
+const http = require('http');
+const path = require('path');
+const { execFileSync } = require('child_process');
+
+const ACTION_YML = path.join(__dirname, '..', 'action.yml');
+
+// git --name-status 코드 → GitHub API 파일 상태
+const FILE_STATUS = { A: 'added', M: 'modified', D: 'removed', R: 'renamed', C: 'copied', T: 'changed' };
+
+/**
+ * 명령행 옵션 파싱
+ * @param {Array<string>} args - 명령행 인자
+ * @returns {Object} 옵션
+ */
+function parseArgs(args) {
+  const options = { event: null, repo: process.cwd(), eventName: null, base: null, head: null, inputs: {} };
+  for (let i = 0; i < args.length; i += 2) {
+    const value = args[i + 1];
+    switch (args[i]) {
+      case '--event': options.event = path.resolve(value); break;
+      case '--repo': options.repo = path.resolve(value); break;
+      case '--event-name': options.eventName = value; break;
+      case '--base': options.base = value; break;
+      case '--head': options.head = value; break;
+      case '--input': {
+        const separator = value.indexOf('=');
+        if (separator === -1) {
+          throw new Error(`Invalid --input "${value}" (expected key=value)`);
+        }
+        options.inputs[value.substring(0, separator)] = value.substring(separator + 1);
+        break;
+      }
+      default:
+        throw new Error(`Unknown option ${args[i]}`);
+    }
+  }
+  if (!options.event) {
+    throw new Error('--event <file> is required');
+  }
+  return options;
+}
+
+/**
+ * action.yml의 입력 기본값 읽기 (러너 밖에서는 기본값이 자동으로 채워지지 않음)
+ * @returns {Object} { 입력 이름: 기본값 }
+ */
+function readInputDefaults() {
+  const defaults = {};
+  let inInputs = false;
+  let current = null;
+
+  fs.readFileSync(ACTION_YML, 'utf8').split('\n').forEach(line => {
+    if (/^\S/.test(line)) {
+      inInputs = line.startsWith('inputs:');
+      return;
+    }
+    if (!inInputs) {
+      return;
+    }
+    const key = line.match(/^ {2}(\w+):\s*$/);
+    if (key) {
+      current = key[1];
+      return;
+    }
+    const value = line.match(/^ {4}default:\s*(.*?)\s*(#.*)?$/);
+    if (current && value && !value[1].includes('${{')) {
+      defaults[current] = value[1].replace(/^(['"])(.*)\1$/, '$2');
+    }
+  });
+  return defaults;
+}
+
+/**
+ * 로컬 저장소에서 git 명령 실행
+ * @param {string} repo - 저장소 경로
+ * @param {Array<string>} args - git 인자
+ * @returns {string} 출력
+ */
+function git(repo, args) {
+  return execFileSync('git', args, { cwd: repo, encoding: 'utf8', maxBuffer: 64 * 1024 * 1024, stdio: ['ignore', 'pipe', 'pipe'] });
+}
+
+/**
+ * 로컬에 존재하는 커밋이면 그대로, 아니면 대체 ref 사용
+ * @param {string} repo - 저장소 경로
+ * @param {string|undefined} ref - 이벤트의 SHA
+ * @param {string} fallback - 대체 ref
+ * @returns {string} ref
+ */
+function resolveRef(repo, ref, fallback) {
+  if (ref) {
+    try {
+      git(repo, ['cat-file', '-e', `${ref}^{commit}`]);
+      return ref;
+    } catch (error) {
+      console.log(`⚠️  ${ref.substring(0, 12)} is not in the local repo, comparing ${fallback} instead`);
+    }
+  }
+  return fallback;
+}
+
+/**
+ * 로컬 diff로 pulls.listFiles 응답 구성
+ * @param {string} repo - 저장소 경로
+ * @param {string} base - 기준 ref
+ * @param {string} head - 대상 ref
+ * @returns {Array} 파일 목록
+ */
+function listPullFiles(repo, base, head) {
+  const counts = new Map();
+  git(repo, ['diff', '--numstat', '-M', base, head]).split('\n').filter(Boolean).forEach(line => {
+    const [additions, deletions, ...names] = line.split('\t');
+    counts.set(names[names.length - 1], { additions: Number(additions) || 0, deletions: Number(deletions) || 0 });
+  });
+
+  return git(repo, ['diff', '--name-status', '-M', base, head]).split('\n').filter(Boolean).map(line => {
+    const [status, ...names] = line.split('\t');
+    const filename = names[names.length - 1];
+    const count = counts.get(filename) || { additions: 0, deletions: 0 };
+    return {
+      filename,
+      status: FILE_STATUS[status.charAt(0)] || 'modified',
+      previous_filename: names.length > 1 ? names[0] : undefined,
+      additions: count.additions,
+      deletions: count.deletions,
+      changes: count.additions + count.deletions,
+      // diff 헤더(diff --git, index, ---, +++)를 제외한 첫 hunk부터가 API의 patch 형식
+      patch: git(repo, ['diff', base, head, '--', filename]).replace(/^[\s\S]*?(?=^@@)/m, '')
+    };
+  });
+}
+
+class DryRunGitHub {
+  /**
+   * DryRunGitHub 생성자
+   * @param {Function} pullFiles - PR 파일 목록을 반환하는 함수
+   */
+  constructor(pullFiles) {
+    this.pullFiles = pullFiles;
+    this.writes = [];
+  }
+
+  /**
+   * 요청 처리: 읽기는 로컬 데이터로 응답하고 쓰기는 기록만 함
+   * @param {http.IncomingMessage} req - 요청
+   * @param {http.ServerResponse} res - 응답
+   */
+  async handle(req, res) {
+    const chunks = [];
+    for await (const chunk of req) {
+      chunks.push(chunk);
+    }
+    const body = chunks.length > 0 ? JSON.parse(Buffer.concat(chunks).toString('utf8')) : undefined;
+    const url = new URL(req.url, 'http://localhost');
+    const send = (status, data) => {
+      res.writeHead(status, { 'content-type': 'application/json; charset=utf-8' });
+      res.end(JSON.stringify(data));
+    };
+
+    if (req.method === 'GET') {
+      if (/\/pulls\/\d+\/files$/.test(url.pathname)) {
+        // 로컬 diff는 한 번에 모두 반환 (페이지 2 이후는 빈 목록)
+        return send(200, (url.searchParams.get('page') || '1') === '1' ? this.pullFiles() : []);
+      }
+      if (/\/issues\/\d+\/comments$/.test(url.pathname)) {
+        return send(200, []);
+      }
+      return send(404, { message: 'Not Found (dry-run)' });
+    }
+
+    this.writes.push({ method: req.method, path: url.pathname, body });
+    console.log(`\n🧪 [dry-run] ${req.method} ${url.pathname}`);
+    console.log(body && typeof body.body === 'string' ? body.body : JSON.stringify(body, null, 2));
+
+    const id = this.writes.length;
+    send(req.method === 'POST' || req.method === 'PUT' ? 201 : 200, {
+      id,
+      number: id,
+      html_url: `https://github.com/dry-run/${id}`,
+      body: body && body.body,
+      content: { sha: `dry-run-${id}` }
+    });
+  }
+
+  /**
+   * 서버 시작
+   * @returns {Promise<string>} 기본 URL
+   */
+  listen() {
+    this.server = http.createServer((req, res) => {
+      this.handle(req, res).catch(error => {
+        res.writeHead(500).end(JSON.stringify({ message: error.message }));
+      });
+    });
+    return new Promise(resolve => {
+      this.server.listen(0, '127.0.0.1', () => resolve(`http://127.0.0.1:${this.server.address().port}`));
+    });
+  }
+}
+
+/**
+ * 외부 알림(웹훅, Jira, Linear, 텔레메트리, 이메일) 전송을 출력으로 대체
+ * Claude SDK와 octokit은 전역 fetch를 사용하지 않으므로 영향받지 않음
+ * @param {Array} writes - 기록할 목록
+ */
+function interceptNotifications(writes) {
+  const realFetch = globalThis.fetch;
+  globalThis.fetch = async (url, init = {}) => {
+    if (String(url).startsWith('http://127.0.0.1')) {
+      return realFetch(url, init);
+    }
+    writes.push({ method: init.method || 'GET', path: String(url), body: init.body });
+    console.log(`\n🧪 [dry-run] ${init.method || 'GET'} ${url}\n${init.body || ''}`);
+    return new Response(JSON.stringify({ ok: true, id: 'dry-run', key: 'DRY-1' }), { status: 200, headers: { 'content-type': 'application/json' } });
+  };
+
+  const SmtpClient = require('../src/smtp-client');
+  SmtpClient.prototype.send = async function (message) {
+    writes.push({ method: 'SMTP', path: [].concat(message.to).join(', '), body: message.subject });
+    console.log(`\n🧪 [dry-run] email to ${[].concat(message.to).join(', ')}: ${message.subject}`);
+  };
+}
+
+async function main(args) {
+  const options = parseArgs(args);
+  const payload = JSON.parse(fs.readFileSync(options.event, 'utf8'));
+  const eventName = options.eventName || (payload.pull_request ? 'pull_request' : payload.commits ? 'push' : null);
+  if (!eventName) {
+    throw new Error('Cannot infer the event name from the payload; pass --event-name');
+  }
+
+  const pr = payload.pull_request || {};
+  const base = options.base || resolveRef(options.repo, pr.base && pr.base.sha, 'HEAD~1');
+  const head = options.head || resolveRef(options.repo, pr.head && pr.head.sha, 'HEAD');
+  const github = new DryRunGitHub(() => listPullFiles(options.repo, base, head));
+  const tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'claude-review-simulate-'));
+
+  // @actions/github 컨텍스트와 @actions/core 입력은 환경 변수에서 읽으므로 모듈 로드 전에 설정
+  Object.assign(process.env, {
+    GITHUB_API_URL: await github.listen(),
+    GITHUB_EVENT_PATH: options.event,
+    GITHUB_EVENT_NAME: eventName,
+    GITHUB_REPOSITORY: payload.repository ? payload.repository.full_name : 'local/simulate',
+    GITHUB_SHA: (pr.head && pr.head.sha) || payload.after || 'simulate',
+    GITHUB_RUN_ID: 'simulate',
+    GITHUB_OUTPUT: path.join(tempDir, 'outputs'),
+    GITHUB_STEP_SUMMARY: path.join(tempDir, 'step-summary.md'),
+    RUNNER_TEMP: tempDir
+  });
+  fs.writeFileSync(process.env.GITHUB_OUTPUT, '');
+  fs.writeFileSync(process.env.GITHUB_STEP_SUMMARY, '');
+
+  const inputs = {
+    ...readInputDefaults(),
+    github_token: 'simulate-token',
+    anthropic_api_key: process.env.ANTHROPIC_API_KEY || '',
+    ...options.inputs
+  };
+  Object.entries(inputs).forEach(([name, value]) => {
+    process.env[`INPUT_${name.replace(/ /g, '_').toUpperCase()}`] = value;
+  });
+
+  console.log(`▶️  Simulating ${eventName} in ${options.repo}${eventName === 'pull_request' ? ` (${base}..${head})` : ''}`);
+  interceptNotifications(github.writes);
+  process.chdir(options.repo);
+
+  const run = require('../src/index');
+  await run();
+  github.server.close();
+
+  console.log(`\n✅ Simulation finished: ${github.writes.length} write(s) captured, nothing was published`);
+  console.log(`   Outputs and reports: ${tempDir}`);
+  return process.exitCode || 0;
+}
+
+if (require.main === module) {
+  main(process.argv.slice(2)).then(code => {
+    process.exitCode = code;
+  }, error => {
+    console.error(error.message);
+    process.exitCode = 1;
+  });
+}
+
+module.exports = { readInputDefaults, listPullFiles };
@@ -0,0 +1,223 @@
+/**
+ * Architecture Reviewer Module
+ * 저장소 지도(RepoMap)를 근거로 변경이 기존 패키지 경계에 맞는지 검토하는 아키텍처 리뷰 패스
+ *
+ * - 규칙 검사: diff에서 새로 추가된 import가 의존성 순환을 만들거나 architecture_layers의 계층 규칙을 어기는지 확인
+ * - 모델 검토: 저장소 지도와 diff를 함께 보내 책임 배치, 경계 침범 같은 구조 문제를 판단
+ * 파일별 리뷰와 같은 결과 형식({ file, issues })으로 반환해 요약 댓글에 함께 표시합니다.
+ */
+
+const path = require('path');
+const { minimatch } = require('minimatch');
+const { ConfigError } = require('./errors');
+const RepoMap = require('./repo-map');
+
+// 이슈에 표시할 보고자 이름
+const REPORTER = 'Architecture Review';
+// 프롬프트에 넣을 diff 전체 최대 길이
+const MAX_DIFF_CHARS = 8000;
+
+const ARCHITECTURE_PROMPT = `당신은 소프트웨어 아키텍트입니다. 저장소 지도(패키지, 주요 타입, 패키지 간 의존성)를 근거로 다음 변경이 기존 아키텍처 경계에 맞는지 리뷰해주세요.
+
+리뷰 관점:
+- 새 코드가 책임에 맞는 패키지에 놓였는지
+- 패키지 경계를 넘어 내부 구현에 직접 의존하는지
+- 계층 규칙 위반과 의존성 방향 역전
+- 기존 추상화를 우회하는 중복 구현
+- 파일 단위 코드 품질 문제는 다른 리뷰어가 담당하므로 보고하지 마세요`;
+
+class ArchitectureReviewer {
+  /**
+   * ArchitectureReviewer 생성자
+   * @param {Object} options - 옵션
+   * @param {CodeReviewer} options.codeReviewer - Claude API 호출용 리뷰어
+   * @param {Array} [options.layers] - 계층 규칙 (parseLayers() 결과, 위 계층부터)
+   */
+  constructor({ codeReviewer, layers = [] }) {
+    this.codeReviewer = codeReviewer;
+    this.layers = layers;
+  }
+
+  /**
+   * architecture_layers 입력값 파싱 ("<계층>: <glob>, <glob>" 줄 목록, 위 계층부터)
+   * 위 계층은 아래 계층에 의존할 수 있지만 아래 계층이 위 계층에 의존하면 위반입니다.
+   * @param {string} text - 입력값
+   * @returns {Array} [{ name, patterns }]
+   */
+  static parseLayers(text) {
+    return (text || '').split('\n').map(line => line.trim()).filter(Boolean).map(line => {
+      const separator = line.indexOf(':');
+      const name = separator === -1 ? '' : line.substring(0, separator).trim();
+      const patterns = line.substring(separator + 1).split(',').map(pattern => pattern.trim()).filter(Boolean);
+      if (!name || patterns.length === 0) {
+        throw new ConfigError(`Invalid architecture_layers entry (expected "<layer>: <glob>, <glob>"): ${line}`);
+      }
+      return { name, patterns };
+    });
+  }
+
+  /**
+   * 파일 또는 디렉터리가 속한 계층 순서
+   * @param {string} filename - 파일 경로
+   * @returns {number} 계층 순서 (없으면 -1)
+   */
+  layerOf(filename) {
+    return this.layers.findIndex(layer => layer.patterns.some(pattern => minimatch(filename, pattern, { dot: true })));
+  }
+
+  /**
+   * diff에서 추가된 import가 만드는 순환 의존성과 계층 위반 검사
+   * @param {RepoMap} map - 저장소 지도
+   * @param {Array} files - [{ filename, diff }]
+   * @returns {Array} 이슈 목록 (file 포함)
+   */
+  checkBoundaries(map, files) {
+    const issues = [];
+    files.forEach(({ filename, diff }) => {
+      const source = path.posix.dirname(filename);
+      addedLines(diff).forEach(({ line, text }) => {
+        RepoMap.parseImports(filename, text, map.index)
+          .filter(target => target !== source)
+          .forEach(target => {
+            const cycle = map.findPath(target, source);
+            if (cycle) {
+              issues.push({
+                file: filename,
+                line,
+                severity: 'high',
+                title: 'New dependency cycle',
+                description: `${source} -> ${cycle.join(' -> ')} 순환 의존성이 생깁니다.`,
+                suggestion: '공통 부분을 아래 패키지로 옮기거나 인터페이스로 의존 방향을 뒤집으세요.'
+              });
+            }
+
+            const sourceLayer = this.layerOf(filename);
+            const targetLayer = this.layerOf(`${target}/_`);
+            if (sourceLayer !== -1 && targetLayer !== -1 && sourceLayer > targetLayer) {
+              issues.push({
+                file: filename,
+                line,
+                severity: 'medium',
+                title: 'Layering violation',
+                description: `${this.layers[sourceLayer].name} 계층(${source})이 위 계층인 ${this.layers[targetLayer].name}(${target})에 의존합니다.`,
+                suggestion: `${this.layers[targetLayer].name} 계층에 대한 의존을 제거하거나 ${this.layers[sourceLayer].name} 계층에 인터페이스를 두세요.`
+              });
+            }
+          });
+      });
+    });
+    return issues;
+  }
+
+  /**
+   * 아키텍처 리뷰 실행
+   * @param {RepoMap} map - 저장소 지도
+   * @param {Array} files - [{ filename, diff }]
+   * @returns {Promise<Array>} 파일별 결과 [{ file, issues, summary }]
+   */
+  async review(map, files) {
+    const ruleIssues = this.checkBoundaries(map, files);
+    const responseText = await this.codeReviewer.sendMessage('architecture', this.buildPrompt(map, files, ruleIssues), this.codeReviewer.model);
+    const modelIssues = ArchitectureReviewer.parseResponse(responseText, files.map(file => file.filename));
+
+    const byFile = new Map();
+    [...ruleIssues, ...modelIssues].forEach(({ file, ...issue }) => {
+      if (!byFile.has(file)) {
+        byFile.set(file, []);
+      }
+      byFile.get(file).push({
+        codeExample: null,
+        why: '',
+        reference: null,
+        ...issue,
+        type: 'maintainability',
+        persona: REPORTER
+      });
+    });
+    return [...byFile.entries()].map(([file, issues]) => ({ file, issues, summary: '' }));
+  }
+
+  /**
+   * 아키텍처 리뷰 프롬프트 생성
+   * @param {RepoMap} map - 저장소 지도
+   * @param {Array} files - [{ filename, diff }]
+   * @param {Array} ruleIssues - 규칙 검사로 이미 찾은 이슈
+   * @returns {string} 프롬프트
+   */
+  buildPrompt(map, files, ruleIssues) {
+    let budget = MAX_DIFF_CHARS;
+    const diffs = files.map(({ filename, diff }) => {
+      const excerpt = budget > 0 ? diff.substring(0, budget) : '';
+      budget -= excerpt.length;
+      return `### ${filename}${excerpt ? `\n\`\`\`diff\n${excerpt}\n\`\`\`` : ''}`;
+    });
+    const layers = this.layers.length > 0
+      ? `\n계층 규칙 (위 계층부터, 아래 계층은 위 계층에 의존할 수 없음):\n${this.layers.map(layer => `- ${layer.name}: ${layer.patterns.join(', ')}`).join('\n')}\n`
+      : '';
+    const known = ruleIssues.length > 0
+      ? `\n이미 보고된 이슈 (다시 보고하지 마세요):\n${ruleIssues.map(issue => `- ${issue.file}:${issue.line} ${issue.title}: ${issue.description}`).join('\n')}\n`
+      : '';
+
+    return `${ARCHITECTURE_PROMPT} ${this.codeReviewer.getLanguageInstruction()}
+
+저장소 지도 (디렉터리 (파일 수) types: 주요 타입 -> 의존하는 디렉터리):
+${map.format()}${layers}
+변경사항:
+${diffs.join('\n\n')}
+${known}
+**중요**: 완전한 JSON만 반환하세요. 구조적인 문제가 없으면 issues는 빈 배열로 두세요. 최대 ${this.codeReviewer.maxIssuesPerFile}개 이슈만 포함하세요.
+
+형식:
+{"issues":[{"file":"변경된 파일 경로","line":숫자,"severity":"low/medium/high/critical","title":"제목(20자)","description":"설명(80자)","suggestion":"제안(80자)"}]}`;
+  }
+
+  /**
+   * 응답 JSON 파싱 (변경된 파일의 이슈만 사용)
+   * @param {string} responseText - 응답 텍스트
+   * @param {Array<string>} filenames - 변경된 파일 경로
+   * @returns {Array} 이슈 목록 (file 포함)
+   */
+  static parseResponse(responseText, filenames) {
+    const firstBrace = responseText.indexOf('{');
+    const lastBrace = responseText.lastIndexOf('}');
+    if (firstBrace === -1 || lastBrace <= firstBrace) {
+      throw new Error('No JSON structure found in architecture review response');
+    }
+    const parsed = JSON.parse(responseText.substring(firstBrace, lastBrace + 1));
+    const text = (value, fallback) => (typeof value === 'string' && value ? value : fallback);
+    return (Array.isArray(parsed.issues) ? parsed.issues : [])
+      .filter(issue => issue && filenames.includes(issue.file))
+      .map(issue => ({
+        file: issue.file,
+        line: Number.isInteger(issue.line) && issue.line > 0 ? issue.line : null,
+        severity: ['low', 'medium', 'high', 'critical'].includes(issue.severity) ? issue.severity : 'medium',
+        title: text(issue.title, 'Architecture issue'),
+        description: text(issue.description, ''),
+        suggestion: text(issue.suggestion, '')
+      }));
+  }
+}
+
+/**
+ * unified diff에서 추가된 줄과 새 파일 기준 줄 번호 추출
+ * @param {string} diff - unified diff
+ * @returns {Array} [{ line, text }]
+ */
+function addedLines(diff) {
+  const lines = [];
+  let lineNumber = 0;
+  (diff || '').split('\n').forEach(raw => {
+    const hunk = raw.match(/^@@ -\d+(?:,\d+)? \+(\d+)/);
+    if (hunk) {
+      lineNumber = parseInt(hunk[1], 10);
+    } else if (raw.startsWith('+') && !raw.startsWith('+++')) {
+      lines.push({ line: lineNumber, text: raw.substring(1) });
+      lineNumber++;
+    } else if (!raw.startsWith('-') && !raw.startsWith('\\')) {
+      lineNumber++;
+    }
+  });
+  return lines;
+}
+
+module.exports = ArchitectureReviewer;
@@ -0,0 +1,231 @@
+/**
+ * Audit Digest Module
+ * 저장소 감사 결과를 지난 감사의 기준선(baseline)과 비교해 하나의 다이제스트 이슈로 게시하는 모듈
+ *
+ * 기준선은 다이제스트 이슈 본문의 숨김 주석에 저장하므로 별도 저장소 파일이나 쓰기 권한이 필요 없습니다.
+ * - 새 이슈: 이번 감사에서 처음 발견
+ * - 해결됨: 기준선에 있었지만, 이번에 다시 감사한 파일에서 더 이상 발견되지 않음
+ * - 지속: 기준선과 이번 감사 모두에 있음 (이번에 감사하지 못한 파일의 기준선 이슈 포함)
+ */
+
+const DisplayLabels = require('./display-labels');
+const { getSeverityLevel } = require('./review-summary');
+
+const DIGEST_MARKER = '<!-- claude-review:audit-digest -->';
+const BASELINE_PATTERN = /<!-- claude-review:audit-baseline ([A-Za-z0-9+/=]+) -->/;
+const CURSOR_PATTERN = /<!-- claude-review:audit-cursor ([A-Za-z0-9+/=]+) -->/;
+
+// 이슈 본문 최대 길이(65536자) 안에 기준선을 담기 위한 상한
+const MAX_BASELINE_CHARS = 40000;
+// 섹션마다 표시할 최대 이슈 수
+const MAX_LISTED = 50;
+
+class AuditDigest {
+  /**
+   * AuditDigest 생성자
+   * @param {Object} octokit - GitHub API 클라이언트
+   * @param {Object} repo - { owner, repo }
+   * @param {Object} [options] - 옵션
+   * @param {string} [options.label] - 다이제스트 이슈 라벨
+   * @param {DisplayLabels} [options.labels] - 심각도 아이콘과 표시 이름
+   */
+  constructor(octokit, repo, options = {}) {
+    this.octokit = octokit;
+    this.repo = repo;
+    this.label = options.label || 'claude-audit';
+    this.labels = options.labels || new DisplayLabels();
+  }
+
+  /**
+   * 열려 있는 다이제스트 이슈 조회
+   * @returns {Promise<Object|null>} 이슈
+   */
+  async findIssue() {
+    const issues = await this.octokit.paginate(this.octokit.rest.issues.listForRepo, {
+      ...this.repo,
+      state: 'open',
+      labels: this.label,
+      per_page: 100
+    });
+    return issues.find(issue => !issue.pull_request && (issue.body || '').includes(DIGEST_MARKER)) || null;
+  }
+
+  /**
+   * 이슈 본문에서 기준선 추출
+   * @param {string} body - 이슈 본문
+   * @returns {Array} 기준선 항목 { fingerprint, file, line, severity, title }
+   */
+  static parseBaseline(body) {
+    const match = (body || '').match(BASELINE_PATTERN);
+    if (!match) {
+      return [];
+    }
+    try {
+      const entries = JSON.parse(Buffer.from(match[1], 'base64').toString('utf8'));
+      return entries.map(([fingerprint, file, line, severity, title]) => ({ fingerprint, file, line, severity, title }));
+    } catch (error) {
+      return [];
+    }
+  }
+
+  /**
+   * 이슈 본문에서 지난 감사의 커서 추출
+   * @param {string} body - 이슈 본문
+   * @returns {string|null} 마지막으로 감사한 파일 경로 (없으면 처음부터)
+   */
+  static parseCursor(body) {
+    const match = (body || '').match(CURSOR_PATTERN);
+    return match ? Buffer.from(match[1], 'base64').toString('utf8') || null : null;
+  }
+
+  /**
+   * 이번 감사 결과와 기준선 비교
+   * @param {Array} baseline - 기준선 항목
+   * @param {Array} results - 파일별 감사 결과
+   * @param {Array<string>} auditedFiles - 이번에 감사한 파일
+   * @returns {Object} { added, fixed, persisting } (각각 기준선 항목 형식)
+   */
+  static compare(baseline, results, auditedFiles) {
+    const current = [];
+    results.forEach(result => {
+      result.issues.forEach(issue => {
+        current.push({ fingerprint: issue.fingerprint, file: result.file, line: issue.line, severity: issue.severity, title: issue.title });
+      });
+    });
+
+    const audited = new Set(auditedFiles);
+    const currentByFingerprint = new Map(current.map(entry => [entry.fingerprint, entry]));
+    const baselineFingerprints = new Set(baseline.map(entry => entry.fingerprint));
+
+    const added = current.filter(entry => !baselineFingerprints.has(entry.fingerprint));
+    const fixed = baseline.filter(entry => audited.has(entry.file) && !currentByFingerprint.has(entry.fingerprint));
+    const persisting = [
+      ...baseline.filter(entry => currentByFingerprint.has(entry.fingerprint)).map(entry => currentByFingerprint.get(entry.fingerprint)),
+      // 예산 부족으로 감사하지 못한 파일의 이슈는 판단을 미루고 유지
+      ...baseline.filter(entry => !audited.has(entry.file) && !currentByFingerprint.has(entry.fingerprint))
+    ];
+
+    return { added: sortBySeverity(added), fixed: sortBySeverity(fixed), persisting: sortBySeverity(persisting) };
+  }
+
+  /**
+   * 다이제스트 이슈 본문 생성
+   * @param {Object} comparison - compare() 결과
+   * @param {Object} run - { auditedFiles, skippedFiles, totalFiles, cursor, runUrl, date }
+   * @returns {string} 이슈 본문
+   */
+  buildBody(comparison, run) {
+    const { added, fixed, persisting } = comparison;
+    const open = [...added, ...persisting];
+    const lines = [
+      DIGEST_MARKER,
+      '## 🛡️ Claude AI 보안 감사 다이제스트',
+      '',
+      `**마지막 감사:** ${run.date}${run.runUrl ? ` ([실행 로그](${run.runUrl}))` : ''}`,
+      `**감사한 파일:** ${run.auditedFiles.length}개${run.totalFiles ? ` / 전체 ${run.totalFiles}개` : ''}${run.skippedFiles.length > 0 ? ` (토큰 예산 초과로 ${run.skippedFiles.length}개는 다음 감사로 미룸)` : ''}`,
+      ...(run.cursor ? [`**다음 감사:** \`${run.cursor}\` 다음 파일부터 이어서 감사`] : []),
+      `**열린 이슈:** ${open.length}개 (🆕 새 이슈 ${added.length}개 · ⏳ 지속 ${persisting.length}개 · ✅ 해결됨 ${fixed.length}개)`,
+      ''
+    ];
+
+    lines.push(...this.buildSection('🆕 새 이슈', added));
+    lines.push(...this.buildSection('⏳ 지속 중인 이슈', persisting));
+    lines.push(...this.buildSection('✅ 지난 감사 이후 해결됨', fixed));
+
+    if (open.length === 0) {
+      lines.push('열린 보안 이슈가 없습니다. 👏', '');
+    }
+
+    lines.push('---', '*이 이슈는 예약된 감사가 실행될 때마다 자동으로 갱신됩니다. 본문을 직접 수정하지 마세요.*');
+    lines.push(this.buildBaselineMarker(open));
+    if (run.cursor) {
+      lines.push(`<!-- claude-review:audit-cursor ${Buffer.from(run.cursor).toString('base64')} -->`);
+    }
+    return lines.join('\n');
+  }
+
+  /**
+   * 이슈 목록 섹션 생성
+   * @param {string} title - 섹션 제목
+   * @param {Array} entries - 항목 목록
+   * @returns {Array<string>} 본문 줄
+   */
+  buildSection(title, entries) {
+    if (entries.length === 0) {
+      return [];
+    }
+    const lines = [`### ${title} (${entries.length})`, ''];
+    entries.slice(0, MAX_LISTED).forEach(entry => {
+      const icon = this.labels.severityIcon(entry.severity);
+      const location = entry.line ? `${entry.file}:${entry.line}` : entry.file;
+      lines.push(`- ${this.labels.withIcon(icon, `**${this.labels.severityLabel(entry.severity)}**`)} ${entry.title} — \`${location}\``);
+    });
+    if (entries.length > MAX_LISTED) {
+      lines.push(`- …외 ${entries.length - MAX_LISTED}개`);
+    }
+    lines.push('');
+    return lines;
+  }
+
+  /**
+   * 다음 감사에서 사용할 기준선 숨김 주석 생성
+   * 본문 길이 제한을 넘으면 심각도가 낮은 항목부터 제외합니다.
+   * @param {Array} entries - 열린 이슈 (심각도 순 정렬)
+   * @returns {string} 숨김 주석
+   */
+  buildBaselineMarker(entries) {
+    let compact = entries.map(entry => [entry.fingerprint, entry.file, entry.line || null, entry.severity, String(entry.title).substring(0, 80)]);
+    let encoded = Buffer.from(JSON.stringify(compact)).toString('base64');
+    while (encoded.length > MAX_BASELINE_CHARS && compact.length > 0) {
+      compact = compact.slice(0, Math.floor(compact.length * 0.9));
+      encoded = Buffer.from(JSON.stringify(compact)).toString('base64');
+    }
+    return `<!-- claude-review:audit-baseline ${encoded} -->`;
+  }
+
+  /**
+   * 다이제스트 이슈 생성 또는 갱신
+   * 열린 이슈가 없으면 기존 이슈를 닫고, 처음부터 이슈가 없으면 새로 만들지 않습니다.
+   * @param {Object|null} issue - findIssue() 결과
+   * @param {string} body - 이슈 본문
+   * @param {number} openCount - 열린 이슈 수
+   * @returns {Promise<Object|null>} { number, url, action: created|updated|closed }
+   */
+  async publish(issue, body, openCount) {
+    if (!issue) {
+      if (openCount === 0) {
+        return null;
+      }
+      const { data } = await this.octokit.rest.issues.create({
+        ...this.repo,
+        title: `🛡️ Security audit: ${openCount} open finding${openCount === 1 ? '' : 's'}`,
+        body,
+        labels: [this.label]
+      });
+      return { number: data.number, url: data.html_url, action: 'created' };
+    }
+
+    const { data } = await this.octokit.rest.issues.update({
+      ...this.repo,
+      issue_number: issue.number,
+      title: `🛡️ Security audit: ${openCount} open finding${openCount === 1 ? '' : 's'}`,
+      body,
+      state: openCount === 0 ? 'closed' : 'open'
+    });
+    return { number: data.number, url: data.html_url, action: openCount === 0 ? 'closed' : 'updated' };
+  }
+}
+
+/**
+ * 심각도 높은 순, 같은 심각도는 파일 경로 순으로 정렬
+ * @param {Array} entries - 항목 목록
+ * @returns {Array} 정렬된 목록
+ */
+function sortBySeverity(entries) {
+  return [...entries].sort((a, b) =>
+    getSeverityLevel(b.severity) - getSeverityLevel(a.severity) || a.file.localeCompare(b.file) || (a.line || 0) - (b.line || 0)
+  );
+}
+
+module.exports = AuditDigest;
+module.exports.DIGEST_MARKER = DIGEST_MARKER;
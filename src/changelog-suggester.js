@@ -0,0 +1,273 @@
+/**
+ * Changelog Suggester Module
+ * PR diff에 사용자가 알아야 하는 변경이 있는데 변경 로그(CHANGELOG)에 기록하지 않았으면
+ * Keep a Changelog 형식의 항목과 넣을 위치를 제안하는 모듈
+ *
+ * - 테스트, 문서, CI 설정만 바뀐 PR과 변경 로그에 새 항목을 이미 추가한 PR은 API를 호출하지 않고 건너뜀
+ * - 사용자에게 영향이 있는지와 분류(Added, Changed, Fixed 등)는 Claude가 판단
+ * - 항목은 `## [Unreleased]` 아래 같은 분류 제목에 넣는 패치로 요약 댓글에 표시
+ * - GitHub 제안 블록은 PR diff 안의 줄에만 달 수 있으므로, 인라인 제안은 변경 로그가 PR에서 수정되었고
+ *   넣을 위치가 diff 안에 있을 때만 작성
+ */
+
+const fs = require('fs');
+const { commentableLines } = require('./comment-manager');
+
+const SUGGESTION_MARKER = '<!-- claude-review:changelog -->';
+
+// 경로를 지정하지 않았을 때 찾는 변경 로그 파일 (저장소 루트)
+const DEFAULT_PATHS = ['CHANGELOG.md', 'CHANGES.md', 'HISTORY.md', 'CHANGELOG'];
+
+// Keep a Changelog 분류
+const CATEGORIES = ['Added', 'Changed', 'Deprecated', 'Removed', 'Fixed', 'Security'];
+
+// 사용자에게 영향이 없는 파일 (테스트, 문서, CI/저장소 설정)
+const NON_USER_FACING_PATTERNS = [
+  /(^|\/)(test|tests|__tests__|spec|specs|testdata|fixtures)\//,
+  /[._-](test|spec)\.[^/]+$/,
+  /_test\.go$/,
+  /\.(md|mdx|rst|adoc|txt)$/i,
+  /^\.github\//,
+  /(^|\/)\.(gitignore|gitattributes|editorconfig|eslintrc[^/]*|prettierrc[^/]*)$/
+];
+
+// 프롬프트에 넣을 diff 전체 최대 길이와 파일 수
+const MAX_DIFF_CHARS = 8000;
+const MAX_FILES = 50;
+
+const CHANGELOG_PROMPT = `당신은 릴리즈 매니저입니다. 다음 Pull Request의 변경사항이 변경 로그(CHANGELOG)에 기록해야 하는 사용자 영향 변경인지 판단하고, 그렇다면 항목을 작성해주세요.
+
+작성 규칙:
+- user_facing: 라이브러리/앱 사용자가 알아야 하는 변경(새 기능, 동작/기본값 변경, 버그 수정, 제거, 보안 수정)이면 true, 리팩터링, 내부 구현, 테스트, 빌드 설정만 바뀌었으면 false
+- category: ${CATEGORIES.join(', ')} 중 하나
+- entry: 사용자 관점의 한 문장 (커밋 메시지나 파일 이름을 나열하지 말 것, 목록 기호 없이)
+- diff에 없는 내용은 추측하지 마세요`;
+
+class ChangelogSuggester {
+  /**
+   * ChangelogSuggester 생성자
+   * @param {Object} options - 옵션
+   * @param {CodeReviewer} options.codeReviewer - Claude API 호출용 리뷰어
+   * @param {Object} options.octokit - GitHub API 클라이언트
+   * @param {Object} options.context - GitHub Actions 컨텍스트 (pull_request 이벤트)
+   * @param {string} [options.changelogPath] - 변경 로그 파일 경로 (없으면 DEFAULT_PATHS에서 찾음)
+   */
+  constructor({ codeReviewer, octokit, context, changelogPath }) {
+    this.codeReviewer = codeReviewer;
+    this.octokit = octokit;
+    this.context = context;
+    this.changelogPath = changelogPath;
+  }
+
+  /**
+   * 변경 로그 파일 경로 찾기
+   * @returns {string|null} 경로 (지정한 경로가 없거나 기본 파일이 없으면 null)
+   */
+  locate() {
+    const candidates = this.changelogPath ? [this.changelogPath] : DEFAULT_PATHS;
+    return candidates.find(candidate => fs.existsSync(candidate)) || null;
+  }
+
+  /**
+   * 사용자에게 영향이 있을 수 있는 파일인지 확인
+   * @param {string} filename - 파일 경로
+   * @returns {boolean} 테스트, 문서, CI 설정이 아니면 true
+   */
+  static isUserFacing(filename) {
+    return !NON_USER_FACING_PATTERNS.some(pattern => pattern.test(filename));
+  }
+
+  /**
+   * PR이 변경 로그에 새 항목을 이미 추가했는지 확인
+   * @param {Object|undefined} changelogFile - PR 변경 파일 중 변경 로그 ({ patch })
+   * @returns {boolean} 추가된 목록 항목 줄이 있으면 true
+   */
+  static alreadyRecorded(changelogFile) {
+    return Boolean(changelogFile) && /^\+\s*[-*]\s+\S/m.test(changelogFile.patch || '');
+  }
+
+  /**
+   * 항목을 넣을 위치 찾기 (Keep a Changelog 형식)
+   * - `## [Unreleased]` 아래 같은 분류 제목이 있으면 그 제목 바로 다음 줄
+   * - 분류 제목이 없으면 Unreleased 제목 다음에 분류 제목과 함께
+   * - Unreleased 제목이 없으면 문서 제목(#) 다음, 그것도 없으면 파일 맨 앞에 Unreleased 영역을 만듦
+   * @param {string} content - 변경 로그 내용
+   * @param {string} category - 분류
+   * @param {string} entry - 목록 항목 줄 (`- ...`)
+   * @returns {Object} { line: 이 줄 다음에 삽입 (0이면 파일 맨 앞), lines: 삽입할 줄, heading: 표시용 위치 (Unreleased › 분류) }
+   */
+  static insertionPoint(content, category, entry) {
+    const lines = content.replace(/\r\n/g, '\n').split('\n');
+    const title = line => line.replace(/^#+\s*/, '').trim();
+    const unreleased = lines.findIndex(line => /^##\s+\[?unreleased\]?/i.test(line));
+    if (unreleased === -1) {
+      const documentTitle = lines.findIndex(line => /^#\s+\S/.test(line));
+      return {
+        line: documentTitle + 1,
+        lines: [...(documentTitle === -1 ? [] : ['']), '## [Unreleased]', '', `### ${category}`, '', entry, ...(documentTitle === -1 ? [''] : [])],
+        heading: `[Unreleased] › ${category} (새로 만듦)`
+      };
+    }
+
+    const next = lines.findIndex((line, index) => index > unreleased && /^##\s/.test(line));
+    const end = next === -1 ? lines.length : next;
+    const existing = lines.findIndex((line, index) =>
+      index > unreleased && index < end && line.trim().toLowerCase() === `### ${category}`.toLowerCase());
+    if (existing !== -1) {
+      // 분류 제목 다음 빈 줄은 그대로 두고 첫 항목 앞에 삽입
+      const anchor = lines[existing + 1] === '' && /^\s*[-*]\s/.test(lines[existing + 2] || '') ? existing + 1 : existing;
+      return { line: anchor + 1, lines: [entry], heading: `${title(lines[unreleased])} › ${title(lines[existing])}` };
+    }
+    return {
+      line: unreleased + 1,
+      lines: ['', `### ${category}`, '', entry],
+      heading: `${title(lines[unreleased])} › ${category} (새로 만듦)`
+    };
+  }
+
+  /**
+   * 항목을 추가하는 unified diff (`git apply`로 적용 가능)
+   * @param {string} path - 변경 로그 경로
+   * @param {string} content - 변경 로그 내용
+   * @param {Object} insertion - insertionPoint() 결과
+   * @returns {string} 패치
+   */
+  static buildPatch(path, content, insertion) {
+    const lines = content.replace(/\r\n/g, '\n').split('\n');
+    const added = insertion.lines.map(line => `+${line}`);
+    if (insertion.line === 0) {
+      return `--- a/${path}\n+++ b/${path}\n@@ -0,0 +1,${added.length} @@\n${added.join('\n')}`;
+    }
+    const anchor = lines[insertion.line - 1];
+    return `--- a/${path}\n+++ b/${path}\n@@ -${insertion.line},1 +${insertion.line},${added.length + 1} @@\n ${anchor}\n${added.join('\n')}`;
+  }
+
+  /**
+   * 판단 프롬프트 생성
+   * @param {Object} pullRequest - PR 페이로드
+   * @param {Array} files - [{ filename, status, diff }]
+   * @returns {string} 프롬프트
+   */
+  buildPrompt(pullRequest, files) {
+    let budget = MAX_DIFF_CHARS;
+    const sections = files.slice(0, MAX_FILES).map(file => {
+      const diff = budget > 0 ? file.diff.substring(0, budget) : '';
+      budget -= diff.length;
+      return `### ${file.filename} (${file.status})${diff ? `\n\`\`\`diff\n${diff}${diff.length < file.diff.length ? '\n// ... (truncated)' : ''}\n\`\`\`` : ''}`;
+    });
+    if (files.length > MAX_FILES) {
+      sections.push(`… 외 ${files.length - MAX_FILES}개 파일`);
+    }
+
+    return `${CHANGELOG_PROMPT} ${this.codeReviewer.getLanguageInstruction()}
+
+PR 제목: ${pullRequest.title}
+
+변경된 파일:
+${sections.join('\n\n')}
+
+**중요**: 완전한 JSON만 반환하세요.
+
+형식:
+{"user_facing":true,"category":"Added","entry":"변경 로그 항목"}`;
+  }
+
+  /**
+   * 응답 JSON 파싱
+   * @param {string} responseText - 응답 텍스트
+   * @returns {Object|null} { category, entry } (사용자 영향이 없으면 null)
+   */
+  static parseResponse(responseText) {
+    const firstBrace = responseText.indexOf('{');
+    const lastBrace = responseText.lastIndexOf('}');
+    if (firstBrace === -1 || lastBrace <= firstBrace) {
+      throw new Error('No JSON structure found in changelog response');
+    }
+    const parsed = JSON.parse(responseText.substring(firstBrace, lastBrace + 1));
+    if (parsed.user_facing !== true) {
+      return null;
+    }
+    const entry = typeof parsed.entry === 'string' ? parsed.entry.split('\n')[0].replace(/^\s*[-*]\s*/, '').trim() : '';
+    if (!entry) {
+      throw new Error('Changelog response has no entry');
+    }
+    const category = CATEGORIES.find(name => name.toLowerCase() === String(parsed.category || '').trim().toLowerCase()) || 'Changed';
+    return { category, entry };
+  }
+
+  /**
+   * 변경 로그 항목 제안
+   * @param {Array} files - PR 변경 파일 [{ filename, status, patch, diff }]
+   * @returns {Promise<Object|null>} { path, category, entry, heading, patch, insertion } (제안할 항목이 없으면 null, 변경 로그 파일이 없으면 path가 null)
+   */
+  async suggest(files) {
+    const path = this.locate();
+    if (path && ChangelogSuggester.alreadyRecorded(files.find(file => file.filename === path))) {
+      return null;
+    }
+    const candidates = files.filter(file => file.filename !== path && ChangelogSuggester.isUserFacing(file.filename));
+    if (candidates.length === 0) {
+      return null;
+    }
+
+    const pullRequest = this.context.payload.pull_request;
+    const responseText = await this.codeReviewer.sendMessage(
+      `PR #${pullRequest.number} changelog`,
+      this.buildPrompt(pullRequest, candidates),
+      this.codeReviewer.model
+    );
+    const parsed = ChangelogSuggester.parseResponse(responseText);
+    if (!parsed) {
+      return null;
+    }
+
+    const reference = `#${pullRequest.number}`;
+    const entry = parsed.entry.includes(reference) ? parsed.entry : `${parsed.entry} (${reference})`;
+    if (!path) {
+      return { path: null, category: parsed.category, entry, heading: null, patch: null, insertion: null };
+    }
+    const content = fs.readFileSync(path, 'utf8');
+    const insertion = ChangelogSuggester.insertionPoint(content, parsed.category, `- ${entry}`);
+    return {
+      path,
+      category: parsed.category,
+      entry,
+      heading: insertion.heading,
+      patch: ChangelogSuggester.buildPatch(path, content, insertion),
+      insertion: { ...insertion, anchorText: insertion.line > 0 ? content.replace(/\r\n/g, '\n').split('\n')[insertion.line - 1] : null }
+    };
+  }
+
+  /**
+   * 변경 로그 파일에 인라인 제안 작성 (넣을 위치가 PR diff 안에 있을 때만, 이미 작성했으면 건너뜀)
+   * @param {Object} suggestion - suggest() 결과
+   * @param {Object|undefined} changelogFile - PR 변경 파일 중 변경 로그 ({ patch })
+   * @returns {Promise<string|null>} 제안 댓글 URL (작성할 수 없으면 null)
+   */
+  async postSuggestion(suggestion, changelogFile) {
+    const { insertion } = suggestion;
+    if (!changelogFile || !insertion || insertion.line === 0 || !commentableLines(changelogFile.patch).has(insertion.line)) {
+      return null;
+    }
+    const pullRequest = this.context.payload.pull_request;
+    const request = { owner: this.context.repo.owner, repo: this.context.repo.repo, pull_number: pullRequest.number };
+    const existing = await this.octokit.paginate(this.octokit.rest.pulls.listReviewComments, { ...request, per_page: 100 });
+    const previous = existing.find(comment => comment.path === suggestion.path && (comment.body || '').includes(SUGGESTION_MARKER));
+    if (previous) {
+      return previous.html_url;
+    }
+
+    const { data } = await this.octokit.rest.pulls.createReviewComment({
+      ...request,
+      commit_id: pullRequest.head.sha,
+      path: suggestion.path,
+      line: insertion.line,
+      side: 'RIGHT',
+      body: `${SUGGESTION_MARKER}\n📰 **변경 로그 항목 제안** (${suggestion.category})\n\n\`\`\`suggestion\n${[insertion.anchorText, ...insertion.lines].join('\n')}\n\`\`\``
+    });
+    return data.html_url;
+  }
+}
+
+module.exports = ChangelogSuggester;
+module.exports.CATEGORIES = CATEGORIES;
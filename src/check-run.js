@@ -0,0 +1,282 @@
+/**
+ * Check Run Module
+ * 리뷰 결과를 GitHub Checks API의 Check Run("Claude Review")으로 게시하는 모듈
+ *
+ * - 이슈마다 파일/줄 주석(annotation)을 달아 Files changed 탭에 표시
+ * - 결론(conclusion)은 심각도로 결정: fail_severity 이상의 이슈가 있으면 failure, 없으면 success
+ * - 브랜치 보호 규칙에서 이 Check를 필수 상태 검사로 지정할 수 있음
+ * - start()로 리뷰 시작 시 in_progress로 만들면 리뷰 중 진행 상황을 출력 요약에 갱신
+ */
+
+const { getSeverityLevel, countBySeverity, getVerdict } = require('./review-summary');
+
+const CHECK_NAME = 'Claude Review';
+// Checks API는 요청 한 번에 주석을 최대 50개까지 받음
+const ANNOTATIONS_PER_REQUEST = 50;
+// 주석 제목 최대 길이 (API 제한 255자)
+const MAX_TITLE_LENGTH = 255;
+// 출력 요약/본문 최대 길이 (API 제한 65535자)
+const MAX_OUTPUT_LENGTH = 65535;
+// 진행 상황 갱신 최소 간격 (하트비트마다 Checks API를 호출하지 않도록 제한)
+const PROGRESS_UPDATE_MS = 60000;
+
+// 이슈 심각도 → 주석 수준
+const ANNOTATION_LEVELS = {
+  critical: 'failure',
+  high: 'failure',
+  medium: 'warning',
+  low: 'notice'
+};
+
+// 판정별 출력 제목
+const VERDICT_TITLES = {
+  changes_requested: 'Changes requested',
+  needs_attention: 'Needs attention',
+  approved: 'No issues found'
+};
+
+class CheckRunPublisher {
+  /**
+   * CheckRunPublisher 생성자
+   * @param {Object} octokit - GitHub API 클라이언트
+   * @param {Object} context - GitHub Actions 컨텍스트
+   * @param {Object} [options] - 옵션
+   * @param {string} [options.failSeverity] - 이 심각도 이상의 이슈가 있으면 결론을 failure로 설정 (기본값: high)
+   * @param {string} [options.name] - Check 이름 (기본값: Claude Review)
+   */
+  constructor(octokit, context, { failSeverity = 'high', name = CHECK_NAME } = {}) {
+    this.octokit = octokit;
+    this.context = context;
+    this.failSeverity = failSeverity;
+    this.name = name;
+    // start()로 만든 Check Run (없으면 publish()가 완료 상태로 새로 만듦)
+    this.checkRunId = null;
+    this.checkRunUrl = null;
+    this.completed = false;
+    this.progressReportedAt = 0;
+    this.pendingProgress = null;
+  }
+
+  /**
+   * 리뷰 시작 시 in_progress Check Run 생성
+   * @param {number} totalFiles - 리뷰할 파일 수
+   * @returns {Promise<Object>} { id, url }
+   */
+  async start(totalFiles) {
+    const { owner, repo } = this.context.repo;
+    const { data } = await this.octokit.rest.checks.create({
+      owner,
+      repo,
+      name: this.name,
+      head_sha: this.headSha(),
+      status: 'in_progress',
+      started_at: new Date().toISOString(),
+      output: { title: 'Review in progress', summary: `Reviewing ${totalFiles} files.` }
+    });
+    this.checkRunId = data.id;
+    this.checkRunUrl = data.html_url;
+    this.progressReportedAt = Date.now();
+    return { id: data.id, url: data.html_url };
+  }
+
+  /**
+   * 진행 상황을 Check 출력 요약에 반영 (ProgressTracker 리스너)
+   * 마지막 갱신 후 PROGRESS_UPDATE_MS가 지나지 않았거나 이전 갱신이 진행 중이면 건너뜀
+   * @param {Object} snapshot - ProgressTracker 스냅샷
+   * @returns {Promise<void>}
+   */
+  async reportProgress(snapshot) {
+    if (!this.checkRunId || this.completed || this.pendingProgress || Date.now() - this.progressReportedAt < PROGRESS_UPDATE_MS) {
+      return;
+    }
+    const { owner, repo } = this.context.repo;
+    this.progressReportedAt = Date.now();
+    this.pendingProgress = this.octokit.rest.checks.update({
+      owner,
+      repo,
+      check_run_id: this.checkRunId,
+      output: this.buildProgressOutput(snapshot)
+    });
+    try {
+      await this.pendingProgress;
+    } finally {
+      this.pendingProgress = null;
+    }
+  }
+
+  /**
+   * 리뷰 중 Check 출력 (제목과 요약)
+   * @param {Object} snapshot - ProgressTracker 스냅샷
+   * @returns {Object} { title, summary }
+   */
+  buildProgressOutput(snapshot) {
+    let summary = `Reviewed ${snapshot.completedFiles} of ${snapshot.totalFiles} files so far and found ${snapshot.findings} issues.`;
+    if (snapshot.remainingMs !== null) {
+      summary += ` About ${Math.ceil(snapshot.remainingMs / 60000)} minutes remaining.`;
+    }
+    return {
+      title: `Review in progress: ${snapshot.completedFiles}/${snapshot.totalFiles} files`,
+      summary
+    };
+  }
+
+  /**
+   * 리뷰가 실패하면 start()로 만든 Check Run을 failure로 완료 (in_progress로 남지 않도록)
+   * @param {string} message - 실패 사유
+   * @returns {Promise<void>}
+   */
+  async fail(message) {
+    if (!this.checkRunId || this.completed) {
+      return;
+    }
+    const { owner, repo } = this.context.repo;
+    await this.settleProgress();
+    await this.octokit.rest.checks.update({
+      owner,
+      repo,
+      check_run_id: this.checkRunId,
+      status: 'completed',
+      conclusion: 'failure',
+      completed_at: new Date().toISOString(),
+      output: { title: 'Review failed', summary: truncate(`The review did not complete: ${message}`, MAX_OUTPUT_LENGTH) }
+    });
+    this.completed = true;
+  }
+
+  /**
+   * 진행 중인 진행 상황 갱신이 끝날 때까지 대기 (완료 후 in_progress로 덮어쓰지 않도록)
+   * @returns {Promise<void>}
+   */
+  async settleProgress() {
+    if (this.pendingProgress) {
+      await this.pendingProgress.catch(() => {});
+    }
+  }
+
+  /**
+   * Check를 연결할 커밋 (PR은 head 커밋이어야 PR의 검사 목록에 표시됨)
+   * @returns {string} 커밋 SHA
+   */
+  headSha() {
+    const pullRequest = this.context.payload.pull_request;
+    return pullRequest ? pullRequest.head.sha : this.context.sha;
+  }
+
+  /**
+   * 리뷰 결과로 Check 결론 계산
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @returns {string} failure 또는 success
+   */
+  conclusion(reviewResults) {
+    const threshold = getSeverityLevel(this.failSeverity);
+    const failing = reviewResults.some(result =>
+      result.issues.some(issue => getSeverityLevel(issue.severity) >= threshold)
+    );
+    return failing ? 'failure' : 'success';
+  }
+
+  /**
+   * 이슈별 파일/줄 주석 생성
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @returns {Array} Checks API annotation 목록 (심각도 높은 순)
+   */
+  buildAnnotations(reviewResults) {
+    return reviewResults
+      .flatMap(result => result.issues.map(issue => ({ file: result.file, issue })))
+      .sort((a, b) => getSeverityLevel(b.issue.severity) - getSeverityLevel(a.issue.severity))
+      .map(({ file, issue }) => {
+        // 줄 번호가 없는 이슈는 파일 첫 줄에 표시
+        const line = issue.line || 1;
+        const title = `[${(issue.severity || 'medium').toUpperCase()}] ${issue.title}`;
+        const message = [issue.description, issue.suggestion ? `Suggestion: ${issue.suggestion}` : '']
+          .filter(Boolean)
+          .join('\n\n');
+        return {
+          path: file,
+          start_line: line,
+          end_line: line,
+          annotation_level: ANNOTATION_LEVELS[issue.severity] || 'warning',
+          title: truncate(title, MAX_TITLE_LENGTH),
+          message: truncate(message || issue.title, MAX_OUTPUT_LENGTH),
+          ...(issue.codeExample ? { raw_details: truncate(issue.codeExample, MAX_OUTPUT_LENGTH) } : {})
+        };
+      });
+  }
+
+  /**
+   * Check 출력 (제목과 요약)
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @param {number} filesReviewed - 리뷰한 파일 수
+   * @returns {Object} { title, summary }
+   */
+  buildOutput(reviewResults, filesReviewed) {
+    const counts = countBySeverity(reviewResults);
+    const total = counts.critical + counts.high + counts.medium + counts.low;
+    const verdict = getVerdict(counts);
+
+    let summary = `Reviewed ${filesReviewed} files and found ${total} issues.\n\n`;
+    summary += '| Severity | Issues |\n|----------|--------|\n';
+    ['critical', 'high', 'medium', 'low'].forEach(severity => {
+      summary += `| ${severity} | ${counts[severity]} |\n`;
+    });
+    summary += `\nThe check fails when an issue of severity \`${this.failSeverity}\` or higher is found.`;
+
+    return {
+      title: total > 0 ? `${VERDICT_TITLES[verdict]}: ${total} issues` : VERDICT_TITLES[verdict],
+      summary: truncate(summary, MAX_OUTPUT_LENGTH)
+    };
+  }
+
+  /**
+   * Check Run 생성 또는 start()로 만든 Check Run 완료 (주석이 50개를 넘으면 나머지는 업데이트로 나눠 추가)
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @param {number} filesReviewed - 리뷰한 파일 수
+   * @returns {Promise<Object>} { id, url, conclusion }
+   */
+  async publish(reviewResults, filesReviewed) {
+    const { owner, repo } = this.context.repo;
+    const output = this.buildOutput(reviewResults, filesReviewed);
+    const annotations = this.buildAnnotations(reviewResults);
+    const conclusion = this.conclusion(reviewResults);
+
+    const completion = {
+      status: 'completed',
+      conclusion,
+      completed_at: new Date().toISOString(),
+      output: { ...output, annotations: annotations.slice(0, ANNOTATIONS_PER_REQUEST) }
+    };
+
+    let data;
+    if (this.checkRunId) {
+      await this.settleProgress();
+      ({ data } = await this.octokit.rest.checks.update({ owner, repo, check_run_id: this.checkRunId, ...completion }));
+    } else {
+      ({ data } = await this.octokit.rest.checks.create({ owner, repo, name: this.name, head_sha: this.headSha(), ...completion }));
+    }
+    this.completed = true;
+
+    for (let start = ANNOTATIONS_PER_REQUEST; start < annotations.length; start += ANNOTATIONS_PER_REQUEST) {
+      await this.octokit.rest.checks.update({
+        owner,
+        repo,
+        check_run_id: data.id,
+        output: { ...output, annotations: annotations.slice(start, start + ANNOTATIONS_PER_REQUEST) }
+      });
+    }
+
+    return { id: data.id, url: data.html_url, conclusion };
+  }
+}
+
+/**
+ * 최대 길이를 넘는 문자열 자르기
+ * @param {string} text - 문자열
+ * @param {number} max - 최대 길이
+ * @returns {string} 잘린 문자열
+ */
+function truncate(text, max) {
+  return text.length > max ? `${text.substring(0, max - 1)}…` : text;
+}
+
+module.exports = CheckRunPublisher;
+module.exports.CHECK_NAME = CHECK_NAME;
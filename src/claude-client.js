@@ -0,0 +1,372 @@
+/**
+ * Claude Client Module
+ * 제공자(provider)별 Claude API 클라이언트 생성 모듈
+ *
+ * 모든 제공자는 Anthropic SDK와 같은 인터페이스를 따릅니다:
+ *   client.messages.create(params).withResponse() → { data, response }
+ *   stream: true 요청은 STREAMING_PROVIDERS만 지원 (data가 Messages API 스트림 이벤트의 async iterable)
+ *
+ * - anthropic: 공개 Anthropic API (anthropic_api_key)
+ * - bedrock: AWS Bedrock (bedrock-runtime). 인증은 AWS 기본 자격 증명 체인
+ *   (환경 변수, aws-actions/configure-aws-credentials의 OIDC 역할, 인스턴스 역할)을 사용
+ * - vertex: Google Cloud Vertex AI. 인증은 Application Default Credentials
+ *   (서비스 계정 키, google-github-actions/auth의 Workload Identity Federation)를 사용
+ * - openai: OpenAI 호환 /chat/completions 엔드포인트 (Azure OpenAI, vLLM, LiteLLM 등 사내 게이트웨이).
+ *   요청과 응답을 Messages API 형식으로 변환하므로 나머지 파이프라인은 그대로 동작
+ * - anthropic + batch 실행 모드: Message Batches API로 요청을 모아 보냄 (message-batches.js)
+ * - ollama: 자체 호스팅 러너의 로컬 모델 (Ollama /api/chat). 외부로 코드가 나가지 않는 대신 리뷰 품질은 모델에 따라 낮아질 수 있음
+ *
+ * Bedrock에서는 Anthropic 모델 ID를 리전 지역의 교차 리전 추론 프로필 ID로 바꿔 호출합니다.
+ * (claude-sonnet-4-20250514 → us.anthropic.claude-sonnet-4-20250514-v1:0)
+ * Vertex AI에서는 날짜 버전을 @로 구분합니다. (claude-sonnet-4-20250514 → claude-sonnet-4@20250514)
+ * 이미 제공자 형식의 모델 ID를 지정했다면 그대로 사용합니다.
+ */
+
+const core = require('@actions/core');
+const Anthropic = require('@anthropic-ai/sdk');
+const MessageBatchClient = require('./message-batches');
+
+const PROVIDERS = ['anthropic', 'bedrock', 'vertex', 'openai', 'ollama'];
+// stream: true 요청을 지원하는 제공자 (Anthropic SDK 기반)
+const STREAMING_PROVIDERS = ['anthropic', 'bedrock', 'vertex'];
+
+// Vertex AI 할당량 초과(429 RESOURCE_EXHAUSTED) 재시도
+// Vertex는 retry-after 헤더 없이 분 단위 할당량으로 제한하므로 SDK 기본 재시도(최대 8초 대기)보다 길게 기다림
+const VERTEX_QUOTA_RETRIES = 4;
+const VERTEX_QUOTA_BASE_DELAY_MS = 5000;
+
+// SDK 없이 직접 호출하는 제공자의 요청 타임아웃 (Anthropic SDK 기본값과 동일)
+const REQUEST_TIMEOUT_MS = 10 * 60 * 1000;
+// OpenAI 호환 엔드포인트 재시도 횟수 (Anthropic SDK 기본값과 동일)
+const OPENAI_MAX_RETRIES = 2;
+
+// Ollama 기본 주소와 컨텍스트 길이 (Ollama 기본값 2048 토큰은 파일 내용이 포함된 프롬프트를 잘라냄)
+const DEFAULT_OLLAMA_HOST = 'http://localhost:11434';
+const DEFAULT_OLLAMA_NUM_CTX = 16384;
+
+// 리전 접두사별 교차 리전 추론 프로필 지역
+const INFERENCE_PROFILE_GEOS = [
+  { prefix: 'us-gov-', geo: 'us-gov' },
+  { prefix: 'us-', geo: 'us' },
+  { prefix: 'ca-', geo: 'us' },
+  { prefix: 'eu-', geo: 'eu' },
+  { prefix: 'ap-', geo: 'apac' }
+];
+
+// Bedrock 모델 ID 버전 접미사 (Bedrock이 v1:0이 아닌 버전으로 게시한 모델만, 나머지는 v1:0)
+const BEDROCK_MODEL_VERSIONS = {
+  'claude-3-5-sonnet-20241022': 'v2:0'
+};
+
+/**
+ * Anthropic 모델 ID를 Bedrock 모델 ID로 변환
+ * Bedrock 모델 ID(anthropic.… 또는 추론 프로필)나 ARN을 그대로 적으면 변환하지 않음
+ * @param {string} model - 모델 ID
+ * @param {string} region - AWS 리전
+ * @returns {string} Bedrock 모델 ID (추론 프로필)
+ */
+function toBedrockModelId(model, region) {
+  if (model.includes('anthropic.') || model.startsWith('arn:')) {
+    return model;
+  }
+  const profile = INFERENCE_PROFILE_GEOS.find(({ prefix }) => (region || '').startsWith(prefix));
+  return `${profile ? `${profile.geo}.` : ''}anthropic.${model}-${BEDROCK_MODEL_VERSIONS[model] || 'v1:0'}`;
+}
+
+/**
+ * Anthropic 모델 ID를 Vertex AI 모델 ID로 변환
+ * @param {string} model - 모델 ID
+ * @returns {string} Vertex AI 모델 ID
+ */
+function toVertexModelId(model) {
+  return model.includes('@') ? model : model.replace(/-(\d{8})$/, '@$1');
+}
+
+/**
+ * Bedrock 클라이언트 생성
+ * 요청의 모델 ID만 Bedrock 형식으로 바꾸고 나머지는 Anthropic SDK와 동일하게 동작
+ * @param {string} region - AWS 리전
+ * @returns {Object} messages.create()를 가진 클라이언트
+ */
+function createBedrockClient(region) {
+  // AWS SDK를 포함하므로 Bedrock을 사용할 때만 로드
+  const { AnthropicBedrock } = require('@anthropic-ai/bedrock-sdk');
+  const client = new AnthropicBedrock({ awsRegion: region });
+  return {
+    messages: {
+      create: params => client.messages.create({ ...params, model: toBedrockModelId(params.model, region) })
+    }
+  };
+}
+
+/**
+ * 할당량 초과(429)면 대기 후 다시 요청
+ * @param {Function} request - 요청 함수
+ * @param {string} label - 로그에 표시할 대상 (모델과 리전)
+ * @returns {Promise<Object>} 요청 결과
+ */
+async function retryOnQuota(request, label) {
+  for (let attempt = 0; ; attempt++) {
+    try {
+      return await request();
+    } catch (error) {
+      if (error.status !== 429) {
+        throw error;
+      }
+      if (attempt >= VERTEX_QUOTA_RETRIES) {
+        error.message = `${error.message} (Vertex AI quota for ${label} is exhausted; request a quota increase or lower max_files)`;
+        throw error;
+      }
+      const delayMs = VERTEX_QUOTA_BASE_DELAY_MS * 2 ** attempt;
+      core.info(`Vertex AI quota exceeded for ${label}, retrying in ${delayMs / 1000}s`);
+      await new Promise(resolve => setTimeout(resolve, delayMs));
+    }
+  }
+}
+
+/**
+ * Vertex AI 클라이언트 생성
+ * 모델 ID를 Vertex 형식으로 바꾸고 할당량 초과 시 재시도
+ * @param {string} region - Vertex AI 리전
+ * @param {string} projectId - Google Cloud 프로젝트 ID
+ * @returns {Object} messages.create()를 가진 클라이언트
+ */
+function createVertexClient(region, projectId) {
+  // google-auth-library를 포함하므로 Vertex AI를 사용할 때만 로드
+  const { AnthropicVertex } = require('@anthropic-ai/vertex-sdk');
+  const client = new AnthropicVertex({ region, projectId });
+  return {
+    messages: {
+      create: params => {
+        const model = toVertexModelId(params.model);
+        return {
+          withResponse: () => retryOnQuota(
+            () => client.messages.create({ ...params, model }).withResponse(),
+            `${model} in ${region}`
+          )
+        };
+      }
+    }
+  };
+}
+
+/**
+ * Messages API 본문(문자열 또는 text 블록 배열)을 문자열로 변환
+ * Chat Completions와 Ollama는 cache_control 블록을 받지 않으므로 텍스트만 이어 붙임
+ * @param {string|Array} content - 시스템 프롬프트 또는 메시지 내용
+ * @returns {string} 텍스트
+ */
+function toPlainText(content) {
+  return Array.isArray(content) ? content.map(block => block.text || '').join('') : content;
+}
+
+/**
+ * Messages API의 system과 messages를 role 메시지 목록으로 변환 (Chat Completions, Ollama)
+ * @param {Object} params - messages.create() 파라미터
+ * @returns {Array} [{ role, content }]
+ */
+function toRoleMessages(params) {
+  return [
+    ...(params.system ? [{ role: 'system', content: toPlainText(params.system) }] : []),
+    ...params.messages.map(message => ({ role: message.role, content: toPlainText(message.content) }))
+  ];
+}
+
+/**
+ * Messages API 요청을 Chat Completions 요청으로 변환
+ * @param {Object} params - messages.create() 파라미터
+ * @param {string} [model] - 엔드포인트에서 사용할 모델 (없으면 요청 모델)
+ * @returns {Object} Chat Completions 요청 본문
+ */
+function toChatCompletionsRequest(params, model) {
+  return {
+    model: model || params.model,
+    max_tokens: params.max_tokens,
+    temperature: params.temperature,
+    messages: toRoleMessages(params)
+  };
+}
+
+/**
+ * Chat Completions 응답을 Messages API 응답으로 변환
+ * @param {Object} completion - Chat Completions 응답
+ * @returns {Object} { model, content: [{ type, text }], usage }
+ */
+function fromChatCompletionsResponse(completion) {
+  const choice = (completion.choices || [])[0];
+  if (!choice || !choice.message) {
+    throw new Error('Chat completions response has no choices');
+  }
+  const usage = completion.usage || {};
+  return {
+    model: completion.model,
+    content: [{ type: 'text', text: choice.message.content || '' }],
+    usage: { input_tokens: usage.prompt_tokens || 0, output_tokens: usage.completion_tokens || 0 }
+  };
+}
+
+/**
+ * OpenAI 호환 엔드포인트 클라이언트 생성
+ * Azure OpenAI(*.openai.azure.com)는 api-key 헤더, 그 외는 Bearer 토큰으로 인증
+ * 429와 5xx 응답은 Retry-After 헤더(없으면 1초부터 두 배씩)만큼 기다렸다가 재시도
+ * @param {string} baseUrl - API 주소 (/chat/completions 앞부분, 쿼리 문자열 유지)
+ * @param {string} [apiKey] - API 키 (게이트웨이가 인증하지 않으면 비워 둠)
+ * @param {string} [model] - 엔드포인트의 모델 또는 배포 이름 (없으면 Claude 모델 ID 그대로 전달)
+ * @returns {Object} messages.create()를 가진 클라이언트
+ */
+function createOpenAiClient(baseUrl, apiKey, model) {
+  const url = new URL(baseUrl);
+  url.pathname = `${url.pathname.replace(/\/+$/, '')}/chat/completions`;
+  const headers = { 'Content-Type': 'application/json' };
+  if (apiKey) {
+    if (url.hostname.endsWith('.openai.azure.com')) {
+      headers['api-key'] = apiKey;
+    } else {
+      headers.Authorization = `Bearer ${apiKey}`;
+    }
+  }
+
+  const send = async body => {
+    for (let attempt = 0; ; attempt++) {
+      const response = await fetch(url, {
+        method: 'POST',
+        headers,
+        body: JSON.stringify(body),
+        signal: AbortSignal.timeout(REQUEST_TIMEOUT_MS)
+      });
+      const retryable = response.status === 429 || response.status >= 500;
+      if (retryable && attempt < OPENAI_MAX_RETRIES) {
+        const retryAfter = parseInt(response.headers.get('retry-after'), 10);
+        const delayMs = isNaN(retryAfter) ? 1000 * 2 ** attempt : retryAfter * 1000;
+        core.info(`OpenAI-compatible endpoint returned ${response.status}, retrying in ${delayMs / 1000}s`);
+        await new Promise(resolve => setTimeout(resolve, delayMs));
+        continue;
+      }
+
+      const text = await response.text();
+      if (!response.ok) {
+        const error = new Error(`${response.status} ${text.substring(0, 200)}`);
+        error.status = response.status;
+        error.headers = { 'request-id': response.headers.get('x-request-id') };
+        throw error;
+      }
+      return { data: fromChatCompletionsResponse(JSON.parse(text)), response };
+    }
+  };
+
+  return {
+    messages: {
+      create: params => ({
+        withResponse: () => send(toChatCompletionsRequest(params, model))
+      })
+    }
+  };
+}
+
+/**
+ * Ollama 클라이언트 생성
+ * 스트리밍 없이 /api/chat을 호출하고 응답을 Messages API 형식으로 변환
+ * @param {string} host - Ollama 주소
+ * @param {string} model - 로컬 모델 이름 (예: qwen2.5-coder:14b)
+ * @param {number} numCtx - 컨텍스트 길이 (토큰)
+ * @returns {Object} messages.create()를 가진 클라이언트
+ */
+function createOllamaClient(host, model, numCtx) {
+  const url = `${host.replace(/\/+$/, '')}/api/chat`;
+
+  const send = async params => {
+    const response = await fetch(url, {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify({
+        model,
+        stream: false,
+        messages: toRoleMessages(params),
+        options: { temperature: params.temperature, num_predict: params.max_tokens, num_ctx: numCtx }
+      }),
+      signal: AbortSignal.timeout(REQUEST_TIMEOUT_MS)
+    });
+    const text = await response.text();
+    if (!response.ok) {
+      const hint = response.status === 404 ? ` (run "ollama pull ${model}" on the runner)` : '';
+      const error = new Error(`${response.status} ${text.substring(0, 200)}${hint}`);
+      error.status = response.status;
+      throw error;
+    }
+    const result = JSON.parse(text);
+    return {
+      data: {
+        model: result.model || model,
+        content: [{ type: 'text', text: (result.message && result.message.content) || '' }],
+        usage: { input_tokens: result.prompt_eval_count || 0, output_tokens: result.eval_count || 0 }
+      },
+      response
+    };
+  };
+
+  return {
+    messages: {
+      create: params => ({
+        withResponse: () => send(params)
+      })
+    }
+  };
+}
+
+/**
+ * 제공자별 Claude 클라이언트 생성
+ * @param {Object} options - 옵션
+ * @param {string} [options.provider] - 제공자 (PROVIDERS 중 하나)
+ * @param {string} [options.apiKey] - Anthropic API 키 (anthropic)
+ * @param {string} [options.awsRegion] - AWS 리전 (bedrock)
+ * @param {string} [options.vertexRegion] - Vertex AI 리전 (vertex)
+ * @param {string} [options.vertexProjectId] - Google Cloud 프로젝트 ID (vertex)
+ * @param {string} [options.openaiBaseUrl] - OpenAI 호환 API 주소 (openai)
+ * @param {string} [options.openaiApiKey] - OpenAI 호환 API 키 (openai)
+ * @param {string} [options.openaiModel] - 엔드포인트의 모델 또는 배포 이름 (openai)
+ * @param {string} [options.ollamaHost] - Ollama 주소 (ollama)
+ * @param {string} [options.ollamaModel] - 로컬 모델 이름 (ollama)
+ * @param {number} [options.ollamaNumCtx] - 컨텍스트 길이 (ollama)
+ * @param {string} [options.executionMode] - 실행 모드 (realtime, batch - anthropic만 지원)
+ * @param {number} [options.batchTimeoutMs] - 배치 하나를 기다리는 최대 시간 (batch)
+ * @returns {Object} messages.create()를 가진 클라이언트
+ */
+function createClaudeClient({
+  provider = 'anthropic', apiKey, awsRegion, vertexRegion, vertexProjectId,
+  openaiBaseUrl, openaiApiKey, openaiModel, ollamaHost, ollamaModel, ollamaNumCtx,
+  executionMode = 'realtime', batchTimeoutMs
+}) {
+  if (executionMode === 'batch') {
+    if (provider !== 'anthropic') {
+      throw new Error(`execution_mode: batch is not supported by provider ${provider}`);
+    }
+    return new MessageBatchClient({ apiKey, timeoutMs: batchTimeoutMs });
+  }
+  switch (provider) {
+    case 'ollama':
+      return createOllamaClient(ollamaHost || DEFAULT_OLLAMA_HOST, ollamaModel, ollamaNumCtx || DEFAULT_OLLAMA_NUM_CTX);
+    case 'openai':
+      return createOpenAiClient(openaiBaseUrl, openaiApiKey, openaiModel);
+    case 'bedrock':
+      return createBedrockClient(awsRegion);
+    case 'vertex':
+      return createVertexClient(vertexRegion, vertexProjectId);
+    case 'anthropic':
+      return new Anthropic({ apiKey });
+    default:
+      throw new Error(`Unknown provider: ${provider}`);
+  }
+}
+
+module.exports = {
+  PROVIDERS,
+  STREAMING_PROVIDERS,
+  EXECUTION_MODES: ['realtime', 'batch'],
+  DEFAULT_OLLAMA_HOST,
+  DEFAULT_OLLAMA_NUM_CTX,
+  toBedrockModelId,
+  toVertexModelId,
+  toChatCompletionsRequest,
+  fromChatCompletionsResponse,
+  createClaudeClient
+};
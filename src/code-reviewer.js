@@ -9,22 +9,221 @@
  * - 다국어 지원
  */
 
-const Anthropic = require('@anthropic-ai/sdk');
+const { createClaudeClient, STREAMING_PROVIDERS } = require('./claude-client');
+const crypto = require('crypto');
+const { PERSONAS, applyWeights, weightedSeverity } = require('./personas');
+const { getSeverityLevel } = require('./review-summary');
+const ReviewMemory = require('./review-memory');
+const I18nCatalog = require('./i18n-catalog');
+const { REVIEW_PASSES, ARBITRATION_PROMPT } = require('./review-passes');
+const { TEST_FILE_PATTERN } = require('./risk-scorer');
+const LicensePolicy = require('./license-policy');
+const CustomReviewTypes = require('./custom-review-types');
+const StreamProgress = require('./stream-progress');
+
+// 기본 리뷰 모델
+const DEFAULT_MODEL = 'claude-sonnet-4-20250514';
+
+// 언어별 리뷰 작성 지시사항 (지원 언어 목록)
+const LANGUAGE_INSTRUCTIONS = {
+  ko: '한국어로 리뷰를 작성해주세요.',
+  en: 'Please write the review in English.',
+  ja: '日本語でレビューを書いてください。',
+  zh: '请用中文写评审。',
+  'zh-TW': '請用繁體中文撰寫評審。',
+  es: 'Por favor, escribe la revisión en español.',
+  fr: 'Veuillez rédiger la revue en français.',
+  de: 'Bitte verfasse das Review auf Deutsch.',
+  pt: 'Por favor, escreva a revisão em português.',
+  it: 'Scrivi la revisione in italiano.',
+  ru: 'Пожалуйста, напишите ревью на русском языке.',
+  vi: 'Vui lòng viết đánh giá bằng tiếng Việt.',
+  id: 'Silakan tulis ulasan dalam bahasa Indonesia.',
+  tr: 'Lütfen incelemeyi Türkçe yazın.'
+};
+
+// 리뷰어 어조별 시스템 프롬프트 보충 문구와 필드별 길이 목표 (글자 수)
+const TONES = {
+  // 기본값: 핵심만 간결하게
+  concise: {
+    system: 'Write short, direct findings.',
+    lengths: { summary: 30, title: 20, description: 50, suggestion: 50 },
+    tokenScale: 1
+  },
+  // 주니어 온보딩: 왜 문제인지와 배경 지식까지 설명
+  educational: {
+    system: 'Explain why each finding matters and the underlying concept, so a junior developer can learn from it.',
+    lengths: { summary: 80, title: 30, description: 200, suggestion: 150 },
+    tokenScale: 2
+  },
+  // 멘토: 격려하는 어조로 스스로 생각해볼 질문 제시
+  mentor: {
+    system: 'Write as a supportive mentor: acknowledge what is good, and phrase suggestions as guidance with a question that helps the author reason about the fix.',
+    lengths: { summary: 60, title: 30, description: 150, suggestion: 150 },
+    tokenScale: 1.5
+  },
+  // 숙련된 팀: 한 줄 지적
+  'terse-senior': {
+    system: 'Write for experienced engineers: one-line findings, no explanations of basics, no pleasantries.',
+    lengths: { summary: 20, title: 15, description: 30, suggestion: 30 },
+    tokenScale: 0.75
+  }
+};
+
+// 테스트 누락 리뷰(review_type: tests)에서 파일 확장자별로 사용할 테스트 형식
+const TEST_FRAMEWORKS = {
+  '.go': 'Go 표준 testing 패키지의 테이블 기반 테스트 (tests 슬라이스와 t.Run 서브테스트)',
+  '.py': 'pytest (@pytest.mark.parametrize로 케이스 나열)',
+  '.js': 'Jest (describe/it, 여러 케이스는 test.each)',
+  '.jsx': 'Jest + React Testing Library',
+  '.ts': 'Jest (describe/it, 여러 케이스는 test.each)',
+  '.tsx': 'Jest + React Testing Library',
+  '.java': 'JUnit 5 (@ParameterizedTest)',
+  '.rs': '같은 파일의 #[cfg(test)] mod tests 안의 #[test] 함수'
+};
+
+// 테스트 누락/문서 리뷰 프롬프트에 나열할 최대 변경 테스트/문서 파일 수
+const MAX_LISTED_FILES = 20;
+
+// 문서 리뷰(review_type: docs)에서 문서로 보는 파일 (README, CHANGELOG, docs/ 아래 파일, 마크업 문서)
+const DOC_FILE_PATTERN = /(^|\/)docs?\/|\.(md|mdx|rst|adoc)$|(^|\/)(README|CHANGELOG|CHANGES|HISTORY)[^/]*$/i;
+
+// 접근성 리뷰(review_type: a11y)의 WCAG 기준 표기 지시사항
+const A11Y_INSTRUCTION = '\n\ntitle 앞에 관련 WCAG 성공 기준 번호를 붙이고(예: [WCAG 1.1.1] 이미지 대체 텍스트 누락), ' +
+  'reference에는 https://www.w3.org/WAI/WCAG22/Understanding/ 아래의 해당 성공 기준 문서 URL을 넣으세요. ' +
+  'code_example에는 문제를 고친 마크업이나 컴포넌트 코드를 작성하세요. UI를 렌더링하지 않는 파일이면 이슈를 보고하지 마세요.';
+
+// 제안 블록(suggestion)으로 바꿀 수 있는 최대 줄 수
+const MAX_FIX_LINES = 10;
+
+// 응답 최대 토큰 수 (파일 리뷰 외의 요청과 code_example 초안을 받는 리뷰 타입에 사용)
+const MAX_RESPONSE_TOKENS = 8000;
+// 이슈마다 code_example(테스트 스켈레톤, 문서 초안 등)을 받아 응답 길이를 어조로 가늠할 수 없는 리뷰 타입
+const CODE_EXAMPLE_REVIEW_TYPES = ['tests', 'i18n', 'docs', 'a11y', 'compliance'];
+
+// 스트리밍 응답을 받는 동안 진행 상황을 남기는 간격
+const STREAM_HEARTBEAT_MS = 15000;
+
+// 모든 리뷰 요청에 공통으로 사용하는 시스템 프롬프트
+const SYSTEM_PROMPT = "You are a senior code reviewer. CRITICAL: Always respond with complete, valid JSON format. Never truncate your response. Ensure the JSON is properly closed with all brackets and braces. Do not include any explanation, markdown, or other text outside the JSON structure.";
 
 class CodeReviewer {
   /**
    * CodeReviewer 생성자
-   * @param {string} apiKey - Anthropic API 키
-   * @param {string} language - 리뷰 언어 (ko, en, ja, zh)
+   * @param {string} apiKey - Anthropic API 키 (bedrock 제공자는 사용하지 않음)
+   * @param {string} language - 리뷰 언어 (LANGUAGE_INSTRUCTIONS 키)
    * @param {number} maxIssuesPerFile - 파일당 최대 이슈 개수 (1-10)
+   * @param {Object} options - 추가 옵션
+   * @param {Object} [options.recorder] - API 호출 기록기 (DebugBundle)
+   * @param {Glossary} [options.glossary] - 번역 용어집 (영어 이외 언어에만 적용)
+   * @param {string} [options.tone] - 리뷰어 어조 (TONES 키)
+   * @param {Array<string>} [options.personas] - 리뷰어 페르소나 (여러 개면 멀티 에이전트 모드)
+   * @param {boolean} [options.explain] - 이슈마다 "왜 중요한지" 설명과 참고 링크 요청 (멘토링용)
+   * @param {ReviewMemory} [options.memory] - 과거 리뷰 결정 (비슷한 사례를 프롬프트에 포함)
+   * @param {number} [options.memoryCases] - 파일마다 포함할 최대 과거 사례 수 (기본 3)
+   * @param {Array<string>} [options.passes] - 전문 리뷰 패스 (지정 시 패스별 병렬 리뷰 후 중재 패스로 병합)
+   * @param {Object} [options.typeModels] - 리뷰 타입별 모델 ({ 리뷰 타입: 모델 }, 없으면 기본 모델)
+   * @param {Object} [options.passModels] - 패스별 모델 ({ 패스 키: 모델 }, 없으면 리뷰 타입의 모델)
+   * @param {string} [options.arbitrationModel] - 중재 패스 모델 (없으면 기본 모델)
+   * @param {I18nCatalog} [options.i18nCatalog] - i18n 리뷰에 사용할 프레임워크와 번역 카탈로그
+   * @param {WorkspacePackages} [options.packageScope] - 모노레포 패키지 범위 (파일이 속한 패키지와 의존 관계를 프롬프트에 포함)
+   * @param {ConversationStore} [options.conversation] - 이 PR의 이전 리뷰 대화 (이슈와 메인테이너 답글을 프롬프트에 포함)
+   * @param {boolean} [options.suggestFixes] - 작고 기계적인 수정에 대해 줄 범위와 대체 코드 요청 (GitHub 제안 블록용)
+   * @param {PromptTemplates} [options.promptTemplates] - 저장소의 시스템/리뷰 프롬프트 템플릿
+   * @param {LicensePolicy} [options.licensePolicy] - 컴플라이언스 리뷰에 사용할 저장소 라이선스와 헤더 관례
+   * @param {CustomReviewTypes} [options.customTypes] - 저장소에 정의한 리뷰 타입 (.claude-review/types/*.md)
+   * @param {string} [options.model] - 기본 모델 (없으면 DEFAULT_MODEL)
+   * @param {Array<string>} [options.fallbackModels] - 과부하·할당량·컨텍스트 길이 오류 시 순서대로 시도할 대체 모델
+   * @param {string} [options.provider] - Claude API 제공자 (anthropic, bedrock, vertex, openai, ollama)
+   * @param {string} [options.awsRegion] - Bedrock 리전
+   * @param {string} [options.vertexRegion] - Vertex AI 리전
+   * @param {string} [options.vertexProjectId] - Vertex AI 프로젝트 ID
+   * @param {string} [options.openaiBaseUrl] - OpenAI 호환 API 주소
+   * @param {string} [options.openaiApiKey] - OpenAI 호환 API 키
+   * @param {string} [options.openaiModel] - OpenAI 호환 엔드포인트의 모델 이름
+   * @param {string} [options.ollamaHost] - Ollama 주소
+   * @param {string} [options.ollamaModel] - Ollama 로컬 모델 이름
+   * @param {number} [options.ollamaNumCtx] - Ollama 컨텍스트 길이
+   * @param {string} [options.executionMode] - 실행 모드 (realtime, batch)
+   * @param {number} [options.batchTimeoutMs] - 배치 하나를 기다리는 최대 시간
+   * @param {boolean} [options.promptCaching] - 시스템 프롬프트와 리뷰 지시사항에 프롬프트 캐시 적용 (기본 true)
    */
-  constructor(apiKey, language = 'en', maxIssuesPerFile = 3) {
-    // Claude API 클라이언트 초기화
-    this.client = new Anthropic({ apiKey });
+  constructor(apiKey, language = 'en', maxIssuesPerFile = 3, options = {}) {
+    // Claude API 클라이언트 초기화 (제공자별)
+    this.client = createClaudeClient({
+      provider: options.provider,
+      apiKey,
+      awsRegion: options.awsRegion,
+      vertexRegion: options.vertexRegion,
+      vertexProjectId: options.vertexProjectId,
+      openaiBaseUrl: options.openaiBaseUrl,
+      openaiApiKey: options.openaiApiKey,
+      openaiModel: options.openaiModel,
+      ollamaHost: options.ollamaHost,
+      ollamaModel: options.ollamaModel,
+      ollamaNumCtx: options.ollamaNumCtx,
+      executionMode: options.executionMode,
+      batchTimeoutMs: options.batchTimeoutMs
+    });
+    // Batch API는 약 50% 할인 (예상 비용 계산용)
+    this.batch = options.executionMode === 'batch';
+    // 긴 응답도 HTTP 타임아웃 없이 받도록 지원하는 제공자는 스트리밍으로 요청 (배치는 제외)
+    this.streaming = STREAMING_PROVIDERS.includes(options.provider || 'anthropic') && !this.batch;
+    // 파일마다 같은 시스템 프롬프트와 리뷰 지시사항은 캐시해서 재사용 (cache_control)
+    this.promptCaching = options.promptCaching !== false;
     this.language = language;
     this.maxIssuesPerFile = Math.max(1, Math.min(10, maxIssuesPerFile)); // 1-10 범위로 제한
-    // 이슈 개수에 따라 토큰 수 동적 조정 (더 많은 이슈 = 더 많은 토큰 필요)
-    this.maxTokens = Math.min(8000, 3000 + (this.maxIssuesPerFile * 500));
+    // 관점별 리뷰어 페르소나 (비어 있으면 reviewType 기본 프롬프트 사용)
+    this.personas = options.personas || [];
+    // 리뷰어 어조 (설명 길이와 시스템 프롬프트 결정, 미지정 시 단일 페르소나의 기본 어조)
+    const personaTone = this.personas.length === 1 ? PERSONAS[this.personas[0]].tone : 'concise';
+    this.tone = TONES[options.tone] ? options.tone : personaTone;
+    // 이슈 개수와 어조에 따라 파일 리뷰의 max_tokens 동적 조정 (더 많은 이슈/긴 설명 = 더 많은 토큰 필요)
+    // 가장 짧은 terse-senior(이슈 1개)도 2625 토큰으로, 필드 길이 목표(이슈당 100자 안팎)의 JSON보다 충분히 큼
+    this.maxTokens = Math.min(MAX_RESPONSE_TOKENS, Math.round((3000 + (this.maxIssuesPerFile * 500)) * TONES[this.tone].tokenScale));
+    // 디버그 번들용 호출 기록기 (선택)
+    this.recorder = options.recorder || null;
+    // 도메인 용어 번역 일관성을 위한 용어집 (선택)
+    this.glossary = options.glossary || null;
+    // 초보자용 설명 모드 (why/reference 필드 추가)
+    this.explain = Boolean(options.explain);
+    // 팀이 이미 결정한 패턴을 반복 지적하지 않도록 참고할 과거 사례 (선택)
+    this.memory = options.memory || null;
+    this.memoryCases = options.memoryCases === undefined ? 3 : options.memoryCases;
+    // 전문 리뷰 패스와 패스별 모델 (비어 있으면 단일 프롬프트 리뷰)
+    this.passes = options.passes || [];
+    this.passModels = options.passModels || {};
+    // 리뷰 타입별 모델 (보안은 상위 모델, 스타일은 경량 모델 등)
+    this.typeModels = options.typeModels || {};
+    this.arbitrationModel = options.arbitrationModel || null;
+    // i18n 리뷰의 프레임워크와 번역 카탈로그 (없으면 감지되지 않은 것으로 안내)
+    this.i18nCatalog = options.i18nCatalog || new I18nCatalog();
+    // 모노레포 패키지 범위 (선택)
+    this.packageScope = options.packageScope || null;
+    // 이전 push의 리뷰 대화 (선택)
+    this.conversation = options.conversation || null;
+    // 한 번에 적용할 수 있는 수정 제안 요청 여부
+    this.suggestFixes = Boolean(options.suggestFixes);
+    // 저장소에서 덮어쓴 프롬프트 템플릿 (선택)
+    this.promptTemplates = options.promptTemplates || null;
+    // 컴플라이언스 리뷰의 저장소 라이선스 (없으면 모르는 것으로 안내)
+    this.licensePolicy = options.licensePolicy || new LicensePolicy();
+    // 저장소에 정의한 리뷰 타입 (이름이 곧 이슈 타입)
+    this.customTypes = options.customTypes || new CustomReviewTypes();
+    // PR에서 함께 변경된 테스트/문서 파일 (setChangedFiles() 이후, 테스트 누락/문서 리뷰용)
+    this.changedTestFiles = null;
+    this.changedDocFiles = null;
+    // 실행 전체의 토큰 사용량 누적
+    // (cacheReadTokens, cacheWriteTokens는 캐시에서 읽거나 캐시에 쓴 입력 토큰, inputTokens에는 포함되지 않음)
+    this.usage = { requests: 0, inputTokens: 0, outputTokens: 0, cacheReadTokens: 0, cacheWriteTokens: 0 };
+    // 모델별 토큰 사용량 (비용 추정용)
+    this.usageByModel = new Map();
+    // 사용할 모델 및 실제 응답한 모델 기록 (리포트 메타데이터용)
+    this.model = options.model || DEFAULT_MODEL;
+    this.modelsUsed = new Set();
+    // 과부하·할당량·컨텍스트 길이 오류 시 순서대로 시도할 대체 모델과 대체 기록
+    this.fallbackModels = options.fallbackModels || [];
+    this.fallbacks = [];
   }
 
   /**
@@ -34,34 +233,478 @@ class CodeReviewer {
    * @param {string} params.content - 파일 전체 내용
    * @param {string} params.diff - Git diff 내용
    * @param {string} params.reviewType - 리뷰 타입 (full, security, performance, style)
+   * @param {string} [params.instructions] - 파일에 적용할 팀별 추가 리뷰 규칙 (설정 파일의 paths 섹션)
    * @returns {Promise<Object>} 파싱된 리뷰 결과
    */
-  async reviewFile({ filename, content, diff, reviewType }) {
-    // 리뷰 프롬프트 생성
-    const prompt = this.buildPrompt(filename, content, diff, reviewType);
+  async reviewFile({ filename, content, diff, reviewType, instructions = '' }) {
+    if (this.passes.length > 0) {
+      return this.reviewWithPasses(filename, content, diff, reviewType, instructions);
+    }
+    const model = this.modelFor(reviewType);
+    if (this.personas.length === 0) {
+      return this.requestReview(filename, this.buildPrompt(filename, content, diff, reviewType, null, null, instructions), model,
+        this.getCacheSegments(filename, reviewType, null, null, instructions), this.responseTokens(reviewType));
+    }
+
+    // 페르소나별로 따로 리뷰한 뒤 결과 병합
+    const reviews = await Promise.all(this.personas.map(async (key) => {
+      const review = await this.requestReview(filename, this.buildPrompt(filename, content, diff, reviewType, key, null, instructions), model,
+        this.getCacheSegments(filename, reviewType, key, null, instructions), this.maxTokens);
+      const issues = review.issues.map(issue => ({ ...issue, persona: PERSONAS[key].name }));
+      return { ...review, issues: applyWeights(issues, PERSONAS[key].weights) };
+    }));
+
+    return reviews.length === 1 ? reviews[0] : this.mergeReviews(reviews, this.personas.map(key => PERSONAS[key].weights));
+  }
+
+  /**
+   * 파일 리뷰 응답의 max_tokens
+   * 페르소나와 전문 패스는 code_example을 요청하지 않으므로 항상 this.maxTokens 사용
+   * @param {string} reviewType - 리뷰 타입
+   * @returns {number} code_example을 받는 리뷰 타입이면 MAX_RESPONSE_TOKENS, 아니면 어조별 this.maxTokens
+   */
+  responseTokens(reviewType) {
+    return CODE_EXAMPLE_REVIEW_TYPES.includes(reviewType) ? MAX_RESPONSE_TOKENS : this.maxTokens;
+  }
+
+  /**
+   * 리뷰 타입에 사용할 모델
+   * @param {string} reviewType - 리뷰 타입
+   * @returns {string} type_models에 지정한 모델 (없으면 기본 모델)
+   */
+  modelFor(reviewType) {
+    return this.typeModels[reviewType] || this.model;
+  }
+
+  /**
+   * 여러 페르소나의 리뷰 결과 병합
+   * 같은 라인의 같은 타입 이슈는 심각도가 높은 쪽 하나만 유지하고, 보고한 페르소나의 가중치를 곱한 심각도 순으로 정렬
+   * @param {Array} reviews - 페르소나별 리뷰 결과
+   * @param {Array<Object>} [weights] - reviews와 같은 순서의 페르소나별 타입 가중치 (없으면 가중치 1)
+   * @returns {Object} 병합된 리뷰 결과
+   */
+  mergeReviews(reviews, weights = []) {
+    const merged = new Map();
+    reviews.forEach((review, index) => {
+      review.issues.forEach(issue => {
+        const key = `${issue.line}:${issue.type}`;
+        const existing = merged.get(key);
+        if (!existing || getSeverityLevel(issue.severity) > getSeverityLevel(existing.issue.severity)) {
+          merged.set(key, { issue, score: weightedSeverity(issue, weights[index] || {}) });
+        }
+      });
+    });
+
+    return {
+      summary: reviews.map(review => review.summary).filter(Boolean).join(' / '),
+      issues: Array.from(merged.values())
+        .sort((a, b) => b.score - a.score)
+        .map(({ issue }) => issue),
+      positiveFeedback: reviews.flatMap(review => review.positiveFeedback || []),
+      followUps: reviews.flatMap(review => review.followUps || []),
+      // 가장 엄격한 관점의 점수 사용
+      overallScore: Math.min(...reviews.map(review => review.overallScore))
+    };
+  }
+
+  /**
+   * 전문 패스별로 병렬 리뷰한 뒤 중재 패스로 하나의 리뷰로 정리
+   * @param {string} filename - 파일명
+   * @param {string} content - 파일 내용
+   * @param {string} diff - Git diff
+   * @param {string} reviewType - 리뷰 타입
+   * @param {string} [instructions] - 팀별 추가 리뷰 규칙
+   * @returns {Promise<Object>} 중재된 리뷰 결과
+   */
+  async reviewWithPasses(filename, content, diff, reviewType, instructions = '') {
+    const typeModel = this.modelFor(reviewType);
+    const reviews = await Promise.all(this.passes.map(async (key) => {
+      const model = this.passModels[key] || typeModel;
+      const review = await this.requestReview(filename, this.buildPrompt(filename, content, diff, reviewType, null, key, instructions), model,
+        this.getCacheSegments(filename, reviewType, null, key, instructions), this.maxTokens);
+      // 시스템 이슈(응답 파싱 실패)는 중재 대상에서 제외
+      const issues = review.issues
+        .filter(issue => issue.type !== 'system')
+        .map(issue => ({ ...issue, persona: REVIEW_PASSES[key].name }));
+      return { ...review, issues };
+    }));
+
+    const candidates = reviews.flatMap(review => review.issues);
+    if (candidates.length === 0) {
+      return this.mergeReviews(reviews);
+    }
+
+    try {
+      const responseText = await this.sendMessage(filename, this.buildArbitrationPrompt(filename, content, candidates), this.arbitrationModel || typeModel);
+      // 후속 답변은 중재 대상이 아니므로 패스 결과에서 그대로 가져옴
+      return { ...this.parseArbitration(responseText, candidates), followUps: reviews.flatMap(review => review.followUps || []) };
+    } catch (error) {
+      // 중재에 실패해도 패스 결과는 버리지 않고 기계적으로 병합
+      console.log(`Arbitration failed for ${filename}, merging pass results instead: ${error.message}`);
+      const merged = this.mergeReviews(reviews);
+      return { ...merged, issues: merged.issues.slice(0, this.maxIssuesPerFile) };
+    }
+  }
+
+  /**
+   * 중재 패스 프롬프트 생성
+   * @param {string} filename - 파일명
+   * @param {string} content - 파일 내용
+   * @param {Array} candidates - 패스들이 보고한 후보 이슈 (persona에 패스 이름)
+   * @returns {string} 중재 프롬프트
+   */
+  buildArbitrationPrompt(filename, content, candidates) {
+    const lengths = TONES[this.tone].lengths;
+    const truncatedContent = content.length > 5000 ?
+      content.substring(0, 5000) + '\n// ... (truncated for performance)' :
+      content;
+    const candidateLines = candidates.map((issue, index) => JSON.stringify({
+      id: `C${index + 1}`,
+      pass: issue.persona,
+      line: issue.line,
+      severity: issue.severity,
+      type: issue.type,
+      title: issue.title,
+      description: issue.description
+    }));
+
+    return `${ARBITRATION_PROMPT} ${this.getLanguageInstruction()}
+
+파일: ${filename}
+
+코드:
+\`\`\`
+${truncatedContent}
+\`\`\`
+
+후보 이슈:
+${candidateLines.join('\n')}
+
+**중요**: 완전한 JSON만 반환하세요. 최종 리뷰에는 최대 ${this.maxIssuesPerFile}개 이슈만 중요도 순으로 포함하세요.
+
+형식:
+{"summary":"파일 전체 요약(${lengths.summary}자)","issues":[{"id":"대표 후보 id","merged":["합친 후보 id"],"severity":"low/medium/high/critical"}],"overall_score":1-10 숫자}`;
+  }
+
+  /**
+   * 중재 패스 응답을 리뷰 결과로 변환
+   * 후보의 제목과 설명은 그대로 두고 중재자가 정한 심각도와 병합 관계만 반영합니다.
+   * @param {string} responseText - 중재 패스 응답
+   * @param {Array} candidates - 후보 이슈
+   * @returns {Object} 리뷰 결과
+   */
+  parseArbitration(responseText, candidates) {
+    const firstBrace = responseText.indexOf('{');
+    const lastBrace = responseText.lastIndexOf('}');
+    if (firstBrace === -1 || lastBrace <= firstBrace) {
+      throw new Error('No JSON structure found in arbitration response');
+    }
+    const parsed = JSON.parse(responseText.substring(firstBrace, lastBrace + 1));
+    if (!parsed || !Array.isArray(parsed.issues)) {
+      throw new Error('Arbitration response has no issues array');
+    }
+
+    const byId = id => candidates[parseInt(String(id).replace(/^C/i, ''), 10) - 1];
+    const used = new Set();
+    const issues = [];
+    parsed.issues.forEach(decision => {
+      const candidate = decision && byId(decision.id);
+      if (!candidate || used.has(candidate)) {
+        return;
+      }
+      const merged = (Array.isArray(decision.merged) ? decision.merged : []).map(byId).filter(item => item && !used.has(item));
+      [candidate, ...merged].forEach(item => used.add(item));
+      issues.push({
+        ...candidate,
+        severity: ['low', 'medium', 'high', 'critical'].includes(decision.severity) ? decision.severity : candidate.severity,
+        // 같은 문제를 보고한 패스를 모두 표시
+        persona: [...new Set([candidate, ...merged].map(item => item.persona))].join(' + ')
+      });
+    });
+
+    return {
+      summary: typeof parsed.summary === 'string' && parsed.summary ? parsed.summary : 'Code review completed',
+      issues: issues
+        .sort((a, b) => getSeverityLevel(b.severity) - getSeverityLevel(a.severity))
+        .slice(0, this.maxIssuesPerFile),
+      positiveFeedback: [],
+      overallScore: Number.isFinite(parsed.overall_score) ? parsed.overall_score : 5
+    };
+  }
+
+  /**
+   * Claude API 호출 및 응답 파싱
+   * @param {string} filename - 파일명 (디버그 번들 기록용)
+   * @param {string} prompt - 리뷰 프롬프트
+   * @param {string} [model] - 사용할 모델 (기본값: this.model)
+   * @param {Array<string>} [cacheSegments] - 프롬프트 앞부분 중 여러 파일이 공유하는 조각 (getCacheSegments(), 캐시 대상)
+   * @param {number} [maxTokens] - 응답 최대 토큰 수
+   * @returns {Promise<Object>} 파싱된 리뷰 결과
+   */
+  async requestReview(filename, prompt, model = this.model, cacheSegments = [], maxTokens = MAX_RESPONSE_TOKENS) {
+    // API 응답을 구조화된 형식으로 파싱
+    return this.parseResponse(await this.sendMessage(filename, prompt, model, cacheSegments, maxTokens));
+  }
+
+  /**
+   * Claude API 호출
+   * @param {string} filename - 파일명 (디버그 번들 기록용)
+   * @param {string} prompt - 사용자 프롬프트
+   * @param {string} model - 사용할 모델
+   * @param {Array<string>} [cacheSegments] - 프롬프트 앞부분 중 캐시할 조각
+   * @param {number} [maxTokens] - 응답 최대 토큰 수
+   * @returns {Promise<string>} 응답 텍스트
+   */
+  async sendMessage(filename, prompt, model, cacheSegments = [], maxTokens = MAX_RESPONSE_TOKENS) {
+    // 요청한 모델이 과부하·할당량·컨텍스트 길이로 실패하면 대체 모델 목록 순서대로 재시도
+    const chain = [model, ...this.fallbackModels.filter(candidate => candidate !== model)];
+    let reason = null;
+    for (const candidate of chain) {
+      try {
+        const responseText = await this.sendToModel(filename, prompt, candidate, cacheSegments, maxTokens);
+        if (candidate !== model) {
+          this.fallbacks.push({ target: filename, requested: model, model: candidate, reason });
+        }
+        return responseText;
+      } catch (error) {
+        const fallbackReason = CodeReviewer.fallbackReason(error);
+        if (!fallbackReason || candidate === chain[chain.length - 1]) {
+          throw new Error(`Claude API error: ${error.message}`);
+        }
+        reason = reason || fallbackReason;
+        console.log(`${candidate} failed for ${filename} (${fallbackReason}), falling back to the next model: ${error.message}`);
+      }
+    }
+  }
+
+  /**
+   * 대체 모델로 넘어갈 오류인지 판단
+   * @param {Error} error - API 오류
+   * @returns {string|null} 대체 사유 (overloaded, rate_limit, context_length), 대체하지 않으면 null
+   */
+  static fallbackReason(error) {
+    const type = error.error && error.error.error ? error.error.error.type : null;
+    if (error.status === 529 || type === 'overloaded_error') {
+      return 'overloaded';
+    }
+    if (error.status === 429) {
+      return 'rate_limit';
+    }
+    if (error.status === 400 && /prompt is too long|context (length|window)|maximum context|too many tokens/i.test(error.message)) {
+      return 'context_length';
+    }
+    return null;
+  }
+
+  /**
+   * 모델 하나에 요청 (오류는 그대로 전달)
+   * @param {string} filename - 파일명 (디버그 번들 기록용)
+   * @param {string} prompt - 사용자 프롬프트
+   * @param {string} model - 사용할 모델
+   * @param {Array<string>} [cacheSegments] - 프롬프트 앞부분 중 캐시할 조각
+   * @param {number} [maxTokens] - 응답 최대 토큰 수
+   * @returns {Promise<string>} 응답 텍스트
+   */
+  async sendToModel(filename, prompt, model, cacheSegments = [], maxTokens = MAX_RESPONSE_TOKENS) {
+    const startedAt = Date.now();
+    const progress = new StreamProgress();
     
     try {
-      // Claude API 호출 (토큰 수 증가 및 스트림 비활성화)
-      const response = await this.client.messages.create({
-        model: 'claude-sonnet-4-20250514', // 코드 분석에 적합한 모델
-        max_tokens: 8000, // 토큰 수 증가로 완전한 응답 보장
+      const params = {
+        model, // 코드 분석에 적합한 모델
+        max_tokens: maxTokens, // 파일 리뷰는 이슈 개수와 어조에 맞춘 this.maxTokens
         temperature: 0.1, // 일관성 있는 응답을 위해 낮은 temperature 사용
-        system: "You are a senior code reviewer. CRITICAL: Always respond with complete, valid JSON format. Never truncate your response. Ensure the JSON is properly closed with all brackets and braces. Do not include any explanation, markdown, or other text outside the JSON structure.",
-        messages: [{
-          role: 'user',
-          content: prompt
-        }]
-      });
+        ...this.buildMessages(prompt, cacheSegments)
+      };
+      // withResponse()로 응답 헤더의 request-id를 함께 받아 디버그 번들에 기록
+      const { data: response, response: rawResponse } = this.streaming
+        ? await this.streamMessage(filename, params, progress)
+        : await this.client.messages.create(params).withResponse();
+
+      this.trackUsage(response.usage, response.model || model);
+      this.modelsUsed.add(response.model || model);
 
       const responseText = response.content[0].text;
       console.log(`Response length: ${responseText.length} characters`);
       console.log(`Response ends with: "${responseText.slice(-50)}"`);
 
-      // API 응답을 구조화된 형식으로 파싱
-      return this.parseResponse(responseText);
+      if (this.recorder) {
+        this.recorder.recordResponse({
+          filename,
+          requestId: rawResponse.headers.get('request-id'),
+          durationMs: Date.now() - startedAt,
+          responseText,
+          model: response.model || model,
+          usage: response.usage
+        });
+      }
+
+      return responseText;
     } catch (error) {
-      throw new Error(`Claude API error: ${error.message}`);
+      // 스트림이 중간에 끊겨도 완성된 이슈가 있으면 받은 부분에서 완성된 이슈만 복구해 결과로 사용
+      const salvaged = progress.findings > 0;
+      if (this.recorder) {
+        this.recorder.recordResponse({
+          filename,
+          requestId: error.headers ? error.headers['request-id'] : null,
+          durationMs: Date.now() - startedAt,
+          responseText: salvaged ? progress.text : undefined,
+          error: error.message
+        });
+      }
+      if (salvaged) {
+        console.log(`Stream for ${filename} was interrupted (${error.message}); using ${progress.findings} findings received so far`);
+        // 끊기기 전에 받은 message_start/message_delta의 사용량 기록
+        // (입력 토큰은 모두 포함되지만, 마지막 message_delta 이후 생성된 출력 토큰은 API가 알려주지 않음)
+        if (progress.usage) {
+          this.trackUsage(progress.usage, progress.model || model);
+        }
+        this.modelsUsed.add(progress.model || model);
+        return this.repairIncompleteJson(progress.text);
+      }
+      throw error;
+    }
+  }
+
+  /**
+   * stream: true로 요청하고 스트림 이벤트로 완성된 메시지 조립
+   * 받은 텍스트는 progress에 모으고, 응답이 끝날 때까지 일정 간격으로 진행 상황을 로그로 남김
+   * (MessageStream 헬퍼 대신 create()의 이벤트 스트림을 직접 읽으므로 SDK 버전과 제공자에 관계없이 동작)
+   * @param {string} filename - 파일명 (로그용)
+   * @param {Object} params - messages.create() 파라미터
+   * @param {StreamProgress} progress - 받은 텍스트와 완성된 이슈 수
+   * @returns {Promise<Object>} { data: 완성된 메시지, response: 응답 헤더 }
+   */
+  async streamMessage(filename, params, progress) {
+    const startedAt = Date.now();
+    const heartbeat = setInterval(() => {
+      console.log(`Streaming ${filename} (${params.model}): ${Math.round((Date.now() - startedAt) / 1000)}s, ` +
+        `${progress.text.length} characters, ${progress.findings} findings so far`);
+    }, STREAM_HEARTBEAT_MS);
+
+    try {
+      const { data: stream, response } = await this.client.messages.create({ ...params, stream: true }).withResponse();
+      let message = null;
+      let stopped = false;
+      for await (const event of stream) {
+        if (event.type === 'message_start') {
+          // 입력 토큰(캐시 포함)은 message_start, 출력 토큰은 message_delta의 usage에 있음
+          message = { ...event.message, content: [] };
+          progress.usage = message.usage;
+          progress.model = message.model;
+        } else if (event.type === 'content_block_start') {
+          message.content[event.index] = { ...event.content_block };
+        } else if (event.type === 'content_block_delta' && event.delta.type === 'text_delta') {
+          message.content[event.index].text += event.delta.text;
+          progress.push(event.delta.text);
+        } else if (event.type === 'message_delta') {
+          message.stop_reason = event.delta.stop_reason;
+          message.usage = { ...message.usage, ...event.usage };
+          progress.usage = message.usage;
+        } else if (event.type === 'message_stop') {
+          stopped = true;
+        }
+      }
+      // message_stop 없이 끝난 스트림은 중단된 것으로 보고 오류 처리 (완성된 이슈는 sendToModel에서 복구)
+      if (!message || !stopped) {
+        throw new Error('Stream ended before message_stop');
+      }
+      return { data: message, response };
+    } finally {
+      clearInterval(heartbeat);
+    }
+  }
+
+  /**
+   * 시스템 프롬프트와 사용자 메시지 구성
+   * 프롬프트 캐시를 사용하면 시스템 프롬프트와 프롬프트 앞부분의 조각마다 cache_control을 붙여
+   * 같은 실행의 다음 파일 리뷰가 캐시된 앞부분을 재사용하도록 함
+   * (캐시 지점까지의 전체 길이가 모델별 최소 길이(1024~4096 토큰)보다 짧으면 API가 캐시하지 않고 그대로 처리)
+   * @param {string} prompt - 사용자 프롬프트
+   * @param {Array<string>} [cacheSegments] - 프롬프트 앞부분의 조각 (프롬프트가 이 조각들로 순서대로 시작할 때만 분리)
+   * @returns {Object} { system, messages }
+   */
+  buildMessages(prompt, cacheSegments = []) {
+    if (!this.promptCaching) {
+      return { system: this.getSystemPrompt(), messages: [{ role: 'user', content: prompt }] };
+    }
+    const cacheControl = { type: 'ephemeral' };
+    // 저장소 프롬프트 템플릿이 앞부분을 바꿨으면 일치하는 조각까지만 캐시 (첫 조각부터 다르면 시스템 프롬프트만)
+    const blocks = [];
+    let offset = 0;
+    for (const segment of cacheSegments.filter(Boolean)) {
+      if (!prompt.startsWith(segment, offset)) {
+        break;
+      }
+      blocks.push({ type: 'text', text: segment, cache_control: cacheControl });
+      offset += segment.length;
+    }
+    const content = blocks.length > 0 && offset < prompt.length
+      ? [...blocks, { type: 'text', text: prompt.substring(offset) }]
+      : prompt;
+    return {
+      system: [{ type: 'text', text: this.getSystemPrompt(), cache_control: cacheControl }],
+      messages: [{ role: 'user', content }]
+    };
+  }
+
+  /**
+   * API 응답의 토큰 사용량 누적
+   * @param {Object} usage - 응답의 usage 필드
+   * @param {string} [model] - 응답한 모델
+   */
+  trackUsage(usage, model = this.model) {
+    this.usage.requests++;
+    if (!this.usageByModel.has(model)) {
+      this.usageByModel.set(model, { requests: 0, inputTokens: 0, outputTokens: 0, cacheReadTokens: 0, cacheWriteTokens: 0 });
+    }
+    const modelUsage = this.usageByModel.get(model);
+    modelUsage.requests++;
+    if (usage) {
+      this.usage.inputTokens += usage.input_tokens || 0;
+      this.usage.outputTokens += usage.output_tokens || 0;
+      modelUsage.inputTokens += usage.input_tokens || 0;
+      modelUsage.outputTokens += usage.output_tokens || 0;
+      [this.usage, modelUsage].forEach(target => {
+        target.cacheReadTokens += usage.cache_read_input_tokens || 0;
+        target.cacheWriteTokens += usage.cache_creation_input_tokens || 0;
+      });
+    }
+  }
+
+  /**
+   * 어조 문구를 포함한 시스템 프롬프트 반환
+   * @returns {string} 시스템 프롬프트
+   */
+  getSystemPrompt() {
+    const prompt = `${SYSTEM_PROMPT} ${TONES[this.tone].system}`;
+    if (!this.promptTemplates) {
+      return prompt;
+    }
+    return this.promptTemplates.renderSystem({ default: prompt, tone: this.tone, language: this.language });
+  }
+
+  /**
+   * 프롬프트 템플릿 해시 계산
+   * 파일 내용 대신 고정된 자리표시자로 프롬프트를 만들어 해시하므로
+   * 템플릿(시스템 프롬프트, 리뷰 타입, 언어 지시사항, 저장소 프롬프트 템플릿)이 바뀔 때만 값이 달라짐
+   * @param {string} reviewType - 리뷰 타입
+   * @returns {string} 12자리 sha256 해시
+   */
+  getPromptTemplateHash(reviewType) {
+    const personas = this.personas.length > 0 ? this.personas : [null];
+    const hash = crypto.createHash('sha256').update(this.getSystemPrompt());
+    if (this.passes.length > 0) {
+      this.passes.forEach(pass => {
+        hash.update(this.buildPrompt('{{filename}}', '{{content}}', '{{diff}}', reviewType, null, pass));
+      });
+      hash.update(this.buildArbitrationPrompt('{{filename}}', '{{content}}', []));
+      return hash.digest('hex').substring(0, 12);
     }
+    personas.forEach(persona => {
+      hash.update(this.buildPrompt('{{filename}}', '{{content}}', '{{diff}}', reviewType, persona));
+    });
+    return hash.digest('hex').substring(0, 12);
   }
 
   /**
@@ -70,14 +713,73 @@ class CodeReviewer {
    * @param {string} content - 파일 내용
    * @param {string} diff - Git diff
    * @param {string} reviewType - 리뷰 타입
+   * @param {string|null} [persona] - 페르소나 키 (지정 시 리뷰 타입 프롬프트 대신 사용)
+   * @param {string|null} [pass] - 전문 리뷰 패스 키 (지정 시 리뷰 타입 프롬프트 대신 사용)
+   * @param {string} [instructions] - 파일 담당 팀의 추가 리뷰 규칙
    * @returns {string} 완성된 프롬프트
    */
-  buildPrompt(filename, content, diff, reviewType) {
-    // 리뷰 타입별 기본 프롬프트 가져오기
-    const basePrompt = this.getBasePrompt(reviewType);
+  buildPrompt(filename, content, diff, reviewType, persona = null, pass = null, instructions = '') {
     // 언어별 지시사항
     const languageInstruction = this.getLanguageInstruction();
-    
+    // 어조별 필드 길이 목표
+    const lengths = TONES[this.tone].lengths;
+    // 설명 모드에서는 이슈마다 배경 설명과 참고 링크 필드 추가
+    // (접근성 리뷰는 reference를 WCAG 성공 기준 문서로 따로 요청)
+    const wcagReference = reviewType === 'a11y' && !persona && !pass;
+    let explainFields = '';
+    let explainInstruction = '';
+    if (this.explain && wcagReference) {
+      explainFields = ',"why":"왜 중요한지(80자)"';
+      explainInstruction = '\n\nwhy에는 초보 개발자도 이해할 수 있도록 이 문제가 왜 중요한지 설명하세요.';
+    } else if (this.explain) {
+      explainFields = ',"why":"왜 중요한지(80자)","reference":"참고 문서 URL"';
+      explainInstruction = '\n\nwhy에는 초보 개발자도 이해할 수 있도록 이 문제가 왜 중요한지 설명하고, reference에는 OWASP, 언어 공식 문서, Go Wiki, MDN처럼 널리 알려진 공식 문서 URL 하나만 넣으세요. 확실한 URL이 없으면 reference는 생략하세요.';
+    }
+    // 테스트 누락 리뷰에서는 이슈마다 테스트 스켈레톤과 테스트 파일 경로 요청
+    const testFields = reviewType === 'tests' && !persona && !pass ? ',"code_example":"테스트 코드 스켈레톤","test_file":"테스트 파일 경로"' : '';
+    const testInstruction = testFields ? this.getTestInstruction(filename) : '';
+    // i18n 리뷰에서는 이슈마다 번역 호출로 추출한 코드와 카탈로그 항목 요청
+    const i18nFields = reviewType === 'i18n' && !persona && !pass ? ',"code_example":"번역 호출로 바꾼 코드와 카탈로그 항목"' : '';
+    const i18nInstruction = i18nFields ? this.i18nCatalog.buildInstruction(content) : '';
+    // 문서 리뷰에서는 이슈마다 누락된 문서 초안 요청
+    const docsFields = reviewType === 'docs' && !persona && !pass ? ',"code_example":"추가하거나 고칠 문서 초안"' : '';
+    const docsInstruction = docsFields ? this.getDocsInstruction() : '';
+    // 접근성 리뷰에서는 이슈마다 수정한 마크업과 WCAG 성공 기준 문서 링크 요청
+    const a11yFields = wcagReference ? ',"code_example":"수정한 마크업/코드","reference":"WCAG 성공 기준 문서 URL"' : '';
+    const a11yInstruction = a11yFields ? A11Y_INSTRUCTION : '';
+    // 컴플라이언스 리뷰에서는 이슈마다 추가할 라이선스 헤더나 출처 표기 요청
+    const complianceFields = reviewType === 'compliance' && !persona && !pass ? ',"code_example":"추가할 라이선스 헤더나 출처 표기"' : '';
+    const complianceInstruction = complianceFields ? this.licensePolicy.buildInstruction() : '';
+    let issueTypes = 'bug/security/performance/style/maintainability';
+    if (testFields) {
+      issueTypes = 'testing';
+    } else if (i18nFields) {
+      issueTypes = 'i18n';
+    } else if (docsFields) {
+      issueTypes = 'documentation';
+    } else if (a11yFields) {
+      issueTypes = 'accessibility';
+    } else if (complianceFields) {
+      issueTypes = 'compliance';
+    } else if (this.customTypes.has(reviewType) && !persona && !pass) {
+      issueTypes = reviewType;
+    }
+    // 비슷한 과거 리뷰 결정
+    const memoryInstruction = this.memory
+      ? ReviewMemory.buildInstruction(this.memory.findSimilar(filename, content, this.memoryCases))
+      : '';
+    // 이전 리뷰 대화와 메인테이너 답글에 대한 후속 답변 요청
+    const { instruction: conversationInstruction, followUpFields } = this.conversation
+      ? this.conversation.buildInstruction(filename)
+      : { instruction: '', followUpFields: '' };
+    // 작고 기계적인 수정은 원본 줄 범위와 대체 코드로 요청 (리뷰 댓글의 제안 블록으로 표시)
+    const fixFields = this.suggestFixes && !testFields && !i18nFields && !docsFields && !a11yFields && !complianceFields
+      ? ',"fixes":[{"line":이슈 줄 번호,"start_line":숫자,"end_line":숫자,"code":"start_line~end_line을 대체할 코드"}]'
+      : '';
+    const fixInstruction = fixFields
+      ? `\n\n이름 변경, null/nil 검사 추가, 매개변수화된 쿼리처럼 작고 기계적인 수정으로 해결되는 이슈만 fixes에 넣으세요. code는 파일의 start_line부터 end_line까지(${MAX_FIX_LINES}줄 이하)를 그대로 대체할 완전한 코드이며 들여쓰기를 원본과 맞춰야 합니다. 설계 변경이 필요한 이슈는 fixes에 넣지 마세요.`
+      : '';
+
     // 파일 내용 길이 제한 (속도 개선)
     const truncatedContent = content.length > 5000 ? 
       content.substring(0, 5000) + '\n// ... (truncated for performance)' : 
@@ -88,7 +790,7 @@ class CodeReviewer {
       diff;
     
     // 명확한 JSON 형식 요청
-    return `${basePrompt} ${languageInstruction}
+    const prompt = `${this.getPromptPrefix(reviewType, persona, pass)}${this.getPromptContext(filename, instructions)}
 
 파일: ${filename}
 
@@ -102,9 +804,80 @@ ${truncatedContent}
 **중요**: 완전한 JSON만 반환하세요. 최대 ${this.maxIssuesPerFile}개 이슈만 포함하고 간결하게 작성하세요.
 
 형식:
-{"summary":"요약(30자)","issues":[{"line":숫자,"severity":"low/medium/high/critical","type":"bug/security/performance/style/maintainability","title":"제목(20자)","description":"설명(50자)","suggestion":"제안(50자)"}],"overall_score":숫자}
+{"summary":"요약(${lengths.summary}자)","issues":[{"line":숫자,"severity":"low/medium/high/critical","type":"${issueTypes}","title":"제목(${lengths.title}자)","description":"설명(${lengths.description}자)","suggestion":"제안(${lengths.suggestion}자)"${explainFields}${testFields}${i18nFields}${docsFields}${a11yFields}${complianceFields}}]${followUpFields}${fixFields},"overall_score":1-10 숫자}
 
-중요도 높은 이슈부터 우선적으로 ${this.maxIssuesPerFile}개까지 선별해서 보고하세요.`;
+중요도 높은 이슈부터 우선적으로 ${this.maxIssuesPerFile}개까지 선별해서 보고하세요.${explainInstruction}${testInstruction}${i18nInstruction}${docsInstruction}${a11yInstruction}${complianceInstruction}${conversationInstruction}${fixInstruction}${memoryInstruction}`;
+
+    if (!this.promptTemplates) {
+      return prompt;
+    }
+    // 저장소 템플릿으로 프롬프트 교체 (기본 프롬프트는 default로 전달)
+    return this.promptTemplates.renderUser({
+      default: prompt,
+      filename,
+      directory: filename.includes('/') ? filename.substring(0, filename.lastIndexOf('/')) : '',
+      extension: (filename.match(/\.[^./]+$/) || [''])[0].toLowerCase(),
+      lines: content.split('\n').length,
+      content: truncatedContent,
+      diff: truncatedDiff,
+      reviewType,
+      language: this.language,
+      languageInstruction,
+      maxIssues: this.maxIssuesPerFile,
+      persona,
+      pass,
+      instructions
+    });
+  }
+
+  /**
+   * 프롬프트 캐시 대상 조각 (buildPrompt()의 앞부분과 같은 순서)
+   * 실행 전체가 공유하는 앞부분과, 같은 패키지·같은 paths 규칙의 파일끼리 공유하는 저장소 문맥을 따로 캐시
+   * @param {string} filename - 파일명
+   * @param {string} reviewType - 리뷰 타입
+   * @param {string|null} [persona] - 페르소나 키
+   * @param {string|null} [pass] - 전문 리뷰 패스 키
+   * @param {string} [instructions] - 파일 담당 팀의 추가 리뷰 규칙
+   * @returns {Array<string>} [앞부분, 저장소 문맥]
+   */
+  getCacheSegments(filename, reviewType, persona = null, pass = null, instructions = '') {
+    return [this.getPromptPrefix(reviewType, persona, pass), this.getPromptContext(filename, instructions)];
+  }
+
+  /**
+   * 파일 내용 앞에 두는 저장소 문맥 (워크스페이스 패키지 범위와 paths 섹션의 팀별 리뷰 규칙)
+   * 같은 패키지나 같은 규칙의 파일은 내용이 같으므로 프롬프트 캐시 대상
+   * (과거 리뷰 사례는 파일 내용으로 고르므로 파일마다 달라 여기에 넣지 않음)
+   * @param {string} filename - 파일명
+   * @param {string} [instructions] - 파일 담당 팀의 추가 리뷰 규칙
+   * @returns {string} 저장소 문맥 (없으면 빈 문자열)
+   */
+  getPromptContext(filename, instructions = '') {
+    // 파일이 속한 워크스페이스 패키지와 의존 관계
+    const packageInstruction = this.packageScope ? this.packageScope.buildInstruction(filename) : '';
+    // 설정 파일의 paths 섹션(경로, CODEOWNERS 담당자)에서 지정한 팀별 리뷰 규칙
+    const teamInstruction = instructions
+      ? `\n\n이 파일에는 담당 팀의 다음 리뷰 규칙도 적용하세요. 규칙 위반도 이슈로 보고하세요:\n${instructions}`
+      : '';
+    return `${packageInstruction}${teamInstruction}`;
+  }
+
+  /**
+   * 파일과 관계없이 같은 프롬프트 앞부분 (페르소나, 리뷰 패스 또는 리뷰 타입별 기본 프롬프트와 언어 지시사항)
+   * 실행 중 모든 파일 리뷰가 공유하므로 프롬프트 캐시 대상
+   * @param {string} reviewType - 리뷰 타입
+   * @param {string|null} [persona] - 페르소나 키
+   * @param {string|null} [pass] - 전문 리뷰 패스 키
+   * @returns {string} 프롬프트 앞부분
+   */
+  getPromptPrefix(reviewType, persona = null, pass = null) {
+    let basePrompt = this.getBasePrompt(reviewType);
+    if (persona) {
+      basePrompt = PERSONAS[persona].prompt;
+    } else if (pass) {
+      basePrompt = REVIEW_PASSES[pass].prompt;
+    }
+    return `${basePrompt} ${this.getLanguageInstruction()}`;
   }
 
   /**
@@ -113,6 +886,11 @@ ${truncatedContent}
    * @returns {string} 기본 프롬프트
    */
   getBasePrompt(reviewType) {
+    // 저장소에 정의한 리뷰 타입
+    if (this.customTypes.has(reviewType)) {
+      return this.customTypes.prompt(reviewType);
+    }
+
     const prompts = {
       // 전체 리뷰: 모든 측면을 종합적으로 검토
       full: `당신은 경험이 풍부한 시니어 개발자입니다. 다음 코드 변경사항을 종합적으로 리뷰해주세요.
@@ -145,6 +923,52 @@ ${truncatedContent}
 - 캐싱 전략
 - 리소스 관리`,
 
+      // 테스트 누락 리뷰: 테스트 없이 바뀐 동작을 찾고 테스트 케이스 제안
+      tests: `당신은 테스트 전문가입니다. 다음 코드 변경사항에서 테스트로 검증되지 않는 동작을 찾아 구체적인 테스트 케이스를 제안해주세요.
+
+리뷰 관점:
+- 새로 추가되거나 바뀐 분기와 반환값
+- 경계값, 빈 입력, 오류 경로
+- 기존 테스트가 더 이상 검증하지 못하는 동작
+- 회귀가 발생하기 쉬운 복잡한 로직`,
+
+      // i18n 리뷰: 번역되지 않는 사용자 노출 문자열과 누락된 번역 키
+      i18n: `당신은 국제화(i18n) 전문가입니다. 다음 코드 변경사항에서 번역되지 않는 사용자 노출 문자열과 번역 카탈로그 누락을 찾아주세요.
+
+리뷰 관점:
+- UI 코드(컴포넌트, 템플릿, 화면에 표시되는 메시지)에 새로 하드코딩된 문자열
+- 번역 카탈로그에 없는 번역 키 사용
+- 문자열 이어 붙이기로 만든 문장 (어순이 다른 언어에서 깨짐, 보간 사용 필요)
+- 하드코딩된 날짜, 숫자, 통화 형식과 복수형 처리`,
+
+      // 문서 리뷰: 바뀐 공개 API, 설정 옵션, 동작이 문서에 반영되었는지 확인
+      docs: `당신은 기술 문서 전문가입니다. 다음 코드 변경사항이 문서에 제대로 반영되었는지 리뷰해주세요.
+
+리뷰 관점:
+- 새로 추가되거나 시그니처가 바뀐 공개(export) API의 doc comment 누락 또는 불일치
+- 새 설정 옵션, 환경 변수, CLI 플래그의 README/문서 누락
+- 사용자가 알아야 하는 동작 변경(기본값, 오류 처리, 호환성)의 CHANGELOG 누락
+- 코드와 맞지 않게 된 기존 주석과 예제`,
+
+      // 접근성 리뷰: 프런트엔드 변경의 WCAG 위반
+      a11y: `당신은 웹 접근성(WCAG 2.2) 전문가입니다. 다음 프런트엔드 코드 변경사항에서 장애가 있는 사용자가 이용할 수 없게 되는 문제를 찾아주세요.
+
+리뷰 관점:
+- 이미지, 아이콘 버튼, 미디어의 대체 텍스트 누락
+- 잘못된 ARIA 사용 (역할과 맞지 않는 속성, 네이티브 요소로 충분한데 쓴 role, aria-hidden 안의 포커스 가능 요소)
+- 레이블이 없는 폼 컨트롤과 오류 메시지 연결 누락
+- 키보드로 조작할 수 없는 클릭 핸들러, 포커스 순서와 포커스 표시 제거(outline: none)
+- 텍스트와 배경의 색상 대비 부족, 색상만으로 전달하는 정보`,
+
+      // 라이선스/컴플라이언스 리뷰: 라이선스 헤더, 호환되지 않는 라이선스, 출처 없는 복사 코드
+      compliance: `당신은 오픈소스 라이선스 컴플라이언스 전문가입니다. 다음 코드 변경사항에서 법무 검토가 필요한 라이선스 문제를 찾아주세요.
+
+리뷰 관점:
+- 새 파일의 라이선스 헤더 누락 또는 저장소 관례와 다른 헤더
+- 다른 프로젝트의 저작권/라이선스 헤더가 붙은 코드 (저장소 라이선스와의 호환성)
+- 다른 프로젝트나 Stack Overflow 등에서 복사한 것으로 보이는 코드와 출처 표기 누락 (CC BY-SA 등 조건이 있는 라이선스)
+- 라이선스 조건(저작권 고지 유지, 변경 사항 표시)을 지키지 않은 벤더링 코드`,
+
       // 스타일 중심 리뷰: 코드 일관성과 가독성
       style: `당신은 코드 스타일 및 컨벤션 전문가입니다. 다음 코드의 스타일과 일관성을 리뷰해주세요.
 
@@ -160,19 +984,70 @@ ${truncatedContent}
     return prompts[reviewType] || prompts.full;
   }
 
+  /**
+   * PR의 변경 파일 목록 설정 (테스트 누락/문서 리뷰에서 함께 변경된 테스트/문서 파일을 알려줌)
+   * @param {Array<string>} filenames - 변경된 전체 파일 경로 (필터링 전)
+   */
+  setChangedFiles(filenames) {
+    this.changedTestFiles = filenames.filter(filename => TEST_FILE_PATTERN.test(filename)).sort();
+    this.changedDocFiles = filenames.filter(filename => DOC_FILE_PATTERN.test(filename)).sort();
+  }
+
+  /**
+   * 테스트 누락 리뷰의 테스트 스켈레톤 작성 지시사항
+   * @param {string} filename - 파일명 (확장자로 테스트 형식 결정)
+   * @returns {string} 지시사항
+   */
+  getTestInstruction(filename) {
+    const extension = (filename.match(/\.[^./]+$/) || [''])[0].toLowerCase();
+    const framework = TEST_FRAMEWORKS[extension] || '이 언어에서 가장 널리 쓰이는 테스트 프레임워크';
+    let instruction = `\n\ncode_example에는 ${framework} 형식으로 그대로 컴파일/실행할 수 있는 테스트 스켈레톤(패키지 선언과 import 포함)을 작성하세요. ` +
+      '기대값을 코드에서 확신할 수 없으면 TODO 주석으로 표시하세요. test_file에는 이 저장소 관례에 맞는 테스트 파일 경로를 넣으세요.';
+
+    // 동작은 바뀌었는데 테스트는 바뀌지 않은 경우를 구분할 수 있도록 함께 변경된 테스트 파일 안내
+    if (this.changedTestFiles) {
+      if (this.changedTestFiles.length === 0) {
+        instruction += '\n\n이 PR에는 변경된 테스트 파일이 없습니다. 동작이 바뀐 부분은 대응하는 테스트 변경이 없는 것이므로 우선 보고하세요.';
+      } else {
+        const listed = this.changedTestFiles.slice(0, MAX_LISTED_FILES);
+        const more = this.changedTestFiles.length - listed.length;
+        instruction += `\n\n이 PR에서 함께 변경된 테스트 파일: ${listed.join(', ')}${more > 0 ? ` 외 ${more}개` : ''}\n` +
+          '이 파일에 대응하는 테스트 파일이 목록에 없다면 바뀐 동작이 테스트 없이 변경된 것이므로 우선 보고하세요.';
+      }
+    }
+    return instruction;
+  }
+
+  /**
+   * 문서 리뷰의 문서 초안 작성 지시사항
+   * @returns {string} 지시사항
+   */
+  getDocsInstruction() {
+    let instruction = '\n\ncode_example에는 그대로 붙여 넣을 수 있는 문서 초안(언어 관례에 맞는 doc comment, README 섹션 또는 CHANGELOG 항목)을 작성하고, ' +
+      'suggestion에는 초안을 넣을 위치를 적으세요. 내부(비공개) 구현의 주석 부족이나 문장 다듬기는 보고하지 마세요.';
+
+    // 문서 파일은 리뷰 대상이 아니므로 같은 PR에서 이미 갱신된 문서 목록으로 중복 지적 방지
+    if (this.changedDocFiles) {
+      if (this.changedDocFiles.length === 0) {
+        instruction += '\n\n이 PR에는 변경된 문서 파일(README, CHANGELOG, docs/)이 없습니다.';
+      } else {
+        const listed = this.changedDocFiles.slice(0, MAX_LISTED_FILES);
+        const more = this.changedDocFiles.length - listed.length;
+        instruction += `\n\n이 PR에서 함께 변경된 문서 파일: ${listed.join(', ')}${more > 0 ? ` 외 ${more}개` : ''}\n` +
+          '이 파일들에 이미 반영되었을 가능성이 높은 변경은 낮은 심각도로 보고하세요.';
+      }
+    }
+    return instruction;
+  }
+
   /**
    * 언어별 지시사항 반환
    * @returns {string} 언어 지시사항
    */
   getLanguageInstruction() {
-    const instructions = {
-      ko: '한국어로 리뷰를 작성해주세요.',
-      en: 'Please write the review in English.',
-      ja: '日本語でレビューを書いてください。',
-      zh: '请用中文写评审。'
-    };
-
-    return instructions[this.language] || instructions.en;
+    const instruction = LANGUAGE_INSTRUCTIONS[this.language] || LANGUAGE_INSTRUCTIONS.en;
+    // 용어집이 있으면 언어 지시사항 바로 뒤에 용어 번역 규칙 추가
+    return this.glossary ? instruction + this.glossary.buildInstruction(this.language) : instruction;
   }
 
   /**
@@ -272,24 +1147,39 @@ ${truncatedContent}
         }
       }
       
-      // 5. 응답 형식 검증 및 기본값 설정
+      // 5. 응답 형식 검증 및 기본값 설정 (모델 출력은 신뢰할 수 없으므로 타입까지 확인)
+      if (!parsed || typeof parsed !== 'object') {
+        throw new Error('Response JSON is not an object');
+      }
+      const text = (value, fallback) => (typeof value === 'string' && value ? value : fallback);
       const result = {
-        summary: parsed.summary || 'Code review completed',
-        issues: Array.isArray(parsed.issues) ? parsed.issues : [],
+        summary: text(parsed.summary, 'Code review completed'),
+        issues: Array.isArray(parsed.issues) ? parsed.issues.filter(issue => issue && typeof issue === 'object') : [],
         positiveFeedback: Array.isArray(parsed.positive_feedback) ? parsed.positive_feedback : [],
-        overallScore: typeof parsed.overall_score === 'number' ? parsed.overall_score : 5
+        overallScore: Number.isFinite(parsed.overall_score) ? parsed.overall_score : 5,
+        // 이전 대화의 메인테이너 답글에 대한 후속 답변
+        followUps: CodeReviewer.parseFollowUps(responseText)
       };
       
       // 6. 이슈 정규화
       result.issues = result.issues.map(issue => ({
-        line: typeof issue.line === 'number' ? issue.line : null,
+        line: Number.isInteger(issue.line) && issue.line > 0 ? issue.line : null,
         severity: ['low', 'medium', 'high', 'critical'].includes(issue.severity) ? issue.severity : 'medium',
-        type: ['bug', 'security', 'performance', 'style', 'maintainability'].includes(issue.type) ? issue.type : 'general',
-        title: issue.title || 'Issue found',
-        description: issue.description || '',
-        suggestion: issue.suggestion || '',
-        codeExample: issue.code_example || issue.codeExample || null
+        type: ['bug', 'security', 'performance', 'style', 'maintainability', 'testing', 'documentation', 'i18n', 'accessibility', 'compliance'].includes(issue.type) || this.customTypes.has(issue.type) ? issue.type : 'general',
+        title: text(issue.title, 'Issue found'),
+        description: text(issue.description, ''),
+        suggestion: text(issue.suggestion, ''),
+        codeExample: text(issue.code_example, null) || text(issue.codeExample, null),
+        why: typeof issue.why === 'string' ? issue.why : '',
+        // 테스트 누락 리뷰의 테스트 파일 경로 (상대 경로만 허용)
+        testFile: typeof issue.test_file === 'string' && /^(?!\/)(?!.*\.\.)[\w./-]+$/.test(issue.test_file) ? issue.test_file : null,
+        // 링크 주입을 막기 위해 http(s) URL만 허용
+        reference: typeof issue.reference === 'string' && /^https?:\/\/[^\s()<>]+$/.test(issue.reference) ? issue.reference : null
       }));
+      // 수정 제안 (줄 범위와 대체 코드)
+      if (this.suggestFixes) {
+        CodeReviewer.attachFixes(responseText, result.issues);
+      }
       
       console.log(`Successfully parsed review with ${result.issues.length} issues`);
       return result;
@@ -316,6 +1206,78 @@ ${truncatedContent}
     }
   }
 
+  /**
+   * 응답 원문에서 최상위 필드 값 추출
+   * 위의 JSON 추출은 중첩 객체를 잘라내고 문자열의 이스케이프를 바꾸므로, 코드나 중첩 배열이 들어가는 필드는
+   * 원본 응답에서 괄호 짝을 맞춰 따로 파싱합니다.
+   * @param {string} responseText - Claude 응답 원문
+   * @param {string} key - 필드 이름
+   * @returns {*} 파싱된 값 (없거나 손상되었으면 null)
+   */
+  static extractField(responseText, key) {
+    const match = new RegExp(`"${key}"\\s*:\\s*([\\[{])`).exec(responseText);
+    if (!match) {
+      return null;
+    }
+    const start = match.index + match[0].length - 1;
+    let depth = 0;
+    let inString = false;
+    for (let i = start; i < responseText.length; i++) {
+      const char = responseText[i];
+      if (inString) {
+        if (char === '\\') {
+          i++;
+        } else if (char === '"') {
+          inString = false;
+        }
+      } else if (char === '"') {
+        inString = true;
+      } else if (char === '[' || char === '{') {
+        depth++;
+      } else if ((char === ']' || char === '}') && --depth === 0) {
+        try {
+          return JSON.parse(responseText.substring(start, i + 1));
+        } catch (error) {
+          return null;
+        }
+      }
+    }
+    return null;
+  }
+
+  /**
+   * 응답의 follow_ups 배열 추출
+   * @param {string} responseText - Claude 응답 원문
+   * @returns {Array} [{ id, reply }] (없거나 손상되었으면 빈 배열)
+   */
+  static parseFollowUps(responseText) {
+    const items = CodeReviewer.extractField(responseText, 'follow_ups');
+    return (Array.isArray(items) ? items : [])
+      .filter(item => item && typeof item.id === 'string' && typeof item.reply === 'string' && item.reply)
+      .map(item => ({ id: item.id.replace(/^\[|\]$/g, ''), reply: item.reply }));
+  }
+
+  /**
+   * 응답의 fixes 배열을 이슈에 연결 (이슈 줄 번호 기준, 줄마다 하나)
+   * @param {string} responseText - Claude 응답 원문
+   * @param {Array} issues - 정규화된 이슈 (fix 필드가 추가됨)
+   */
+  static attachFixes(responseText, issues) {
+    const fixes = CodeReviewer.extractField(responseText, 'fixes');
+    const valid = (Array.isArray(fixes) ? fixes : []).filter(fix =>
+      fix && Number.isInteger(fix.start_line) && Number.isInteger(fix.end_line) && typeof fix.code === 'string' &&
+      fix.start_line > 0 && fix.end_line >= fix.start_line && fix.end_line - fix.start_line < MAX_FIX_LINES
+    );
+    issues.forEach(issue => {
+      const index = valid.findIndex(fix => fix.line === issue.line ||
+        (!Number.isInteger(fix.line) && issue.line >= fix.start_line && issue.line <= fix.end_line));
+      if (issue.line && index !== -1) {
+        const [fix] = valid.splice(index, 1);
+        issue.fix = { startLine: fix.start_line, endLine: fix.end_line, code: fix.code.replace(/\n$/, '') };
+      }
+    });
+  }
+
   /**
    * 불완전한 JSON을 복구하는 메서드
    * @param {string} incompleteJson - 불완전한 JSON 문자열
@@ -423,4 +1385,8 @@ ${truncatedContent}
   }
 }
 
-module.exports = CodeReviewer;
\ No newline at end of file
+module.exports = CodeReviewer;
+module.exports.SUPPORTED_LANGUAGES = Object.keys(LANGUAGE_INSTRUCTIONS);
+module.exports.SUPPORTED_TONES = Object.keys(TONES);
+module.exports.SUPPORTED_PERSONAS = Object.keys(PERSONAS);
+module.exports.SUPPORTED_PASSES = Object.keys(REVIEW_PASSES);
\ No newline at end of file
@@ -0,0 +1,95 @@
+/**
+ * Codeowners Module
+ * CODEOWNERS 파일을 읽어 파일 경로별 담당자(@사용자, @조직/팀)를 찾는 모듈
+ *
+ * GitHub 규칙과 같이 아래쪽에 있는 규칙이 우선하며, 이메일 담당자는 멘션할 수 없으므로 제외합니다.
+ * - "/"로 시작하거나 중간에 "/"가 있는 패턴은 저장소 루트 기준
+ * - 그 밖의 패턴("*.js", "build/")은 어느 디렉터리에서나 일치
+ * - 디렉터리와 일치하는 패턴은 그 아래 모든 파일과 일치
+ */
+
+const fs = require('fs').promises;
+const path = require('path');
+const { minimatch } = require('minimatch');
+
+// GitHub가 CODEOWNERS를 찾는 위치 (앞쪽 우선)
+const CODEOWNERS_LOCATIONS = ['.github/CODEOWNERS', 'CODEOWNERS', 'docs/CODEOWNERS'];
+
+class Codeowners {
+  /**
+   * Codeowners 생성자
+   * @param {Array} rules - [{ pattern, owners }] (파일 순서대로)
+   */
+  constructor(rules = []) {
+    this.rules = rules;
+  }
+
+  /**
+   * CODEOWNERS 파일 로드 (없으면 규칙 없는 인스턴스)
+   * @param {string} [filePath] - 파일 경로 (생략 시 GitHub 기본 위치에서 찾음)
+   * @param {string} [root] - 저장소 루트 (기본값: 현재 디렉터리)
+   * @returns {Promise<Codeowners>} 담당자 매핑
+   */
+  static async load(filePath, root = process.cwd()) {
+    for (const location of filePath ? [filePath] : CODEOWNERS_LOCATIONS) {
+      try {
+        return Codeowners.parse(await fs.readFile(path.join(root, location), 'utf8'));
+      } catch (error) {
+        if (error.code !== 'ENOENT' || filePath) {
+          throw new Error(`Cannot read CODEOWNERS ${location}: ${error.message}`);
+        }
+      }
+    }
+    return new Codeowners();
+  }
+
+  /**
+   * CODEOWNERS 내용 파싱
+   * @param {string} text - 파일 내용
+   * @returns {Codeowners} 담당자 매핑
+   */
+  static parse(text) {
+    const rules = (text || '').split('\n')
+      .map(line => line.replace(/(^|\s)#.*$/, '').trim())
+      .filter(Boolean)
+      .map(line => {
+        const [pattern, ...owners] = line.split(/\s+/);
+        return { pattern, owners: owners.filter(owner => owner.startsWith('@')) };
+      });
+    return new Codeowners(rules);
+  }
+
+  /**
+   * 파일 담당자 조회 (마지막으로 일치한 규칙)
+   * @param {string} file - 파일 경로
+   * @returns {Array<string>} 담당자 (없으면 빈 배열)
+   */
+  ownersOf(file) {
+    for (let i = this.rules.length - 1; i >= 0; i--) {
+      if (Codeowners.matches(this.rules[i].pattern, file)) {
+        return this.rules[i].owners;
+      }
+    }
+    return [];
+  }
+
+  /**
+   * CODEOWNERS 패턴 일치 여부
+   * @param {string} pattern - CODEOWNERS 패턴
+   * @param {string} file - 파일 경로
+   * @returns {boolean} 일치하면 true
+   */
+  static matches(pattern, file) {
+    const anchored = pattern.startsWith('/') || pattern.replace(/\/$/, '').includes('/');
+    let glob = pattern.replace(/^\//, '');
+    if (glob.endsWith('/')) {
+      glob += '**';
+    }
+    if (!anchored) {
+      glob = `**/${glob}`;
+    }
+    return minimatch(file, glob, { dot: true }) || minimatch(file, `${glob.replace(/\/\*\*$/, '')}/**`, { dot: true });
+  }
+}
+
+module.exports = Codeowners;
@@ -4,23 +4,53 @@
  * 
  * 주요 기능:
  * - PR 댓글 작성 및 업데이트
- * - 인라인 코드 댓글 작성
+ * - 인라인 코드 댓글 작성 (comment_mode: inline, diff에 고정할 수 없는 이슈는 요약 댓글에 표시)
  * - 리뷰 결과 포맷팅
  * - 심각도별 이모지 및 색상 지원
  */
 
 const github = require('@actions/github');
+const { formatRunMetadata } = require('./run-metadata');
+const { formatCost } = require('./job-summary');
+const { getTopFindings } = require('./review-summary');
+const DisplayLabels = require('./display-labels');
+const ReferenceLinks = require('./reference-links');
+const { DEFAULT_FINDING_TEMPLATE, DEFAULT_FILE_TEMPLATE, renderTemplate } = require('./comment-template');
+
+// verbosity가 top일 때 댓글에 표시할 이슈 수
+const TOP_FINDINGS_LIMIT = 5;
+
+// 액션이 작성한 댓글과 개별 이슈를 찾기 위한 숨김 마커
+const SUMMARY_MARKER = '<!-- claude-review:summary -->';
+const FINDING_MARKER_PREFIX = '<!-- claude-review:finding:';
+const FINDING_MARKER_PATTERN = /<!-- claude-review:finding:([0-9a-f]+) -->/g;
+const RESOLVED_MARKER = '<!-- claude-review:resolved -->';
 
 class CommentManager {
   /**
    * CommentManager 생성자
    * @param {string} githubToken - GitHub API 접근 토큰
    * @param {Object} context - GitHub Actions 컨텍스트
+   * @param {Object} [options] - 추가 옵션
+   * @param {DisplayLabels} [options.labels] - 심각도/타입 아이콘과 표시 이름
+   * @param {Object} [options.templates] - { finding, file } 댓글 레이아웃 템플릿
+   * @param {ReferenceLinks} [options.references] - 이슈 타입별 "더 알아보기" 링크
+   * @param {string} [options.mode] - 댓글 방식 (summary: 요약 댓글 하나, inline: 줄 단위 PR 리뷰 + 요약 댓글)
+   * @param {boolean} [options.updateComment] - 이전 요약/인라인 댓글이 있으면 새로 쓰지 않고 수정 (기본값: true)
    */
-  constructor(githubToken, context) {
+  constructor(githubToken, context, options = {}) {
     // GitHub API 클라이언트 초기화
     this.octokit = github.getOctokit(githubToken);
     this.context = context;
+    this.labels = options.labels || new DisplayLabels();
+    this.references = options.references || new ReferenceLinks();
+    this.commentMode = options.mode || 'summary';
+    this.updateComment = options.updateComment !== false;
+    this.templates = {
+      finding: DEFAULT_FINDING_TEMPLATE,
+      file: DEFAULT_FILE_TEMPLATE,
+      ...options.templates
+    };
   }
 
   /**
@@ -30,18 +60,44 @@ class CommentManager {
    */
   async postReviewComment(reviewResults, metadata) {
     // 리뷰 댓글 본문 생성
-    const commentBody = this.buildCommentBody(reviewResults, metadata);
+    let commentBody = this.buildCommentBody(reviewResults, metadata);
 
     // 이벤트 타입에 따라 다른 방식으로 댓글 작성
     if (this.context.eventName === 'pull_request') {
-      // PR인 경우: 일반 댓글만 작성 (인라인 댓글은 diff 제약으로 인해 비활성화)
-      await this.postPullRequestComment(commentBody);
+      // 인라인 모드: diff 줄에 고정할 수 있는 이슈는 PR 리뷰의 줄 댓글로, 나머지만 요약 댓글에 표시
+      if (this.commentMode === 'inline') {
+        const { inline, remaining } = this.splitInlineFindings(reviewResults, metadata.patches || {});
+        const posted = await this.listInlineComments();
+        // 지적된 코드가 바뀌었고 다시 보고되지 않은 이슈의 이전 인라인 댓글에 해결 표시
+        const resolvedCount = await this.resolveFixedComments(posted, reviewResults, metadata.reviewedFiles);
+        // 이전 push에서 이미 인라인 댓글을 단 이슈는 다시 달지 않고, 내용이 바뀌었으면 그 댓글을 수정 (댓글의 지문 마커로 식별)
+        const { results: fresh, repeated } = this.excludePostedFindings(inline, posted);
+        const inlineCount = fresh.reduce((sum, result) => sum + result.issues.length, 0);
+        const repeatedCount = repeated.length;
+        if (inlineCount > 0 || repeatedCount > 0 || resolvedCount > 0) {
+          try {
+            if (inlineCount > 0) {
+              await this.postInlineComments(fresh);
+            }
+            if (this.updateComment) {
+              await this.updateInlineComments(repeated);
+            }
+            commentBody = this.buildCommentBody(remaining, { ...metadata, inlineCount, repeatedCount, resolvedCount });
+          } catch (error) {
+            // 리뷰 생성에 실패하면 모든 이슈를 요약 댓글에 표시
+            console.warn(error.message);
+          }
+        }
+      }
+      await this.postPullRequestComment(commentBody, { updateOnly: metadata.updateOnly });
+    } else if (this.context.eventName === 'push') {
+      // Push인 경우: PR이 없으므로 push된 커밋에 댓글 작성
+      // 보고할 내용이 없는 실행은 이전 요약 댓글을 수정하기만 함 (없으면 댓글을 만들지 않음)
+      await this.postCommitComment(commentBody, { updateOnly: metadata.updateOnly });
+      console.log(`✅ 총 ${metadata.totalFiles}개 파일에서 ${metadata.totalIssues}개 이슈 발견`);
     } else {
-      // Push인 경우: commit comment 권한 문제로 인해 콘솔 로그만 출력
-      console.log('📋 Push 이벤트 코드 리뷰 완료');
-      console.log('='.repeat(50));
+      // 커밋 범위 리뷰(workflow_dispatch 등): 댓글 대상이 없으므로 워크플로우 로그에 출력 (출력값과 리포트로 결과 사용)
       console.log(commentBody);
-      console.log('='.repeat(50));
       console.log(`✅ 총 ${metadata.totalFiles}개 파일에서 ${metadata.totalIssues}개 이슈 발견`);
     }
   }
@@ -53,13 +109,38 @@ class CommentManager {
    * @returns {string} 포맷팅된 댓글 본문
    */
   buildCommentBody(reviewResults, metadata) {
-    const { totalFiles, totalIssues, reviewType } = metadata;
+    const { totalFiles, totalIssues, reviewType, reviewTypes = {}, runMetadata, overallScore, verbosity = 'full', commitFindings = [], prConvention = null, changelog = null, reviewers = [], compatibility = null, dependencies = null, packages = [], modelFallbacks = [], conversation = null, incremental = null, commitReviews = [], risk = null, inlineCount = 0, repeatedCount = 0, resolvedCount = 0 } = metadata;
     
     // 댓글 헤더
-    let comment = `## 🤖 Claude AI 코드 리뷰\n\n`;
-    comment += `**리뷰 타입:** ${this.getReviewTypeEmoji(reviewType)} ${reviewType}\n`;
+    let comment = `${SUMMARY_MARKER}\n`;
+    comment += `## 🤖 Claude AI 코드 리뷰\n\n`;
+    // 경로별로 다른 리뷰 타입을 적용했으면 타입별 파일 수 표시
+    const appliedTypes = Object.entries(reviewTypes).sort((a, b) => b[1] - a[1]);
+    const headerType = appliedTypes.length === 1 ? appliedTypes[0][0] : reviewType;
+    comment += appliedTypes.length > 1
+      ? `**리뷰 타입:** ${appliedTypes.map(([type, count]) => `${this.getReviewTypeEmoji(type)} ${type} (${count}개 파일)`).join(', ')}\n`
+      : `**리뷰 타입:** ${this.getReviewTypeEmoji(headerType)} ${headerType}\n`;
     comment += `**검토한 파일:** ${totalFiles}개\n`;
-    comment += `**발견된 이슈:** ${totalIssues}개\n\n`;
+    if (incremental && incremental.isIncremental()) {
+      comment += `**증분 리뷰:** \`${incremental.base.substring(0, 7)}\` 이후 변경분만 검토 (이전 커밋의 이슈는 인라인 댓글과 이전 리뷰 참고)\n`;
+    }
+    comment += `**발견된 이슈:** ${totalIssues}개\n`;
+    if (typeof overallScore === 'number') {
+      comment += `**종합 점수:** ${overallScore}/10\n`;
+    }
+    if (risk) {
+      comment += `**위험도:** ${this.formatRisk(risk)}\n`;
+    }
+    if (inlineCount > 0) {
+      comment += `**인라인 댓글:** ${inlineCount}개 (변경된 코드 줄에 직접 작성)\n`;
+    }
+    if (repeatedCount > 0) {
+      comment += `**이미 댓글을 단 이슈:** ${repeatedCount}개 (이전 push의 인라인 댓글 참고)\n`;
+    }
+    if (resolvedCount > 0) {
+      comment += `**해결된 이슈:** ${resolvedCount}개 (이전 인라인 댓글에 ✅ 표시)\n`;
+    }
+    comment += `\n`;
 
     // 이슈가 없는 경우
     if (totalIssues === 0) {
@@ -68,27 +149,294 @@ class CommentManager {
     } else {
       // 이슈가 있는 경우
       comment += `### 📋 리뷰 요약\n\n`;
+      if (inlineCount > 0 || repeatedCount > 0) {
+        comment += reviewResults.length > 0
+          ? `> 💬 diff의 줄에 고정할 수 없는 이슈만 아래에 표시합니다.\n\n`
+          : `> 💬 모든 이슈를 변경된 코드 줄에 인라인 댓글로 작성했습니다.\n\n`;
+      }
       
       // 심각도별 통계
       const severityStats = this.getSeverityStats(reviewResults);
       comment += this.buildSeverityTable(severityStats);
       
-      // 파일별 상세 리뷰
-      comment += `\n### 📁 파일별 상세 리뷰\n\n`;
-      
-      for (const result of reviewResults) {
-        comment += this.buildFileReview(result);
+      if (verbosity === 'top') {
+        // 심각도 높은 이슈만 표시
+        comment += this.buildTopFindings(reviewResults, totalIssues);
+      } else if (verbosity === 'full' && commitReviews.length > 0) {
+        // 커밋별 리뷰: 이슈를 처음 보고된 커밋 아래에 표시
+        comment += this.buildCommitSections(commitReviews, reviewResults);
+      } else if (verbosity === 'full') {
+        // 파일별 상세 리뷰
+        comment += `\n### 📁 파일별 상세 리뷰\n\n`;
+        
+        for (const result of reviewResults) {
+          comment += this.buildFileReview(result);
+        }
       }
+
+      if (verbosity !== 'full') {
+        comment += `\n> 💡 전체 이슈는 리포트 파일(\`report_path\` 출력값)에서 확인할 수 있습니다.\n`;
+      }
+    }
+
+    // 커밋 메시지 리뷰 결과
+    if (commitFindings.length > 0) {
+      comment += this.buildCommitReview(commitFindings);
+    }
+
+    // PR 제목/설명 규칙 검사 결과
+    if (prConvention) {
+      comment += this.buildPrConventionReview(prConvention);
+    }
+
+    // 변경 로그 항목 제안
+    if (changelog) {
+      comment += this.buildChangelogSuggestion(changelog);
+    }
+
+    // 이슈 위치 기반 추천 리뷰어
+    if (reviewers.length > 0) {
+      comment += this.buildReviewerSuggestions(reviewers);
+    }
+
+    // Go 공개 API 호환성 결과
+    if (compatibility && compatibility.changes.length > 0) {
+      comment += this.buildCompatibilityReview(compatibility);
+    }
+
+    // 의존성 변경 검토 결과
+    if (dependencies && dependencies.changes.length > 0) {
+      comment += this.buildDependencyReview(dependencies);
+    }
+
+    // 모노레포 패키지별 판정
+    if (packages.length > 0) {
+      comment += this.buildPackageReview(packages);
+    }
+
+    // 대체 모델로 리뷰한 대상
+    if (modelFallbacks.length > 0) {
+      comment += this.buildModelFallbacks(modelFallbacks);
+    }
+
+    // 이전 push부터 이어지는 리뷰 대화와 다음 실행을 위한 상태
+    if (conversation) {
+      comment += conversation.buildSection();
+      comment += `${conversation.marker()}\n`;
+    }
+
+    // 다음 push의 증분 리뷰 기준이 되는 마지막 리뷰 커밋
+    if (incremental) {
+      comment += `${incremental.marker()}\n`;
     }
 
     // 댓글 푸터
     comment += `\n---\n`;
     comment += `*리뷰 시간: ${new Date().toISOString()}*\n`;
+    if (runMetadata && runMetadata.usage && runMetadata.usage.tokens > 0) {
+      // 토큰 사용량과 예상 비용 (가격을 모르는 모델만 사용했으면 토큰 수만)
+      const { tokens, costUsd } = runMetadata.usage;
+      comment += `*토큰 사용량: ${tokens.toLocaleString('en-US')}${costUsd !== null ? ` (예상 비용 ${formatCost(costUsd)})` : ''}*\n`;
+    }
+    if (runMetadata) {
+      // 재현 및 비교를 위한 실행 메타데이터
+      comment += `<sub>${formatRunMetadata(runMetadata)}</sub>\n\n`;
+    }
     comment += `*Powered by Claude AI* 🚀`;
 
     return comment;
   }
 
+  /**
+   * 커밋 메시지 리뷰 섹션 생성
+   * @param {Array} commitFindings - [{ sha, subject, problems, suggestion }]
+   * @returns {string} 마크다운 섹션
+   */
+  buildCommitReview(commitFindings) {
+    const cell = text => String(text).replace(/\|/g, '\\|').replace(/\n/g, ' ');
+    let section = `\n### 📝 커밋 메시지 리뷰 (${commitFindings.length}개)\n\n`;
+    section += `| 커밋 | 문제 | 제안 |\n|------|------|------|\n`;
+    commitFindings.forEach(finding => {
+      const suggestion = finding.suggestion ? `\`${cell(finding.suggestion)}\`` : '-';
+      section += `| \`${finding.sha.substring(0, 7)}\` ${cell(finding.subject)} | ${finding.problems.map(cell).join('<br>')} | ${suggestion} |\n`;
+    });
+    return section;
+  }
+
+  /**
+   * PR 제목/설명 규칙 섹션 생성
+   * @param {Object} prConvention - { title, problems, suggestedTitle, sections: [{ name, content }] }
+   * @returns {string} 마크다운 섹션
+   */
+  buildPrConventionReview(prConvention) {
+    let section = `\n### 🏷️ PR 제목/설명 규칙 (${prConvention.problems.length}개)\n\n`;
+    prConvention.problems.forEach(problem => {
+      section += `- ${problem}\n`;
+    });
+    if (prConvention.suggestedTitle) {
+      section += `\n**제안 제목:** \`${prConvention.suggestedTitle.replace(/`/g, "'")}\`\n`;
+    }
+    prConvention.sections.forEach(({ name, content }) => {
+      section += `\n<details>\n<summary>제안 섹션: ${name}</summary>\n\n\`\`\`\`markdown\n## ${name}\n\n${content}\n\`\`\`\`\n\n</details>\n`;
+    });
+    return section;
+  }
+
+  /**
+   * 변경 로그 항목 제안 섹션 생성
+   * @param {Object} changelog - { path, category, entry, heading, patch, url }
+   * @returns {string} 마크다운 섹션
+   */
+  buildChangelogSuggestion(changelog) {
+    let section = `\n### 📰 변경 로그 항목 제안\n\n`;
+    if (!changelog.path) {
+      section += `사용자에게 영향이 있는 변경입니다. 변경 로그 파일이 없어 항목만 제안합니다.\n\n`;
+      section += `> **${changelog.category}:** ${changelog.entry}\n`;
+      return section;
+    }
+    section += `\`${changelog.path}\`의 **${changelog.heading}**에 다음 항목을 추가해주세요`;
+    section += changelog.url ? ` ([인라인 제안](${changelog.url})).\n\n` : '.\n\n';
+    section += `\`\`\`diff\n${changelog.patch}\n\`\`\`\n`;
+    return section;
+  }
+
+  /**
+   * 추천 리뷰어 섹션 생성
+   * @param {Array} reviewers - [{ reviewer, score, reasons, requested }]
+   * @returns {string} 마크다운 섹션
+   */
+  buildReviewerSuggestions(reviewers) {
+    // 댓글 수정 때마다 알림이 가지 않도록 멘션 대신 코드 표기
+    let section = `\n### 👀 추천 리뷰어\n\n`;
+    section += `| 리뷰어 | 근거 | 상태 |\n|--------|------|------|\n`;
+    reviewers.forEach(item => {
+      const reasons = item.reasons.slice(0, 3).join('<br>') + (item.reasons.length > 3 ? `<br>외 ${item.reasons.length - 3}개` : '');
+      section += `| \`${item.reviewer}\` | ${reasons} | ${item.requested ? '리뷰 요청함' : '추천'} |\n`;
+    });
+    return section;
+  }
+
+  /**
+   * 모델 대체 섹션 생성
+   * @param {Array} modelFallbacks - [{ target, requested, model, reason }]
+   * @returns {string} 마크다운 섹션
+   */
+  buildModelFallbacks(modelFallbacks) {
+    const reasons = { overloaded: '과부하', rate_limit: '요청 한도 초과', context_length: '컨텍스트 길이 초과' };
+    let section = `\n<details>\n<summary>🔁 대체 모델로 리뷰한 대상 (${modelFallbacks.length}개)</summary>\n\n`;
+    section += `| 대상 | 요청한 모델 | 리뷰한 모델 | 사유 |\n|------|-------------|-------------|------|\n`;
+    modelFallbacks.forEach(item => {
+      section += `| \`${item.target}\` | \`${item.requested}\` | \`${item.model}\` | ${reasons[item.reason] || item.reason} |\n`;
+    });
+    section += `\n나머지 대상은 요청한 모델로 리뷰했습니다.\n</details>\n`;
+    return section;
+  }
+
+  /**
+   * 커밋별 리뷰 섹션 생성 (이슈가 없는 커밋도 표시)
+   * @param {Array} commitReviews - [{ sha, subject, results }] (오래된 순)
+   * @param {Array} reviewResults - 댓글에 표시할 결과 (제외되거나 인라인으로 작성한 이슈는 커밋 섹션에서도 제외)
+   * @returns {string} 마크다운 섹션
+   */
+  buildCommitSections(commitReviews, reviewResults) {
+    const shown = new Set(reviewResults.flatMap(result => result.issues));
+    let section = `\n### 🧱 커밋별 리뷰 (${commitReviews.length}개 커밋)\n\n`;
+    commitReviews.forEach(commit => {
+      const results = commit.results
+        .map(result => ({ ...result, issues: result.issues.filter(issue => shown.has(issue)) }))
+        .filter(result => result.issues.length > 0);
+      const issueCount = results.reduce((sum, result) => sum + result.issues.length, 0);
+      section += `#### \`${commit.sha.substring(0, 7)}\` ${commit.subject}\n\n`;
+      section += issueCount > 0 ? `이 커밋에서 생긴 이슈 ${issueCount}개\n\n` : `✅ 이 커밋에서 생긴 이슈가 없습니다.\n\n`;
+      results.forEach(result => {
+        section += this.buildFileReview(result);
+      });
+    });
+    return section;
+  }
+
+  /**
+   * Go API 호환성 섹션 생성 (호환성을 깨는 변경 먼저)
+   * @param {Object} compatibility - { changes, requiresMajor, majorBumped }
+   * @returns {string} 마크다운 섹션
+   */
+  buildCompatibilityReview(compatibility) {
+    const cell = text => String(text).replace(/\|/g, '\\|').replace(/\n/g, ' ');
+    const changeLabels = { added: '추가', removed: '삭제', changed: '변경' };
+    const breaking = compatibility.changes.filter(change => change.breaking);
+    let section = `\n### 🧩 Go API 호환성 (호환성을 깨는 변경 ${breaking.length}개)\n\n`;
+    if (compatibility.requiresMajor && !compatibility.majorBumped) {
+      section += `> ⚠️ 호환성을 깨는 변경이 있어 **메이저 버전 업데이트**가 필요합니다 (go.mod 모듈 경로에 \`/vN\` 접미사 추가).\n\n`;
+    } else if (compatibility.requiresMajor) {
+      section += `> ℹ️ 호환성을 깨는 변경이 있지만 이 PR에서 모듈 메이저 버전이 올라갔습니다.\n\n`;
+    }
+    section += `| 패키지 | 식별자 | 변경 | 호환성 | 이유 |\n|--------|--------|------|--------|------|\n`;
+    [...breaking, ...compatibility.changes.filter(change => !change.breaking)].forEach(change => {
+      const signature = change.after || change.before;
+      section += `| \`${cell(change.package)}\` | \`${cell(change.symbol)}\` | ${changeLabels[change.change]}: \`${cell(signature)}\` | ${change.breaking ? '❌ 깨짐' : '✅ 호환'} | ${cell(change.reason) || '-'} |\n`;
+    });
+    return section;
+  }
+
+  /**
+   * 의존성 변경 섹션 생성 (위험도 높은 변경, 메이저 업데이트 순)
+   * @param {Object} dependencies - { summary, changes }
+   * @returns {string} 마크다운 섹션
+   */
+  buildDependencyReview(dependencies) {
+    const cell = text => String(text).replace(/\|/g, '\\|').replace(/\n/g, ' ');
+    const riskLabels = { high: '🔴 high', medium: '🟡 medium', low: '🟢 low' };
+    const riskOrder = { high: 3, medium: 2, low: 1 };
+    const changes = [...dependencies.changes]
+      .sort((a, b) => (riskOrder[b.risk] || 0) - (riskOrder[a.risk] || 0) || Number(b.major) - Number(a.major));
+    const major = changes.filter(change => change.major).length;
+    const risky = changes.filter(change => change.risk === 'high').length;
+
+    let section = `\n### 🔗 의존성 변경 (${changes.length}개, 메이저 업데이트 ${major}개, 위험 ${risky}개)\n\n`;
+    if (dependencies.summary) {
+      section += `> ${cell(dependencies.summary)}\n\n`;
+    }
+    section += `| 매니페스트 | 패키지 | 변경 | 위험도 | 영향 범위 | 메모 |\n|------------|--------|------|--------|-----------|------|\n`;
+    changes.forEach(change => {
+      let description = `추가 \`${cell(change.after)}\``;
+      if (change.change === 'removed') {
+        description = `삭제 \`${cell(change.before)}\``;
+      } else if (change.change === 'updated') {
+        description = `\`${cell(change.before)}\` → \`${cell(change.after)}\`${change.major ? ' ⚠️ 메이저' : ''}`;
+      }
+      const scope = change.scope === 'runtime' ? '' : ` (${change.scope})`;
+      const usages = typeof change.usages === 'number' ? `파일 ${change.usages}개` : '-';
+      const license = change.license ? `${change.licenseCompatible === false ? '❌' : '⚖️'} ${cell(change.license)} ` : '';
+      section += `| \`${cell(change.manifest)}\` | \`${cell(change.name)}\`${scope} | ${description} | ${riskLabels[change.risk] || '-'} | ${usages} | ${license}${cell(change.notes) || (license ? '' : '-')} |\n`;
+    });
+    return section;
+  }
+
+  /**
+   * 패키지별 판정 섹션 생성 (fail 먼저)
+   * @param {Array} packages - [{ name, path, reason, via, files, issues, highestSeverity, verdict }]
+   * @returns {string} 마크다운 섹션
+   */
+  buildPackageReview(packages) {
+    const cell = text => String(text).replace(/\|/g, '\\|').replace(/\n/g, ' ');
+    const failed = packages.filter(pkg => pkg.verdict === 'fail');
+    let section = `\n### 📦 패키지별 결과 (영향받은 패키지 ${packages.length}개, 실패 ${failed.length}개)\n\n`;
+    section += `| 패키지 | 영향 | 이슈 | 판정 |\n|--------|------|------|------|\n`;
+    [...failed, ...packages.filter(pkg => pkg.verdict !== 'fail')].forEach(pkg => {
+      let reason = `파일 ${pkg.files}개 변경`;
+      if (pkg.reason === 'dependency') {
+        reason = `의존 패키지 \`${cell(pkg.via)}\` 변경`;
+      } else if (pkg.reason === 'root') {
+        reason = `루트 파일 \`${cell(pkg.via)}\` 변경`;
+      }
+      const issues = pkg.issues > 0
+        ? `${pkg.issues}개 (최고 ${this.labels.withIcon(this.labels.severityIcon(pkg.highestSeverity), this.labels.severityLabel(pkg.highestSeverity))})`
+        : '-';
+      section += `| \`${cell(pkg.name)}\` | ${reason} | ${issues} | ${pkg.verdict === 'fail' ? '❌ fail' : '✅ pass'} |\n`;
+    });
+    return section;
+  }
+
   /**
    * 리뷰 타입별 이모지 반환
    * @param {string} reviewType - 리뷰 타입
@@ -99,7 +447,12 @@ class CommentManager {
       full: '🔍',
       security: '🔒',
       performance: '⚡',
-      style: '🎨'
+      style: '🎨',
+      tests: '🧪',
+      docs: '📖',
+      a11y: '♿',
+      compliance: '⚖️',
+      i18n: '🌐'
     };
     return emojis[reviewType] || '🔍';
   }
@@ -135,43 +488,57 @@ class CommentManager {
     let table = `| 심각도 | 개수 | 설명 |\n`;
     table += `|--------|------|------|\n`;
     
-    if (stats.critical > 0) {
-      table += `| 🔴 **Critical** | ${stats.critical} | 즉시 수정이 필요한 심각한 문제 |\n`;
-    }
-    if (stats.high > 0) {
-      table += `| 🟠 **High** | ${stats.high} | 중요한 문제, 빠른 수정 권장 |\n`;
-    }
-    if (stats.medium > 0) {
-      table += `| 🟡 **Medium** | ${stats.medium} | 일반적인 개선 사항 |\n`;
-    }
-    if (stats.low > 0) {
-      table += `| 🟢 **Low** | ${stats.low} | 선택적 개선 사항 |\n`;
+    const descriptions = {
+      critical: '즉시 수정이 필요한 심각한 문제',
+      high: '중요한 문제, 빠른 수정 권장',
+      medium: '일반적인 개선 사항',
+      low: '선택적 개선 사항'
+    };
+
+    for (const severity of ['critical', 'high', 'medium', 'low']) {
+      if (stats[severity] > 0) {
+        const label = this.labels.withIcon(this.labels.severityIcon(severity), `**${this.labels.severityLabel(severity)}**`);
+        table += `| ${label} | ${stats[severity]} | ${descriptions[severity]} |\n`;
+      }
     }
 
     return table;
   }
 
+  /**
+   * 심각도 상위 이슈 목록 생성 (verbosity: top)
+   * @param {Array} reviewResults - 리뷰 결과
+   * @param {number} totalIssues - 전체 이슈 수
+   * @returns {string} 포맷팅된 상위 이슈
+   */
+  buildTopFindings(reviewResults, totalIssues) {
+    const findings = getTopFindings(reviewResults, TOP_FINDINGS_LIMIT);
+    let section = `\n### 🔝 주요 이슈 (${findings.length}/${totalIssues})\n\n`;
+
+    findings.forEach(finding => {
+      const location = finding.line ? `${finding.file}:${finding.line}` : finding.file;
+      section += `**📄 \`${location}\`**\n\n`;
+      section += this.buildIssueBlock(finding);
+    });
+
+    return section;
+  }
+
   /**
    * 파일별 리뷰 내용 생성
    * @param {Object} result - 파일 리뷰 결과
    * @returns {string} 포맷팅된 리뷰 내용
    */
   buildFileReview(result) {
-    let review = `<details>\n`;
-    review += `<summary><b>📄 ${result.file}</b> (${result.issues.length}개 이슈)</summary>\n\n`;
-    
-    // 파일 요약
-    if (result.summary) {
-      review += `> ${result.summary}\n\n`;
-    }
-
     // 각 이슈 상세 내용
-    result.issues.forEach((issue, index) => {
-      review += this.buildIssueBlock(issue, index + 1);
-    });
+    const findings = result.issues.map(issue => this.buildIssueBlock(issue)).join('');
 
-    review += `</details>\n\n`;
-    return review;
+    return renderTemplate(this.templates.file, {
+      file: result.file,
+      issueCount: result.issues.length,
+      summary: result.summary,
+      findings
+    });
   }
 
   /**
@@ -181,35 +548,90 @@ class CommentManager {
    * @returns {string} 포맷팅된 이슈 블록
    */
   buildIssueBlock(issue) {
-    const severityEmoji = this.getSeverityEmoji(issue.severity);
-    const typeEmoji = this.getTypeEmoji(issue.type);
-    
-    let block = `#### ${severityEmoji} ${issue.title}\n`;
-    block += `**타입:** ${typeEmoji} ${issue.type} | `;
-    block += `**심각도:** ${issue.severity}`;
-    
-    if (issue.line) {
-      block += ` | **라인:** ${issue.line}`;
-    }
-    block += `\n\n`;
+    const severityIcon = this.getSeverityEmoji(issue.severity);
+    const typeIcon = this.getTypeEmoji(issue.type);
+    const snippet = issue.snippet || null;
 
-    // 설명
-    if (issue.description) {
-      block += `**문제점:**\n${issue.description}\n\n`;
-    }
+    // 외부 연동(Slack 등)에서 이 이슈 블록을 찾을 수 있도록 지문 마커는 템플릿과 관계없이 삽입
+    const marker = issue.fingerprint ? `${this.findingMarker(issue.fingerprint)}\n` : '';
+    return marker + renderTemplate(this.templates.finding, {
+      heading: this.labels.withIcon(severityIcon, issue.title),
+      title: issue.title,
+      severity: issue.severity,
+      severityIcon,
+      severityLabel: this.labels.severityLabel(issue.severity),
+      type: issue.type,
+      typeIcon,
+      typeLabel: this.labels.typeLabel(issue.type),
+      typeBadge: this.labels.withIcon(typeIcon, this.labels.typeLabel(issue.type)),
+      line: issue.line,
+      description: issue.description,
+      suggestion: issue.suggestion,
+      codeExample: issue.codeExample,
+      testFile: issue.testFile,
+      why: issue.why,
+      reference: issue.reference,
+      learnMore: this.references.resolve(issue.type),
+      snippet: snippet ? snippet.code : null,
+      snippetStartLine: snippet ? snippet.startLine : null,
+      fingerprint: issue.fingerprint,
+      persona: issue.persona
+    });
+  }
 
-    // 개선 제안
-    if (issue.suggestion) {
-      block += `**개선 방안:**\n${issue.suggestion}\n\n`;
-    }
+  /**
+   * 위험도 점수와 점수를 구성한 요소 표시
+   * @param {Object} risk - RiskScorer.score() 결과 { score, level, factors }
+   * @returns {string} 예: 🟡 42/100 (medium) — 변경 규모 +15, 이슈 +24
+   */
+  formatRisk(risk) {
+    const icons = { high: '🔴', medium: '🟡', low: '🟢' };
+    const names = { size: '변경 규모', paths: '민감 경로', findings: '이슈', tests: '테스트 부족' };
+    const factors = risk.factors
+      .filter(factor => factor.points > 0)
+      .map(factor => `${names[factor.name] || factor.name} +${factor.points}`);
+    return `${icons[risk.level] || ''} ${risk.score}/100 (${risk.level})${factors.length > 0 ? ` — ${factors.join(', ')}` : ''}`;
+  }
 
-    // 코드 예시
-    if (issue.codeExample) {
-      block += `**예시 코드:**\n\`\`\`\n${issue.codeExample}\n\`\`\`\n\n`;
-    }
+  /**
+   * 이슈 지문 마커 생성
+   * @param {string} fingerprint - 이슈 지문
+   * @returns {string} 숨김 HTML 주석
+   */
+  findingMarker(fingerprint) {
+    return `${FINDING_MARKER_PREFIX}${fingerprint} -->`;
+  }
 
-    block += `---\n\n`;
-    return block;
+  /**
+   * 리뷰 댓글의 특정 이슈 블록에 메모 추가 (예: Slack에서 무시 처리됨)
+   * @param {number} issueNumber - PR 번호
+   * @param {string} fingerprint - 이슈 지문
+   * @param {string} note - 추가할 메모 (마크다운)
+   * @returns {Promise<number>} 수정한 댓글 수
+   */
+  async annotateFinding(issueNumber, fingerprint, note) {
+    const marker = this.findingMarker(fingerprint);
+    const comments = await this.octokit.paginate(this.octokit.rest.issues.listComments, {
+      owner: this.context.repo.owner,
+      repo: this.context.repo.repo,
+      issue_number: issueNumber,
+      per_page: 100
+    });
+
+    let updated = 0;
+    for (const comment of comments) {
+      if (!comment.body || !comment.body.includes(SUMMARY_MARKER) || !comment.body.includes(marker)) {
+        continue;
+      }
+      await this.octokit.rest.issues.updateComment({
+        owner: this.context.repo.owner,
+        repo: this.context.repo.repo,
+        comment_id: comment.id,
+        body: comment.body.replace(marker, `${marker}\n> ${note}\n`)
+      });
+      updated++;
+    }
+    return updated;
   }
 
   /**
@@ -218,13 +640,7 @@ class CommentManager {
    * @returns {string} 이모지
    */
   getSeverityEmoji(severity) {
-    const emojis = {
-      critical: '🔴',
-      high: '🟠',
-      medium: '🟡',
-      low: '🟢'
-    };
-    return emojis[severity] || '🔵';
+    return this.labels.severityIcon(severity);
   }
 
   /**
@@ -233,46 +649,83 @@ class CommentManager {
    * @returns {string} 이모지
    */
   getTypeEmoji(type) {
-    const emojis = {
-      bug: '🐛',
-      security: '🔒',
-      performance: '⚡',
-      style: '🎨',
-      maintainability: '🔧',
-      'best-practice': '📚'
-    };
-    return emojis[type] || '📝';
+    return this.labels.typeIcon(type);
   }
 
   /**
    * Pull Request에 댓글 작성
    * @param {string} commentBody - 댓글 본문
+   * @param {Object} [options] - 옵션
+   * @param {boolean} [options.updateOnly] - 이전 요약 댓글이 있을 때만 수정 (새 댓글은 만들지 않음)
    */
-  async postPullRequestComment(commentBody) {
+  async postPullRequestComment(commentBody, { updateOnly = false } = {}) {
+    const issue = {
+      owner: this.context.repo.owner,
+      repo: this.context.repo.repo,
+      issue_number: this.context.payload.pull_request.number
+    };
     try {
-      // 항상 새 댓글 생성
-      await this.octokit.rest.issues.createComment({
-        owner: this.context.repo.owner,
-        repo: this.context.repo.repo,
-        issue_number: this.context.payload.pull_request.number,
-        body: commentBody
-      });
+      // 이전 요약 댓글이 있으면 수정 (내용이 같으면 그대로 둠), 없으면 새 댓글 생성
+      if (this.updateComment) {
+        const comments = await this.octokit.paginate(this.octokit.rest.issues.listComments, { ...issue, per_page: 100 });
+        const previous = [...comments].reverse().find(comment => (comment.body || '').includes(SUMMARY_MARKER));
+        if (previous) {
+          if (previous.body !== commentBody) {
+            await this.octokit.rest.issues.updateComment({
+              owner: issue.owner,
+              repo: issue.repo,
+              comment_id: previous.id,
+              body: commentBody
+            });
+          }
+          return;
+        }
+      }
+      if (!updateOnly) {
+        await this.octokit.rest.issues.createComment({ ...issue, body: commentBody });
+      }
     } catch (error) {
       throw new Error(`Failed to post PR comment: ${error.message}`);
     }
   }
 
   /**
-   * 인라인 코드 댓글 작성
-   * @param {Array} reviewResults - 리뷰 결과
+   * PR에 위험도 라벨 적용 (risk:high|medium|low, 이전 등급 라벨은 제거)
+   * @param {string} level - 위험도 등급
+   */
+  async applyRiskLabel(level) {
+    const issue = {
+      owner: this.context.repo.owner,
+      repo: this.context.repo.repo,
+      issue_number: this.context.payload.pull_request.number
+    };
+    try {
+      const { data: current } = await this.octokit.rest.issues.listLabelsOnIssue({ ...issue, per_page: 100 });
+      const stale = current.map(label => label.name).filter(name => name.startsWith('risk:') && name !== `risk:${level}`);
+      for (const name of stale) {
+        await this.octokit.rest.issues.removeLabel({ ...issue, name });
+      }
+      if (!current.some(label => label.name === `risk:${level}`)) {
+        await this.octokit.rest.issues.addLabels({ ...issue, labels: [`risk:${level}`] });
+      }
+    } catch (error) {
+      throw new Error(`Failed to apply risk label: ${error.message}`);
+    }
+  }
+
+  /**
+   * 인라인 코드 댓글 작성 (모든 댓글이 diff 안의 줄이어야 리뷰 생성이 성공)
+   * @param {Array} reviewResults - 리뷰 결과 (splitInlineFindings()의 inline)
+   * @returns {Promise<Object>} 생성된 리뷰
    */
   async postInlineComments(reviewResults) {
     try {
       // PR 리뷰 생성
-      const review = await this.octokit.rest.pulls.createReview({
+      const { data: review } = await this.octokit.rest.pulls.createReview({
         owner: this.context.repo.owner,
         repo: this.context.repo.repo,
         pull_number: this.context.payload.pull_request.number,
+        commit_id: this.context.payload.pull_request.head ? this.context.payload.pull_request.head.sha : undefined,
         event: 'COMMENT',
         body: 'Claude AI가 코드를 검토했습니다. 아래 인라인 댓글을 확인해주세요.',
         comments: this.buildInlineComments(reviewResults)
@@ -280,11 +733,144 @@ class CommentManager {
 
       return review;
     } catch (error) {
-      // 인라인 댓글 실패는 무시 (메인 댓글이 더 중요)
-      console.warn(`Failed to post inline comments: ${error.message}`);
+      throw new Error(`Failed to post inline comments: ${error.message}`);
+    }
+  }
+
+  /**
+   * 이 PR에 액션이 이미 작성한 인라인 댓글 (실패하면 빈 목록으로 모두 다시 작성)
+   * @returns {Promise<Map<string, Object>>} 이슈 지문 → 댓글 { id, body }
+   */
+  async listInlineComments() {
+    try {
+      const comments = await this.octokit.paginate(this.octokit.rest.pulls.listReviewComments, {
+        owner: this.context.repo.owner,
+        repo: this.context.repo.repo,
+        pull_number: this.context.payload.pull_request.number,
+        per_page: 100
+      });
+      const posted = new Map();
+      comments.forEach(comment => {
+        [...String(comment.body || '').matchAll(FINDING_MARKER_PATTERN)].forEach(match => posted.set(match[1], comment));
+      });
+      return posted;
+    } catch (error) {
+      console.warn(`Failed to list previous inline comments: ${error.message}`);
+      return new Map();
     }
   }
 
+  /**
+   * 이미 인라인 댓글을 단 이슈 제외
+   * @param {Array} reviewResults - 리뷰 결과 (splitInlineFindings()의 inline)
+   * @param {Map<string, Object>} posted - 이슈 지문 → 이전 인라인 댓글
+   * @returns {Object} { results, repeated } (repeated는 { issue, comment } 목록)
+   */
+  excludePostedFindings(reviewResults, posted) {
+    const repeated = [];
+    const results = reviewResults
+      .map(result => {
+        const issues = result.issues.filter(issue => {
+          const comment = issue.fingerprint ? posted.get(issue.fingerprint) : null;
+          if (comment) {
+            repeated.push({ issue, comment });
+          }
+          return !comment;
+        });
+        return { ...result, issues };
+      })
+      .filter(result => result.issues.length > 0);
+
+    return { results, repeated };
+  }
+
+  /**
+   * 해결된 이슈의 이전 인라인 댓글에 해결 표시 추가
+   * GitHub은 고정된 줄이 이후 커밋에서 바뀐 댓글을 outdated(line: null)로 표시하므로,
+   * outdated이면서 이번 리뷰에서 같은 지문으로 다시 보고되지 않은 이슈를 해결된 것으로 봄
+   * @param {Map<string, Object>} posted - 이슈 지문 → 이전 인라인 댓글
+   * @param {Array} reviewResults - 이번 리뷰 결과
+   * @param {Array<string>} [reviewedFiles] - 이번에 리뷰한 파일 (다른 파일의 댓글은 그대로 둠, 없으면 모든 댓글 대상)
+   * @returns {Promise<number>} 해결 표시한 댓글 수
+   */
+  async resolveFixedComments(posted, reviewResults, reviewedFiles = null) {
+    const current = new Set(reviewResults.flatMap(result => result.issues.map(issue => issue.fingerprint)).filter(Boolean));
+    const head = this.context.payload.pull_request.head;
+    const commit = head && head.sha ? head.sha.substring(0, 7) : '최신 커밋';
+    let resolved = 0;
+    for (const [fingerprint, comment] of posted) {
+      const body = String(comment.body || '');
+      if (current.has(fingerprint) || comment.line !== null || body.includes(RESOLVED_MARKER) ||
+          (reviewedFiles && !reviewedFiles.includes(comment.path))) {
+        continue;
+      }
+      try {
+        await this.octokit.rest.pulls.updateReviewComment({
+          owner: this.context.repo.owner,
+          repo: this.context.repo.repo,
+          comment_id: comment.id,
+          body: `${body}\n\n${RESOLVED_MARKER}\n✅ **${commit}에서 해결됨** — 지적된 코드가 변경되었고 다시 보고되지 않았습니다.`
+        });
+        resolved++;
+      } catch (error) {
+        console.warn(`Failed to mark comment ${comment.id} as resolved: ${error.message}`);
+      }
+    }
+    return resolved;
+  }
+
+  /**
+   * 내용이 바뀐 이슈의 이전 인라인 댓글 수정 (같은 내용이면 그대로 둠)
+   * @param {Array} repeated - excludePostedFindings()의 repeated
+   * @returns {Promise<number>} 수정한 댓글 수
+   */
+  async updateInlineComments(repeated) {
+    let updated = 0;
+    for (const { issue, comment } of repeated) {
+      const body = this.buildInlineCommentBody(issue);
+      if (body === comment.body) {
+        continue;
+      }
+      await this.octokit.rest.pulls.updateReviewComment({
+        owner: this.context.repo.owner,
+        repo: this.context.repo.repo,
+        comment_id: comment.id,
+        body
+      });
+      updated++;
+    }
+    return updated;
+  }
+
+  /**
+   * 이슈를 diff 줄에 고정할 수 있는 것과 없는 것으로 분리
+   * @param {Array} reviewResults - 리뷰 결과
+   * @param {Object} patches - 파일 경로 → PR diff (unified diff)
+   * @returns {Object} { inline, remaining } (둘 다 파일별 결과 형식)
+   */
+  splitInlineFindings(reviewResults, patches) {
+    const inline = [];
+    const remaining = [];
+    reviewResults.forEach(result => {
+      const lines = commentableLines(patches[result.file]);
+      const hunks = hunkRanges(patches[result.file]);
+      // 수정 제안은 줄 범위 전체가 한 hunk 안에 있을 때만 제안 블록으로 사용
+      const issues = result.issues.map(issue => {
+        const fits = issue.fix && hunks.some(hunk => issue.fix.startLine >= hunk.start && issue.fix.endLine <= hunk.end);
+        return issue.fix && !fits ? { ...issue, fix: null } : issue;
+      });
+      const anchored = issues.filter(issue => issue.fix || (issue.line && lines.has(issue.line)));
+      const unanchored = issues.filter(issue => !anchored.includes(issue));
+      if (anchored.length > 0) {
+        inline.push({ ...result, issues: anchored });
+      }
+      if (unanchored.length > 0) {
+        remaining.push({ ...result, issues: unanchored });
+      }
+    });
+    return { inline, remaining };
+  }
+
   /**
    * 인라인 댓글 배열 생성
    * @param {Array} reviewResults - 리뷰 결과
@@ -295,8 +881,18 @@ class CommentManager {
 
     reviewResults.forEach(result => {
       result.issues.forEach(issue => {
-        // 라인 번호가 있는 이슈만 인라인 댓글로 생성
-        if (issue.line && issue.line > 0) {
+        // 수정 제안이 있으면 대체할 줄 범위에, 없으면 이슈 줄에 댓글 생성
+        if (issue.fix) {
+          const range = issue.fix.endLine > issue.fix.startLine
+            ? { start_line: issue.fix.startLine, start_side: 'RIGHT', line: issue.fix.endLine, side: 'RIGHT' }
+            : { line: issue.fix.startLine };
+          comments.push({
+            path: result.file,
+            ...range,
+            body: this.buildInlineCommentBody(issue)
+          });
+        } else if (issue.line && issue.line > 0) {
+          // 라인 번호가 있는 이슈만 인라인 댓글로 생성
           comments.push({
             path: result.file,
             line: issue.line,
@@ -315,8 +911,9 @@ class CommentManager {
    * @returns {string} 인라인 댓글 본문
    */
   buildInlineCommentBody(issue) {
-    const severityEmoji = this.getSeverityEmoji(issue.severity);
-    let body = `${severityEmoji} **${issue.title}**\n\n`;
+    // 다음 push에서 같은 이슈에 다시 댓글을 달지 않도록 지문 마커 삽입
+    let body = issue.fingerprint ? `${this.findingMarker(issue.fingerprint)}\n` : '';
+    body += `${this.labels.withIcon(this.getSeverityEmoji(issue.severity), `**${issue.title}**`)}\n\n`;
     
     if (issue.description) {
       body += `${issue.description}\n\n`;
@@ -326,19 +923,109 @@ class CommentManager {
       body += `💡 **제안:** ${issue.suggestion}`;
     }
 
+    // 작성자가 한 번에 적용할 수 있는 GitHub 제안 블록 (코드에 ```가 있으면 더 긴 펜스 사용)
+    if (issue.fix) {
+      const longest = Math.max(2, ...(issue.fix.code.match(/`+/g) || []).map(run => run.length));
+      const fence = '`'.repeat(longest + 1);
+      body += `\n\n${fence}suggestion\n${issue.fix.code}\n${fence}`;
+    }
+
+    const learnMore = this.references.resolve(issue.type);
+    if (learnMore) {
+      body += `\n\n📚 더 알아보기: ${learnMore}`;
+    }
+
     return body;
   }
 
   /**
-   * 커밋에 댓글 작성 (Push 이벤트용) - 권한 문제로 비활성화
-   * GitHub Actions의 기본 토큰으로는 commit comment 생성 권한이 없음
-   * 대신 워크플로우 로그에 리뷰 결과 출력
+   * push된 커밋에 댓글 작성 (PR이 없는 push 이벤트용)
+   * 같은 커밋을 다시 리뷰하면 이전 요약 댓글을 수정하며, commit comment는 contents: write 권한이 필요하므로
+   * 권한이 없으면 워크플로우 로그에 결과를 출력 (Check Run은 별도로 게시)
    * @param {string} commentBody - 댓글 본문
+   * @param {Object} [options] - 옵션
+   * @param {boolean} [options.updateOnly] - 이전 요약 댓글이 있을 때만 수정 (새 댓글은 만들지 않음)
    */
-  async postCommitComment(commentBody) {
-    console.log('⚠️  Commit comment 권한 없음. 워크플로우 로그에 결과 출력');
-    console.log('리뷰 결과:', commentBody.substring(0, 200) + '...');
+  async postCommitComment(commentBody, { updateOnly = false } = {}) {
+    const commit = {
+      owner: this.context.repo.owner,
+      repo: this.context.repo.repo,
+      commit_sha: this.context.payload.after || this.context.sha
+    };
+    try {
+      if (this.updateComment) {
+        const comments = await this.octokit.paginate(this.octokit.rest.repos.listCommentsForCommit, { ...commit, per_page: 100 });
+        const previous = [...comments].reverse().find(comment => (comment.body || '').includes(SUMMARY_MARKER));
+        if (previous) {
+          if (previous.body !== commentBody) {
+            await this.octokit.rest.repos.updateCommitComment({
+              owner: commit.owner,
+              repo: commit.repo,
+              comment_id: previous.id,
+              body: commentBody
+            });
+          }
+          return;
+        }
+      }
+      if (!updateOnly) {
+        await this.octokit.rest.repos.createCommitComment({ ...commit, body: commentBody });
+      }
+    } catch (error) {
+      console.log(`⚠️  Commit comment 작성 실패 (contents: write 권한 필요): ${error.message}. 워크플로우 로그에 결과 출력`);
+      console.log(commentBody);
+    }
   }
 }
 
-module.exports = CommentManager;
\ No newline at end of file
+/**
+ * PR diff에서 줄 댓글을 달 수 있는 새 파일 기준 줄 번호 (추가 줄과 hunk 안의 문맥 줄)
+ * @param {string} patch - unified diff
+ * @returns {Set<number>} 줄 번호
+ */
+function commentableLines(patch) {
+  const lines = new Set();
+  let lineNumber = 0;
+  let inHunk = false;
+  (patch || '').split('\n').forEach(raw => {
+    const hunk = raw.match(/^@@ -\d+(?:,\d+)? \+(\d+)/);
+    if (hunk) {
+      lineNumber = parseInt(hunk[1], 10);
+      inHunk = true;
+    } else if (raw.startsWith('diff ')) {
+      inHunk = false;
+    } else if (!inHunk || raw.startsWith('-') || raw.startsWith('\\')) {
+      return;
+    } else if (raw.startsWith('+') || raw.startsWith(' ')) {
+      lines.add(lineNumber);
+      lineNumber++;
+    }
+  });
+  return lines;
+}
+
+/**
+ * PR diff의 hunk별 새 파일 줄 범위 (제안 블록은 한 hunk 안의 줄만 대체할 수 있음)
+ * @param {string} patch - unified diff
+ * @returns {Array} [{ start, end }]
+ */
+function hunkRanges(patch) {
+  const ranges = [];
+  (patch || '').split('\n').forEach(raw => {
+    const hunk = raw.match(/^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))?/);
+    if (hunk) {
+      const start = parseInt(hunk[1], 10);
+      const count = hunk[2] === undefined ? 1 : parseInt(hunk[2], 10);
+      if (count > 0) {
+        ranges.push({ start, end: start + count - 1 });
+      }
+    }
+  });
+  return ranges;
+}
+
+module.exports = CommentManager;
+module.exports.SUMMARY_MARKER = SUMMARY_MARKER;
+module.exports.FINDING_MARKER_PREFIX = FINDING_MARKER_PREFIX;
+module.exports.commentableLines = commentableLines;
+module.exports.hunkRanges = hunkRanges;
\ No newline at end of file
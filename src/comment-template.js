@@ -0,0 +1,87 @@
+/**
+ * Comment Template Module
+ * PR 댓글의 이슈 블록과 파일 블록 레이아웃을 템플릿으로 렌더링하는 모듈
+ *
+ * 템플릿 문법 (Mustache 일부):
+ * - {{name}}               값 치환
+ * - {{#name}}...{{/name}}  값이 있을 때만 출력
+ * - {{^name}}...{{/name}}  값이 없을 때만 출력
+ */
+
+const fs = require('fs').promises;
+
+// 이슈 블록 기본 템플릿
+const DEFAULT_FINDING_TEMPLATE = `#### {{heading}}
+**타입:** {{typeBadge}} | **심각도:** {{severityLabel}}{{#line}} | **라인:** {{line}}{{/line}}
+
+{{#description}}**문제점:**
+{{description}}
+
+{{/description}}{{#why}}**왜 중요한가요?**
+{{why}}
+
+{{/why}}{{#reference}}📖 참고: {{reference}}
+
+{{/reference}}{{#learnMore}}📚 더 알아보기: {{learnMore}}
+
+{{/learnMore}}{{#suggestion}}**개선 방안:**
+{{suggestion}}
+
+{{/suggestion}}{{#testFile}}**테스트 파일:** \`{{testFile}}\`
+
+{{/testFile}}{{#codeExample}}**예시 코드:**
+\`\`\`
+{{codeExample}}
+\`\`\`
+
+{{/codeExample}}---
+
+`;
+
+// 파일 블록 기본 템플릿
+const DEFAULT_FILE_TEMPLATE = `<details>
+<summary><b>📄 {{file}}</b> ({{issueCount}}개 이슈)</summary>
+
+{{#summary}}> {{summary}}
+
+{{/summary}}{{findings}}</details>
+
+`;
+
+/**
+ * 템플릿 렌더링
+ * @param {string} template - 템플릿
+ * @param {Object} values - 치환할 값
+ * @returns {string} 렌더링 결과
+ */
+function renderTemplate(template, values) {
+  const isPresent = value => value !== undefined && value !== null && value !== '' && value !== false;
+
+  // 조건 구간 먼저 처리한 뒤 값을 한 번에 치환 (값 안의 {{ }}는 다시 해석하지 않음)
+  const sectioned = template.replace(/\{\{([#^])(\w+)\}\}([\s\S]*?)\{\{\/\2\}\}/g, (match, kind, name, body) => {
+    const present = isPresent(values[name]);
+    return (kind === '#') === present ? body : '';
+  });
+
+  return sectioned.replace(/\{\{(\w+)\}\}/g, (match, name) => (isPresent(values[name]) ? String(values[name]) : ''));
+}
+
+/**
+ * 템플릿 파일 로드
+ * @param {string} filePath - 템플릿 파일 경로
+ * @returns {Promise<string>} 템플릿
+ */
+async function loadTemplate(filePath) {
+  try {
+    return await fs.readFile(filePath, 'utf8');
+  } catch (error) {
+    throw new Error(`Cannot read comment template ${filePath}: ${error.message}`);
+  }
+}
+
+module.exports = {
+  DEFAULT_FINDING_TEMPLATE,
+  DEFAULT_FILE_TEMPLATE,
+  renderTemplate,
+  loadTemplate
+};
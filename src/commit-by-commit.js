@@ -0,0 +1,128 @@
+/**
+ * Commit By Commit Module
+ * PR의 커밋마다 그 커밋의 diff와 그 시점의 파일 내용을 따로 리뷰하고, 이슈를 처음 보고된 커밋에 연결하는 모듈
+ *
+ * 원자적 커밋을 지키는 팀에서 커밋 단위로 리뷰하고, 어떤 커밋이 이슈를 만들었는지 찾을 때 사용합니다.
+ * - 머지 커밋은 건너뛰며, 커밋 diff 중 PR에서 변경되고 리뷰 대상인 파일만 리뷰
+ * - 같은 이슈(지문)가 여러 커밋에서 보고되면 가장 먼저 보고된 커밋에 연결
+ * - 지적된 줄이 PR head의 파일에 더 이상 없으면 이후 커밋에서 고쳐진 것으로 보고 제외
+ */
+
+const { normalizeCode } = require('./fingerprint');
+
+// 리뷰할 최대 커밋 수 (파일 수와 곱해져 API 호출이 늘어나므로 제한)
+const MAX_COMMITS = 20;
+
+class CommitByCommit {
+  /**
+   * CommitByCommit 생성자
+   * @param {Object} options - 옵션
+   * @param {Object} options.octokit - GitHub API 클라이언트
+   * @param {Object} options.context - GitHub Actions 컨텍스트 (pull_request 이벤트)
+   * @param {number} [options.maxCommits] - 리뷰할 최대 커밋 수 (최근 커밋 기준)
+   */
+  constructor({ octokit, context, maxCommits = MAX_COMMITS }) {
+    this.octokit = octokit;
+    this.context = context;
+    this.maxCommits = maxCommits;
+    // 리뷰한 커밋 [{ sha, subject }] (오래된 순)
+    this.commits = [];
+    // 건너뛴 이전 커밋 수 (maxCommits 초과)
+    this.skippedCommits = 0;
+  }
+
+  /**
+   * 커밋별 리뷰 단위 계산
+   * @param {Array} filesToReview - 필터링을 거친 PR 변경 파일
+   * @param {Object} [options] - 옵션
+   * @param {string|null} [options.since] - 이 커밋 이후의 커밋만 리뷰 (증분 리뷰의 마지막 리뷰 커밋)
+   * @returns {Promise<Array>} 리뷰 단위 (PR 파일 정보 + { ref, commit, deltaPatch })
+   */
+  async load(filesToReview, { since = null } = {}) {
+    const listed = await this.octokit.paginate(this.octokit.rest.pulls.listCommits, {
+      owner: this.context.repo.owner,
+      repo: this.context.repo.repo,
+      pull_number: this.context.payload.pull_request.number,
+      per_page: 100
+    });
+    const sinceIndex = since ? listed.findIndex(commit => commit.sha === since) : -1;
+    const commits = listed
+      .slice(sinceIndex + 1)
+      .filter(commit => (commit.parents || []).length < 2);
+    this.skippedCommits = Math.max(0, commits.length - this.maxCommits);
+
+    const reviewable = new Map(filesToReview.map(file => [file.filename, file]));
+    const units = [];
+    for (const commit of commits.slice(-this.maxCommits)) {
+      const { data } = await this.octokit.rest.repos.getCommit({
+        owner: this.context.repo.owner,
+        repo: this.context.repo.repo,
+        ref: commit.sha
+      });
+      const summary = { sha: commit.sha, subject: String(commit.commit.message || '').split('\n')[0] };
+      this.commits.push(summary);
+      (data.files || [])
+        .filter(file => file.status !== 'removed' && reviewable.has(file.filename))
+        .forEach(file => units.push({
+          ...reviewable.get(file.filename),
+          ref: commit.sha,
+          commit: summary,
+          deltaPatch: file.patch || ''
+        }));
+    }
+    return units;
+  }
+
+  /**
+   * 커밋별 리뷰 결과를 파일별 결과와 커밋 섹션으로 정리
+   * @param {Array} commitResults - 리뷰 단위별 결과 ({ file, commit, issues, summary, score })
+   * @param {Map<string, string>} headContents - 파일 경로 → PR head의 파일 내용
+   * @returns {Object} { results: 파일별 결과, commits: [{ sha, subject, results }], fixedCount }
+   */
+  attribute(commitResults, headContents) {
+    const order = new Map(this.commits.map((commit, index) => [commit.sha, index]));
+    const sorted = [...commitResults].sort((a, b) => order.get(a.commit.sha) - order.get(b.commit.sha));
+    const seen = new Set();
+    const byFile = new Map();
+    const byCommit = new Map(this.commits.map(commit => [commit.sha, []]));
+    let fixedCount = 0;
+
+    sorted.forEach(result => {
+      const headLines = new Set(String(headContents.get(result.file) || '').split('\n').map(normalizeCode));
+      const issues = result.issues.filter(issue => {
+        if (issue.fingerprint && seen.has(issue.fingerprint)) {
+          return false;
+        }
+        if (issue.fingerprint) {
+          seen.add(issue.fingerprint);
+        }
+        // 지적된 줄이 head에 없으면 이후 커밋에서 고쳐진 이슈
+        if (issue.snippet && normalizeCode(issue.snippet.target) && !headLines.has(normalizeCode(issue.snippet.target))) {
+          fixedCount++;
+          return false;
+        }
+        issue.commit = result.commit.sha;
+        return true;
+      });
+      if (issues.length === 0) {
+        return;
+      }
+
+      byCommit.get(result.commit.sha).push({ file: result.file, issues, summary: result.summary, score: result.score });
+      const merged = byFile.get(result.file);
+      if (merged) {
+        merged.issues.push(...issues);
+      } else {
+        byFile.set(result.file, { file: result.file, issues: [...issues], summary: result.summary, score: result.score });
+      }
+    });
+
+    return {
+      results: [...byFile.values()],
+      commits: this.commits.map(commit => ({ ...commit, results: byCommit.get(commit.sha) })),
+      fixedCount
+    };
+  }
+}
+
+module.exports = CommitByCommit;
@@ -0,0 +1,169 @@
+/**
+ * Commit Message Reviewer Module
+ * PR의 커밋 메시지를 Conventional Commits 또는 팀 패턴 기준으로 검사하고 다시 쓴 메시지를 제안하는 모듈
+ *
+ * 형식 위반과 길이는 규칙으로 먼저 검사하고, 모호한 메시지 판단과 재작성 제안은 Claude에 맡깁니다.
+ * 규칙 검사 결과는 프롬프트에 힌트로 넣고, 응답에 빠진 규칙 위반도 결과에 그대로 남깁니다.
+ */
+
+// Conventional Commits 1.0 제목 형식
+const CONVENTIONAL_PATTERN = /^(feat|fix|docs|style|refactor|perf|test|build|ci|chore|revert)(\([\w./-]+\))?!?: \S.*/;
+
+// 제목 최대 길이 (git 관례)
+const MAX_SUBJECT_LENGTH = 72;
+
+// 검사할 최대 커밋 수
+const MAX_COMMITS = 50;
+
+const REVIEW_PROMPT = `당신은 커밋 메시지 리뷰어입니다. 다음 Pull Request의 커밋 메시지를 검토해주세요.
+
+리뷰 기준:
+- 제목만 보고 무엇이 왜 바뀌었는지 알 수 있어야 합니다 ("fix", "update", "wip", "changes" 같은 모호한 메시지 지적)
+- 지정된 형식 규칙을 지켜야 합니다
+- 제안은 변경된 파일 목록과 원래 메시지에서 알 수 있는 내용만 사용하고, 지정된 형식을 따르세요
+- 문제가 없는 커밋은 결과에 넣지 마세요`;
+
+class CommitMessageReviewer {
+  /**
+   * CommitMessageReviewer 생성자
+   * @param {Object} options - 옵션
+   * @param {CodeReviewer} options.codeReviewer - Claude API 호출용 리뷰어
+   * @param {Object} options.octokit - GitHub API 클라이언트
+   * @param {Object} options.context - GitHub Actions 컨텍스트 (pull_request 이벤트)
+   * @param {string} [options.pattern] - 팀 커밋 제목 정규식 (없으면 Conventional Commits)
+   */
+  constructor({ codeReviewer, octokit, context, pattern }) {
+    this.codeReviewer = codeReviewer;
+    this.octokit = octokit;
+    this.context = context;
+    this.pattern = pattern ? new RegExp(pattern) : CONVENTIONAL_PATTERN;
+    this.convention = pattern ? `팀 패턴 /${pattern}/` : 'Conventional Commits';
+  }
+
+  /**
+   * PR 커밋 목록 조회 (머지 커밋 제외)
+   * @returns {Promise<Array>} [{ sha, message }]
+   */
+  async listCommits() {
+    const commits = await this.octokit.paginate(this.octokit.rest.pulls.listCommits, {
+      owner: this.context.repo.owner,
+      repo: this.context.repo.repo,
+      pull_number: this.context.payload.pull_request.number,
+      per_page: 100
+    });
+    return commits
+      .filter(commit => (commit.parents || []).length < 2)
+      .slice(-MAX_COMMITS)
+      .map(commit => ({ sha: commit.sha, message: commit.commit.message }));
+  }
+
+  /**
+   * 규칙 기반 검사
+   * @param {string} message - 커밋 메시지
+   * @returns {Array<string>} 위반 내용
+   */
+  checkRules(message) {
+    const lines = message.split('\n');
+    const subject = lines[0];
+    const problems = [];
+    if (!this.pattern.test(subject)) {
+      problems.push(`제목이 ${this.convention} 형식이 아닙니다`);
+    }
+    if (subject.length > MAX_SUBJECT_LENGTH) {
+      problems.push(`제목이 ${MAX_SUBJECT_LENGTH}자를 넘습니다 (${subject.length}자)`);
+    }
+    if (lines.length > 1 && lines[1].trim() !== '') {
+      problems.push('제목과 본문 사이에 빈 줄이 없습니다');
+    }
+    return problems;
+  }
+
+  /**
+   * 커밋 메시지 리뷰 실행
+   * @param {Array<string>} changedFiles - PR에서 변경된 파일 경로
+   * @returns {Promise<Array>} [{ sha, subject, problems, suggestion }]
+   */
+  async review(changedFiles) {
+    const commits = (await this.listCommits()).map(commit => ({
+      ...commit,
+      subject: commit.message.split('\n')[0],
+      problems: this.checkRules(commit.message)
+    }));
+    if (commits.length === 0) {
+      return [];
+    }
+
+    const responseText = await this.codeReviewer.sendMessage(
+      `PR #${this.context.payload.pull_request.number} commits`,
+      this.buildPrompt(commits, changedFiles),
+      this.codeReviewer.model
+    );
+    const suggestions = CommitMessageReviewer.parseResponse(responseText);
+
+    // 모델이 지적한 커밋과 규칙 위반 커밋을 합침
+    return commits
+      .map(commit => {
+        const suggestion = suggestions.find(item => commit.sha.startsWith(item.sha));
+        return {
+          sha: commit.sha,
+          subject: commit.subject,
+          problems: [...commit.problems, ...(suggestion && suggestion.problem ? [suggestion.problem] : [])],
+          suggestion: suggestion ? suggestion.suggestion : ''
+        };
+      })
+      .filter(commit => commit.problems.length > 0);
+  }
+
+  /**
+   * 리뷰 프롬프트 생성
+   * @param {Array} commits - 규칙 검사 결과를 포함한 커밋 목록
+   * @param {Array<string>} changedFiles - 변경된 파일 경로
+   * @returns {string} 프롬프트
+   */
+  buildPrompt(commits, changedFiles) {
+    const commitLines = commits.map(commit => JSON.stringify({
+      sha: commit.sha.substring(0, 7),
+      message: commit.message.substring(0, 500),
+      rule_violations: commit.problems
+    }));
+
+    return `${REVIEW_PROMPT} ${this.codeReviewer.getLanguageInstruction()}
+
+형식 규칙: ${this.pattern === CONVENTIONAL_PATTERN ? `${this.convention} (${CONVENTIONAL_PATTERN.source})` : this.convention}, 제목 ${MAX_SUBJECT_LENGTH}자 이하, 제목과 본문 사이 빈 줄
+
+변경된 파일:
+${changedFiles.slice(0, 100).join('\n')}
+
+커밋:
+${commitLines.join('\n')}
+
+**중요**: 완전한 JSON만 반환하세요. rule_violations가 있는 커밋은 반드시 포함하세요.
+
+형식:
+{"commits":[{"sha":"7자리 sha","problem":"문제(50자)","suggestion":"다시 쓴 커밋 제목"}]}`;
+  }
+
+  /**
+   * 응답 JSON 파싱
+   * @param {string} responseText - 응답 텍스트
+   * @returns {Array} [{ sha, problem, suggestion }]
+   */
+  static parseResponse(responseText) {
+    const firstBrace = responseText.indexOf('{');
+    const lastBrace = responseText.lastIndexOf('}');
+    if (firstBrace === -1 || lastBrace <= firstBrace) {
+      throw new Error('No JSON structure found in commit review response');
+    }
+    const parsed = JSON.parse(responseText.substring(firstBrace, lastBrace + 1));
+    return (Array.isArray(parsed.commits) ? parsed.commits : [])
+      .filter(item => item && typeof item.sha === 'string' && item.sha.length >= 7)
+      .map(item => ({
+        sha: item.sha,
+        problem: typeof item.problem === 'string' ? item.problem : '',
+        suggestion: typeof item.suggestion === 'string' ? item.suggestion.split('\n')[0] : ''
+      }));
+  }
+}
+
+module.exports = CommitMessageReviewer;
+module.exports.CONVENTIONAL_PATTERN = CONVENTIONAL_PATTERN;
@@ -0,0 +1,307 @@
+/**
+ * Conversation Store Module
+ * PR마다 리뷰 대화(이슈, 메인테이너 답글, 리뷰어 후속 답변)를 요약 댓글의 숨김 메타데이터로 저장하는 모듈
+ *
+ * push마다 새로 리뷰하더라도 이전 대화를 이어 가도록 합니다.
+ * - 상태는 가장 최근 요약 댓글의 HTML 주석에 base64 JSON으로 저장 (별도 저장소나 권한이 필요 없음)
+ * - 메인테이너 답글: 마지막으로 처리한 댓글 이후의 PR 댓글 중 이슈 제목을 인용(> 제목)하거나 이슈 ID를 언급한 댓글
+ * - 이슈는 지문(fingerprint)으로 push 간에 식별하며, 다시 리뷰한 파일에서 사라진 이슈는 해결됨으로 표시
+ */
+
+const { SUMMARY_MARKER } = require('./comment-manager');
+
+const STATE_MARKER_PREFIX = '<!-- claude-review:conversation:';
+const STATE_VERSION = 1;
+
+// 댓글 크기 제한(65536자) 안에 들어가도록 하는 저장 상한
+const MAX_STATE_CHARS = 40000;
+const MAX_THREAD_ENTRIES = 8;
+const MAX_ENTRY_CHARS = 500;
+// 프롬프트에 포함할 파일당 최대 이전 이슈 수
+const MAX_PROMPT_FINDINGS = 5;
+
+// 상태 표시 이름
+const STATUS_LABELS = {
+  open: '미해결',
+  resolved: '해결됨'
+};
+
+class ConversationStore {
+  /**
+   * ConversationStore 생성자
+   * @param {Object} [state] - 저장된 상태 { version, rounds, findings, lastCommentId }
+   */
+  constructor(state = {}) {
+    this.state = {
+      version: STATE_VERSION,
+      rounds: state.rounds || [],
+      findings: state.findings || {},
+      lastCommentId: state.lastCommentId || 0
+    };
+    // 이번 실행에서 답글, 후속 답변, 상태 변화가 있었던 이슈 지문
+    this.activity = new Set();
+  }
+
+  /**
+   * PR 댓글에서 이전 상태와 새 메인테이너 답글 로드
+   * @param {Object} octokit - GitHub API 클라이언트
+   * @param {Object} context - GitHub Actions 컨텍스트 (pull_request 이벤트)
+   * @returns {Promise<ConversationStore>} 대화 상태 (이전 상태가 없으면 빈 상태)
+   */
+  static async load(octokit, context) {
+    const comments = await octokit.paginate(octokit.rest.issues.listComments, {
+      owner: context.repo.owner,
+      repo: context.repo.repo,
+      issue_number: context.payload.pull_request.number,
+      per_page: 100
+    });
+
+    const latest = [...comments].reverse().find(comment =>
+      (comment.body || '').includes(SUMMARY_MARKER) && (comment.body || '').includes(STATE_MARKER_PREFIX)
+    );
+    const store = new ConversationStore(latest ? ConversationStore.decode(latest.body) : {});
+    store.addReplies(comments.filter(comment => comment.id > store.state.lastCommentId));
+    return store;
+  }
+
+  /**
+   * 댓글 본문의 상태 마커 해석
+   * @param {string} body - 댓글 본문
+   * @returns {Object} 상태 (마커가 없거나 손상되었으면 빈 객체)
+   */
+  static decode(body) {
+    const match = (body || '').match(/<!-- claude-review:conversation:([A-Za-z0-9+/=]+) -->/);
+    if (!match) {
+      return {};
+    }
+    try {
+      const state = JSON.parse(Buffer.from(match[1], 'base64').toString('utf8'));
+      return state && state.version === STATE_VERSION ? state : {};
+    } catch (error) {
+      return {};
+    }
+  }
+
+  /**
+   * 요약 댓글에 붙일 상태 마커 (크기 제한을 넘으면 오래된 해결 이슈와 대화부터 제거)
+   * @returns {string} HTML 주석 마커
+   */
+  marker() {
+    const state = JSON.parse(JSON.stringify(this.state));
+    const encode = () => `${STATE_MARKER_PREFIX}${Buffer.from(JSON.stringify(state)).toString('base64')} -->`;
+    let encoded = encode();
+    const resolved = Object.keys(state.findings)
+      .filter(fingerprint => state.findings[fingerprint].status === 'resolved')
+      .sort((a, b) => state.findings[a].lastRound - state.findings[b].lastRound);
+    while (encoded.length > MAX_STATE_CHARS && resolved.length > 0) {
+      delete state.findings[resolved.shift()];
+      encoded = encode();
+    }
+    if (encoded.length > MAX_STATE_CHARS) {
+      Object.values(state.findings).forEach(finding => {
+        finding.thread = finding.thread.slice(-2);
+      });
+      encoded = encode();
+    }
+    return encoded;
+  }
+
+  /**
+   * 이슈 지문의 짧은 ID (댓글에서 이슈를 가리킬 때 사용)
+   * @param {string} fingerprint - 이슈 지문
+   * @returns {string} 8자리 ID
+   */
+  static shortId(fingerprint) {
+    return fingerprint.substring(0, 8);
+  }
+
+  /**
+   * 새 PR 댓글 중 이슈에 대한 메인테이너 답글을 대화에 추가
+   * @param {Array} comments - PR 댓글 (id 오름차순)
+   */
+  addReplies(comments) {
+    comments.forEach(comment => {
+      this.state.lastCommentId = Math.max(this.state.lastCommentId, comment.id);
+      const body = comment.body || '';
+      // 봇과 이 액션이 작성한 댓글은 제외
+      if ((comment.user && comment.user.type === 'Bot') || body.includes('<!-- claude-review:')) {
+        return;
+      }
+      const quoted = body.split('\n').filter(line => line.startsWith('>')).join('\n');
+      const reply = body.split('\n').filter(line => !line.startsWith('>')).join('\n').trim();
+      if (!reply) {
+        return;
+      }
+      Object.entries(this.state.findings).forEach(([fingerprint, finding]) => {
+        const mentioned = body.includes(ConversationStore.shortId(fingerprint)) || (quoted && quoted.includes(finding.title));
+        if (mentioned) {
+          this.appendEntry(fingerprint, { role: 'maintainer', author: comment.user ? comment.user.login : null, body: reply });
+        }
+      });
+    });
+  }
+
+  /**
+   * 이슈 대화에 항목 추가
+   * @param {string} fingerprint - 이슈 지문
+   * @param {Object} entry - { role: maintainer|model, author, body }
+   */
+  appendEntry(fingerprint, entry) {
+    const finding = this.state.findings[fingerprint];
+    finding.thread.push({ ...entry, body: entry.body.substring(0, MAX_ENTRY_CHARS), round: this.state.rounds.length + 1 });
+    finding.thread = finding.thread.slice(-MAX_THREAD_ENTRIES);
+    this.activity.add(fingerprint);
+  }
+
+  /**
+   * 리뷰어 답변을 기다리는 메인테이너 답글이 있는 이슈
+   * @param {string} filename - 파일 경로
+   * @returns {Array} [{ fingerprint, finding }]
+   */
+  findingsFor(filename) {
+    return Object.entries(this.state.findings)
+      .filter(([, finding]) => finding.file === filename)
+      .map(([fingerprint, finding]) => ({ fingerprint, finding }))
+      .sort((a, b) => Number(a.finding.status !== 'open') - Number(b.finding.status !== 'open'))
+      .slice(0, MAX_PROMPT_FINDINGS);
+  }
+
+  /**
+   * 마지막 항목이 메인테이너 답글인 이슈 (리뷰어 답변 필요)
+   * @param {Object} finding - 저장된 이슈
+   * @returns {boolean} 답변이 필요하면 true
+   */
+  static awaitingReply(finding) {
+    return finding.thread.length > 0 && finding.thread[finding.thread.length - 1].role === 'maintainer';
+  }
+
+  /**
+   * 파일 리뷰 프롬프트에 넣을 이전 대화 지시사항
+   * @param {string} filename - 파일 경로
+   * @returns {Object} { instruction, followUpFields } (이전 대화가 없으면 빈 문자열)
+   */
+  buildInstruction(filename) {
+    const entries = this.findingsFor(filename);
+    if (entries.length === 0) {
+      return { instruction: '', followUpFields: '' };
+    }
+    const lines = entries.map(({ fingerprint, finding }) => {
+      const thread = finding.thread.map(entry =>
+        `  - ${entry.role === 'maintainer' ? `메인테이너${entry.author ? ` @${entry.author}` : ''}` : '리뷰어'}: ${JSON.stringify(entry.body)}`
+      );
+      return [
+        `- [${ConversationStore.shortId(fingerprint)}] ${finding.line ? `줄 ${finding.line} ` : ''}${finding.severity} ${JSON.stringify(finding.title)} (${STATUS_LABELS[finding.status]})`,
+        ...thread
+      ].join('\n');
+    });
+    const awaiting = entries.some(({ finding }) => ConversationStore.awaitingReply(finding));
+
+    let instruction = `\n\n이 PR의 이전 리뷰 대화:\n${lines.join('\n')}\n\n`;
+    instruction += '이전에 보고한 문제가 아직 남아 있으면 같은 제목으로 다시 보고하고, 고쳐졌으면 보고하지 마세요. ' +
+      '메인테이너가 답글로 의도나 근거를 설명했고 그 설명이 타당하면 그 이슈는 다시 보고하지 마세요.';
+    if (awaiting) {
+      instruction += ' 마지막 항목이 메인테이너 답글인 이슈에는 follow_ups에 이슈 ID와 답변을 작성하세요 (동의하면 인정하고, 동의하지 않으면 근거를 설명).';
+    }
+    return {
+      instruction,
+      followUpFields: awaiting ? ',"follow_ups":[{"id":"이전 이슈 ID","reply":"답글에 대한 답변(80자)"}]' : ''
+    };
+  }
+
+  /**
+   * 이번 리뷰 결과로 대화 상태 갱신
+   * @param {Array} reviewResults - 파일별 리뷰 결과 (지문 포함)
+   * @param {Array} followUps - [{ file, id, reply }] 리뷰어 후속 답변
+   * @param {Array<string>} reviewedFiles - 이번에 리뷰한 파일 (여기서 사라진 이슈는 해결됨)
+   * @param {string} sha - 리뷰한 커밋 SHA
+   */
+  update(reviewResults, followUps, reviewedFiles, sha) {
+    const round = this.state.rounds.length + 1;
+    const current = new Set();
+    reviewResults.forEach(result => {
+      result.issues.filter(issue => issue.fingerprint).forEach(issue => {
+        current.add(issue.fingerprint);
+        const existing = this.state.findings[issue.fingerprint];
+        if (!existing) {
+          this.state.findings[issue.fingerprint] = {
+            file: result.file,
+            line: issue.line,
+            title: issue.title,
+            severity: issue.severity,
+            status: 'open',
+            firstRound: round,
+            lastRound: round,
+            thread: []
+          };
+        } else {
+          if (existing.status !== 'open') {
+            this.activity.add(issue.fingerprint);
+          }
+          Object.assign(existing, { line: issue.line, severity: issue.severity, status: 'open', lastRound: round });
+        }
+      });
+    });
+
+    Object.entries(this.state.findings).forEach(([fingerprint, finding]) => {
+      if (finding.status === 'open' && !current.has(fingerprint) && reviewedFiles.includes(finding.file)) {
+        finding.status = 'resolved';
+        finding.lastRound = round;
+        this.activity.add(fingerprint);
+      }
+    });
+
+    followUps.forEach(({ file, id, reply }) => {
+      const fingerprint = Object.keys(this.state.findings).find(key =>
+        ConversationStore.shortId(key) === id && this.state.findings[key].file === file
+      );
+      if (fingerprint && ConversationStore.awaitingReply(this.state.findings[fingerprint])) {
+        this.appendEntry(fingerprint, { role: 'model', author: null, body: reply });
+      }
+    });
+
+    this.state.rounds.push({ sha, at: new Date().toISOString() });
+  }
+
+  /**
+   * 이번 실행에서 대화에 변화가 있었는지 여부
+   * @returns {boolean} 변화가 있으면 true
+   */
+  hasActivity() {
+    return this.activity.size > 0;
+  }
+
+  /**
+   * 요약 댓글의 리뷰 대화 섹션 (이번 실행에서 변화가 있었던 이슈와 이슈 ID 목록)
+   * @returns {string} 마크다운 섹션
+   */
+  buildSection() {
+    const findings = Object.entries(this.state.findings);
+    if (findings.length === 0) {
+      return '';
+    }
+    const round = this.state.rounds.length;
+    const cell = text => String(text).replace(/\|/g, '\\|').replace(/\n/g, ' ');
+    let section = `\n### 💬 리뷰 대화 (${round}번째 리뷰)\n\n`;
+
+    const active = findings.filter(([fingerprint]) => this.activity.has(fingerprint));
+    active.forEach(([fingerprint, finding]) => {
+      section += `**\`${ConversationStore.shortId(fingerprint)}\` ${finding.title}** (\`${finding.file}${finding.line ? `:${finding.line}` : ''}\`) — ${STATUS_LABELS[finding.status]}\n`;
+      finding.thread.filter(entry => entry.round === round).forEach(entry => {
+        const who = entry.role === 'maintainer' ? `@${entry.author || 'maintainer'}` : '🤖 리뷰어';
+        section += `> **${who}:** ${entry.body.replace(/\n+/g, ' ')}\n`;
+      });
+      section += '\n';
+    });
+
+    const open = findings.filter(([, finding]) => finding.status === 'open');
+    section += `<details>\n<summary>이슈 ID (미해결 ${open.length}개, 전체 ${findings.length}개)</summary>\n\n`;
+    section += '| ID | 위치 | 이슈 | 상태 |\n|----|------|------|------|\n';
+    findings.forEach(([fingerprint, finding]) => {
+      section += `| \`${ConversationStore.shortId(fingerprint)}\` | \`${cell(finding.file)}${finding.line ? `:${finding.line}` : ''}\` | ${cell(finding.title)} | ${STATUS_LABELS[finding.status]} |\n`;
+    });
+    section += '\n</details>\n\n> 💬 이슈 제목을 인용하거나 이슈 ID를 언급해 답글을 달면 다음 push의 리뷰에서 이어서 답변합니다.\n';
+    return section;
+  }
+}
+
+module.exports = ConversationStore;
@@ -0,0 +1,147 @@
+/**
+ * Custom Review Types Module
+ * 저장소에 체크인한 Markdown 프롬프트(.claude-review/types/<이름>.md)로 새 리뷰 타입을 등록하는 모듈
+ *
+ * - 파일 이름이 리뷰 타입 이름이자 이슈 타입(카테고리)이 됨 (review_type: <이름>)
+ * - 파일 본문이 리뷰 타입별 기본 프롬프트를 대신하고, JSON 형식과 언어/메모리 지시사항 등은 기본 리뷰와 같음
+ * - 기본 리뷰 타입과 같은 이름은 사용할 수 없음
+ *
+ * 예: .claude-review/types/graphql.md
+ *   당신은 GraphQL API 설계 전문가입니다. 다음 스키마와 리졸버 변경사항을 리뷰해주세요.
+ *
+ *   리뷰 관점:
+ *   - nullable 필드를 non-null로 바꾸는 호환성 깨짐
+ *   - 페이지네이션 없는 리스트 필드
+ */
+
+const fs = require('fs').promises;
+const path = require('path');
+const { ConfigError } = require('./errors');
+
+const DEFAULT_TYPES_DIR = '.claude-review/types';
+
+// 액션에 내장된 리뷰 타입 (같은 이름의 사용자 정의 타입은 허용하지 않음)
+const BUILTIN_REVIEW_TYPES = ['full', 'security', 'performance', 'style', 'tests', 'i18n', 'docs', 'a11y', 'compliance'];
+
+// 리뷰 타입 이름 (이슈 타입으로 댓글, SARIF, 리포트에 그대로 표시)
+const TYPE_NAME_PATTERN = /^[a-z][a-z0-9-]{0,31}$/;
+
+// 프롬프트 최대 길이 (파일마다 반복해서 보내므로 제한)
+const MAX_PROMPT_LENGTH = 4000;
+
+const TYPE_EXTENSION = '.md';
+
+class CustomReviewTypes {
+  /**
+   * CustomReviewTypes 생성자
+   * @param {Object} [types] - { 이름: 프롬프트 }
+   * @param {string} [source] - 정의를 읽은 디렉터리 (로그용)
+   */
+  constructor(types = {}, source = DEFAULT_TYPES_DIR) {
+    Object.keys(types).forEach(name => CustomReviewTypes.validate(name, types[name]));
+    this.types = new Map(Object.entries(types).map(([name, prompt]) => [name, prompt.trim()]));
+    this.source = source;
+  }
+
+  /**
+   * 리뷰 타입 디렉터리 로드 (디렉터리가 없으면 사용자 정의 타입 없음)
+   * @param {string} [dir] - 리뷰 타입 디렉터리
+   * @returns {Promise<CustomReviewTypes>} 로드된 리뷰 타입
+   */
+  static async load(dir = DEFAULT_TYPES_DIR) {
+    let entries;
+    try {
+      entries = await fs.readdir(dir);
+    } catch (error) {
+      if (error.code === 'ENOENT') {
+        return new CustomReviewTypes({}, dir);
+      }
+      throw new ConfigError(`Cannot read review types ${dir}: ${error.message}`);
+    }
+
+    const types = {};
+    for (const entry of entries.filter(name => name.endsWith(TYPE_EXTENSION)).sort()) {
+      const name = entry.slice(0, -TYPE_EXTENSION.length);
+      types[name] = await fs.readFile(path.join(dir, entry), 'utf8');
+      try {
+        CustomReviewTypes.validate(name, types[name]);
+      } catch (error) {
+        throw new ConfigError(`${path.join(dir, entry)}: ${error.message}`);
+      }
+    }
+    return new CustomReviewTypes(types, dir);
+  }
+
+  /**
+   * 리뷰 타입 이름과 프롬프트 검증
+   * @param {string} name - 리뷰 타입 이름
+   * @param {string} prompt - 프롬프트
+   * @throws {ConfigError} 잘못된 이름, 내장 타입과 같은 이름, 비어 있거나 너무 긴 프롬프트
+   */
+  static validate(name, prompt) {
+    if (!TYPE_NAME_PATTERN.test(name)) {
+      throw new ConfigError(`Invalid review type name "${name}" (lowercase letters, digits and hyphens, up to 32 characters)`);
+    }
+    if (BUILTIN_REVIEW_TYPES.includes(name)) {
+      throw new ConfigError(`Review type "${name}" is built in and cannot be redefined`);
+    }
+    const text = String(prompt || '').trim();
+    if (!text) {
+      throw new ConfigError(`Review type "${name}" has an empty prompt`);
+    }
+    if (text.length > MAX_PROMPT_LENGTH) {
+      throw new ConfigError(`Review type "${name}" prompt is too long (${text.length} > ${MAX_PROMPT_LENGTH} characters)`);
+    }
+  }
+
+  /**
+   * 사용자 정의 리뷰 타입인지 확인
+   * @param {string} name - 리뷰 타입 이름
+   * @returns {boolean} 등록된 타입이면 true
+   */
+  has(name) {
+    return this.types.has(name);
+  }
+
+  /**
+   * 등록된 리뷰 타입 이름 목록
+   * @returns {Array<string>} 이름 목록
+   */
+  names() {
+    return [...this.types.keys()];
+  }
+
+  /**
+   * 리뷰 타입의 프롬프트
+   * @param {string} name - 리뷰 타입 이름
+   * @returns {string|null} 프롬프트 (등록되지 않은 타입이면 null)
+   */
+  prompt(name) {
+    return this.types.get(name) || null;
+  }
+}
+
+/**
+ * type_models 입력값 파싱 ("<리뷰 타입>: <모델>" 줄 목록)
+ * 사용자 정의 리뷰 타입은 로드한 뒤에 확인하므로 여기서는 형식만 검사
+ * @param {string} text - 입력값
+ * @returns {Object} { 리뷰 타입: 모델 }
+ */
+function parseTypeModels(text) {
+  const models = {};
+  (text || '').split('\n').map(line => line.trim()).filter(Boolean).forEach(line => {
+    const separator = line.indexOf(':');
+    const type = separator === -1 ? '' : line.substring(0, separator).trim();
+    const model = line.substring(separator + 1).trim();
+    if (!type || !model) {
+      throw new ConfigError(`Invalid type_models entry (expected "<review type>: <model>"): ${line}`);
+    }
+    models[type] = model;
+  });
+  return models;
+}
+
+module.exports = CustomReviewTypes;
+module.exports.DEFAULT_TYPES_DIR = DEFAULT_TYPES_DIR;
+module.exports.BUILTIN_REVIEW_TYPES = BUILTIN_REVIEW_TYPES;
+module.exports.parseTypeModels = parseTypeModels;
@@ -0,0 +1,216 @@
+/**
+ * Debug Bundle Module
+ * 액션 실패 시 재현에 필요한 정보를 하나의 JSON 파일로 모으는 모듈
+ *
+ * 주요 기능:
+ * - 단계별 실행 시간 측정
+ * - Claude API 요청 ID 및 최근 응답 기록
+ * - 이벤트 페이로드/설정값의 민감 정보 제거 (설정의 웹훅/알림 URL은 호스트만 남김)
+ * - 실패 시 번들 파일 작성 (upload-artifact로 업로드 가능)
+ */
+
+const fs = require('fs');
+const os = require('os');
+const path = require('path');
+
+// 번들에 보관할 최근 응답 개수와 응답당 최대 길이
+const MAX_RESPONSES = 10;
+const MAX_RESPONSE_LENGTH = 4000;
+
+// 값 자체를 가려야 하는 설정 키 (API 키, 토큰 등)
+const SECRET_KEY_PATTERN = /(key|token|secret|password|authorization)/i;
+// URL 자체가 자격 증명인 설정 키 (Slack/Teams/Discord 웹훅, notify_url, telemetry_url 등)
+// 경로와 쿼리를 알면 누구나 채널에 게시할 수 있으므로 호스트만 남김
+const URL_KEY_PATTERN = /(webhook|url)$/i;
+
+// 문자열 안에 섞여 있는 자격 증명 패턴
+const SECRET_VALUE_PATTERNS = [
+  /sk-ant-[A-Za-z0-9_-]+/g,                 // Anthropic API 키
+  /gh[pousr]_[A-Za-z0-9]{20,}/g,            // GitHub 토큰
+  /github_pat_[A-Za-z0-9_]{20,}/g,          // GitHub fine-grained 토큰
+  /(Bearer|token)\s+[A-Za-z0-9._-]{16,}/gi  // Authorization 헤더 값
+];
+
+class DebugBundle {
+  /**
+   * DebugBundle 생성자
+   * @param {Object} context - GitHub Actions 컨텍스트
+   */
+  constructor(context) {
+    this.context = context;
+    this.config = null;
+    this.requests = [];
+    this.responses = [];
+    this.timings = {};
+    this.timers = {};
+    this.startedAt = Date.now();
+  }
+
+  /**
+   * 해석이 끝난 설정값 저장 (비밀값은 마스킹)
+   * @param {Object} config - 액션 입력값 객체
+   */
+  setConfig(config) {
+    this.config = this.redact(config, { urls: true });
+  }
+
+  /**
+   * 실행 단계 시간 측정 시작
+   * @param {string} phase - 단계 이름
+   */
+  startPhase(phase) {
+    this.timers[phase] = Date.now();
+  }
+
+  /**
+   * 실행 단계 시간 측정 종료
+   * @param {string} phase - 단계 이름
+   */
+  endPhase(phase) {
+    if (this.timers[phase]) {
+      this.timings[phase] = Date.now() - this.timers[phase];
+      delete this.timers[phase];
+    }
+  }
+
+  /**
+   * Claude API 호출 결과 기록
+   * @param {Object} record - 호출 정보
+   * @param {string} record.filename - 리뷰 대상 파일
+   * @param {string|null} record.requestId - Anthropic request-id 헤더 값
+   * @param {number} record.durationMs - 호출 소요 시간
+   * @param {string} [record.responseText] - 응답 원문
+   * @param {string} [record.error] - 실패 시 에러 메시지
+   * @param {string} [record.model] - 응답한 모델
+   * @param {Object} [record.usage] - 응답의 usage 필드 (요청별 토큰 수)
+   */
+  recordResponse({ filename, requestId, durationMs, responseText, error, model, usage }) {
+    this.requests.push({
+      filename,
+      requestId: requestId || null,
+      durationMs,
+      model: model || null,
+      inputTokens: usage ? usage.input_tokens || 0 : null,
+      outputTokens: usage ? usage.output_tokens || 0 : null,
+      cacheReadTokens: usage ? usage.cache_read_input_tokens || 0 : null,
+      error: error || null
+    });
+
+    if (responseText) {
+      this.responses.push({
+        filename,
+        requestId: requestId || null,
+        text: this.redactString(responseText.substring(0, MAX_RESPONSE_LENGTH))
+      });
+      // 최근 응답만 유지
+      if (this.responses.length > MAX_RESPONSES) {
+        this.responses.shift();
+      }
+    }
+  }
+
+  /**
+   * 번들 내용 생성
+   * @param {Error} error - 액션을 실패시킨 에러
+   * @returns {Object} 번들 객체
+   */
+  build(error) {
+    // 진행 중이던 단계는 실패 시점까지의 시간으로 기록
+    const now = Date.now();
+    const timings = { ...this.timings };
+    for (const [phase, start] of Object.entries(this.timers)) {
+      timings[phase] = now - start;
+    }
+
+    return {
+      createdAt: new Date(now).toISOString(),
+      durationMs: now - this.startedAt,
+      error: {
+        name: error.name,
+        message: this.redactString(error.message || ''),
+        stack: this.redactString(error.stack || '')
+      },
+      event: {
+        name: this.context.eventName,
+        repository: `${this.context.repo.owner}/${this.context.repo.repo}`,
+        sha: this.context.sha,
+        runId: this.context.runId,
+        payload: this.redact(this.context.payload)
+      },
+      config: this.config,
+      requests: this.requests,
+      responses: this.responses,
+      timings,
+      runtime: {
+        node: process.version,
+        platform: process.platform
+      }
+    };
+  }
+
+  /**
+   * 번들을 파일로 작성
+   * @param {Error} error - 액션을 실패시킨 에러
+   * @returns {string} 작성된 파일 경로
+   */
+  write(error) {
+    const dir = process.env.RUNNER_TEMP || os.tmpdir();
+    const filePath = path.join(dir, `claude-review-debug-${this.context.runId || Date.now()}.json`);
+    fs.writeFileSync(filePath, JSON.stringify(this.build(error), null, 2));
+    return filePath;
+  }
+
+  /**
+   * 객체에서 민감 정보 제거 (재귀)
+   * @param {*} value - 원본 값
+   * @param {Object} [options] - 옵션
+   * @param {boolean} [options.urls] - URL_KEY_PATTERN 키의 URL도 마스킹 (설정값용, 이벤트 페이로드의 공개 *_url은 유지)
+   * @returns {*} 마스킹된 값
+   */
+  redact(value, options = {}) {
+    if (Array.isArray(value)) {
+      return value.map(item => this.redact(item, options));
+    }
+    if (value && typeof value === 'object') {
+      const result = {};
+      for (const [key, item] of Object.entries(value)) {
+        if (typeof item === 'string' && item && SECRET_KEY_PATTERN.test(key)) {
+          result[key] = '***';
+        } else if (typeof item === 'string' && item && options.urls && URL_KEY_PATTERN.test(key)) {
+          result[key] = redactUrl(item);
+        } else {
+          result[key] = this.redact(item, options);
+        }
+      }
+      return result;
+    }
+    if (typeof value === 'string') {
+      return this.redactString(value);
+    }
+    return value;
+  }
+
+  /**
+   * 문자열에 포함된 자격 증명 마스킹
+   * @param {string} text - 원본 문자열
+   * @returns {string} 마스킹된 문자열
+   */
+  redactString(text) {
+    return SECRET_VALUE_PATTERNS.reduce((result, pattern) => result.replace(pattern, '***'), text);
+  }
+}
+
+/**
+ * URL의 경로와 쿼리 마스킹 (어느 호스트로 보냈는지만 남김)
+ * @param {string} value - URL
+ * @returns {string} 예: https://hooks.slack.com/***
+ */
+function redactUrl(value) {
+  try {
+    return `${new URL(value).origin}/***`;
+  } catch (error) {
+    return '***';
+  }
+}
+
+module.exports = DebugBundle;
@@ -0,0 +1,376 @@
+/**
+ * Dependency Reviewer Module
+ * 의존성 매니페스트(package.json, go.mod, requirements.txt, Cargo.toml)가 바뀐 PR에서
+ * 추가/삭제/업데이트된 의존성을 정리하고 위험도와 영향 범위를 검토하는 모듈
+ *
+ * base와 head의 매니페스트를 규칙으로 비교해 변경 목록과 메이저 버전 업데이트를 먼저 계산하고,
+ * 저장소에서 패키지 이름이 언급된 파일 수(영향 범위 추정)와 함께 Claude에게 보내
+ * 알려진 위험/유지보수 중단 패키지, 메이저 업데이트의 호환성 문제, 영향 범위를 설명하게 합니다.
+ * 잠금 파일(package-lock.json, go.sum 등)은 변경이 너무 많아 비교하지 않습니다.
+ * 라이선스 검토를 켜면(컴플라이언스 리뷰) 새 의존성의 라이선스와 저장소 라이선스와의 호환성도 판단해
+ * 호환되지 않는 의존성을 매니페스트 파일의 compliance 이슈로 만들 수 있습니다.
+ */
+
+const fs = require('fs').promises;
+const path = require('path');
+const GoApiReviewer = require('./go-api-reviewer');
+
+// 프롬프트에 넣을 최대 변경 수
+const MAX_CHANGES = 50;
+// 영향 범위로 셀 최대 파일 수
+const MAX_USAGE_FILES = 100;
+
+// 매니페스트 파일 이름 → 생태계
+const MANIFESTS = [
+  { pattern: /(^|\/)package\.json$/, ecosystem: 'npm' },
+  { pattern: /(^|\/)go\.mod$/, ecosystem: 'go' },
+  { pattern: /(^|\/)requirements[^/]*\.txt$/, ecosystem: 'pip' },
+  { pattern: /(^|\/)Cargo\.toml$/, ecosystem: 'cargo' }
+];
+
+const RISK_LEVELS = ['low', 'medium', 'high'];
+
+// 라이선스 호환성 이슈에 표시할 보고자 이름
+const LICENSE_REPORTER = 'Dependency Licenses';
+
+const DEPENDENCY_PROMPT = `당신은 소프트웨어 공급망 보안과 의존성 관리 전문가입니다. 다음은 이 PR에서 바뀐 의존성 목록입니다. 각 의존성의 위험도를 판단하고 변경 전체를 요약해주세요.
+
+판단 기준:
+- 알려진 취약점이 있는 버전, 유지보수가 중단되었거나 폐기(deprecated)된 패키지, 타이포스쿼팅이 의심되는 이름은 high
+- 메이저 버전 업데이트(major: true)는 알려진 호환성 깨짐(API 삭제, 기본값 변경, 최소 런타임 버전 상향)을 설명하고 영향 범위가 넓으면 medium 이상
+- usages는 저장소에서 패키지 이름이 언급된 파일 수이며 영향 범위 추정에 사용 (null이면 알 수 없음)
+- 개발 의존성(scope: dev)은 런타임에 영향이 없으므로 위험도를 한 단계 낮게 판단
+- 확실하지 않은 취약점이나 유지보수 상태는 추측하지 말고 low로 두세요`;
+
+class DependencyReviewer {
+  /**
+   * DependencyReviewer 생성자
+   * @param {Object} options - 옵션
+   * @param {CodeReviewer} options.codeReviewer - Claude API 호출용 리뷰어
+   * @param {Object} options.git - simple-git 인스턴스
+   * @param {string} [options.root] - 저장소 루트 (기본값: 현재 디렉터리)
+   * @param {boolean} [options.checkLicenses] - 의존성 라이선스와 저장소 라이선스의 호환성도 판단
+   * @param {string|null} [options.projectLicense] - 저장소 라이선스 SPDX 식별자 (모르면 null)
+   */
+  constructor({ codeReviewer, git, root = process.cwd(), checkLicenses = false, projectLicense = null }) {
+    this.codeReviewer = codeReviewer;
+    this.git = git;
+    this.root = root;
+    this.checkLicenses = checkLicenses;
+    this.projectLicense = projectLicense;
+  }
+
+  /**
+   * 파일의 매니페스트 생태계
+   * @param {string} filename - 파일 경로
+   * @returns {string|null} npm, go, pip, cargo (매니페스트가 아니면 null)
+   */
+  static ecosystemOf(filename) {
+    const manifest = MANIFESTS.find(item => item.pattern.test(filename));
+    return manifest ? manifest.ecosystem : null;
+  }
+
+  /**
+   * 매니페스트에서 의존성 목록 추출
+   * @param {string} ecosystem - 생태계
+   * @param {string} text - 매니페스트 내용 (없으면 빈 문자열)
+   * @returns {Map<string, Object>} 이름 → { version, scope: runtime|dev|indirect }
+   */
+  static parseManifest(ecosystem, text) {
+    const dependencies = new Map();
+    if (!text) {
+      return dependencies;
+    }
+
+    if (ecosystem === 'npm') {
+      let manifest;
+      try {
+        manifest = JSON.parse(text);
+      } catch (error) {
+        return dependencies;
+      }
+      [['dependencies', 'runtime'], ['peerDependencies', 'runtime'], ['optionalDependencies', 'runtime'], ['devDependencies', 'dev']]
+        .forEach(([field, scope]) => {
+          Object.entries(manifest[field] || {}).forEach(([name, version]) => {
+            if (!dependencies.has(name)) {
+              dependencies.set(name, { version: String(version), scope });
+            }
+          });
+        });
+    } else if (ecosystem === 'go') {
+      let inBlock = false;
+      text.split('\n').forEach(line => {
+        if (/^require\s*\(\s*$/.test(line)) {
+          inBlock = true;
+          return;
+        }
+        if (inBlock && /^\)/.test(line)) {
+          inBlock = false;
+          return;
+        }
+        const match = (inBlock ? line.match(/^\s*(\S+)\s+(v\S+)(.*)$/) : line.match(/^require\s+(\S+)\s+(v\S+)(.*)$/));
+        if (match) {
+          dependencies.set(match[1], { version: match[2], scope: /\/\/\s*indirect/.test(match[3]) ? 'indirect' : 'runtime' });
+        }
+      });
+    } else if (ecosystem === 'pip') {
+      text.split('\n').forEach(line => {
+        const spec = line.replace(/\s+#.*$/, '').trim();
+        const match = spec.match(/^([A-Za-z0-9][A-Za-z0-9._-]*)(\[[^\]]*\])?\s*(.*)$/);
+        if (match && !spec.startsWith('-')) {
+          dependencies.set(match[1].toLowerCase().replace(/[._]/g, '-'), { version: match[3].split(';')[0].trim() || '*', scope: 'runtime' });
+        }
+      });
+    } else if (ecosystem === 'cargo') {
+      let scope = null;
+      text.split('\n').forEach(line => {
+        const section = line.match(/^\s*\[([^\]]+)\]\s*$/);
+        if (section) {
+          const name = section[1].split('.').pop();
+          scope = { dependencies: 'runtime', 'build-dependencies': 'runtime', 'dev-dependencies': 'dev' }[name] || null;
+          return;
+        }
+        const match = scope && line.match(/^\s*([\w-]+)\s*=\s*(.+)$/);
+        if (match) {
+          const version = match[2].match(/^"([^"]*)"/) || match[2].match(/version\s*=\s*"([^"]*)"/);
+          dependencies.set(match[1], { version: version ? version[1] : '*', scope });
+        }
+      });
+    }
+    return dependencies;
+  }
+
+  /**
+   * 두 의존성 목록 비교
+   * @param {Map} before - base 의존성
+   * @param {Map} after - head 의존성
+   * @returns {Array} [{ name, change: added|removed|updated, before, after, scope, major }]
+   */
+  static compare(before, after) {
+    const changes = [];
+    before.forEach((dependency, name) => {
+      if (!after.has(name)) {
+        changes.push({ name, change: 'removed', before: dependency.version, after: null, scope: dependency.scope, major: false });
+      } else if (after.get(name).version !== dependency.version) {
+        const updated = after.get(name);
+        changes.push({
+          name,
+          change: 'updated',
+          before: dependency.version,
+          after: updated.version,
+          scope: updated.scope,
+          major: DependencyReviewer.isMajorUpdate(dependency.version, updated.version)
+        });
+      }
+    });
+    after.forEach((dependency, name) => {
+      if (!before.has(name)) {
+        changes.push({ name, change: 'added', before: null, after: dependency.version, scope: dependency.scope, major: false });
+      }
+    });
+    return changes;
+  }
+
+  /**
+   * 메이저 버전 업데이트 여부 (0.x는 마이너 버전 변경도 호환성을 깰 수 있으므로 메이저로 취급)
+   * @param {string} before - 이전 버전 또는 범위 (^1.2.0, ~=2.0, v1.4.0 등)
+   * @param {string} after - 새 버전 또는 범위
+   * @returns {boolean} 메이저 업데이트면 true
+   */
+  static isMajorUpdate(before, after) {
+    const parse = version => {
+      const match = String(version).match(/(\d+)(?:\.(\d+))?/);
+      return match ? { major: parseInt(match[1], 10), minor: parseInt(match[2] || '0', 10) } : null;
+    };
+    const from = parse(before);
+    const to = parse(after);
+    if (!from || !to) {
+      return false;
+    }
+    if (from.major === 0 && to.major === 0) {
+      return to.minor > from.minor;
+    }
+    return to.major > from.major;
+  }
+
+  /**
+   * 변경된 매니페스트의 의존성 비교
+   * @param {Array<string>} filenames - 변경된 전체 파일 경로
+   * @param {string} base - 기준 커밋
+   * @returns {Promise<Array>} [{ manifest, ecosystem, name, change, before, after, scope, major }]
+   */
+  async diff(filenames, base) {
+    const manifests = filenames.filter(filename => DependencyReviewer.ecosystemOf(filename) && !/(^|\/)(node_modules|vendor)\//.test(filename));
+    if (manifests.length === 0) {
+      return [];
+    }
+    // base 커밋이 없으면 모든 의존성이 추가된 것으로 보이므로 중단 (얕은 클론)
+    await this.git.raw(['cat-file', '-e', `${base}^{commit}`]).catch(() => {
+      throw new Error(`base commit ${base} is not available (use actions/checkout with fetch-depth: 0)`);
+    });
+
+    const changes = [];
+    for (const manifest of manifests) {
+      const ecosystem = DependencyReviewer.ecosystemOf(manifest);
+      const before = await this.git.show([`${base}:${manifest}`]).catch(() => '');
+      const after = await fs.readFile(path.join(this.root, manifest), 'utf8').catch(() => '');
+      DependencyReviewer.compare(DependencyReviewer.parseManifest(ecosystem, before), DependencyReviewer.parseManifest(ecosystem, after))
+        .forEach(change => changes.push({ manifest, ecosystem, ...change }));
+    }
+    return changes;
+  }
+
+  /**
+   * 저장소에서 패키지 이름이 언급된 파일 수 (매니페스트와 잠금 파일 제외, 영향 범위 추정용)
+   * @param {string} name - 패키지 이름
+   * @returns {Promise<number|null>} 파일 수 (검색 실패 시 null)
+   */
+  async countUsages(name) {
+    try {
+      // 기본 pathspec에서 *는 /도 포함하므로 모든 디렉터리의 매니페스트가 제외됨
+      const output = await this.git.raw(['grep', '-l', '-F', '-e', name, '--', '.',
+        ':!*package.json', ':!*go.mod', ':!*go.sum', ':!*requirements*.txt', ':!*Cargo.toml',
+        ':!*.lock', ':!*-lock.json', ':!*-lock.yaml', ':!*.md']);
+      return Math.min(MAX_USAGE_FILES, output.split('\n').filter(Boolean).length);
+    } catch (error) {
+      // git grep은 일치하는 파일이 없으면 종료 코드 1로 실패
+      return /exit code 1|^$/.test(error.message || '') ? 0 : null;
+    }
+  }
+
+  /**
+   * 의존성 변경을 검토해 결과 생성
+   * @param {Object} context - GitHub Actions 컨텍스트
+   * @param {Array<string>} filenames - 변경된 전체 파일 경로
+   * @returns {Promise<Object|null>} { summary, changes } (의존성 변경이 없으면 null)
+   */
+  async review(context, filenames) {
+    const base = GoApiReviewer.baseRef(context);
+    const changes = (await this.diff(filenames, base))
+      .map(change => ({ ...change, usages: null, risk: null, notes: '', license: null, licenseCompatible: null }));
+    if (changes.length === 0) {
+      return null;
+    }
+    for (const change of changes.slice(0, MAX_CHANGES)) {
+      change.usages = change.change === 'added' ? null : await this.countUsages(change.name);
+    }
+
+    let summary = '';
+    try {
+      const responseText = await this.codeReviewer.sendMessage('Dependency review', this.buildPrompt(changes), this.codeReviewer.model);
+      const verdicts = DependencyReviewer.parseResponse(responseText);
+      summary = verdicts.summary;
+      changes.forEach(change => {
+        const verdict = verdicts.dependencies.find(item => item.manifest === change.manifest && item.name === change.name);
+        if (verdict) {
+          change.risk = verdict.risk;
+          change.notes = verdict.notes;
+          change.license = verdict.license;
+          change.licenseCompatible = verdict.licenseCompatible;
+        }
+      });
+    } catch (error) {
+      console.warn(`Dependency review fell back to rules: ${error.message}`);
+    }
+
+    return { summary, changes };
+  }
+
+  /**
+   * 의존성 검토 프롬프트 생성
+   * @param {Array} changes - 변경 목록
+   * @returns {string} 프롬프트
+   */
+  buildPrompt(changes) {
+    const lines = changes.slice(0, MAX_CHANGES).map(change => JSON.stringify({
+      manifest: change.manifest,
+      ecosystem: change.ecosystem,
+      name: change.name,
+      change: change.change,
+      before: change.before,
+      after: change.after,
+      scope: change.scope,
+      major: change.major,
+      usages: change.usages
+    }));
+
+    // 라이선스 검토: 추가/업데이트된 의존성의 라이선스와 저장소 라이선스와의 호환성
+    let licenseInstruction = '';
+    if (this.checkLicenses) {
+      licenseInstruction = this.projectLicense
+        ? `\n\n이 저장소의 라이선스는 ${this.projectLicense}입니다. 추가되거나 업데이트된 의존성마다 license에 SPDX 식별자를, license_compatible에 이 저장소와 함께 배포할 수 있는지를 넣으세요.`
+        : '\n\n이 저장소의 라이선스를 알 수 없습니다. 추가되거나 업데이트된 의존성마다 license에 SPDX 식별자를 넣고, 카피레프트(GPL/AGPL 등) 라이선스이면 license_compatible을 false로 두세요.';
+      licenseInstruction += ' 라이선스를 확실히 모르면 license는 null, license_compatible은 true로 두세요.';
+    }
+    const licenseFields = this.checkLicenses ? ',"license":"SPDX 식별자","license_compatible":true' : '';
+
+    return `${DEPENDENCY_PROMPT} ${this.codeReviewer.getLanguageInstruction()}
+
+변경 목록:
+${lines.join('\n')}
+
+**중요**: 완전한 JSON만 반환하세요. 모든 변경에 대해 판단하세요.${licenseInstruction}
+
+형식:
+{"summary":"변경 전체 요약과 영향 범위(150자)","dependencies":[{"manifest":"매니페스트 경로","name":"패키지 이름","risk":"low/medium/high","notes":"위험 요소, 호환성 깨짐, 영향 범위(100자)"${licenseFields}}]}`;
+  }
+
+  /**
+   * 응답 JSON 파싱
+   * @param {string} responseText - 응답 텍스트
+   * @returns {Object} { summary, dependencies: [{ manifest, name, risk, notes, license, licenseCompatible }] }
+   */
+  static parseResponse(responseText) {
+    const firstBrace = responseText.indexOf('{');
+    const lastBrace = responseText.lastIndexOf('}');
+    if (firstBrace === -1 || lastBrace <= firstBrace) {
+      throw new Error('No JSON structure found in dependency review response');
+    }
+    const parsed = JSON.parse(responseText.substring(firstBrace, lastBrace + 1));
+    return {
+      summary: typeof parsed.summary === 'string' ? parsed.summary : '',
+      dependencies: (Array.isArray(parsed.dependencies) ? parsed.dependencies : [])
+        .filter(item => item && typeof item.manifest === 'string' && typeof item.name === 'string')
+        .map(item => ({
+          manifest: item.manifest,
+          name: item.name,
+          risk: RISK_LEVELS.includes(item.risk) ? item.risk : 'low',
+          notes: typeof item.notes === 'string' ? item.notes : '',
+          license: typeof item.license === 'string' && item.license ? item.license : null,
+          licenseCompatible: typeof item.license_compatible === 'boolean' ? item.license_compatible : null
+        }))
+    };
+  }
+
+  /**
+   * 저장소 라이선스와 호환되지 않는 의존성을 매니페스트 파일의 compliance 이슈로 변환
+   * @param {Object|null} review - review() 결과
+   * @returns {Array} 파일별 리뷰 결과 형식 [{ file, issues, summary }]
+   */
+  static complianceResults(review) {
+    const results = new Map();
+    (review ? review.changes : [])
+      .filter(change => change.change !== 'removed' && change.licenseCompatible === false)
+      .forEach(change => {
+        if (!results.has(change.manifest)) {
+          results.set(change.manifest, { file: change.manifest, issues: [], summary: '' });
+        }
+        results.get(change.manifest).issues.push({
+          line: null,
+          severity: 'high',
+          type: 'compliance',
+          title: `Incompatible dependency license: ${change.name}`,
+          description: `\`${change.name}\` ${change.after}의 라이선스 ${change.license || '(알 수 없음)'}은(는) 이 저장소의 라이선스와 함께 배포할 수 없을 수 있습니다.${change.notes ? ` ${change.notes}` : ''}`,
+          suggestion: '법무 검토를 받거나 허용형 라이선스의 대체 패키지를 사용하세요.',
+          codeExample: null,
+          why: '',
+          reference: null,
+          persona: LICENSE_REPORTER
+        });
+      });
+    return [...results.values()];
+  }
+}
+
+module.exports = DependencyReviewer;
+module.exports.RISK_LEVELS = RISK_LEVELS;
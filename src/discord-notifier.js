@@ -0,0 +1,110 @@
+/**
+ * Discord Notifier Module
+ * 리뷰 요약을 Discord 채널 웹훅으로 전송하는 모듈
+ *
+ * 주요 기능:
+ * - 심각도 임계값 기반 전송 여부 판단
+ * - Embed 형식 메시지 생성 (PR 링크, 판정, 심각도별 개수, 상위 3개 이슈)
+ */
+
+const core = require('@actions/core');
+const { postJson } = require('./webhook-client');
+const DisplayLabels = require('./display-labels');
+const { hasFindingsAtOrAbove } = require('./review-summary');
+
+// 판정별 표시 문구와 embed 색상 (10진수 RGB)
+const VERDICTS = {
+  changes_requested: { label: 'Changes requested', color: 0xe74c3c },
+  needs_attention: { label: 'Needs attention', color: 0xf1c40f },
+  approved: { label: 'Looks good', color: 0x2ecc71 }
+};
+
+// Discord embed 필드 값 최대 길이
+const MAX_FIELD_LENGTH = 1024;
+
+class DiscordNotifier {
+  /**
+   * DiscordNotifier 생성자
+   * @param {Object} config - 설정
+   * @param {string} config.webhookUrl - Discord 채널 웹훅 URL
+   * @param {string} [config.minSeverity] - 이 심각도 이상 이슈가 있을 때만 전송
+   * @param {DisplayLabels} [config.labels] - 심각도 아이콘/표시 이름
+   */
+  constructor({ webhookUrl, minSeverity = 'high', labels = new DisplayLabels() }) {
+    this.webhookUrl = webhookUrl;
+    this.minSeverity = minSeverity;
+    this.labels = labels;
+  }
+
+  /**
+   * 임계값 이상 이슈 존재 여부 확인
+   * @param {Object} summary - buildReviewSummary() 결과
+   * @returns {boolean} 전송 여부
+   */
+  shouldNotify(summary) {
+    return hasFindingsAtOrAbove(summary, this.minSeverity);
+  }
+
+  /**
+   * Embed 메시지 생성
+   * @param {Object} summary - 리뷰 요약
+   * @returns {Object} Discord 웹훅 페이로드
+   */
+  buildMessage(summary) {
+    const verdict = VERDICTS[summary.verdict];
+    const fields = ['critical', 'high', 'medium', 'low'].map(severity => ({
+      name: this.labels.withIcon(this.labels.severityIcon(severity), this.labels.severityLabel(severity)),
+      value: String(summary.counts[severity]),
+      inline: true
+    }));
+
+    if (summary.topFindings.length > 0) {
+      const lines = summary.topFindings.map(finding => {
+        const location = finding.line ? `${finding.file}:${finding.line}` : finding.file;
+        return `${this.labels.withIcon(this.labels.severityIcon(finding.severity), `**${finding.title}**`)} — \`${location}\``;
+      });
+      fields.push({
+        name: 'Top findings',
+        value: lines.join('\n').substring(0, MAX_FIELD_LENGTH),
+        inline: false
+      });
+    }
+
+    return {
+      username: 'Claude AI Code Review',
+      // 멘션이 포함된 이슈 제목으로 채널 전체 알림이 가지 않도록 차단
+      allowed_mentions: { parse: [] },
+      embeds: [{
+        title: summary.title.substring(0, 256),
+        url: summary.url,
+        description: this.labels.withIcon(this.labels.verdictIcon(summary.verdict), verdict.label),
+        color: verdict.color,
+        fields,
+        footer: {
+          text: `${summary.repository} · ${summary.totalIssues} issues in ${summary.filesWithIssues} files`
+        },
+        timestamp: new Date().toISOString()
+      }]
+    };
+  }
+
+  /**
+   * 조건 충족 시 Discord로 전송
+   * @param {Object} summary - 리뷰 요약
+   */
+  async notify(summary) {
+    if (!this.shouldNotify(summary)) {
+      core.info(`Discord notification skipped: no findings at or above ${this.minSeverity}`);
+      return;
+    }
+
+    try {
+      await postJson(this.webhookUrl, this.buildMessage(summary));
+      core.info('Discord notification sent');
+    } catch (error) {
+      core.warning(`Failed to send Discord notification: ${error.message}`);
+    }
+  }
+}
+
+module.exports = DiscordNotifier;
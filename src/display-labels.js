@@ -0,0 +1,160 @@
+/**
+ * Display Labels Module
+ * 심각도와 이슈 타입의 아이콘/표시 이름을 관리하는 모듈
+ *
+ * PR 댓글, 채팅 알림, 이메일 다이제스트가 모두 같은 매핑을 사용합니다.
+ * display_labels 입력값 형식 (한 줄에 하나):
+ *   plain                   ← 모든 아이콘 제거 (이모지 금지 조직용)
+ *   critical: 🔴 | CRITICAL
+ *   security: [SEC] | Security
+ *   low: | minor             ← 아이콘 없이 이름만 변경
+ */
+
+const { ConfigError } = require('./errors');
+
+// 심각도 기본 표시
+const DEFAULT_SEVERITIES = {
+  critical: { icon: '🔴', label: 'Critical' },
+  high: { icon: '🟠', label: 'High' },
+  medium: { icon: '🟡', label: 'Medium' },
+  low: { icon: '🟢', label: 'Low' }
+};
+
+// 이슈 타입 기본 표시
+const DEFAULT_TYPES = {
+  bug: { icon: '🐛', label: 'bug' },
+  security: { icon: '🔒', label: 'security' },
+  performance: { icon: '⚡', label: 'performance' },
+  style: { icon: '🎨', label: 'style' },
+  maintainability: { icon: '🔧', label: 'maintainability' },
+  testing: { icon: '🧪', label: 'testing' },
+  documentation: { icon: '📖', label: 'documentation' },
+  i18n: { icon: '🌐', label: 'i18n' },
+  accessibility: { icon: '♿', label: 'accessibility' },
+  compliance: { icon: '⚖️', label: 'compliance' },
+  'best-practice': { icon: '📚', label: 'best-practice' }
+};
+
+// 판정별 아이콘으로 사용할 심각도
+const VERDICT_SEVERITIES = {
+  changes_requested: 'critical',
+  needs_attention: 'medium',
+  approved: 'low'
+};
+
+// 매핑에 없는 값의 기본 아이콘
+const FALLBACK_SEVERITY_ICON = '🔵';
+const FALLBACK_TYPE_ICON = '📝';
+
+class DisplayLabels {
+  /**
+   * DisplayLabels 생성자
+   * @param {Object} overrides - { 키: { icon, label } } 재정의 (키는 심각도 또는 이슈 타입)
+   * @param {boolean} plain - true면 모든 아이콘 제거
+   */
+  constructor(overrides = {}, plain = false) {
+    const merge = defaults => {
+      const merged = {};
+      for (const [key, value] of Object.entries(defaults)) {
+        merged[key] = { icon: plain ? '' : value.icon, label: value.label, ...overrides[key] };
+      }
+      return merged;
+    };
+
+    this.plain = plain;
+    this.severities = merge(DEFAULT_SEVERITIES);
+    this.types = merge(DEFAULT_TYPES);
+  }
+
+  /**
+   * display_labels 입력값 파싱
+   * @param {string} text - 여러 줄 매핑
+   * @returns {DisplayLabels} 표시 매핑
+   */
+  static parse(text) {
+    let plain = false;
+    const overrides = {};
+
+    (text || '').split('\n').map(line => line.trim()).filter(Boolean).forEach(line => {
+      if (line === 'plain') {
+        plain = true;
+        return;
+      }
+
+      const separator = line.indexOf(':');
+      const key = separator === -1 ? '' : line.substring(0, separator).trim().toLowerCase();
+      if (!DEFAULT_SEVERITIES[key] && !DEFAULT_TYPES[key]) {
+        throw new ConfigError(`Invalid display_labels entry (expected "<severity|type>: <icon> | <label>"): ${line}`);
+      }
+
+      const [icon, label] = line.substring(separator + 1).split('|').map(part => part.trim());
+      overrides[key] = { icon };
+      if (label) {
+        overrides[key].label = label;
+      }
+    });
+
+    return new DisplayLabels(overrides, plain);
+  }
+
+  /**
+   * 심각도 아이콘
+   * @param {string} severity - 심각도
+   * @returns {string} 아이콘 (없으면 빈 문자열)
+   */
+  severityIcon(severity) {
+    const entry = this.severities[severity];
+    return entry ? entry.icon : (this.plain ? '' : FALLBACK_SEVERITY_ICON);
+  }
+
+  /**
+   * 심각도 표시 이름
+   * @param {string} severity - 심각도
+   * @returns {string} 표시 이름
+   */
+  severityLabel(severity) {
+    const entry = this.severities[severity];
+    return entry ? entry.label : String(severity);
+  }
+
+  /**
+   * 이슈 타입 아이콘
+   * @param {string} type - 이슈 타입
+   * @returns {string} 아이콘 (없으면 빈 문자열)
+   */
+  typeIcon(type) {
+    const entry = this.types[type];
+    return entry ? entry.icon : (this.plain ? '' : FALLBACK_TYPE_ICON);
+  }
+
+  /**
+   * 이슈 타입 표시 이름
+   * @param {string} type - 이슈 타입
+   * @returns {string} 표시 이름
+   */
+  typeLabel(type) {
+    const entry = this.types[type];
+    return entry ? entry.label : String(type);
+  }
+
+  /**
+   * 판정 아이콘 (판정 수준에 대응하는 심각도 아이콘 사용)
+   * @param {string} verdict - getVerdict() 결과
+   * @returns {string} 아이콘
+   */
+  verdictIcon(verdict) {
+    return this.severityIcon(VERDICT_SEVERITIES[verdict] || 'low');
+  }
+
+  /**
+   * 아이콘과 텍스트 결합 (아이콘이 비어 있으면 텍스트만)
+   * @param {string} icon - 아이콘
+   * @param {string} text - 텍스트
+   * @returns {string} 결합된 문자열
+   */
+  withIcon(icon, text) {
+    return icon ? `${icon} ${text}` : text;
+  }
+}
+
+module.exports = DisplayLabels;
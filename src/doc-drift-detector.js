@@ -0,0 +1,241 @@
+/**
+ * Doc Drift Detector Module
+ * 공개 API, CLI 플래그, 설정 옵션이 바뀌었는데 관련 문서가 같은 PR에서 갱신되지 않은 경우를 찾는 모듈
+ *
+ * - diff의 추가/삭제 줄에서 공개 이름(export, Go/Python 공개 선언, --플래그, action 입력값)을 추출
+ * - 저장소 문서 파일에서 그 이름이 언급된 위치를 찾아, 이 PR에서 수정되지 않은 문서를 오래된 문서로 보고
+ * - 새로 추가된 플래그/설정 옵션이 어떤 문서에도 없으면 문서 누락으로 보고
+ * Claude 호출 없이 규칙만으로 동작합니다.
+ */
+
+const fs = require('fs');
+const path = require('path');
+const { minimatch } = require('minimatch');
+
+// 이슈에 표시할 보고자 이름
+const REPORTER = 'Documentation Drift';
+
+// 문서 파일 기본 패턴
+const DEFAULT_DOC_PATTERNS = ['**/*.md', '**/*.mdx', '**/*.rst', '**/*.adoc', 'docs/**', 'doc/**'];
+// 문서로 보지 않는 파일 (변경 이력은 과거 이름을 그대로 남김)
+const IGNORED_DOCS = ['**/CHANGELOG*', '**/HISTORY*', '**/node_modules/**'];
+// 검색할 최대 문서 파일 수와 이름당 표시할 위치 수
+const MAX_DOC_FILES = 200;
+const MAX_LOCATIONS = 5;
+// 너무 짧은 이름은 문서 본문과 우연히 겹치므로 제외
+const MIN_NAME_LENGTH = 3;
+
+// 종류별 표시 이름
+const KIND_LABELS = {
+  export: '공개 API',
+  flag: 'CLI 플래그',
+  option: '설정 옵션'
+};
+
+class DocDriftDetector {
+  /**
+   * DocDriftDetector 생성자
+   * @param {Object} options - 옵션
+   * @param {Array<string>} [options.docPatterns] - 문서 파일 glob 패턴 (비어 있으면 기본 패턴)
+   * @param {string} [options.root] - 저장소 루트 (기본값: 현재 디렉터리)
+   */
+  constructor({ docPatterns = [], root = process.cwd() } = {}) {
+    this.docPatterns = docPatterns.length > 0 ? docPatterns : DEFAULT_DOC_PATTERNS;
+    this.root = root;
+  }
+
+  /**
+   * 문서 파일 여부
+   * @param {string} filename - 파일 경로
+   * @returns {boolean} 문서 파일이면 true
+   */
+  isDoc(filename) {
+    const match = pattern => minimatch(filename, pattern, { dot: true, matchBase: !pattern.includes('/') });
+    return this.docPatterns.some(match) && !IGNORED_DOCS.some(match);
+  }
+
+  /**
+   * diff에서 바뀐 공개 이름 추출
+   * @param {string} filename - 파일 경로
+   * @param {string} diff - unified diff
+   * @returns {Array} [{ name, kind, line, change: added|removed|changed }]
+   */
+  static extractNames(filename, diff) {
+    const names = new Map();
+    diffLines(diff).forEach(({ line, text, added }) => {
+      DocDriftDetector.namesInLine(filename, text).forEach(({ name, kind }) => {
+        const key = `${kind}:${name}`;
+        const change = added ? 'added' : 'removed';
+        const existing = names.get(key);
+        if (!existing) {
+          names.set(key, { name, kind, line, change });
+        } else if (existing.change !== change) {
+          existing.change = 'changed';
+          existing.line = added ? line : existing.line;
+        }
+      });
+    });
+    return [...names.values()].filter(item => item.name.length >= MIN_NAME_LENGTH);
+  }
+
+  /**
+   * 한 줄에서 공개 이름 추출
+   * @param {string} filename - 파일 경로
+   * @param {string} text - 줄 내용
+   * @returns {Array} [{ name, kind }]
+   */
+  static namesInLine(filename, text) {
+    const extension = path.extname(filename);
+    const found = [];
+    const add = (kind, pattern) => {
+      const match = text.match(pattern);
+      if (match) {
+        found.push({ name: match[1], kind });
+      }
+    };
+
+    if (/^action\.ya?ml$/.test(path.posix.basename(filename))) {
+      add('option', /^ {2}([a-z][\w-]*):\s*$/);
+    } else if (extension === '.go') {
+      add('export', /^func\s+(?:\([^)]*\)\s*)?([A-Z]\w*)/);
+      add('export', /^type\s+([A-Z]\w*)/);
+    } else if (extension === '.py') {
+      add('export', /^(?:async\s+)?def\s+([a-zA-Z]\w*)/);
+      add('export', /^class\s+([A-Z]\w*)/);
+    } else if (['.js', '.jsx', '.mjs', '.cjs', '.ts', '.tsx'].includes(extension)) {
+      add('export', /^\s*export\s+(?:default\s+)?(?:async\s+)?(?:function\*?|class|const|let|var|interface|type|enum)\s+(\w+)/);
+      add('export', /^\s*(?:module\.)?exports\.(\w+)\s*=/);
+    }
+
+    for (const match of text.matchAll(/getInput\(\s*['"]([\w-]+)['"]/g)) {
+      found.push({ name: match[1], kind: 'option' });
+    }
+    for (const match of text.matchAll(/['"`](--[a-z][\w-]*)['"`=]/g)) {
+      found.push({ name: match[1], kind: 'flag' });
+    }
+    return found;
+  }
+
+  /**
+   * 문서 드리프트 검사
+   * @param {Array} files - 리뷰 대상 파일 [{ filename, diff }]
+   * @param {Array<string>} changedFilenames - PR에서 변경된 모든 파일 경로 (문서 포함)
+   * @param {Array<string>} trackedFiles - 저장소 추적 파일 목록
+   * @returns {Array} 파일별 결과 [{ file, issues, summary }]
+   */
+  detect(files, changedFilenames, trackedFiles) {
+    const docs = trackedFiles.filter(file => this.isDoc(file)).slice(0, MAX_DOC_FILES);
+    const updatedDocs = new Set(changedFilenames.filter(file => this.isDoc(file)));
+    const contents = new Map();
+    const read = doc => {
+      if (!contents.has(doc)) {
+        try {
+          contents.set(doc, fs.readFileSync(path.join(this.root, doc), 'utf8').split('\n'));
+        } catch (error) {
+          contents.set(doc, []);
+        }
+      }
+      return contents.get(doc);
+    };
+
+    const results = [];
+    files.filter(file => !this.isDoc(file.filename)).forEach(({ filename, diff }) => {
+      const issues = [];
+      DocDriftDetector.extractNames(filename, diff).forEach(({ name, kind, line, change }) => {
+        const pattern = new RegExp(`(^|[^\\w-])${escapeRegExp(name)}(?![\\w-])`);
+        const mentions = [];
+        docs.forEach(doc => {
+          read(doc).forEach((text, index) => {
+            if (pattern.test(text)) {
+              mentions.push({ doc, line: index + 1 });
+            }
+          });
+        });
+
+        const stale = mentions.filter(mention => !updatedDocs.has(mention.doc));
+        const label = KIND_LABELS[kind];
+        if (change !== 'added' && stale.length > 0) {
+          issues.push({
+            line,
+            severity: 'medium',
+            title: 'Stale documentation',
+            description: `${label} \`${name}\`이(가) ${change === 'removed' ? '삭제' : '변경'}되었지만 이 PR에서 갱신되지 않은 문서가 있습니다: ${formatLocations(stale)}`,
+            suggestion: `위 문서의 \`${name}\` 설명을 변경 내용에 맞게 수정하세요.`
+          });
+        } else if (change === 'added' && kind !== 'export' && mentions.length === 0) {
+          issues.push({
+            line,
+            severity: 'low',
+            title: kind === 'flag' ? 'Undocumented flag' : 'Undocumented option',
+            description: `새 ${label} \`${name}\`이(가) 어떤 문서에도 없습니다.`,
+            suggestion: `${[...updatedDocs][0] || docs.find(doc => /readme/i.test(doc)) || 'README.md'}에 \`${name}\` 설명을 추가하세요.`
+          });
+        }
+      });
+
+      if (issues.length > 0) {
+        results.push({
+          file: filename,
+          issues: issues.map(issue => ({
+            codeExample: null,
+            why: '',
+            reference: null,
+            ...issue,
+            type: 'documentation',
+            persona: REPORTER
+          })),
+          summary: ''
+        });
+      }
+    });
+    return results;
+  }
+}
+
+/**
+ * unified diff의 추가/삭제 줄과 줄 번호 (삭제 줄은 새 파일에서 그 위치의 줄 번호)
+ * @param {string} diff - unified diff
+ * @returns {Array} [{ line, text, added }]
+ */
+function diffLines(diff) {
+  const lines = [];
+  let lineNumber = 0;
+  (diff || '').split('\n').forEach(raw => {
+    const hunk = raw.match(/^@@ -\d+(?:,\d+)? \+(\d+)/);
+    if (hunk) {
+      lineNumber = parseInt(hunk[1], 10);
+    } else if (raw.startsWith('+++') || raw.startsWith('---')) {
+      return;
+    } else if (raw.startsWith('+')) {
+      lines.push({ line: lineNumber, text: raw.substring(1), added: true });
+      lineNumber++;
+    } else if (raw.startsWith('-')) {
+      lines.push({ line: Math.max(lineNumber, 1), text: raw.substring(1), added: false });
+    } else if (!raw.startsWith('\\')) {
+      lineNumber++;
+    }
+  });
+  return lines;
+}
+
+/**
+ * 문서 위치 목록 문자열 (최대 MAX_LOCATIONS개)
+ * @param {Array} locations - [{ doc, line }]
+ * @returns {string} "README.md:12, docs/api.md:40 외 2곳"
+ */
+function formatLocations(locations) {
+  const shown = locations.slice(0, MAX_LOCATIONS).map(({ doc, line }) => `${doc}:${line}`).join(', ');
+  return locations.length > MAX_LOCATIONS ? `${shown} 외 ${locations.length - MAX_LOCATIONS}곳` : shown;
+}
+
+/**
+ * 정규식 특수 문자 이스케이프
+ * @param {string} text - 문자열
+ * @returns {string} 이스케이프된 문자열
+ */
+function escapeRegExp(text) {
+  return text.replace(/[.*+?^${}()|[\]\\]/g, '\\$&');
+}
+
+module.exports = DocDriftDetector;
+module.exports.DEFAULT_DOC_PATTERNS = DEFAULT_DOC_PATTERNS;
@@ -0,0 +1,419 @@
+/**
+ * Doctor Module
+ * 리뷰 실행 전에 환경 문제(API 키, GitHub 토큰 권한, 이벤트 페이로드, 설정, 네트워크)를 점검하는 모듈
+ *
+ * 지원 요청의 대부분은 코드가 아니라 환경 문제이므로,
+ * 각 항목을 통과/경고/실패 체크리스트로 보여 주어 사용자가 직접 원인을 찾을 수 있게 합니다.
+ */
+
+const fs = require('fs');
+const net = require('net');
+const { ConfigError } = require('./errors');
+
+const DEFAULT_ANTHROPIC_URL = 'https://api.anthropic.com';
+const DEFAULT_GITHUB_URL = 'https://api.github.com';
+const ANTHROPIC_VERSION = '2023-06-01';
+
+// 리뷰할 파일을 찾을 수 있는 이벤트
+const SUPPORTED_EVENTS = ['pull_request', 'push', 'issue_comment', 'pull_request_review_comment'];
+
+// 체크리스트 표시 아이콘
+const STATUS_ICONS = { pass: '✅', warn: '⚠️', fail: '❌' };
+
+class Doctor {
+  /**
+   * Doctor 생성자
+   * @param {Object} options - 옵션
+   * @param {Function} options.loadConfig - 액션 입력값을 읽고 검증하는 비동기 함수 (ConfigError로 실패)
+   * @param {Object} [options.env] - 환경 변수 (기본 process.env)
+   * @param {number} [options.timeoutMs] - 네트워크 요청 제한 시간 (기본 5000)
+   */
+  constructor(options) {
+    this.loadConfig = options.loadConfig;
+    this.env = options.env || process.env;
+    this.timeoutMs = options.timeoutMs || 5000;
+    this.inputs = null;
+    this.payload = null;
+  }
+
+  /**
+   * 모든 점검 실행
+   * 앞 단계가 실패해도 나머지 항목은 계속 점검해 한 번에 모든 문제를 보여 줍니다.
+   * @returns {Promise<Array>} { name, status: pass|warn|fail, detail } 목록
+   */
+  async run() {
+    const checks = [
+      await this.checkConfig(),
+      this.checkEvent()
+    ];
+    checks.push(...await this.checkNetwork());
+    checks.push(await this.checkAnthropicKey());
+    checks.push(await this.checkGitHubToken());
+    return checks;
+  }
+
+  /**
+   * 설정 점검 (액션과 같은 검증 로직 사용)
+   * @returns {Promise<Object>} 점검 결과
+   */
+  async checkConfig() {
+    try {
+      this.inputs = await this.loadConfig();
+      return { name: 'config', status: 'pass', detail: 'All inputs are valid' };
+    } catch (error) {
+      const detail = error instanceof ConfigError ? error.message : `Unexpected error while reading inputs: ${error.message}`;
+      return { name: 'config', status: 'fail', detail };
+    }
+  }
+
+  /**
+   * 이벤트 페이로드 점검
+   * @returns {Object} 점검 결과
+   */
+  checkEvent() {
+    const eventName = this.env.GITHUB_EVENT_NAME;
+    const eventPath = this.env.GITHUB_EVENT_PATH;
+    if (!eventName || !eventPath) {
+      return { name: 'event', status: 'fail', detail: 'GITHUB_EVENT_NAME and GITHUB_EVENT_PATH are not set (run inside a workflow, or use npm run simulate locally)' };
+    }
+    if (!fs.existsSync(eventPath)) {
+      return { name: 'event', status: 'fail', detail: `Event payload not found at ${eventPath}` };
+    }
+
+    try {
+      this.payload = JSON.parse(fs.readFileSync(eventPath, 'utf8'));
+    } catch (error) {
+      return { name: 'event', status: 'fail', detail: `Event payload is not valid JSON: ${error.message}` };
+    }
+
+    if (this.inputs && this.inputs.baseRef) {
+      return { name: 'event', status: 'pass', detail: `commit range ${this.inputs.baseRef}..${this.inputs.headRef} (${eventName})` };
+    }
+    if (!SUPPORTED_EVENTS.includes(eventName)) {
+      return { name: 'event', status: 'warn', detail: `${eventName} events are not reviewed (supported: ${SUPPORTED_EVENTS.join(', ')})` };
+    }
+    if (eventName === 'pull_request' && !(this.payload.pull_request && this.payload.pull_request.number)) {
+      return { name: 'event', status: 'fail', detail: 'pull_request payload has no pull_request.number' };
+    }
+    if (eventName === 'push' && !(this.payload.before && this.payload.after)) {
+      return { name: 'event', status: 'fail', detail: 'push payload has no before/after commits' };
+    }
+
+    const subject = eventName === 'pull_request' ? `PR #${this.payload.pull_request.number}` : `${this.payload.after.substring(0, 7)}`;
+    return { name: 'event', status: 'pass', detail: `${eventName} payload for ${subject}` };
+  }
+
+  /**
+   * 네트워크 도달 가능 여부 점검 (API 및 설정된 알림 대상)
+   * HTTP 응답을 받으면 상태 코드와 관계없이 도달 가능한 것으로 봅니다.
+   * @returns {Promise<Array>} 점검 결과 목록
+   */
+  async checkNetwork() {
+    const inputs = this.inputs || {};
+    const claudeTargets = {
+      bedrock: { label: 'Bedrock API', url: `https://bedrock-runtime.${inputs.awsRegion}.amazonaws.com` },
+      vertex: {
+        label: 'Vertex AI API',
+        url: inputs.vertexRegion === 'global' ? 'https://aiplatform.googleapis.com' : `https://${inputs.vertexRegion}-aiplatform.googleapis.com`
+      },
+      openai: { label: 'OpenAI-compatible API', url: inputs.openaiBaseUrl },
+      ollama: { label: 'Ollama', url: inputs.ollamaHost }
+    };
+    const targets = [
+      claudeTargets[inputs.provider] || { label: 'Anthropic API', url: this.anthropicUrl() },
+      { label: 'GitHub API', url: this.githubUrl() }
+    ];
+
+    [
+      ['Slack webhook', inputs.slackWebhookUrl],
+      ['Teams webhook', inputs.teamsWebhookUrl],
+      ['Discord webhook', inputs.discordWebhookUrl],
+      ['Notify URL', inputs.notifyUrl],
+      ['Webhook', inputs.webhookUrl],
+      ['Telemetry', inputs.telemetryUrl],
+      ['Jira', inputs.jiraBaseUrl]
+    ].forEach(([label, url]) => {
+      if (url) {
+        targets.push({ label, url });
+      }
+    });
+
+    const checks = await Promise.all(targets.map(target => this.checkHttpReachable(target.label, target.url)));
+    if (inputs.smtpHost) {
+      checks.push(await this.checkTcpReachable('SMTP', inputs.smtpHost, inputs.smtpPort));
+    }
+    return checks;
+  }
+
+  /**
+   * HTTP 엔드포인트 도달 가능 여부 점검
+   * @param {string} label - 표시 이름
+   * @param {string} url - 엔드포인트 URL
+   * @returns {Promise<Object>} 점검 결과
+   */
+  async checkHttpReachable(label, url) {
+    let origin;
+    try {
+      origin = new URL(url).origin;
+    } catch (error) {
+      return { name: `network: ${label}`, status: 'fail', detail: `Invalid URL ${url}` };
+    }
+
+    try {
+      // 웹훅 URL의 경로에는 비밀값이 들어 있으므로 출력과 요청 모두 origin만 사용
+      const response = await fetch(origin, { method: 'HEAD', signal: AbortSignal.timeout(this.timeoutMs) });
+      return { name: `network: ${label}`, status: 'pass', detail: `${origin} reachable (HTTP ${response.status})` };
+    } catch (error) {
+      return { name: `network: ${label}`, status: 'fail', detail: `${origin} unreachable: ${describeNetworkError(error)}` };
+    }
+  }
+
+  /**
+   * TCP 포트 도달 가능 여부 점검
+   * @param {string} label - 표시 이름
+   * @param {string} host - 호스트
+   * @param {number} port - 포트
+   * @returns {Promise<Object>} 점검 결과
+   */
+  checkTcpReachable(label, host, port) {
+    return new Promise(resolve => {
+      const socket = net.connect({ host, port });
+      const finish = result => {
+        socket.destroy();
+        resolve({ name: `network: ${label}`, ...result });
+      };
+      socket.setTimeout(this.timeoutMs, () => finish({ status: 'fail', detail: `${host}:${port} timed out after ${this.timeoutMs}ms` }));
+      socket.once('connect', () => finish({ status: 'pass', detail: `${host}:${port} reachable` }));
+      socket.once('error', error => finish({ status: 'fail', detail: `${host}:${port} unreachable: ${describeNetworkError(error)}` }));
+    });
+  }
+
+  /**
+   * Anthropic API 키 유효성 점검 (토큰을 소비하지 않는 모델 목록 조회 사용)
+   * @returns {Promise<Object>} 점검 결과
+   */
+  async checkAnthropicKey() {
+    if (this.inputs && this.inputs.provider === 'bedrock') {
+      return this.checkAwsCredentials();
+    }
+    if (this.inputs && this.inputs.provider === 'vertex') {
+      return this.checkGoogleCredentials();
+    }
+    if (this.inputs && this.inputs.provider === 'ollama') {
+      return this.checkOllamaModel();
+    }
+    if (this.inputs && this.inputs.provider === 'openai') {
+      // 게이트웨이마다 키 확인 API가 달라 설정 여부만 확인
+      return this.inputs.openaiApiKey
+        ? { name: 'openai api key', status: 'pass', detail: 'openai_api_key is set (not verified)' }
+        : { name: 'openai api key', status: 'warn', detail: 'openai_api_key is not set (fine if the gateway does not require authentication)' };
+    }
+    const apiKey = this.inputs ? this.inputs.anthropicApiKey : this.env.INPUT_ANTHROPIC_API_KEY;
+    if (!apiKey) {
+      return { name: 'anthropic api key', status: 'fail', detail: 'anthropic_api_key is not set' };
+    }
+
+    try {
+      const response = await fetch(`${this.anthropicUrl()}/v1/models?limit=1`, {
+        headers: { 'x-api-key': apiKey, 'anthropic-version': ANTHROPIC_VERSION },
+        signal: AbortSignal.timeout(this.timeoutMs)
+      });
+      if (response.ok) {
+        return { name: 'anthropic api key', status: 'pass', detail: 'Key accepted' };
+      }
+      if (response.status === 401 || response.status === 403) {
+        return { name: 'anthropic api key', status: 'fail', detail: `Key rejected (HTTP ${response.status}): ${await errorMessage(response)}` };
+      }
+      return { name: 'anthropic api key', status: 'warn', detail: `Could not verify key (HTTP ${response.status}): ${await errorMessage(response)}` };
+    } catch (error) {
+      return { name: 'anthropic api key', status: 'fail', detail: `Could not reach ${this.anthropicUrl()}: ${describeNetworkError(error)}` };
+    }
+  }
+
+  /**
+   * Bedrock 제공자의 AWS 자격 증명 점검
+   * 자격 증명 체인을 직접 해석하지 않고 알려진 환경 변수만 확인 (인스턴스 역할은 실행 시에만 확인 가능)
+   * @returns {Object} 점검 결과
+   */
+  checkAwsCredentials() {
+    if (this.env.AWS_WEB_IDENTITY_TOKEN_FILE && this.env.AWS_ROLE_ARN) {
+      return { name: 'aws credentials', status: 'pass', detail: `Web identity role ${this.env.AWS_ROLE_ARN} (${this.inputs.awsRegion})` };
+    }
+    if (this.env.AWS_ACCESS_KEY_ID && this.env.AWS_SECRET_ACCESS_KEY) {
+      return { name: 'aws credentials', status: 'pass', detail: `Access key from environment (${this.inputs.awsRegion})` };
+    }
+    if (this.env.AWS_PROFILE) {
+      return { name: 'aws credentials', status: 'pass', detail: `Profile ${this.env.AWS_PROFILE} (${this.inputs.awsRegion})` };
+    }
+    return { name: 'aws credentials', status: 'warn', detail: 'No AWS credentials in the environment (use aws-actions/configure-aws-credentials, or rely on an instance role)' };
+  }
+
+  /**
+   * Vertex AI 제공자의 Google 자격 증명 점검
+   * google-github-actions/auth는 서비스 계정 키와 Workload Identity Federation 모두 자격 증명 파일 경로를 설정함
+   * @returns {Object} 점검 결과
+   */
+  checkGoogleCredentials() {
+    const credentialsFile = this.env.GOOGLE_APPLICATION_CREDENTIALS;
+    if (!credentialsFile) {
+      return { name: 'google credentials', status: 'warn', detail: 'GOOGLE_APPLICATION_CREDENTIALS is not set (use google-github-actions/auth, or rely on the metadata server)' };
+    }
+    if (!fs.existsSync(credentialsFile)) {
+      return { name: 'google credentials', status: 'fail', detail: `Credentials file not found at ${credentialsFile}` };
+    }
+    return { name: 'google credentials', status: 'pass', detail: `Application Default Credentials for ${this.inputs.vertexProjectId} (${this.inputs.vertexRegion})` };
+  }
+
+  /**
+   * Ollama에 로컬 모델이 받아져 있는지 점검
+   * @returns {Promise<Object>} 점검 결과
+   */
+  async checkOllamaModel() {
+    const { ollamaHost, ollamaModel } = this.inputs;
+    try {
+      const response = await fetch(`${ollamaHost.replace(/\/+$/, '')}/api/tags`, { signal: AbortSignal.timeout(this.timeoutMs) });
+      if (!response.ok) {
+        return { name: 'ollama model', status: 'warn', detail: `Could not list models (HTTP ${response.status}): ${await errorMessage(response)}` };
+      }
+      const { models = [] } = await response.json();
+      // 태그를 생략한 모델 이름은 :latest와 같음
+      const wanted = ollamaModel.includes(':') ? ollamaModel : `${ollamaModel}:latest`;
+      if (models.some(model => model.name === wanted)) {
+        return { name: 'ollama model', status: 'pass', detail: `${wanted} is available on ${ollamaHost}` };
+      }
+      return { name: 'ollama model', status: 'fail', detail: `${wanted} is not pulled on ${ollamaHost} (run "ollama pull ${ollamaModel}")` };
+    } catch (error) {
+      return { name: 'ollama model', status: 'fail', detail: `Could not reach ${ollamaHost}: ${describeNetworkError(error)}` };
+    }
+  }
+
+  /**
+   * GitHub 토큰과 권한 점검
+   * 클래식 PAT는 x-oauth-scopes 헤더로 스코프를 확인하고,
+   * GITHUB_TOKEN과 fine-grained 토큰은 스코프를 알려 주지 않으므로 실제 읽기 요청으로 확인합니다.
+   * @returns {Promise<Object>} 점검 결과
+   */
+  async checkGitHubToken() {
+    const token = this.inputs ? this.inputs.githubToken : this.env.INPUT_GITHUB_TOKEN;
+    if (!token) {
+      return { name: 'github token', status: 'fail', detail: 'github_token is not set' };
+    }
+    const repository = this.env.GITHUB_REPOSITORY ||
+      (this.payload && this.payload.repository && this.payload.repository.full_name);
+    if (!repository) {
+      return { name: 'github token', status: 'warn', detail: 'GITHUB_REPOSITORY is not set; token permissions were not checked' };
+    }
+
+    try {
+      const repoResponse = await this.githubRequest(token, `/repos/${repository}`);
+      if (repoResponse.status === 401) {
+        return { name: 'github token', status: 'fail', detail: 'Token rejected (HTTP 401): it is invalid or expired' };
+      }
+      if (!repoResponse.ok) {
+        return { name: 'github token', status: 'fail', detail: `Token cannot read ${repository} (HTTP ${repoResponse.status}): ${await errorMessage(repoResponse)}` };
+      }
+
+      const scopesHeader = repoResponse.headers.get('x-oauth-scopes');
+      if (scopesHeader !== null) {
+        const repo = await repoResponse.json();
+        const scopes = scopesHeader.split(',').map(scope => scope.trim()).filter(Boolean);
+        const required = repo.private ? ['repo'] : ['repo', 'public_repo'];
+        if (!scopes.some(scope => required.includes(scope))) {
+          return { name: 'github token', status: 'fail', detail: `Token scopes [${scopes.join(', ') || 'none'}] do not include ${required.join(' or ')}, needed to post review comments` };
+        }
+        return { name: 'github token', status: 'pass', detail: `Classic token with scopes [${scopes.join(', ')}]` };
+      }
+
+      const prNumber = this.payload && this.payload.pull_request && this.payload.pull_request.number;
+      if (prNumber) {
+        const filesResponse = await this.githubRequest(token, `/repos/${repository}/pulls/${prNumber}/files?per_page=1`);
+        if (!filesResponse.ok) {
+          return { name: 'github token', status: 'fail', detail: `Token cannot list files of PR #${prNumber} (HTTP ${filesResponse.status}); grant pull-requests: read` };
+        }
+      }
+      // 쓰기 권한은 실제로 쓰지 않고는 확인할 수 없음
+      return { name: 'github token', status: 'pass', detail: `Read access to ${repository} verified; make sure the workflow grants pull-requests: write and contents: read` };
+    } catch (error) {
+      return { name: 'github token', status: 'fail', detail: `Could not reach ${this.githubUrl()}: ${describeNetworkError(error)}` };
+    }
+  }
+
+  /**
+   * GitHub API GET 요청
+   * @param {string} token - GitHub 토큰
+   * @param {string} path - API 경로
+   * @returns {Promise<Response>} 응답
+   */
+  githubRequest(token, path) {
+    return fetch(`${this.githubUrl()}${path}`, {
+      headers: {
+        accept: 'application/vnd.github+json',
+        authorization: `Bearer ${token}`,
+        'user-agent': 'claude-code-review-action-doctor'
+      },
+      signal: AbortSignal.timeout(this.timeoutMs)
+    });
+  }
+
+  /**
+   * Anthropic API 기본 URL (SDK와 같은 환경 변수 사용)
+   * @returns {string} 기본 URL
+   */
+  anthropicUrl() {
+    return (this.env.ANTHROPIC_BASE_URL || DEFAULT_ANTHROPIC_URL).replace(/\/+$/, '');
+  }
+
+  /**
+   * GitHub API 기본 URL (GitHub Enterprise Server 지원)
+   * @returns {string} 기본 URL
+   */
+  githubUrl() {
+    return (this.env.GITHUB_API_URL || DEFAULT_GITHUB_URL).replace(/\/+$/, '');
+  }
+
+  /**
+   * 점검 결과를 체크리스트 문자열로 변환
+   * @param {Array} checks - run() 결과
+   * @returns {string} 체크리스트
+   */
+  static format(checks) {
+    const lines = checks.map(check => `${STATUS_ICONS[check.status]} ${check.name} — ${check.detail}`);
+    const failed = checks.filter(check => check.status === 'fail').length;
+    const warned = checks.filter(check => check.status === 'warn').length;
+    lines.push('');
+    lines.push(failed > 0
+      ? `${failed} check(s) failed, ${warned} warning(s)`
+      : `All checks passed${warned > 0 ? ` with ${warned} warning(s)` : ''}`);
+    return lines.join('\n');
+  }
+}
+
+/**
+ * 네트워크 오류를 읽기 쉬운 문자열로 변환 (fetch는 원인을 cause에 담음)
+ * @param {Error} error - 오류
+ * @returns {string} 설명
+ */
+function describeNetworkError(error) {
+  if (error.name === 'TimeoutError') {
+    return 'timed out';
+  }
+  const cause = error.cause || error;
+  return cause.code ? `${cause.code}${cause.hostname ? ` (${cause.hostname})` : ''}` : cause.message;
+}
+
+/**
+ * API 오류 응답에서 메시지 추출
+ * @param {Response} response - 응답
+ * @returns {Promise<string>} 메시지
+ */
+async function errorMessage(response) {
+  const text = await response.text();
+  try {
+    const body = JSON.parse(text);
+    return (body.error && body.error.message) || body.message || text;
+  } catch (error) {
+    return text.substring(0, 200);
+  }
+}
+
+module.exports = Doctor;
@@ -0,0 +1,154 @@
+/**
+ * Email Notifier Module
+ * 리뷰에서 발견된 전체 이슈를 HTML 다이제스트 메일로 전송하는 모듈
+ *
+ * PR을 지켜보는 사람이 없는 정기 전체 감사 실행에서 결과를 전달하는 용도입니다.
+ */
+
+const core = require('@actions/core');
+const SmtpClient = require('./smtp-client');
+const { hasFindingsAtOrAbove, getSeverityLevel } = require('./review-summary');
+const DisplayLabels = require('./display-labels');
+const { renderPlainText } = require('./plain-text-renderer');
+
+// 심각도별 배지 색상
+const SEVERITY_COLORS = {
+  critical: '#d73a49',
+  high: '#e36209',
+  medium: '#dbab09',
+  low: '#28a745'
+};
+
+class EmailNotifier {
+  /**
+   * EmailNotifier 생성자
+   * @param {Object} config - 설정
+   * @param {Object} config.smtp - SmtpClient 설정 (host, port, username, password, allowInsecureAuth)
+   * @param {string} config.from - 발신자 주소
+   * @param {Array<string>} config.to - 수신자 주소 목록
+   * @param {string} [config.minSeverity] - 이 심각도 이상 이슈가 있을 때만 전송
+   * @param {DisplayLabels} [config.labels] - 심각도 아이콘/표시 이름
+   * @param {boolean} [config.plainText] - HTML 없이 텍스트 본문만 전송
+   */
+  constructor({ smtp, from, to, minSeverity = 'low', labels = new DisplayLabels(), plainText = false }) {
+    this.smtp = smtp;
+    this.from = from;
+    this.to = to;
+    this.minSeverity = minSeverity;
+    this.labels = labels;
+    this.plainText = plainText;
+  }
+
+  /**
+   * 임계값 이상 이슈 존재 여부 확인
+   * @param {Object} summary - buildReviewSummary() 결과
+   * @returns {boolean} 전송 여부
+   */
+  shouldNotify(summary) {
+    return hasFindingsAtOrAbove(summary, this.minSeverity);
+  }
+
+  /**
+   * HTML 특수문자 이스케이프
+   * @param {string} text - 원본 문자열
+   * @returns {string} 이스케이프된 문자열
+   */
+  escape(text) {
+    return String(text || '')
+      .replace(/&/g, '&amp;')
+      .replace(/</g, '&lt;')
+      .replace(/>/g, '&gt;')
+      .replace(/"/g, '&quot;');
+  }
+
+  /**
+   * 메일 제목 생성
+   * @param {Object} summary - 리뷰 요약
+   * @returns {string} 제목
+   */
+  buildSubject(summary) {
+    return `[Claude Review] ${summary.repository}: ${summary.totalIssues} issues (${summary.counts.critical} critical, ${summary.counts.high} high)`;
+  }
+
+  /**
+   * 심각도 표시 문자열 (아이콘 + 이름)
+   * @param {string} severity - 심각도
+   * @returns {string} 표시 문자열
+   */
+  severityText(severity) {
+    return this.labels.withIcon(this.labels.severityIcon(severity), this.labels.severityLabel(severity));
+  }
+
+  /**
+   * HTML 다이제스트 본문 생성 (파일별 전체 이슈 표)
+   * @param {Object} summary - 리뷰 요약
+   * @returns {string} HTML
+   */
+  buildHtml(summary) {
+    const counts = ['critical', 'high', 'medium', 'low']
+      .map(severity => `<td style="padding:4px 12px"><b style="color:${SEVERITY_COLORS[severity]}">${this.escape(this.severityText(severity))}</b> ${summary.counts[severity]}</td>`)
+      .join('');
+
+    const sections = summary.results.map(result => {
+      const rows = [...result.issues]
+        .sort((a, b) => getSeverityLevel(b.severity) - getSeverityLevel(a.severity))
+        .map(issue => `<tr>
+  <td style="padding:4px 8px;color:${SEVERITY_COLORS[issue.severity] || '#666'}">${this.escape(this.severityText(issue.severity))}</td>
+  <td style="padding:4px 8px">${issue.line || '-'}</td>
+  <td style="padding:4px 8px"><b>${this.escape(issue.title)}</b><br>${this.escape(issue.description)}${issue.suggestion ? `<br><i>${this.escape(issue.suggestion)}</i>` : ''}</td>
+</tr>`)
+        .join('\n');
+
+      return `<h3 style="font-family:monospace">${this.escape(result.file)}</h3>
+<table style="border-collapse:collapse;width:100%" border="1" bordercolor="#e1e4e8">
+<tr style="background:#f6f8fa"><th>Severity</th><th>Line</th><th>Finding</th></tr>
+${rows}
+</table>`;
+    }).join('\n');
+
+    return `<!DOCTYPE html>
+<html><body style="font-family:-apple-system,Segoe UI,Helvetica,Arial,sans-serif">
+<h2>🤖 Claude AI Code Review</h2>
+<p><a href="${this.escape(summary.url)}">${this.escape(summary.title)}</a></p>
+<table><tr>${counts}</tr></table>
+<p>${summary.totalIssues} issues in ${summary.filesWithIssues} files</p>
+${sections}
+</body></html>`;
+  }
+
+  /**
+   * 텍스트 본문 생성 (HTML을 표시하지 못하는 메일 클라이언트용)
+   * @param {Object} summary - 리뷰 요약
+   * @returns {string} 텍스트
+   */
+  buildText(summary) {
+    return renderPlainText(summary, this.labels);
+  }
+
+  /**
+   * 조건 충족 시 메일 전송
+   * @param {Object} summary - 리뷰 요약
+   */
+  async notify(summary) {
+    if (!this.shouldNotify(summary)) {
+      core.info(`Email digest skipped: no findings at or above ${this.minSeverity}`);
+      return;
+    }
+
+    try {
+      const client = new SmtpClient(this.smtp);
+      await client.send({
+        from: this.from,
+        to: this.to,
+        subject: this.buildSubject(summary),
+        text: this.buildText(summary),
+        html: this.plainText ? null : this.buildHtml(summary)
+      });
+      core.info(`Email digest sent to ${this.to.length} recipients`);
+    } catch (error) {
+      core.warning(`Failed to send email digest: ${error.message}`);
+    }
+  }
+}
+
+module.exports = EmailNotifier;
@@ -0,0 +1,22 @@
+/**
+ * Error Types
+ * 액션 전반에서 사용하는 에러 타입 정의
+ *
+ * 설정 오류는 사용자가 워크플로우를 고쳐야 하는 문제이므로
+ * 디버그 번들 생성 등 장애 분석 흐름에서 제외하기 위해 별도 타입으로 구분합니다.
+ */
+
+/**
+ * 잘못된 입력값/설정으로 인한 에러
+ */
+class ConfigError extends Error {
+  /**
+   * @param {string} message - 에러 메시지
+   */
+  constructor(message) {
+    super(message);
+    this.name = 'ConfigError';
+  }
+}
+
+module.exports = { ConfigError };
@@ -22,6 +22,8 @@ class FileAnalyzer {
    * @param {string} config.excludePatterns - 제외할 파일 패턴 (쉼표로 구분)
    * @param {number} config.maxFiles - 최대 리뷰 파일 수
    * @param {string} config.githubToken - GitHub 토큰
+   * @param {string} [config.baseRef] - 지정하면 이벤트와 관계없이 baseRef..headRef 커밋 범위를 리뷰
+   * @param {string} [config.headRef] - 커밋 범위의 끝 (기본값: HEAD)
    */
   constructor(config) {
     // 파일 패턴을 배열로 변환
@@ -30,6 +32,11 @@ class FileAnalyzer {
     this.maxFiles = config.maxFiles;
     // Git 작업을 위한 simple-git 인스턴스
     this.git = simpleGit();
+    // 파일별 diff의 기준 커밋과 끝 커밋 (push 이벤트와 커밋 범위 리뷰에서 설정)
+    this.diffBase = 'HEAD~1';
+    this.diffHead = 'HEAD';
+    this.baseRef = config.baseRef || '';
+    this.headRef = config.headRef || 'HEAD';
     // GitHub API 클라이언트 생성
     this.octokit = github.getOctokit(config.githubToken);
   }
@@ -41,6 +48,10 @@ class FileAnalyzer {
    */
   async getChangedFiles(context) {
     try {
+      // 커밋 범위를 지정했으면 PR 없이 그 범위를 리뷰 (workflow_dispatch 등)
+      if (this.baseRef) {
+        return await this.getRangeFiles();
+      }
       // 이벤트 타입에 따라 다른 방식으로 파일 목록 가져오기
       if (context.eventName === 'pull_request') {
         // PR 이벤트: GitHub API를 통해 파일 목록 가져오기
@@ -61,8 +72,8 @@ class FileAnalyzer {
    * @returns {Promise<Array>} PR에서 변경된 파일 목록
    */
   async getPullRequestFiles(context) {
-    // GitHub REST API를 사용하여 PR 파일 목록 조회
-    const { data: files } = await this.octokit.rest.pulls.listFiles({
+    // GitHub REST API를 사용하여 PR 파일 목록 조회 (100개 초과 PR도 모든 페이지 조회)
+    const files = await this.octokit.paginate(this.octokit.rest.pulls.listFiles, {
       owner: context.repo.owner,
       repo: context.repo.repo,
       pull_number: context.payload.pull_request.number,
@@ -82,28 +93,47 @@ class FileAnalyzer {
    * @returns {Promise<Array>} Push에서 변경된 파일 목록
    */
   async getPushFiles(context) {
-    try {
-      // Push 이벤트에서 제공하는 before/after 커밋 SHA
-      const beforeSha = context.payload.before;
-      const afterSha = context.payload.after;
+    // Push 이벤트에서 제공하는 before/after 커밋 SHA
+    const beforeSha = context.payload.before;
+    const afterSha = context.payload.after || 'HEAD';
+    // 새 브랜치 생성인 경우 (before가 null 커밋) HEAD 커밋과 이전 커밋 비교
+    const base = !beforeSha || /^0+$/.test(beforeSha) ? 'HEAD~1' : beforeSha;
 
-      // 새 브랜치 생성인 경우 (before가 null 커밋)
-      if (beforeSha === '0000000000000000000000000000000000000000') {
-        // HEAD 커밋과 이전 커밋 비교
-        const diffSummary = await this.git.diff(['--name-status', 'HEAD~1', 'HEAD']);
-        return this.parseDiffOutput(diffSummary);
-      } else {
-        // 기존 브랜치에 푸시: before와 after 커밋 비교
-        const diffSummary = await this.git.diff(['--name-status', beforeSha, afterSha]);
-        return this.parseDiffOutput(diffSummary);
-      }
+    try {
+      // 기존 브랜치에 푸시: before와 after 커밋 비교 (push에 포함된 모든 커밋)
+      const diffSummary = await this.git.diff(['--name-status', base, afterSha]);
+      this.diffBase = base;
+      return this.parseDiffOutput(diffSummary);
     } catch (error) {
+      if (base !== 'HEAD~1') {
+        // 강제 push로 이전 커밋을 가져올 수 없으면 마지막 커밋만 리뷰
+        console.warn(`Cannot diff against ${base.substring(0, 7)} (force-push or shallow clone), reviewing the last commit only`);
+        return this.getPushFiles({ ...context, payload: { ...context.payload, before: null } });
+      }
       // Git diff 실패 시 빈 배열 반환 (액션 실패 방지)
       console.warn('Git diff failed, using alternative method');
       return [];
     }
   }
 
+  /**
+   * 지정한 커밋 범위(baseRef..headRef)에서 변경된 파일 목록 가져오기
+   * headRef가 체크아웃한 커밋이 아니면 파일 내용을 그 커밋에서 읽도록 ref를 붙임
+   * @returns {Promise<Array>} 범위에서 변경된 파일 목록
+   */
+  async getRangeFiles() {
+    let diffSummary;
+    try {
+      diffSummary = await this.git.diff(['--name-status', this.baseRef, this.headRef]);
+    } catch (error) {
+      throw new Error(`Cannot diff ${this.baseRef}..${this.headRef} (check out with fetch-depth: 0 so both refs exist): ${error.message}`);
+    }
+    this.diffBase = this.baseRef;
+    this.diffHead = this.headRef;
+    const files = this.parseDiffOutput(diffSummary);
+    return this.headRef === 'HEAD' ? files : files.map(file => ({ ...file, ref: this.headRef }));
+  }
+
   /**
    * Git diff 출력을 파싱하여 파일 정보 배열로 변환
    * @param {string} diffOutput - git diff --name-status 출력
@@ -115,12 +145,15 @@ class FileAnalyzer {
 
     for (const line of lines) {
       // Git diff 형식: "M\tfilename" 또는 "A\tfilename" 등
-      const [status, filename] = line.split('\t');
+      // 이름 변경/복사는 "R100\told\tnew" 형식이므로 마지막 경로(새 이름)를 사용
+      const [rawStatus, ...paths] = line.split('\t');
+      const status = rawStatus.trim().charAt(0);
+      const filename = (paths[paths.length - 1] || '').trim();
       
       // 삭제된 파일은 제외
       if (status !== 'D' && filename) {
         files.push({
-          filename: filename.trim(),
+          filename,
           status: this.mapGitStatus(status),
           additions: 0, // Push 이벤트에서는 정확한 수치를 알 수 없음
           deletions: 0
@@ -242,11 +275,14 @@ class FileAnalyzer {
 
   /**
    * 파일 내용 읽기
-   * @param {Object} file - 파일 정보 객체
+   * @param {Object} file - 파일 정보 객체 (ref가 있으면 그 커밋 시점의 내용)
    * @returns {Promise<string>} 파일 내용
    */
   async getFileContent(file) {
     try {
+      if (file.ref) {
+        return await this.git.show([`${file.ref}:${file.filename}`]);
+      }
       // UTF-8 인코딩으로 파일 읽기
       const content = await fs.readFile(file.filename, 'utf8');
       return content;
@@ -262,8 +298,8 @@ class FileAnalyzer {
    */
   async getFileDiff(file) {
     try {
-      // HEAD와 이전 커밋 간의 특정 파일 diff
-      const diff = await this.git.diff(['HEAD~1', 'HEAD', '--', file.filename]);
+      // HEAD와 이전 커밋(push는 push 이전 커밋, 커밋 범위는 base_ref..head_ref) 간의 특정 파일 diff
+      const diff = await this.git.diff([this.diffBase, this.diffHead, '--', file.filename]);
       return diff || '';
     } catch (error) {
       // diff 실패 시 빈 문자열 반환 (리뷰는 계속 진행)
@@ -0,0 +1,154 @@
+/**
+ * Finding Baseline Module
+ * 기존 코드베이스의 알려진 이슈를 기준선 파일(.claude-review-baseline.json)로 저장하고,
+ * 이후 리뷰에서 기준선에 있는 이슈를 제외하는 모듈
+ *
+ * 저장 형식:
+ * {
+ *   "version": 1,
+ *   "generatedAt": "ISO 날짜",
+ *   "findings": [
+ *     { "fingerprint": "...", "file": "src/app.js", "type": "bug", "severity": "high", "title": "..." }
+ *   ]
+ * }
+ *
+ * 레거시 저장소에 액션을 도입할 때 감사 모드(audit)와 baseline_update로 기준선을 만들어 커밋하면,
+ * PR에는 새로 생긴 이슈만 보고됩니다. 이슈는 지문(fingerprint.js)으로 비교하므로 줄 번호가 바뀌어도 유지됩니다.
+ * 감사 모드는 기준선을 저장소 전체의 현재 이슈로 교체하고, PR/push 리뷰는 리뷰한 파일의 이슈를 기존 기준선에 추가합니다.
+ */
+
+const fs = require('fs').promises;
+const path = require('path');
+const { ConfigError } = require('./errors');
+
+const DEFAULT_BASELINE_PATH = '.claude-review-baseline.json';
+
+class FindingBaseline {
+  /**
+   * FindingBaseline 생성자
+   * @param {Array} findings - 기준선 항목 ({ fingerprint, file, type, severity, title })
+   * @param {string|null} [generatedAt] - 기준선 생성 시각
+   */
+  constructor(findings = [], generatedAt = null) {
+    this.findings = findings;
+    this.generatedAt = generatedAt;
+    this.fingerprints = new Set(findings.map(finding => finding.fingerprint));
+  }
+
+  /**
+   * 기준선 파일 로드 (파일이 없으면 빈 기준선)
+   * @param {string} [filePath] - 기준선 파일 경로
+   * @returns {Promise<FindingBaseline>} 기준선
+   * @throws {ConfigError} 잘못된 기준선 파일
+   */
+  static async load(filePath = DEFAULT_BASELINE_PATH) {
+    let text;
+    try {
+      text = await fs.readFile(filePath, 'utf8');
+    } catch (error) {
+      if (error.code === 'ENOENT') {
+        return new FindingBaseline();
+      }
+      throw new ConfigError(`Cannot read baseline ${filePath}: ${error.message}`);
+    }
+
+    let data;
+    try {
+      data = JSON.parse(text);
+    } catch (error) {
+      throw new ConfigError(`Invalid baseline ${filePath}: ${error.message}`);
+    }
+    const findings = data && Array.isArray(data.findings) ? data.findings : null;
+    if (!findings || findings.some(finding => !finding || typeof finding.fingerprint !== 'string')) {
+      throw new ConfigError(`Invalid baseline ${filePath}: expected { "findings": [{ "fingerprint": "..." }] }`);
+    }
+    return new FindingBaseline(findings, typeof data.generatedAt === 'string' ? data.generatedAt : null);
+  }
+
+  /**
+   * 리뷰 결과로 기준선 생성 (감사 모드: 저장소 전체의 현재 이슈로 교체)
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @param {Date} [now] - 생성 시각
+   * @returns {FindingBaseline} 기준선
+   */
+  static fromResults(reviewResults, now = new Date()) {
+    return new FindingBaseline().withResults(reviewResults, now);
+  }
+
+  /**
+   * 기존 항목에 리뷰 결과의 이슈를 더한 기준선 (같은 지문은 하나만, 파일/지문 순으로 정렬해 diff를 안정적으로 유지)
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @param {Date} [now] - 생성 시각
+   * @returns {FindingBaseline} 새 기준선
+   */
+  withResults(reviewResults, now = new Date()) {
+    const findings = new Map(this.findings.map(finding => [finding.fingerprint, finding]));
+    reviewResults.forEach(result => result.issues
+      .filter(issue => issue.fingerprint && !findings.has(issue.fingerprint))
+      .forEach(issue => findings.set(issue.fingerprint, {
+        fingerprint: issue.fingerprint,
+        file: result.file,
+        type: issue.type,
+        severity: issue.severity,
+        title: issue.title
+      })));
+
+    const sorted = [...findings.values()].sort((a, b) =>
+      String(a.file).localeCompare(String(b.file)) || a.fingerprint.localeCompare(b.fingerprint));
+    return new FindingBaseline(sorted, now.toISOString());
+  }
+
+  /**
+   * 기준선에 포함된 이슈인지 확인
+   * @param {string} fingerprint - 이슈 지문
+   * @returns {boolean} 포함 여부
+   */
+  has(fingerprint) {
+    return this.fingerprints.has(fingerprint);
+  }
+
+  /**
+   * 리뷰 결과에서 기준선에 있는 이슈 제거
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @returns {Object} { results, baselinedCount }
+   */
+  filterResults(reviewResults) {
+    let baselinedCount = 0;
+    const results = reviewResults
+      .map(result => {
+        const issues = result.issues.filter(issue => {
+          const baselined = Boolean(issue.fingerprint) && this.has(issue.fingerprint);
+          if (baselined) {
+            baselinedCount++;
+          }
+          return !baselined;
+        });
+        return { ...result, issues };
+      })
+      .filter(result => result.issues.length > 0);
+
+    return { results, baselinedCount };
+  }
+
+  /**
+   * 파일 내용 직렬화
+   * @returns {string} JSON 문자열
+   */
+  serialize() {
+    return JSON.stringify({ version: 1, generatedAt: this.generatedAt, findings: this.findings }, null, 2) + '\n';
+  }
+
+  /**
+   * 기준선 파일 작성 (작업 트리에 쓰므로 이후 단계에서 커밋)
+   * @param {string} [filePath] - 기준선 파일 경로
+   * @returns {Promise<string>} 작성한 파일 경로
+   */
+  async write(filePath = DEFAULT_BASELINE_PATH) {
+    await fs.mkdir(path.dirname(filePath), { recursive: true });
+    await fs.writeFile(filePath, this.serialize());
+    return filePath;
+  }
+}
+
+module.exports = FindingBaseline;
+module.exports.DEFAULT_BASELINE_PATH = DEFAULT_BASELINE_PATH;
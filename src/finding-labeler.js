@@ -0,0 +1,130 @@
+/**
+ * Finding Labeler Module
+ * 발견된 이슈의 타입, 심각도와 PR 위험도로 PR에 라벨(ai:security, ai:needs-tests 등)을 붙이는 모듈
+ *
+ * 규칙 형식 (finding_label_rules 입력값, 한 줄에 하나):
+ *   type=security -> ai:security
+ *   severity=critical path=src/auth/** -> ai:critical, ai:auth
+ *   risk=high -> ai:large-risk
+ *
+ * - 조건은 notify_routes와 같고 (severity, type, path), risk=<low|medium|high>는 PR 위험도 등급 이상일 때 일치
+ * - 이슈 조건 규칙은 하나 이상의 이슈가 일치하면 적용되며, 일치하는 모든 규칙의 라벨을 붙임
+ * - 규칙에 있는 라벨 중 더 이상 일치하지 않는 라벨은 제거 (증분 리뷰는 이전 변경분의 이슈를 모르므로 추가만)
+ * - 규칙을 지정하지 않으면 DEFAULT_RULES 사용
+ */
+
+const { ConfigError } = require('./errors');
+const { parseConditions, matchesConditions } = require('./notification-router');
+
+const DEFAULT_RULES = `type=security -> ai:security
+type=testing -> ai:needs-tests
+type=performance -> ai:performance
+severity=critical -> ai:critical
+risk=high -> ai:large-risk`;
+
+// 위험도 등급 순서
+const RISK_LEVELS = { low: 1, medium: 2, high: 3 };
+
+/**
+ * 라벨 규칙 파싱
+ * @param {string} text - 여러 줄 규칙 (빈 줄과 #으로 시작하는 줄은 무시, 비어 있으면 기본 규칙)
+ * @returns {Array} 규칙 배열 { severity, types, path, risk, labels }
+ */
+function parseLabelRules(text) {
+  return (String(text || '').trim() || DEFAULT_RULES)
+    .split('\n')
+    .map(line => line.trim())
+    .filter(line => line && !line.startsWith('#'))
+    .map(line => {
+      const arrow = line.indexOf('->');
+      if (arrow === -1) {
+        throw new ConfigError(`Invalid finding label rule (missing "->"): ${line}`);
+      }
+      const labels = line.substring(arrow + 2).split(',').map(label => label.trim()).filter(Boolean);
+      if (labels.length === 0) {
+        throw new ConfigError(`Finding label rule has no label: ${line}`);
+      }
+
+      // risk 조건은 이슈가 아니라 PR 전체에 대한 조건이므로 따로 분리
+      let risk = null;
+      const conditions = line.substring(0, arrow).trim().split(/\s+/).filter(condition => {
+        if (!condition.startsWith('risk=')) {
+          return true;
+        }
+        risk = condition.substring('risk='.length).toLowerCase();
+        if (!RISK_LEVELS[risk]) {
+          throw new ConfigError(`Invalid risk "${risk}" in finding label rule (expected low, medium, high): ${line}`);
+        }
+        return false;
+      });
+      const rule = { ...parseConditions(conditions.join(' '), line, 'finding label rule'), risk, labels };
+      if (risk && (rule.severity || rule.types || rule.path)) {
+        throw new ConfigError(`risk cannot be combined with finding conditions in finding label rule: ${line}`);
+      }
+      return rule;
+    });
+}
+
+class FindingLabeler {
+  /**
+   * FindingLabeler 생성자
+   * @param {Object} octokit - GitHub API 클라이언트
+   * @param {Object} context - GitHub Actions 컨텍스트 (pull_request 이벤트)
+   * @param {Array} rules - parseLabelRules() 결과
+   */
+  constructor(octokit, context, rules) {
+    this.octokit = octokit;
+    this.context = context;
+    this.rules = rules;
+  }
+
+  /**
+   * 리뷰 결과와 위험도에 일치하는 라벨 계산
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @param {string|null} riskLevel - PR 위험도 등급
+   * @returns {Array<string>} 붙일 라벨 (규칙 순서)
+   */
+  labelsFor(reviewResults, riskLevel) {
+    const labels = new Set();
+    this.rules
+      .filter(rule => rule.risk
+        ? RISK_LEVELS[riskLevel] >= RISK_LEVELS[rule.risk]
+        : reviewResults.some(result => result.issues.some(issue => matchesConditions(rule, result.file, issue))))
+      .forEach(rule => rule.labels.forEach(label => labels.add(label)));
+    return [...labels];
+  }
+
+  /**
+   * PR 라벨 갱신 (일치하는 라벨 추가, 규칙의 다른 라벨 제거)
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @param {string|null} riskLevel - PR 위험도 등급
+   * @param {Object} [options] - 옵션
+   * @param {boolean} [options.partial] - 일부 변경분만 리뷰했는지 (증분 리뷰, 라벨을 제거하지 않음)
+   * @returns {Promise<Object>} { added, removed }
+   */
+  async apply(reviewResults, riskLevel, { partial = false } = {}) {
+    const issue = {
+      owner: this.context.repo.owner,
+      repo: this.context.repo.repo,
+      issue_number: this.context.payload.pull_request.number
+    };
+    const wanted = this.labelsFor(reviewResults, riskLevel);
+    const managed = new Set(this.rules.flatMap(rule => rule.labels));
+    const { data: current } = await this.octokit.rest.issues.listLabelsOnIssue({ ...issue, per_page: 100 });
+    const currentNames = current.map(label => label.name);
+
+    const removed = partial ? [] : currentNames.filter(name => managed.has(name) && !wanted.includes(name));
+    for (const name of removed) {
+      await this.octokit.rest.issues.removeLabel({ ...issue, name });
+    }
+    const added = wanted.filter(name => !currentNames.includes(name));
+    if (added.length > 0) {
+      await this.octokit.rest.issues.addLabels({ ...issue, labels: added });
+    }
+    return { added, removed };
+  }
+}
+
+module.exports = FindingLabeler;
+module.exports.parseLabelRules = parseLabelRules;
+module.exports.DEFAULT_RULES = DEFAULT_RULES;
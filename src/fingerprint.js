@@ -0,0 +1,79 @@
+/**
+ * Fingerprint Module
+ * 이슈를 실행 간에 동일하게 식별하기 위한 지문(fingerprint) 생성 모듈
+ *
+ * 지문 = 정규화된 파일 경로 + 지적된 코드 라인 해시 + 카테고리
+ * 라인 번호는 위쪽 코드 변경으로 쉽게 바뀌므로 지문에 포함하지 않습니다.
+ */
+
+const crypto = require('crypto');
+
+/**
+ * 파일 경로 정규화 (구분자 통일, ./ 제거)
+ * @param {string} filePath - 파일 경로
+ * @returns {string} 정규화된 경로
+ */
+function normalizePath(filePath) {
+  return String(filePath || '')
+    .replace(/\\/g, '/')
+    .replace(/^\.\//, '')
+    .replace(/\/{2,}/g, '/');
+}
+
+/**
+ * 코드 비교용 정규화 (공백 차이 무시)
+ * @param {string} code - 코드 문자열
+ * @returns {string} 정규화된 코드
+ */
+function normalizeCode(code) {
+  return String(code || '').replace(/\s+/g, ' ').trim();
+}
+
+/**
+ * 이슈 라인 주변 코드 발췌
+ * @param {string} content - 파일 전체 내용
+ * @param {number|null} line - 이슈 라인 (1부터 시작)
+ * @param {number} context - 앞뒤로 포함할 라인 수
+ * @returns {Object|null} { startLine, code, target } 또는 null
+ */
+function extractSnippet(content, line, context = 2) {
+  if (!content || !line || line < 1) {
+    return null;
+  }
+  const lines = content.split('\n');
+  if (line > lines.length) {
+    return null;
+  }
+
+  const start = Math.max(1, line - context);
+  const end = Math.min(lines.length, line + context);
+  return {
+    startLine: start,
+    code: lines.slice(start - 1, end).join('\n'),
+    // 지문 계산에 사용하는 지적된 라인 자체
+    target: lines[line - 1]
+  };
+}
+
+/**
+ * 이슈 지문 계산
+ * @param {string} file - 파일 경로
+ * @param {Object} issue - 이슈 (snippet이 있으면 코드 기준, 없으면 제목 기준)
+ * @returns {string} 16자리 sha256 해시
+ */
+function fingerprintFinding(file, issue) {
+  const anchor = issue.snippet && normalizeCode(issue.snippet.target)
+    ? normalizeCode(issue.snippet.target)
+    : normalizeCode(issue.title).toLowerCase();
+
+  return crypto.createHash('sha256')
+    .update(normalizePath(file))
+    .update('\0')
+    .update(anchor)
+    .update('\0')
+    .update(issue.type || 'general')
+    .digest('hex')
+    .substring(0, 16);
+}
+
+module.exports = { normalizePath, normalizeCode, extractSnippet, fingerprintFinding };
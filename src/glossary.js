@@ -0,0 +1,75 @@
+/**
+ * Glossary Module
+ * 영어 이외의 언어로 리뷰할 때 도메인 용어 번역을 일관되게 유지하기 위한 용어집
+ *
+ * 파일 형식 (JSON):
+ * {
+ *   "doNotTranslate": ["Pod", "Ingress", "tenant ID"],
+ *   "terms": {
+ *     "ko": { "deployment": "배포", "tenant": "테넌트" },
+ *     "ja": { "tenant": "テナント" }
+ *   }
+ * }
+ */
+
+const fs = require('fs').promises;
+
+class Glossary {
+  /**
+   * Glossary 생성자
+   * @param {Object} data - 용어집 데이터
+   * @param {Array<string>} [data.doNotTranslate] - 원문 그대로 둘 용어
+   * @param {Object} [data.terms] - 언어별 { 원문 용어: 선호 번역 }
+   */
+  constructor({ doNotTranslate = [], terms = {} } = {}) {
+    this.doNotTranslate = doNotTranslate;
+    this.terms = terms;
+  }
+
+  /**
+   * 파일에서 용어집 로드
+   * @param {string} filePath - 용어집 파일 경로
+   * @returns {Promise<Glossary>} 용어집
+   */
+  static async loadFile(filePath) {
+    let data;
+    try {
+      data = JSON.parse(await fs.readFile(filePath, 'utf8'));
+    } catch (error) {
+      throw new Error(`Invalid glossary file ${filePath}: ${error.message}`);
+    }
+
+    if (data.doNotTranslate && !Array.isArray(data.doNotTranslate)) {
+      throw new Error(`Invalid glossary file ${filePath}: doNotTranslate must be an array`);
+    }
+    if (data.terms && (typeof data.terms !== 'object' || Array.isArray(data.terms))) {
+      throw new Error(`Invalid glossary file ${filePath}: terms must be an object keyed by language`);
+    }
+    return new Glossary(data);
+  }
+
+  /**
+   * 리뷰 언어에 맞는 용어 지시사항 생성 (영어 리뷰는 번역이 없으므로 생략)
+   * @param {string} language - 리뷰 언어
+   * @returns {string} 프롬프트에 추가할 지시사항 (없으면 빈 문자열)
+   */
+  buildInstruction(language) {
+    if (language === 'en') {
+      return '';
+    }
+
+    const lines = [];
+    const terms = Object.entries(this.terms[language] || {});
+    if (terms.length > 0) {
+      lines.push('Use these translations for domain terms:');
+      terms.forEach(([source, translation]) => lines.push(`- ${source} → ${translation}`));
+    }
+    if (this.doNotTranslate.length > 0) {
+      lines.push(`Keep these terms in their original form (do not translate): ${this.doNotTranslate.join(', ')}`);
+    }
+
+    return lines.length > 0 ? `\n\n용어집:\n${lines.join('\n')}` : '';
+  }
+}
+
+module.exports = Glossary;
@@ -0,0 +1,415 @@
+/**
+ * Go API Reviewer Module
+ * Go 저장소에서 base와 head의 공개(exported) API를 비교해 메이저 버전 업데이트가 필요한 호환성 깨짐을 찾는 모듈
+ *
+ * apidiff와 비슷하게 패키지별 공개 선언(함수, 메서드, 타입, 구조체 필드, 인터페이스 메서드, 상수, 변수)의
+ * 시그니처를 비교합니다. go 도구 없이 소스를 줄 단위로 읽으므로 타입 추론은 하지 않으며,
+ * 규칙으로 1차 분류한 변경 목록을 Claude가 다시 검토해 호환성 판단과 이유를 붙입니다.
+ * internal 패키지, main 패키지, _test.go 파일은 공개 API가 아니므로 제외합니다.
+ */
+
+const fs = require('fs').promises;
+const path = require('path');
+
+// 프롬프트에 넣을 최대 변경 수
+const MAX_CHANGES = 100;
+
+const COMPATIBILITY_PROMPT = `당신은 Go 모듈 관리자입니다. 다음은 base와 head 사이 공개 API의 변경 목록입니다. Go 모듈 호환성 규칙(https://go.dev/blog/module-compatibility)에 따라 각 변경이 기존 사용자 코드를 깨뜨리는지 판단해주세요.
+
+판단 기준:
+- 공개 식별자 삭제, 함수/메서드 시그니처 변경, 필드 타입 변경은 호환성을 깹니다
+- 인터페이스에 메서드를 추가하면 외부 구현체가 깨지므로 호환성을 깹니다
+- 구조체에 필드를 추가하는 것은 호환되지만, 키 없는 구조체 리터럴 사용자를 깨뜨릴 수 있음을 이유에 적으세요
+- 새 함수, 타입, 메서드 추가는 호환됩니다
+- rule 값은 규칙 기반 1차 판단이며, 틀렸다면 바로잡으세요`;
+
+class GoApiReviewer {
+  /**
+   * GoApiReviewer 생성자
+   * @param {Object} options - 옵션
+   * @param {CodeReviewer} options.codeReviewer - Claude API 호출용 리뷰어
+   * @param {Object} options.git - simple-git 인스턴스
+   * @param {string} [options.root] - 저장소 루트 (기본값: 현재 디렉터리)
+   */
+  constructor({ codeReviewer, git, root = process.cwd() }) {
+    this.codeReviewer = codeReviewer;
+    this.git = git;
+    this.root = root;
+  }
+
+  /**
+   * 비교 기준 커밋 (PR은 base 브랜치, push는 이전 커밋)
+   * @param {Object} context - GitHub Actions 컨텍스트
+   * @returns {string} 커밋 SHA 또는 참조
+   */
+  static baseRef(context) {
+    if (context.eventName === 'pull_request') {
+      return context.payload.pull_request.base.sha;
+    }
+    const before = context.payload.before;
+    return before && !/^0+$/.test(before) ? before : 'HEAD~1';
+  }
+
+  /**
+   * 변경된 Go 패키지의 공개 API 비교
+   * @param {string} base - 기준 커밋
+   * @returns {Promise<Array>} [{ package, symbol, change: added|removed|changed, before, after }]
+   */
+  async diff(base) {
+    const changed = (await this.git.diff(['--name-only', base, 'HEAD', '--', '*.go'])).split('\n').filter(Boolean);
+    const packages = [...new Set(changed.map(file => path.posix.dirname(file)))]
+      .filter(dir => !/(^|\/)(internal|testdata|vendor)(\/|$)/.test(dir));
+
+    const changes = [];
+    for (const dir of packages) {
+      const before = await this.packageApi(dir, base);
+      const after = await this.packageApi(dir, null);
+      if (before === null && after === null) {
+        continue;
+      }
+      GoApiReviewer.compare(before || new Map(), after || new Map())
+        .forEach(change => changes.push({ package: dir, ...change }));
+    }
+    return changes;
+  }
+
+  /**
+   * 한 패키지의 공개 API
+   * @param {string} dir - 패키지 디렉터리
+   * @param {string|null} ref - 커밋 (null이면 작업 디렉터리)
+   * @returns {Promise<Map|null>} 식별자 → 시그니처 (패키지가 없거나 main이면 null)
+   */
+  async packageApi(dir, ref) {
+    const listing = ref
+      ? await this.git.raw(['ls-tree', '--name-only', ref, `${dir}/`]).catch(() => '')
+      : await this.git.raw(['ls-files', '--', `${dir}/*.go`]);
+    const files = listing.split('\n')
+      .filter(file => file && path.posix.dirname(file) === dir && file.endsWith('.go') && !file.endsWith('_test.go'));
+    if (files.length === 0) {
+      return null;
+    }
+
+    const api = new Map();
+    for (const file of files) {
+      const source = ref
+        ? await this.git.show([`${ref}:${file}`])
+        : await fs.readFile(path.join(this.root, file), 'utf8').catch(() => '');
+      if (/^package\s+main\b/m.test(source)) {
+        return null;
+      }
+      GoApiReviewer.extractApi(source).forEach((signature, symbol) => api.set(symbol, signature));
+    }
+    return api;
+  }
+
+  /**
+   * Go 소스에서 공개 선언 추출
+   * @param {string} source - Go 소스
+   * @returns {Map<string, string>} 식별자(메서드와 멤버는 "타입.이름") → 정규화된 시그니처
+   */
+  static extractApi(source) {
+    const lines = stripComments(source).split('\n');
+    const api = new Map();
+    let i = 0;
+    while (i < lines.length) {
+      const line = lines[i];
+      const func = line.match(/^func\s+(?:\(\s*(?:\w+\s+)?\*?\s*(\w+)(?:\[[^\]]*\])?\s*\)\s*)?(\w+)/);
+      const group = line.match(/^(type|const|var)\s*\(\s*$/);
+      const single = line.match(/^(type|const|var)\s+(\w+(?:\s*,\s*\w+)*)(.*)$/);
+
+      if (func) {
+        // 시그니처가 여러 줄이면 괄호가 닫힐 때까지 이어 붙임
+        let signature = line;
+        let end = i;
+        while (depth(signature, '(', ')') > 0 && end + 1 < lines.length) {
+          end++;
+          signature += ` ${lines[end].trim()}`;
+        }
+        const [, receiver, name] = func;
+        if (isExported(name) && (!receiver || isExported(receiver))) {
+          api.set(receiver ? `${receiver}.${name}` : name, normalize(signature.replace(/\{[^{}]*\}?\s*$/, '')));
+        }
+        i = skipBlock(lines, end);
+      } else if (group) {
+        i++;
+        while (i < lines.length && !/^\)/.test(lines[i])) {
+          const spec = lines[i].match(/^\s+(\w+(?:\s*,\s*\w+)*)(.*)$/);
+          if (spec && group[1] === 'type') {
+            i = parseTypeSpec(api, spec[1], spec[2], lines, i);
+            continue;
+          }
+          if (spec) {
+            addValueSpec(api, group[1], spec[1], spec[2]);
+          }
+          i = skipBlock(lines, i);
+        }
+        i++;
+      } else if (single && single[1] === 'type') {
+        i = parseTypeSpec(api, single[2], single[3], lines, i);
+      } else if (single) {
+        addValueSpec(api, single[1], single[2], single[3]);
+        i = skipBlock(lines, i);
+      } else {
+        i = skipBlock(lines, i);
+      }
+    }
+    return api;
+  }
+
+  /**
+   * 두 API 비교
+   * @param {Map} before - base API
+   * @param {Map} after - head API
+   * @returns {Array} [{ symbol, change, before, after }]
+   */
+  static compare(before, after) {
+    const changes = [];
+    before.forEach((signature, symbol) => {
+      if (!after.has(symbol)) {
+        changes.push({ symbol, change: 'removed', before: signature, after: null });
+      } else if (after.get(symbol) !== signature) {
+        changes.push({ symbol, change: 'changed', before: signature, after: after.get(symbol) });
+      }
+    });
+    after.forEach((signature, symbol) => {
+      if (!before.has(symbol)) {
+        changes.push({ symbol, change: 'added', before: null, after: signature });
+      }
+    });
+    return changes;
+  }
+
+  /**
+   * 규칙 기반 1차 호환성 판단
+   * @param {Object} change - compare() 결과 항목
+   * @returns {boolean} 호환성을 깨면 true
+   */
+  static isBreakingByRule(change) {
+    if (change.change === 'added') {
+      // 인터페이스 메서드 추가는 외부 구현체를 깨뜨림
+      return /^interface method /.test(change.after);
+    }
+    return true;
+  }
+
+  /**
+   * 공개 API 변경을 검토해 호환성 결과 생성
+   * @param {Object} context - GitHub Actions 컨텍스트
+   * @returns {Promise<Object|null>} { changes, requiresMajor, majorBumped } (API 변경이 없으면 null)
+   */
+  async review(context) {
+    const base = GoApiReviewer.baseRef(context);
+    const changes = (await this.diff(base)).map(change => ({
+      ...change,
+      breaking: GoApiReviewer.isBreakingByRule(change),
+      reason: ''
+    }));
+    if (changes.length === 0) {
+      return null;
+    }
+
+    try {
+      const responseText = await this.codeReviewer.sendMessage('Go API compatibility', this.buildPrompt(changes), this.codeReviewer.model);
+      const verdicts = GoApiReviewer.parseResponse(responseText);
+      changes.forEach(change => {
+        const verdict = verdicts.find(item => item.package === change.package && item.symbol === change.symbol);
+        if (verdict) {
+          // 삭제는 모델 판단과 관계없이 항상 호환성을 깸
+          change.breaking = change.change === 'removed' || verdict.breaking;
+          change.reason = verdict.reason;
+        }
+      });
+    } catch (error) {
+      console.warn(`Go API compatibility review fell back to rules: ${error.message}`);
+    }
+
+    const majorBumped = await this.isMajorBumped(base);
+    return {
+      changes,
+      requiresMajor: changes.some(change => change.breaking),
+      majorBumped
+    };
+  }
+
+  /**
+   * go.mod 모듈 경로의 메이저 버전 접미사(/vN)가 올라갔는지 확인
+   * @param {string} base - 기준 커밋
+   * @returns {Promise<boolean>} 올라갔으면 true
+   */
+  async isMajorBumped(base) {
+    const major = text => {
+      const match = (text || '').match(/^module\s+\S+?(?:\/v(\d+))?\s*$/m);
+      return match && match[1] ? parseInt(match[1], 10) : 1;
+    };
+    const before = await this.git.show([`${base}:go.mod`]).catch(() => '');
+    const after = await fs.readFile(path.join(this.root, 'go.mod'), 'utf8').catch(() => '');
+    return Boolean(before && after) && major(after) > major(before);
+  }
+
+  /**
+   * 호환성 검토 프롬프트 생성
+   * @param {Array} changes - 규칙 판단을 포함한 변경 목록
+   * @returns {string} 프롬프트
+   */
+  buildPrompt(changes) {
+    const lines = changes.slice(0, MAX_CHANGES).map(change => JSON.stringify({
+      package: change.package,
+      symbol: change.symbol,
+      change: change.change,
+      before: change.before,
+      after: change.after,
+      rule: change.breaking ? 'breaking' : 'compatible'
+    }));
+
+    return `${COMPATIBILITY_PROMPT} ${this.codeReviewer.getLanguageInstruction()}
+
+변경 목록:
+${lines.join('\n')}
+
+**중요**: 완전한 JSON만 반환하세요. 모든 변경에 대해 판단하세요.
+
+형식:
+{"changes":[{"package":"패키지 디렉터리","symbol":"식별자","breaking":true,"reason":"이유(80자)"}]}`;
+  }
+
+  /**
+   * 응답 JSON 파싱
+   * @param {string} responseText - 응답 텍스트
+   * @returns {Array} [{ package, symbol, breaking, reason }]
+   */
+  static parseResponse(responseText) {
+    const firstBrace = responseText.indexOf('{');
+    const lastBrace = responseText.lastIndexOf('}');
+    if (firstBrace === -1 || lastBrace <= firstBrace) {
+      throw new Error('No JSON structure found in compatibility response');
+    }
+    const parsed = JSON.parse(responseText.substring(firstBrace, lastBrace + 1));
+    return (Array.isArray(parsed.changes) ? parsed.changes : [])
+      .filter(item => item && typeof item.package === 'string' && typeof item.symbol === 'string' && typeof item.breaking === 'boolean')
+      .map(item => ({
+        package: item.package,
+        symbol: item.symbol,
+        breaking: item.breaking,
+        reason: typeof item.reason === 'string' ? item.reason : ''
+      }));
+  }
+}
+
+/**
+ * 타입 선언 하나를 읽어 API에 추가 (구조체 필드와 인터페이스 메서드 포함)
+ * @param {Map} api - 공개 API (수정됨)
+ * @param {string} name - 타입 이름
+ * @param {string} rest - 이름 뒤의 선언
+ * @param {Array<string>} lines - 소스 줄
+ * @param {number} index - 선언 줄 번호
+ * @returns {number} 다음에 읽을 줄 번호
+ */
+function parseTypeSpec(api, name, rest, lines, index) {
+  const body = rest.match(/^(\[[^\]]*\])?\s*(struct|interface)\s*\{\s*$/);
+  if (!body) {
+    if (isExported(name)) {
+      // 빈 구조체/인터페이스("struct{}")는 여러 줄 선언과 같은 시그니처로 맞춤
+      api.set(name, normalize(`type ${name}${rest.replace(/^(\s*\[[^\]]*\])?\s*(struct|interface)\s*\{\s*\}\s*$/, '$1 $2')}`));
+    }
+    return skipBlock(lines, index);
+  }
+
+  const [, typeParams = '', kind] = body;
+  if (isExported(name)) {
+    api.set(name, `type ${name}${typeParams} ${kind}`);
+  }
+  let i = index + 1;
+  let level = 1;
+  while (i < lines.length && level > 0) {
+    const line = lines[i];
+    if (level === 1 && isExported(name)) {
+      const method = kind === 'interface' && line.match(/^\s*(\w+)\s*(\(.*)$/);
+      const field = kind === 'struct' && line.match(/^\s*(\w+(?:\s*,\s*\w+)*)\s+([^`]+?)\s*(`.*`)?\s*$/);
+      const embedded = line.match(/^\s*\*?([\w.]+)\s*(`.*`)?\s*$/);
+      if (method && isExported(method[1])) {
+        api.set(`${name}.${method[1]}`, normalize(`interface method ${method[1]}${method[2]}`));
+      } else if (field && !/^\s*(\}|\/\/)/.test(line)) {
+        field[1].split(',').map(part => part.trim()).filter(isExported)
+          .forEach(fieldName => api.set(`${name}.${fieldName}`, normalize(`field ${fieldName} ${field[2].replace(/\{\s*$/, '{...}')}`)));
+      } else if (embedded && isExported(embedded[1].split('.').pop())) {
+        api.set(`${name}.${embedded[1]}`, `embedded ${line.trim()}`);
+      }
+    }
+    level += depth(line, '{', '}');
+    i++;
+  }
+  return i;
+}
+
+/**
+ * 상수/변수 선언을 API에 추가 (값은 비교하지 않고 명시된 타입만 비교)
+ * @param {Map} api - 공개 API (수정됨)
+ * @param {string} kind - const 또는 var
+ * @param {string} names - 쉼표로 구분한 이름
+ * @param {string} rest - 이름 뒤의 선언
+ */
+function addValueSpec(api, kind, names, rest) {
+  const type = rest.split('=')[0].trim();
+  names.split(',').map(name => name.trim()).filter(isExported).forEach(name => {
+    api.set(name, normalize(`${kind} ${name}${type ? ` ${type}` : ''}`));
+  });
+}
+
+/**
+ * 블록이 열린 줄이면 닫힐 때까지 건너뜀
+ * @param {Array<string>} lines - 소스 줄
+ * @param {number} index - 현재 줄 번호
+ * @returns {number} 다음에 읽을 줄 번호
+ */
+function skipBlock(lines, index) {
+  let level = depth(lines[index], '{', '}');
+  let i = index + 1;
+  while (level > 0 && i < lines.length) {
+    level += depth(lines[i], '{', '}');
+    i++;
+  }
+  return i;
+}
+
+/**
+ * 문자열 리터럴을 제외한 여는/닫는 괄호 수 차이
+ * @param {string} text - 줄
+ * @param {string} open - 여는 괄호
+ * @param {string} close - 닫는 괄호
+ * @returns {number} 여는 수 - 닫는 수
+ */
+function depth(text, open, close) {
+  const code = text.replace(/"(?:\\.|[^"\\])*"|`[^`]*`|'(?:\\.|[^'\\])+'/g, '""');
+  return code.split(open).length - code.split(close).length;
+}
+
+/**
+ * 주석 제거 (블록 주석과 줄 주석)
+ * @param {string} source - Go 소스
+ * @returns {string} 주석을 제거한 소스 (줄 번호 유지)
+ */
+function stripComments(source) {
+  return source
+    .replace(/\/\*[\s\S]*?\*\//g, match => match.replace(/[^\n]/g, ''))
+    .replace(/(^|[\s;{}(),])\/\/.*$/gm, '$1');
+}
+
+/**
+ * 공백 정규화
+ * @param {string} text - 시그니처
+ * @returns {string} 연속 공백을 하나로 줄이고 괄호 안쪽 공백을 제거한 문자열
+ */
+function normalize(text) {
+  return text.replace(/\s+/g, ' ').replace(/\(\s+/g, '(').replace(/\s+\)/g, ')').replace(/,\s*\)/g, ')').trim();
+}
+
+/**
+ * Go 공개 식별자 여부
+ * @param {string} name - 식별자
+ * @returns {boolean} 대문자로 시작하면 true
+ */
+function isExported(name) {
+  return /^[A-Z]/.test(name || '');
+}
+
+module.exports = GoApiReviewer;
@@ -0,0 +1,176 @@
+/**
+ * HTML Report Module
+ * 리뷰 결과를 외부 도구 없이 열 수 있는 단일 HTML 파일로 만드는 모듈
+ *
+ * - CSS와 스크립트를 파일 안에 포함해 아티팩트로 보관하거나 GitHub 밖에 공유할 수 있음
+ * - 심각도, 이슈 타입, 파일, 검색어로 이슈를 필터링 (스크립트가 꺼져 있어도 전체 목록은 표시)
+ */
+
+const fs = require('fs');
+const os = require('os');
+const path = require('path');
+const DisplayLabels = require('./display-labels');
+const { getSeverityLevel } = require('./review-summary');
+
+const SEVERITIES = ['critical', 'high', 'medium', 'low'];
+
+// 심각도별 배지 색상 (이메일 다이제스트와 동일)
+const SEVERITY_COLORS = {
+  critical: '#d73a49',
+  high: '#e36209',
+  medium: '#dbab09',
+  low: '#28a745'
+};
+
+// 판정별 표시 이름
+const VERDICT_LABELS = {
+  changes_requested: 'Changes requested',
+  needs_attention: 'Needs attention',
+  approved: 'Looks good'
+};
+
+const STYLE = `
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0 auto; max-width: 1200px; padding: 24px; color: #24292f; }
+h1 { font-size: 22px; margin-bottom: 4px; }
+.meta { color: #57606a; margin-bottom: 16px; }
+.counts { display: flex; gap: 12px; margin-bottom: 16px; }
+.count { border: 1px solid #d0d7de; border-radius: 6px; padding: 8px 16px; }
+.count b { font-size: 20px; display: block; }
+.filters { display: flex; flex-wrap: wrap; gap: 12px; align-items: center; padding: 12px; background: #f6f8fa; border-radius: 6px; margin-bottom: 16px; }
+.filters select, .filters input[type=search] { padding: 4px 8px; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #d0d7de; padding: 8px; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+.badge { color: #fff; border-radius: 12px; padding: 2px 8px; font-size: 12px; white-space: nowrap; }
+.file { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 12px; word-break: break-all; }
+.description { color: #57606a; white-space: pre-wrap; }
+.suggestion { white-space: pre-wrap; margin-top: 4px; }
+pre { background: #f6f8fa; padding: 8px; overflow-x: auto; }
+#empty { display: none; color: #57606a; padding: 16px 0; }
+`;
+
+// 필터 스크립트: 각 행의 data-* 속성으로 표시 여부 결정
+const SCRIPT = `
+(function () {
+  var rows = Array.prototype.slice.call(document.querySelectorAll('tr.finding'));
+  var controls = document.querySelectorAll('[data-filter]');
+  function apply() {
+    var severities = Array.prototype.slice.call(document.querySelectorAll('input[name=severity]:checked')).map(function (input) { return input.value; });
+    var type = document.getElementById('type').value;
+    var file = document.getElementById('file').value;
+    var query = document.getElementById('query').value.toLowerCase();
+    var visible = 0;
+    rows.forEach(function (row) {
+      var show = severities.indexOf(row.dataset.severity) !== -1 &&
+        (!type || row.dataset.type === type) &&
+        (!file || row.dataset.file === file) &&
+        (!query || row.textContent.toLowerCase().indexOf(query) !== -1);
+      row.style.display = show ? '' : 'none';
+      if (show) { visible++; }
+    });
+    document.getElementById('visible').textContent = visible;
+    document.getElementById('empty').style.display = visible === 0 ? 'block' : 'none';
+  }
+  Array.prototype.forEach.call(controls, function (control) { control.addEventListener('input', apply); });
+  apply();
+})();
+`;
+
+/**
+ * HTML 리포트 생성
+ * @param {Object} summary - buildReviewSummary() 결과
+ * @param {Object} [options] - 옵션
+ * @param {DisplayLabels} [options.labels] - 심각도/타입 표시 매핑
+ * @param {string} [options.generatedAt] - 생성 시각 (기본값: 현재 시각)
+ * @returns {string} HTML 문서
+ */
+function buildHtmlReport(summary, { labels = new DisplayLabels(), generatedAt = new Date().toISOString() } = {}) {
+  const findings = summary.results
+    .flatMap(result => result.issues.map(issue => ({ file: result.file, ...issue })))
+    .sort((a, b) => getSeverityLevel(b.severity) - getSeverityLevel(a.severity) || a.file.localeCompare(b.file) || (a.line || 0) - (b.line || 0));
+  const types = [...new Set(findings.map(finding => finding.type))].sort();
+  const files = [...new Set(findings.map(finding => finding.file))].sort();
+  const severityText = severity => labels.withIcon(labels.severityIcon(severity), labels.severityLabel(severity));
+  const metadata = summary.runMetadata;
+
+  const counts = SEVERITIES.map(severity =>
+    `<div class="count" style="border-left: 4px solid ${SEVERITY_COLORS[severity]}"><b>${summary.counts[severity]}</b>${escape(severityText(severity))}</div>`
+  ).join('\n');
+
+  const severityFilters = SEVERITIES.map(severity =>
+    `<label><input type="checkbox" name="severity" value="${severity}" data-filter checked> ${escape(severityText(severity))}</label>`
+  ).join('\n');
+
+  const rows = findings.map(finding => `<tr class="finding" data-severity="${escape(finding.severity)}" data-type="${escape(finding.type)}" data-file="${escape(finding.file)}">
+<td><span class="badge" style="background: ${SEVERITY_COLORS[finding.severity] || '#6e7781'}">${escape(severityText(finding.severity))}</span></td>
+<td>${escape(labels.withIcon(labels.typeIcon(finding.type), labels.typeLabel(finding.type)))}</td>
+<td class="file">${escape(finding.file)}${finding.line ? `:${finding.line}` : ''}</td>
+<td><b>${escape(finding.title)}</b>
+<div class="description">${escape(finding.description)}</div>${finding.suggestion ? `
+<div class="suggestion"><i>Suggestion:</i> ${escape(finding.suggestion)}</div>` : ''}${finding.codeExample ? `
+<pre><code>${escape(finding.codeExample)}</code></pre>` : ''}</td>
+</tr>`).join('\n');
+
+  return `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Claude Code Review - ${escape(summary.title)}</title>
+<style>${STYLE}</style>
+</head>
+<body>
+<h1>🤖 Claude AI Code Review</h1>
+<div class="meta"><a href="${escape(summary.url)}">${escape(summary.title)}</a> · ${escape(summary.repository)} · ${escape(labels.withIcon(labels.verdictIcon(summary.verdict), VERDICT_LABELS[summary.verdict]))}</div>
+<div class="meta">Generated ${escape(generatedAt)}${metadata ? ` · action v${escape(metadata.actionVersion)} · ${escape(metadata.models.join(', '))}` : ''}</div>
+<div class="counts">
+${counts}
+</div>
+<div class="filters">
+${severityFilters}
+<select id="type" data-filter><option value="">All types</option>${types.map(type => `<option value="${escape(type)}">${escape(labels.typeLabel(type))}</option>`).join('')}</select>
+<select id="file" data-filter><option value="">All files</option>${files.map(file => `<option value="${escape(file)}">${escape(file)}</option>`).join('')}</select>
+<input type="search" id="query" placeholder="Search findings" data-filter>
+<span><span id="visible">${findings.length}</span> of ${findings.length} findings</span>
+</div>
+<table>
+<thead><tr><th>Severity</th><th>Type</th><th>Location</th><th>Finding</th></tr></thead>
+<tbody>
+${rows}
+</tbody>
+</table>
+<div id="empty">No findings match the current filters.</div>
+<script>${SCRIPT}</script>
+</body>
+</html>
+`;
+}
+
+/**
+ * HTML 특수문자 이스케이프
+ * @param {string} text - 원본 문자열
+ * @returns {string} 이스케이프된 문자열
+ */
+function escape(text) {
+  return String(text === null || text === undefined ? '' : text)
+    .replace(/&/g, '&amp;')
+    .replace(/</g, '&lt;')
+    .replace(/>/g, '&gt;')
+    .replace(/"/g, '&quot;');
+}
+
+/**
+ * HTML 리포트를 파일로 작성 (아티팩트 업로드용)
+ * @param {Object} summary - buildReviewSummary() 결과
+ * @param {string|number} runId - 워크플로우 실행 ID
+ * @param {Object} [options] - buildHtmlReport() 옵션
+ * @returns {string} 작성한 파일 경로
+ */
+function writeHtmlReport(summary, runId, options = {}) {
+  const dir = process.env.RUNNER_TEMP || os.tmpdir();
+  const filePath = path.join(dir, `claude-review-report-${runId || Date.now()}.html`);
+  fs.writeFileSync(filePath, buildHtmlReport(summary, options));
+  return filePath;
+}
+
+module.exports = { buildHtmlReport, writeHtmlReport };
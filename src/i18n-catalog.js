@@ -0,0 +1,234 @@
+/**
+ * I18n Catalog Module
+ * 저장소의 i18n 프레임워크와 번역 카탈로그를 찾아 i18n 리뷰(review_type: i18n) 프롬프트에 넣을 정보를 만드는 모듈
+ *
+ * - 프레임워크: package.json, requirements.txt, go.mod, pubspec.yaml의 의존성으로 감지
+ * - 카탈로그: JSON(중첩 키는 "."로 연결), ARB, gettext .po, .properties 파일의 키를 읽음
+ * - 파일에서 사용하는 번역 키 중 기본 로케일 카탈로그에 없는 키를 미리 계산해 모델에 알려 줌
+ */
+
+const fs = require('fs').promises;
+const path = require('path');
+const { minimatch } = require('minimatch');
+
+// 번역 카탈로그 기본 패턴
+const DEFAULT_CATALOG_PATTERNS = [
+  '**/locales/**/*.json',
+  '**/locale/**/*.json',
+  '**/i18n/**/*.json',
+  '**/lang/**/*.json',
+  '**/messages/*.json',
+  '**/*.arb',
+  '**/*.po',
+  '**/messages*.properties',
+  'config/locales/*.yml'
+];
+
+// 의존성 이름 → 프레임워크 표시 이름 (앞쪽 우선)
+const FRAMEWORKS = [
+  ['next-intl', 'next-intl (useTranslations)'],
+  ['react-i18next', 'react-i18next (useTranslation, t())'],
+  ['react-intl', 'react-intl (FormattedMessage, intl.formatMessage)'],
+  ['vue-i18n', 'vue-i18n ($t())'],
+  ['@ngx-translate/core', 'ngx-translate (translate 파이프)'],
+  ['@angular/localize', 'Angular i18n ($localize, i18n 속성)'],
+  ['i18next', 'i18next (t())'],
+  ['django', 'Django i18n (gettext, {% translate %})'],
+  ['flask-babel', 'Flask-Babel (gettext, _())'],
+  ['babel', 'Babel (gettext)'],
+  ['go-i18n', 'go-i18n (Localizer.Localize)'],
+  ['flutter_localizations', 'Flutter intl (ARB, AppLocalizations)']
+];
+
+// 프롬프트에 표시할 최대 카탈로그 수와 누락 키 수
+const MAX_CATALOGS = 10;
+const MAX_MISSING_KEYS = 20;
+
+// 번역 키를 사용하는 호출 패턴
+const KEY_PATTERNS = [
+  /(?:^|[^\w$.])(?:\$?t|i18n\.t|i18next\.t|translate|\$localize)\(\s*['"`]([\w.:-]+)['"`]/g,
+  /formatMessage\(\s*\{\s*id:\s*['"]([\w.:-]+)['"]/g,
+  /<FormattedMessage[^>]*\bid=["']([\w.:-]+)["']/g
+];
+// gettext 호출 (키가 원문 문자열)
+const GETTEXT_PATTERN = /(?:^|[^\w.])(?:_|gettext|gettext_lazy)\(\s*['"]([^'"]+)['"]\s*\)/g;
+
+class I18nCatalog {
+  /**
+   * I18nCatalog 생성자
+   * @param {Object} options - 옵션
+   * @param {string|null} [options.framework] - 감지한 i18n 프레임워크 표시 이름
+   * @param {Array} [options.catalogs] - [{ path, locale, keys: Array<string>|null }] (키를 읽지 못하면 null)
+   */
+  constructor({ framework = null, catalogs = [] } = {}) {
+    this.framework = framework;
+    this.catalogs = catalogs.map(catalog => ({ ...catalog, keys: catalog.keys ? new Set(catalog.keys) : null }));
+    // 기본 로케일: en이 있으면 en, 없으면 키가 가장 많은 카탈로그의 로케일
+    const locales = this.catalogs.filter(catalog => catalog.keys);
+    const english = locales.find(catalog => /^en([-_]|$)/i.test(catalog.locale || ''));
+    const largest = [...locales].sort((a, b) => b.keys.size - a.keys.size)[0];
+    this.defaultLocale = (english || largest || {}).locale || null;
+  }
+
+  /**
+   * 저장소에서 프레임워크와 카탈로그 로드
+   * @param {Object} git - simple-git 인스턴스
+   * @param {Array<string>} [patterns] - 카탈로그 glob 패턴 (비어 있으면 기본 패턴)
+   * @param {string} [root] - 저장소 루트 (기본값: 현재 디렉터리)
+   * @returns {Promise<I18nCatalog>} 카탈로그
+   */
+  static async load(git, patterns = [], root = process.cwd()) {
+    const files = (await git.raw(['ls-files'])).split('\n').filter(Boolean);
+    const catalogPatterns = patterns.length > 0 ? patterns : DEFAULT_CATALOG_PATTERNS;
+    const catalogFiles = files.filter(file =>
+      !file.includes('node_modules/') && catalogPatterns.some(pattern => minimatch(file, pattern, { dot: true }))
+    );
+
+    const catalogs = [];
+    for (const file of catalogFiles) {
+      const content = await fs.readFile(path.join(root, file), 'utf8').catch(() => null);
+      if (content !== null) {
+        catalogs.push({ path: file, locale: I18nCatalog.localeOf(file), keys: I18nCatalog.parseKeys(file, content) });
+      }
+    }
+    return new I18nCatalog({ framework: await I18nCatalog.detectFramework(root, catalogs), catalogs });
+  }
+
+  /**
+   * 의존성 파일로 i18n 프레임워크 감지
+   * @param {string} root - 저장소 루트
+   * @param {Array} catalogs - 카탈로그 목록 (감지 실패 시 형식으로 추정)
+   * @returns {Promise<string|null>} 프레임워크 표시 이름
+   */
+  static async detectFramework(root, catalogs) {
+    const manifests = await Promise.all(['package.json', 'requirements.txt', 'pyproject.toml', 'go.mod', 'pubspec.yaml']
+      .map(file => fs.readFile(path.join(root, file), 'utf8').catch(() => '')));
+    const text = manifests.join('\n').toLowerCase();
+    const found = FRAMEWORKS.find(([dependency]) =>
+      new RegExp(`(^|["'\\s/])${dependency.replace(/[.*+?^${}()|[\]\\/]/g, '\\$&')}(["'\\s=<>~:]|$)`, 'm').test(text)
+    );
+    if (found) {
+      return found[1];
+    }
+    if (catalogs.some(catalog => catalog.path.endsWith('.po'))) {
+      return 'gettext';
+    }
+    return null;
+  }
+
+  /**
+   * 카탈로그 경로에서 로케일 추출 (디렉터리 또는 파일 이름의 en, ko-KR, pt_BR 형식)
+   * @param {string} file - 카탈로그 경로
+   * @returns {string|null} 로케일
+   */
+  static localeOf(file) {
+    const segments = file.replace(/\.[^./]+$/, '').split('/');
+    const base = segments.pop();
+    // 파일 이름 전체(de), 끝부분(messages_pt_BR → pt_BR), 상위 디렉터리(locales/en/common) 순서로 확인
+    const candidates = [base, base.replace(/^.*?[_.-](?=[a-z]{2}([-_][A-Za-z]{2,4})?$)/, ''), ...segments.reverse()];
+    return candidates.find(part => /^[a-z]{2}([-_][A-Za-z]{2,4})?$/.test(part)) || null;
+  }
+
+  /**
+   * 카탈로그 키 파싱
+   * @param {string} file - 카탈로그 경로
+   * @param {string} content - 파일 내용
+   * @returns {Array<string>|null} 키 목록 (지원하지 않는 형식이면 null)
+   */
+  static parseKeys(file, content) {
+    const extension = path.extname(file);
+    if (extension === '.json' || extension === '.arb') {
+      try {
+        const keys = [];
+        const walk = (value, prefix) => {
+          Object.entries(value).forEach(([key, child]) => {
+            if (extension === '.arb' && key.startsWith('@')) {
+              return;
+            }
+            const name = prefix ? `${prefix}.${key}` : key;
+            if (child && typeof child === 'object' && !Array.isArray(child)) {
+              walk(child, name);
+            } else {
+              keys.push(name);
+            }
+          });
+        };
+        walk(JSON.parse(content), '');
+        return keys;
+      } catch (error) {
+        return null;
+      }
+    }
+    if (extension === '.po') {
+      return [...content.matchAll(/^msgid\s+"((?:\\.|[^"\\])+)"/gm)].map(match => match[1]);
+    }
+    if (extension === '.properties') {
+      return [...content.matchAll(/^\s*([^#!\s=:][^=:\s]*)\s*[=:]/gm)].map(match => match[1]);
+    }
+    return null;
+  }
+
+  /**
+   * 파일에서 사용하는 번역 키
+   * @param {string} content - 파일 내용
+   * @param {boolean} [gettext] - true면 gettext 원문, false면 키 기반 호출의 키
+   * @returns {Array<string>} 키 목록
+   */
+  static usedKeys(content, gettext = false) {
+    const keys = new Set();
+    (gettext ? [GETTEXT_PATTERN] : KEY_PATTERNS).forEach(pattern => {
+      for (const match of content.matchAll(pattern)) {
+        keys.add(match[1]);
+      }
+    });
+    return [...keys];
+  }
+
+  /**
+   * 기본 로케일 카탈로그에 없는 키 (i18next 네임스페이스 "ns:key"는 두 형태 모두 확인)
+   * @param {string} content - 파일 내용
+   * @returns {Array<string>} 누락된 키
+   */
+  missingKeys(content) {
+    const catalogs = this.catalogs.filter(catalog => catalog.keys && catalog.locale === this.defaultLocale);
+    if (catalogs.length === 0) {
+      return [];
+    }
+    const known = key => catalogs.some(catalog =>
+      catalog.keys.has(key) || catalog.keys.has(key.replace(/^[\w-]+:/, '')) || catalog.keys.has(key.replace(':', '.'))
+    );
+    // gettext 카탈로그는 원문 문자열이 키
+    const gettext = catalogs.every(catalog => catalog.path.endsWith('.po'));
+    return I18nCatalog.usedKeys(content, gettext).filter(key => !known(key));
+  }
+
+  /**
+   * i18n 리뷰 프롬프트 지시사항
+   * @param {string} content - 파일 내용
+   * @returns {string} 지시사항
+   */
+  buildInstruction(content) {
+    const catalogs = this.catalogs.slice(0, MAX_CATALOGS).map(catalog =>
+      `  - ${catalog.path} (${catalog.locale || 'locale 알 수 없음'}${catalog.keys ? `, 키 ${catalog.keys.size}개` : ''})`
+    );
+    if (this.catalogs.length > MAX_CATALOGS) {
+      catalogs.push(`  - … 외 ${this.catalogs.length - MAX_CATALOGS}개`);
+    }
+    const missing = this.missingKeys(content);
+
+    let instruction = '\n\ni18n 정보:\n';
+    instruction += `- 프레임워크: ${this.framework || '감지되지 않음 (파일이 이미 쓰는 방식이 있으면 따르고, 없으면 이 언어의 표준 방식을 제안)'}\n`;
+    instruction += catalogs.length > 0
+      ? `- 번역 카탈로그 (기본 로케일: ${this.defaultLocale || '알 수 없음'}):\n${catalogs.join('\n')}\n`
+      : '- 번역 카탈로그: 없음\n';
+    if (missing.length > 0) {
+      instruction += `- 기본 로케일 카탈로그에 없는 키: ${missing.slice(0, MAX_MISSING_KEYS).join(', ')}${missing.length > MAX_MISSING_KEYS ? ` 외 ${missing.length - MAX_MISSING_KEYS}개` : ''}\n`;
+    }
+    instruction += '\n로그, 예외 메시지, 테스트, 내부 식별자처럼 사용자에게 보이지 않는 문자열은 보고하지 마세요. ' +
+      'code_example에는 문자열을 위 프레임워크 호출로 바꾼 코드와 기본 로케일 카탈로그에 추가할 항목을 함께 작성하세요.';
+    return instruction;
+  }
+}
+
+module.exports = I18nCatalog;
+module.exports.DEFAULT_CATALOG_PATTERNS = DEFAULT_CATALOG_PATTERNS;
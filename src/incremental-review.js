@@ -0,0 +1,127 @@
+/**
+ * Incremental Review Module
+ * PR에 새 커밋이 push되면(synchronize) 마지막으로 리뷰한 커밋 이후의 변경분만 리뷰하도록 하는 모듈
+ *
+ * - 마지막으로 리뷰한 커밋 SHA는 요약 댓글의 숨김 마커로 저장 (별도 저장소나 캐시가 필요 없음)
+ * - 변경분은 Compare API로 마지막 리뷰 커밋과 PR head를 비교해 계산
+ * - 이전 리뷰 커밋이 없거나, 강제 push로 이력이 바뀌었거나, 같은 커밋을 다시 실행하면 PR 전체를 리뷰
+ */
+
+const { SUMMARY_MARKER } = require('./comment-manager');
+
+const REVIEWED_SHA_MARKER_PATTERN = /<!-- claude-review:reviewed-sha:([0-9a-f]{40}) -->/;
+
+class IncrementalReview {
+  /**
+   * IncrementalReview 생성자
+   * @param {Object} options - 옵션
+   * @param {string|null} options.base - 마지막으로 리뷰한 커밋 SHA (변경분 리뷰가 아니면 null)
+   * @param {string} options.head - 이번에 리뷰하는 PR head 커밋 SHA
+   * @param {Array|null} [options.files] - 변경분 파일 목록 (pulls.listFiles 형식, 전체 리뷰면 null)
+   * @param {string|null} [options.reason] - 전체 리뷰로 돌아간 이유 (로그용)
+   */
+  constructor({ base, head, files = null, reason = null }) {
+    this.base = base;
+    this.head = head;
+    this.files = files;
+    this.reason = reason;
+  }
+
+  /**
+   * 이전 요약 댓글의 마지막 리뷰 커밋과 PR head를 비교해 변경분 계산
+   * @param {Object} octokit - GitHub API 클라이언트
+   * @param {Object} context - GitHub Actions 컨텍스트 (pull_request 이벤트)
+   * @returns {Promise<IncrementalReview>} 변경분 (전체 리뷰가 필요하면 base가 null)
+   */
+  static async load(octokit, context) {
+    const pullRequest = context.payload.pull_request;
+    const head = pullRequest.head.sha;
+    if (context.payload.action !== 'synchronize') {
+      return new IncrementalReview({ base: null, head, reason: `${context.payload.action || 'unknown'} event` });
+    }
+
+    const comments = await octokit.paginate(octokit.rest.issues.listComments, {
+      owner: context.repo.owner,
+      repo: context.repo.repo,
+      issue_number: pullRequest.number,
+      per_page: 100
+    });
+    const base = IncrementalReview.lastReviewedSha(comments);
+    if (!base) {
+      return new IncrementalReview({ base: null, head, reason: 'no previous review' });
+    }
+    if (base === head) {
+      return new IncrementalReview({ base: null, head, reason: `${base.substring(0, 7)} was already reviewed` });
+    }
+
+    let comparison;
+    try {
+      ({ data: comparison } = await octokit.rest.repos.compareCommits({
+        owner: context.repo.owner,
+        repo: context.repo.repo,
+        base,
+        head
+      }));
+    } catch (error) {
+      // 강제 push 후 이전 커밋이 사라지면 비교할 수 없음
+      return new IncrementalReview({ base: null, head, reason: `cannot compare ${base.substring(0, 7)}: ${error.message}` });
+    }
+    // 강제 push/rebase로 이전 리뷰 커밋이 PR 이력에 없으면 변경분이 PR 전체와 다를 수 있음
+    if (comparison.status !== 'ahead') {
+      return new IncrementalReview({ base: null, head, reason: `history ${comparison.status} since ${base.substring(0, 7)}` });
+    }
+
+    // 삭제된 파일과 내용 변경이 없는 파일은 PR 리뷰와 같이 제외
+    const files = (comparison.files || []).filter(file =>
+      file.status !== 'removed' &&
+      file.additions + file.deletions > 0
+    );
+    return new IncrementalReview({ base, head, files });
+  }
+
+  /**
+   * 댓글 중 가장 최근 요약 댓글에 기록된 마지막 리뷰 커밋
+   * @param {Array} comments - PR 댓글 (id 오름차순)
+   * @returns {string|null} 커밋 SHA (기록이 없으면 null)
+   */
+  static lastReviewedSha(comments) {
+    const latest = [...comments].reverse().find(comment =>
+      (comment.body || '').includes(SUMMARY_MARKER) && REVIEWED_SHA_MARKER_PATTERN.test(comment.body || '')
+    );
+    return latest ? latest.body.match(REVIEWED_SHA_MARKER_PATTERN)[1] : null;
+  }
+
+  /**
+   * 요약 댓글에 붙일 마지막 리뷰 커밋 마커 (다음 push에서 변경분의 기준이 됨)
+   * @returns {string} HTML 주석 마커
+   */
+  marker() {
+    return `<!-- claude-review:reviewed-sha:${this.head} -->`;
+  }
+
+  /**
+   * 리뷰할 파일 (변경분 중 PR에서 변경된 파일만, base 브랜치를 병합해 들어온 파일은 제외)
+   * PR 파일 정보에 변경분 diff(deltaPatch)를 붙이므로 인라인 댓글은 PR diff 기준으로 고정됨
+   * @param {Array} changedFiles - PR 전체 변경 파일
+   * @returns {Array} 리뷰할 파일 (전체 리뷰면 changedFiles 그대로)
+   */
+  filesWithin(changedFiles) {
+    if (!this.isIncremental()) {
+      return changedFiles;
+    }
+    const delta = new Map(this.files.map(file => [file.filename, file]));
+    return changedFiles
+      .filter(file => delta.has(file.filename))
+      .map(file => ({ ...file, deltaPatch: delta.get(file.filename).patch || '' }));
+  }
+
+  /**
+   * 변경분만 리뷰하는지 여부
+   * @returns {boolean} 마지막 리뷰 커밋 이후 변경분을 리뷰하면 true
+   */
+  isIncremental() {
+    return Boolean(this.base);
+  }
+}
+
+module.exports = IncrementalReview;
@@ -14,71 +14,320 @@ const github = require('@actions/github');
 const CodeReviewer = require('./code-reviewer');
 const FileAnalyzer = require('./file-analyzer');
 const CommentManager = require('./comment-manager');
+const DebugBundle = require('./debug-bundle');
+const Doctor = require('./doctor');
+const ProgressTracker = require('./progress-tracker');
+const TelemetryReporter = require('./telemetry');
+const { buildRunMetadata } = require('./run-metadata');
+const SlackNotifier = require('./slack-notifier');
+const TeamsNotifier = require('./teams-notifier');
+const DiscordNotifier = require('./discord-notifier');
+const EmailNotifier = require('./email-notifier');
+const WebhookNotifier = require('./webhook-notifier');
+const { NOTIFIER_FORMATS, detectFormat, createFormattedNotifier } = require('./notifier-registry');
+const JiraClient = require('./jira-client');
+const LinearClient = require('./linear-client');
+const PagerDutyClient = require('./pagerduty-client');
+const SuppressionStore = require('./suppression-store');
+const RepoAuditor = require('./repo-auditor');
+const AuditDigest = require('./audit-digest');
+const Glossary = require('./glossary');
+const ReviewMemory = require('./review-memory');
+const { parsePassModels } = require('./review-passes');
+const RiskScorer = require('./risk-scorer');
+const PrDescriber = require('./pr-describer');
+const TestCommitter = require('./test-committer');
+const CommitMessageReviewer = require('./commit-message-reviewer');
+const PrConventionChecker = require('./pr-convention-checker');
+const ChangelogSuggester = require('./changelog-suggester');
+const ArchitectureReviewer = require('./architecture-reviewer');
+const RepoMap = require('./repo-map');
+const DocDriftDetector = require('./doc-drift-detector');
+const ReleaseNotesGenerator = require('./release-notes-generator');
+const TeamRouter = require('./team-router');
+const Codeowners = require('./codeowners');
+const ReviewerSuggester = require('./reviewer-suggester');
+const GoApiReviewer = require('./go-api-reviewer');
+const DependencyReviewer = require('./dependency-reviewer');
+const LicensePolicy = require('./license-policy');
+const I18nCatalog = require('./i18n-catalog');
+const WorkspacePackages = require('./workspace-packages');
+const ConversationStore = require('./conversation-store');
+const IncrementalReview = require('./incremental-review');
+const CommitByCommit = require('./commit-by-commit');
+const DisplayLabels = require('./display-labels');
+const ReferenceLinks = require('./reference-links');
+const { loadTemplate } = require('./comment-template');
+const NotificationRouter = require('./notification-router');
+const { parseLanguageMapping, resolveLanguage } = require('./language-resolver');
+const { extractSnippet, fingerprintFinding } = require('./fingerprint');
+const { writeJsonReport, writeFindingsFile } = require('./json-report');
+const { writeSarifReport, uploadSarif } = require('./sarif-report');
+const { writeHtmlReport } = require('./html-report');
+const { writeJunitReport } = require('./junit-report');
+const RepoConfig = require('./repo-config');
+const PromptTemplates = require('./prompt-templates');
+const CustomReviewTypes = require('./custom-review-types');
+const FindingBaseline = require('./finding-baseline');
+const ReviewCommand = require('./review-command');
+const ThreadExplainer = require('./thread-explainer');
+const CheckRunPublisher = require('./check-run');
+const ReviewSubmitter = require('./review-submitter');
+const FindingLabeler = require('./finding-labeler');
+const { buildJobSummary, summarizeUsage } = require('./job-summary');
+const { getSeverityLevel, buildReviewSummary } = require('./review-summary');
+const { ConfigError } = require('./errors');
+const { PROVIDERS, EXECUTION_MODES, DEFAULT_OLLAMA_HOST, DEFAULT_OLLAMA_NUM_CTX } = require('./claude-client');
+
+// 지원하는 알림 대상
+const NOTIFY_TARGETS = ['slack', 'teams', 'discord', 'email', 'webhook', 'url'];
+// 순수 텍스트로 전송할 수 있는 출력 대상
+const PLAIN_TEXT_SINKS = ['email', 'webhook'];
+// 모델 이름 형식 (영숫자로 시작, 제공자 형식의 . : @ / - _ 허용)
+const MODEL_NAME_PATTERN = /^[A-Za-z0-9][\w.:@\/-]*$/;
+// 실패 종류별 종료 코드 (심각도 게이트 실패와 인프라/설정 오류를 구분)
+const EXIT_CODES = { findings: 1, error: 2 };
 
 /**
  * 메인 실행 함수
  * GitHub Action이 실행될 때 호출되는 진입점
  */
 async function run() {
+  // 실패 시 재현 정보를 남기기 위한 디버그 번들
+  const debugBundle = new DebugBundle(github.context);
+  // 텔레메트리 전송용 실행 결과 (코드 내용은 포함하지 않음)
+  const runState = { outcome: 'skipped', startedAt: Date.now(), filesReviewed: 0, issuesFound: 0 };
+  let inputs = null;
+  let codeReviewer = null;
+  // 리뷰 시작 시 만든 in_progress Check Run (check_run 사용 시)
+  let checkRunPublisher = null;
+
   try {
+    // 점검 모드: 리뷰 대신 환경 점검 체크리스트만 출력
+    if (core.getInput('doctor') === 'true') {
+      await runDoctor();
+      return;
+    }
+
     // 1. 액션 입력값 수집
     // core.getInput()을 통해 action.yml에 정의된 입력값들을 가져옵니다
-    const inputs = {
-      anthropicApiKey: core.getInput('anthropic_api_key', { required: true }),
-      githubToken: core.getInput('github_token', { required: true }),
-      reviewType: core.getInput('review_type') || 'full',
-      filePatterns: core.getInput('file_patterns') || '**/*.js,**/*.ts,**/*.jsx,**/*.tsx,**/*.py,**/*.java,**/*.go,**/*.rs',
-      excludePatterns: core.getInput('exclude_patterns') || '**/node_modules/**,**/dist/**,**/build/**',
-      maxFiles: parseInt(core.getInput('max_files') || '10'),
-      maxIssuesPerFile: Math.max(1, Math.min(10, parseInt(core.getInput('max_issues_per_file') || '3'))), // 1-10 범위로 제한
-      language: core.getInput('language') || 'en',
-      severityFilter: core.getInput('severity_filter') || 'medium'
-    };
+    inputs = readInputs();
+    debugBundle.setConfig(inputs);
 
     // GitHub 컨텍스트 정보 가져오기
     // PR 정보, 커밋 정보, 리포지토리 정보 등이 포함됨
-    const context = github.context;
+    let context = github.context;
     core.info(`Starting code review for ${context.eventName}`);
 
+    // PR 댓글의 /claude review 명령: 명령 옵션을 적용하고 해당 PR을 리뷰
+    if (context.eventName === 'issue_comment') {
+      const commandContext = await handleReviewCommand(inputs, context);
+      if (!commandContext) {
+        return;
+      }
+      context = commandContext;
+    }
+
     // 2. 필요한 컴포넌트 초기화
     const fileAnalyzer = new FileAnalyzer({
       ...inputs,
       githubToken: inputs.githubToken
     });
-    const codeReviewer = new CodeReviewer(inputs.anthropicApiKey, inputs.language, inputs.maxIssuesPerFile);
-    const commentManager = new CommentManager(inputs.githubToken, context);
+    // 작성자별 매핑이 있으면 PR 작성자에 맞는 리뷰 언어 선택
+    const language = await resolveLanguage(inputs.languageMapping, context, github.getOctokit(inputs.githubToken));
+    const glossary = inputs.glossaryPath ? await loadGlossary(inputs.glossaryPath) : null;
+    const memory = inputs.memoryCases > 0 ? await loadReviewMemory(inputs) : null;
+    // paths 섹션의 owner 조건은 CODEOWNERS 담당자로 판단
+    if (inputs.repoConfig.hasOwnerSections()) {
+      inputs.repoConfig.useCodeowners(await Codeowners.load(inputs.codeownersPath));
+    }
+    // 설정 파일의 paths 섹션이 경로별로 다른 리뷰 타입을 지정할 수 있음
+    const reviewTypes = [inputs.reviewType, ...inputs.repoConfig.sectionReviewTypes()];
+    const i18nCatalog = reviewTypes.includes('i18n') ? await loadI18nCatalog(inputs, fileAnalyzer) : null;
+    const workspace = inputs.monorepoScope ? await loadWorkspace(fileAnalyzer) : null;
+    const conversation = inputs.conversation && context.eventName === 'pull_request' ? await loadConversation(inputs, context) : null;
+    const promptTemplates = await PromptTemplates.load(inputs.promptTemplatesDir);
+    const customTypes = await loadCustomReviewTypes(inputs);
+    const licensePolicy = await LicensePolicy.load({ license: inputs.projectLicense, header: inputs.licenseHeader });
+    if (inputs.reviewType === 'compliance') {
+      core.info(`Compliance review: project license ${licensePolicy.license || 'unknown'}${licensePolicy.source ? ` (${licensePolicy.source})` : ''}`);
+    }
+    if (promptTemplates.names().length > 0) {
+      core.info(`Using prompt templates from ${promptTemplates.source}: ${promptTemplates.names().join(', ')}`);
+    }
+    codeReviewer = new CodeReviewer(inputs.anthropicApiKey, language, inputs.maxIssuesPerFile, {
+      recorder: debugBundle,
+      glossary,
+      tone: inputs.tone,
+      personas: inputs.personas,
+      passes: inputs.reviewPasses,
+      model: inputs.models[0],
+      fallbackModels: inputs.models.slice(1),
+      typeModels: inputs.typeModels,
+      passModels: inputs.passModels,
+      arbitrationModel: inputs.arbitrationModel,
+      explain: inputs.explain,
+      memory,
+      memoryCases: inputs.memoryCases,
+      i18nCatalog,
+      packageScope: workspace,
+      conversation,
+      suggestFixes: inputs.suggestFixes,
+      promptTemplates,
+      licensePolicy,
+      customTypes,
+      provider: inputs.provider,
+      awsRegion: inputs.awsRegion,
+      vertexRegion: inputs.vertexRegion,
+      vertexProjectId: inputs.vertexProjectId,
+      openaiBaseUrl: inputs.openaiBaseUrl,
+      openaiApiKey: inputs.openaiApiKey,
+      openaiModel: inputs.openaiModel,
+      ollamaHost: inputs.ollamaHost,
+      ollamaModel: inputs.ollamaModel,
+      ollamaNumCtx: inputs.ollamaNumCtx,
+      executionMode: inputs.executionMode,
+      batchTimeoutMs: inputs.batchTimeoutMinutes * 60 * 1000,
+      promptCaching: inputs.promptCaching
+    });
+    const commentManager = new CommentManager(inputs.githubToken, context, {
+      labels: inputs.displayLabels,
+      templates: await loadCommentTemplates(inputs),
+      references: inputs.referenceLinks,
+      mode: inputs.commentMode,
+      updateComment: inputs.updateComment
+    });
+
+    // 예약 감사 모드: 변경분 대신 저장소 전체를 감사하고 다이제스트 이슈 갱신
+    if (inputs.audit) {
+      debugBundle.startPhase('audit');
+      const audit = await runAudit(inputs, context, codeReviewer);
+      debugBundle.endPhase('audit');
+      runState.outcome = 'success';
+      runState.filesReviewed = audit.filesAudited;
+      runState.issuesFound = audit.openFindings;
+      return;
+    }
+
+    // 인라인 리뷰 댓글 스레드의 /claude explain 질문에 답변
+    if (context.eventName === 'pull_request_review_comment') {
+      await answerThreadQuestion(inputs, context, codeReviewer);
+      runState.outcome = 'success';
+      return;
+    }
+
+    // 릴리즈 노트 모드: release 이벤트나 태그 push에서 이전 태그부터의 릴리즈 노트를 릴리즈 초안에 붙임
+    if (inputs.releaseNotes && ReleaseNotesGenerator.isReleaseEvent(context)) {
+      await publishReleaseNotes(inputs, context, codeReviewer);
+      runState.outcome = 'success';
+      return;
+    }
 
     // 3. 변경된 파일 목록 가져오기
     // PR이나 Push에서 변경된 파일들을 감지
+    debugBundle.startPhase('collectFiles');
     const changedFiles = await fileAnalyzer.getChangedFiles(context);
     core.info(`Found ${changedFiles.length} changed files`);
+    codeReviewer.setChangedFiles(changedFiles.map(file => file.filename));
+
+    // 모노레포: 변경의 영향을 받는 워크스페이스 패키지 계산 (선택적 CI용 출력)
+    if (workspace) {
+      const affected = workspace.setChangedFiles(changedFiles.map(file => file.filename));
+      core.info(`Affected packages: ${[...affected.keys()].join(', ') || 'none'}`);
+      core.setOutput('affected_packages', [...affected.keys()].sort().join(','));
+    }
 
     // 변경된 파일이 없으면 조기 종료
     if (changedFiles.length === 0) {
       core.info('No files to review');
+      publishFindings(buildReviewSummary([], context), context.runId);
+      await publishCheckRun(inputs, context, [], 0);
       return;
     }
 
+    // PR 설명 초안을 제안 댓글(비어 있거나 짧을 때) 또는 PR 본문의 관리 영역에 작성 (실패해도 리뷰는 계속)
+    if (inputs.describePr && context.eventName === 'pull_request') {
+      await suggestDescription(inputs, context, changedFiles, fileAnalyzer, codeReviewer);
+    }
+
+    // 증분 리뷰: 새 커밋이 push된 PR은 마지막으로 리뷰한 커밋 이후 변경된 파일만 리뷰
+    const incremental = inputs.incrementalReview && context.eventName === 'pull_request'
+      ? await loadIncrementalReview(inputs, context)
+      : null;
+
     // 4. 파일 필터링
     // 설정된 패턴에 맞는 파일만 선택하고, 제외 패턴 적용
-    const filesToReview = await fileAnalyzer.filterFiles(changedFiles);
+    const filesToReview = await fileAnalyzer.filterFiles(incremental ? incremental.filesWithin(changedFiles) : changedFiles);
+    debugBundle.endPhase('collectFiles');
     core.info(`Reviewing ${filesToReview.length} files after filtering`);
 
     if (filesToReview.length === 0) {
       core.info('No files match the review criteria');
+      // 리뷰 대상이 아닌 파일(CI 설정 등)만 바뀐 PR도 위험도는 계산
+      const risk = await publishRisk(inputs, changedFiles, [], context, commentManager);
+      if (inputs.findingLabels && context.eventName === 'pull_request') {
+        await applyFindingLabels(inputs, context, [], risk.level, Boolean(incremental && incremental.isIncremental()));
+      }
+      if (inputs.maxRiskScore !== null) {
+        enforceRiskGate(inputs.maxRiskScore, risk);
+      }
+      if (workspace) {
+        publishPackageVerdicts(inputs, workspace, []);
+      }
+      // 배포 게이트 등 후속 단계가 출력값에 의존하므로 이슈가 없어도 설정
+      publishFindings(buildReviewSummary([], context), context.runId);
+      // 필수 상태 검사로 지정된 경우 리뷰할 파일이 없어도 Check가 있어야 병합 가능
+      await publishCheckRun(inputs, context, [], 0);
       return;
     }
 
     // 리뷰 결과 저장 변수
     let totalIssues = 0;
-    const reviewResults = [];
+    let reviewResults = [];
+    // 종합 점수 계산용 파일별 점수 (이슈가 없는 파일 포함)
+    const fileScores = [];
+    // 이전 대화의 메인테이너 답글에 대한 후속 답변
+    const followUps = [];
+    // 인라인 댓글을 diff 줄에 고정하기 위한 파일별 PR diff
+    const patches = {};
+    // 리뷰 타입별 파일 수 (paths 섹션으로 경로마다 다른 타입을 적용한 경우 댓글에 표시)
+    const reviewTypeCounts = {};
+    filesToReview.forEach(file => {
+      const type = inputs.repoConfig.sectionFor(file.filename).reviewType || inputs.reviewType;
+      reviewTypeCounts[type] = (reviewTypeCounts[type] || 0) + 1;
+    });
+
+    // 커밋별 리뷰: 파일마다 PR 전체 대신 커밋마다 그 커밋의 diff와 그 시점의 내용을 리뷰
+    let commitByCommit = inputs.commitByCommit && context.eventName === 'pull_request'
+      ? new CommitByCommit({ octokit: github.getOctokit(inputs.githubToken), context })
+      : null;
+    let reviewUnits = filesToReview;
+    if (commitByCommit) {
+      try {
+        reviewUnits = await commitByCommit.load(filesToReview, { since: incremental && incremental.base });
+        core.info(`Commit-by-commit review: ${reviewUnits.length} file revisions in ${commitByCommit.commits.length} commits${commitByCommit.skippedCommits > 0 ? ` (skipped ${commitByCommit.skippedCommits} older commits)` : ''}`);
+      } catch (error) {
+        core.warning(`Failed to list pull request commits, reviewing the whole diff: ${error.message}`);
+        commitByCommit = null;
+      }
+    }
 
     // 5. 병렬로 각 파일에 대해 AI 리뷰 실행 (속도 개선)
-    core.info(`Starting parallel review of ${filesToReview.length} files...`);
+    core.info(`Starting parallel review of ${reviewUnits.length} files...`);
+    debugBundle.startPhase('review');
+
+    // 긴 리뷰가 멈춘 것처럼 보이지 않도록 주기적으로 진행 상황 출력
+    const progress = new ProgressTracker(reviewUnits.length, inputs.progressInterval);
+    // Check Run을 리뷰 시작 시 in_progress로 만들고 하트비트마다 출력 요약에 진행 상황 반영
+    checkRunPublisher = await startCheckRun(inputs, context, reviewUnits.length);
+    if (checkRunPublisher) {
+      progress.onProgress(snapshot => checkRunPublisher.reportProgress(snapshot));
+    }
+    progress.start();
     
-    const reviewPromises = filesToReview.map(async (file) => {
+    const reviewPromises = reviewUnits.map(async (file) => {
       try {
-        core.info(`Reviewing file: ${file.filename}`);
+        core.info(`Reviewing file: ${file.filename}${file.commit ? ` @ ${file.commit.sha.substring(0, 7)}` : ''}`);
         
         // 파일 내용과 diff를 병렬로 가져오기
         const [fileContent, diff] = await Promise.all([
@@ -86,26 +335,49 @@ async function run() {
           fileAnalyzer.getFileDiff(file)
         ]);
         
-        // Claude AI를 통한 코드 리뷰 실행
+        patches[file.filename] = file.patch || diff;
+
+        // 설정 파일의 paths 섹션이 이 파일의 리뷰 타입과 심각도 필터를 덮어쓰고 팀별 리뷰 규칙을 추가
+        const section = inputs.repoConfig.sectionFor(file.filename);
+        const severityFilter = section.severityFilter || inputs.severityFilter;
+        const reviewType = section.reviewType || inputs.reviewType;
+
+        // Claude AI를 통한 코드 리뷰 실행 (증분 리뷰는 마지막 리뷰 이후의 diff만 전달)
         const review = await codeReviewer.reviewFile({
           filename: file.filename,
           content: fileContent,
-          diff: diff,
-          reviewType: inputs.reviewType
+          diff: file.deltaPatch || diff,
+          reviewType,
+          instructions: section.instructions
         });
 
+        if (review && typeof review.overallScore === 'number') {
+          fileScores.push(review.overallScore);
+        }
+        if (review && review.followUps) {
+          followUps.push(...review.followUps.map(followUp => ({ ...followUp, file: file.filename })));
+        }
+
         // 리뷰 결과 처리 및 필터링
         if (review && review.issues.length > 0) {
           // 설정된 심각도 이상의 이슈만 필터링
           const filteredIssues = review.issues.filter(issue => 
-            getSeverityLevel(issue.severity) >= getSeverityLevel(inputs.severityFilter)
+            getSeverityLevel(issue.severity) >= getSeverityLevel(severityFilter)
           );
           
+          // 코드 발췌와 지문을 붙여 실행 간 동일 이슈를 식별할 수 있게 함
+          filteredIssues.forEach(issue => {
+            issue.snippet = extractSnippet(fileContent, issue.line);
+            issue.fingerprint = fingerprintFinding(file.filename, issue);
+          });
+
           if (filteredIssues.length > 0) {
             return {
               file: file.filename,
+              ...(file.commit ? { commit: file.commit } : {}),
               issues: filteredIssues,
-              summary: review.summary
+              summary: review.summary,
+              score: review.overallScore
             };
           }
         }
@@ -116,26 +388,231 @@ async function run() {
         core.warning(`Failed to review file ${file.filename}: ${error.message}`);
         return null;
       }
-    });
+    }).map(promise => promise.then(result => {
+      // 파일 하나가 끝날 때마다 진행 상황 갱신
+      progress.fileCompleted(result ? result.issues.length : 0);
+      return result;
+    }));
 
     // 모든 리뷰 완료까지 대기
     const parallelResults = await Promise.all(reviewPromises);
+    progress.stop();
+    debugBundle.endPhase('review');
     
     // null이 아닌 결과만 수집
-    parallelResults.forEach(result => {
-      if (result) {
-        totalIssues += result.issues.length;
-        reviewResults.push(result);
+    // 커밋별 리뷰는 같은 이슈를 처음 보고한 커밋에 연결하고 파일별 결과로 합침
+    let commitReviews = [];
+    if (commitByCommit) {
+      const headContents = new Map(await Promise.all(filesToReview.map(async file =>
+        [file.filename, await fileAnalyzer.getFileContent(file).catch(() => '')])));
+      const attributed = commitByCommit.attribute(parallelResults.filter(Boolean), headContents);
+      reviewResults.push(...attributed.results);
+      commitReviews = attributed.commits;
+      if (attributed.fixedCount > 0) {
+        core.info(`Skipped ${attributed.fixedCount} findings fixed by a later commit`);
       }
-    });
+    } else {
+      parallelResults.forEach(result => {
+        if (result) {
+          reviewResults.push(result);
+        }
+      });
+    }
+
+    // 저장소 지도를 근거로 한 아키텍처 리뷰 결과를 파일별 결과에 합침
+    if (inputs.architectureReview) {
+      mergeExtraResults(reviewResults, await reviewArchitecture(inputs, filesToReview, fileAnalyzer, codeReviewer));
+    }
+
+    // 공개 API/옵션 변경에 맞춰 갱신되지 않은 문서 검사
+    if (inputs.docDrift) {
+      mergeExtraResults(reviewResults, await detectDocDrift(inputs, filesToReview, changedFiles, fileAnalyzer));
+    }
+
+    // 의존성 매니페스트 변경 검토 (같은 요약 댓글에 섹션으로 추가, 실패해도 리뷰는 계속)
+    // 컴플라이언스 리뷰에서는 새 의존성의 라이선스 호환성도 검토해 호환되지 않는 의존성을 이슈로 추가
+    const complianceReview = inputs.reviewType === 'compliance';
+    const dependencies = inputs.dependencyReview || complianceReview
+      ? await reviewDependencies(context, changedFiles, fileAnalyzer, codeReviewer, complianceReview ? licensePolicy : null)
+      : null;
+    if (complianceReview) {
+      mergeExtraResults(reviewResults, DependencyReviewer.complianceResults(dependencies));
+    }
 
-    // 6. 리뷰 결과를 GitHub에 댓글로 작성
-    if (reviewResults.length > 0) {
+    // Slack 등에서 무시/일시 중지한 이슈 제외
+    const suppressions = await SuppressionStore.loadFile(inputs.suppressionsPath);
+    const { results: activeResults, suppressedCount } = suppressions.filterResults(reviewResults);
+    if (suppressedCount > 0) {
+      core.info(`Skipped ${suppressedCount} dismissed or snoozed findings (${inputs.suppressionsPath})`);
+    }
+    // 기준선 파일에 기록된 기존 이슈 제외 (baseline_update면 현재 이슈를 기준선에 추가)
+    reviewResults = await applyBaseline(inputs, activeResults);
+    totalIssues = reviewResults.reduce((sum, result) => sum + result.issues.length, 0);
+
+    // PR 위험도 점수 출력 및 라벨 적용
+    const risk = await publishRisk(inputs, changedFiles, reviewResults, context, commentManager);
+    // 이슈 타입, 심각도, 위험도에 따른 분류 라벨 적용
+    if (inputs.findingLabels && context.eventName === 'pull_request') {
+      await applyFindingLabels(inputs, context, reviewResults, risk.level, Boolean(incremental && incremental.isIncremental()));
+    }
+
+    // 결과 재현을 위한 실행 메타데이터 (모델, 프롬프트/설정 해시, 커밋 SHA)
+    let runMetadata = buildRunMetadata({ context, inputs, codeReviewer });
+
+    const overallScore = fileScores.length > 0
+      ? Math.round((fileScores.reduce((sum, score) => sum + score, 0) / fileScores.length) * 10) / 10
+      : null;
+
+    // 전체 결과 리포트는 verbosity와 관계없이 항상 파일로 작성 (아티팩트 업로드용)
+    const reviewSummary = buildReviewSummary(reviewResults, context, runMetadata);
+    const reportPath = writeJsonReport(reviewSummary, context.runId);
+    core.setOutput('report_path', reportPath);
+    publishFindings(reviewSummary, context.runId);
+    if (inputs.htmlReport) {
+      core.setOutput('html_report_path', writeHtmlReport(reviewSummary, context.runId, { labels: inputs.displayLabels }));
+    }
+    if (inputs.junit) {
+      core.setOutput('junit_path', writeJunitReport(reviewSummary, context.runId, inputs.junitFailSeverity));
+    }
+
+    // SARIF 리포트 작성 및 Code Scanning 업로드 (업로드 실패해도 리뷰는 계속)
+    await publishSarif(inputs, context, reviewSummary);
+
+    // 커밋 메시지 리뷰 (같은 요약 댓글에 섹션으로 추가, 실패해도 리뷰는 계속)
+    const commitFindings = inputs.commitReview && context.eventName === 'pull_request'
+      ? await reviewCommitMessages(inputs, context, changedFiles, codeReviewer)
+      : [];
+
+    // PR 제목/설명 규칙 검사 (같은 요약 댓글에 섹션으로 추가, 실패해도 리뷰는 계속)
+    const prConvention = inputs.prConventionCheck && context.eventName === 'pull_request'
+      ? await checkPrConventions(inputs, context, changedFiles, codeReviewer)
+      : null;
+
+    // 사용자 영향 변경의 변경 로그 항목 제안 (같은 요약 댓글에 섹션으로 추가, 실패해도 리뷰는 계속)
+    const changelog = inputs.changelogEntry && context.eventName === 'pull_request'
+      ? await suggestChangelogEntry(inputs, context, changedFiles, fileAnalyzer, codeReviewer)
+      : null;
+
+    // Go 공개 API 호환성 검토 (같은 요약 댓글에 섹션으로 추가, 실패해도 리뷰는 계속)
+    const compatibility = inputs.goApiReview ? await reviewGoApi(context, fileAnalyzer, codeReviewer) : null;
+
+    // 이번 결과와 후속 답변으로 리뷰 대화 갱신 (요약 댓글에 상태를 저장해 다음 push에서 이어 감)
+    if (conversation) {
+      conversation.update(reviewResults, followUps, filesToReview.map(file => file.filename), context.payload.pull_request.head.sha);
+    }
+
+    // 영향을 받은 패키지별 판정 (같은 요약 댓글에 섹션으로 추가)
+    const packages = workspace ? publishPackageVerdicts(inputs, workspace, reviewResults) : [];
+
+    // 이슈 위치의 CODEOWNERS와 최근 수정자로 리뷰어 추천 또는 요청 (같은 요약 댓글에 섹션으로 추가, 실패해도 리뷰는 계속)
+    const reviewers = inputs.suggestReviewers !== 'off' && context.eventName === 'pull_request'
+      ? await suggestReviewers(inputs, context, reviewResults)
+      : [];
+
+    // 커밋 메시지·PR 규칙·변경 로그·Go API 검토도 모델을 호출하므로 댓글 푸터, 알림, run_metadata 출력의
+    // 토큰 사용량과 예상 비용이 이 호출들까지 포함하도록 메타데이터를 다시 생성
+    runMetadata = buildRunMetadata({ context, inputs, codeReviewer });
+
+    // 6. 리뷰 결과를 GitHub에 댓글로 작성 (comment_mode: none이면 Check Run 등 다른 출력만 사용)
+    // 보고할 내용이 없어도 이전 요약 댓글이 있으면 이슈가 해결되었음을 반영하도록 수정
+    const hasComment = reviewResults.length > 0 || commitFindings.length > 0 || prConvention || changelog || reviewers.length > 0 || compatibility || dependencies || (conversation && conversation.hasActivity());
+    if ((hasComment || inputs.updateComment) && inputs.commentMode !== 'none') {
+      debugBundle.startPhase('publish');
       await commentManager.postReviewComment(reviewResults, {
         totalFiles: filesToReview.length,
         totalIssues: totalIssues,
-        reviewType: inputs.reviewType
+        reviewType: inputs.reviewType,
+        reviewTypes: reviewTypeCounts,
+        runMetadata,
+        overallScore,
+        verbosity: inputs.verbosity,
+        commitFindings,
+        prConvention,
+        changelog,
+        reviewers,
+        compatibility,
+        dependencies,
+        packages,
+        modelFallbacks: codeReviewer.fallbacks,
+        conversation,
+        incremental,
+        commitReviews,
+        risk,
+        patches,
+        reviewedFiles: filesToReview.map(file => file.filename),
+        updateOnly: !hasComment
+      });
+      debugBundle.endPhase('publish');
+    }
+
+    // 정책에 따라 승인/변경 요청 리뷰 제출 (실패해도 리뷰는 계속)
+    if (inputs.submitReview && context.eventName === 'pull_request') {
+      await submitReviewVerdict(inputs, context, reviewResults, Boolean(incremental && incremental.isIncremental()));
+    }
+
+    // 파일별 주석과 심각도 기반 결론을 담은 Check Run 게시 (실패해도 리뷰는 계속)
+    await publishCheckRun(inputs, context, reviewResults, filesToReview.length, checkRunPublisher);
+
+    // 담당 팀별 스레드에 해당 팀의 이슈만 멘션 (실패해도 리뷰는 계속)
+    if (inputs.teamRoutes.length > 0 && context.eventName === 'pull_request') {
+      await notifyTeams(inputs, context, reviewResults);
+    }
+
+    // 테스트 누락 리뷰의 테스트 스켈레톤을 PR 브랜치에 커밋 (실패해도 댓글은 이미 게시됨)
+    if (inputs.testCommit && inputs.reviewType === 'tests' && context.eventName === 'pull_request') {
+      try {
+        const { committed, skipped } = await new TestCommitter(github.getOctokit(inputs.githubToken), context).commit(reviewResults);
+        core.info(`Committed ${committed.length} test skeletons${skipped.length > 0 ? ` (skipped existing files: ${skipped.join(', ')})` : ''}`);
+        core.setOutput('committed_test_files', committed.join(','));
+      } catch (error) {
+        core.warning(`Failed to commit test skeletons: ${error.message}`);
+      }
+    }
+
+    // 이슈 트래커 연동에서 공통으로 사용하는 PR/커밋 링크
+    const { title, url } = buildReviewSummary(reviewResults, context);
+    const link = { title, url };
+
+    // 알림 전송 (라우팅 규칙 적용 후 대상별로 전송)
+    await dispatchNotifications(inputs, reviewResults, context, runMetadata);
+
+    // 심각한 이슈를 Jira에 등록 (지문 기준 중복 방지)
+    if (inputs.jiraBaseUrl) {
+      const jira = new JiraClient({
+        baseUrl: inputs.jiraBaseUrl,
+        email: inputs.jiraEmail,
+        apiToken: inputs.jiraApiToken,
+        projectKey: inputs.jiraProjectKey,
+        issueType: inputs.jiraIssueType,
+        minSeverity: inputs.jiraMinSeverity
+      });
+      const jiraKeys = await jira.syncFindings(reviewResults, link);
+      core.setOutput('jira_issues', jiraKeys.join(','));
+    }
+
+    // 심각한 이슈를 Linear에 등록 (Jira와 동일한 지문 기준 중복 방지)
+    if (inputs.linearApiKey) {
+      const linear = new LinearClient({
+        apiKey: inputs.linearApiKey,
+        teamKey: inputs.linearTeamKey,
+        label: inputs.linearLabel,
+        minSeverity: inputs.linearMinSeverity
+      });
+      try {
+        const linearIds = await linear.syncFindings(reviewResults, link);
+        core.setOutput('linear_issues', linearIds.join(','));
+      } catch (error) {
+        core.warning(`Failed to sync Linear issues: ${error.message}`);
+      }
+    }
+
+    // 보호 브랜치 대상 리뷰의 critical 보안 이슈는 보안 온콜 호출
+    if (inputs.pagerdutyRoutingKey) {
+      const pagerduty = new PagerDutyClient({
+        routingKey: inputs.pagerdutyRoutingKey,
+        branches: inputs.pagerdutyBranches
       });
+      await pagerduty.alert(reviewResults, context, link);
     }
 
     // 7. 액션 출력값 설정
@@ -143,29 +620,1480 @@ async function run() {
     core.setOutput('review_summary', generateSummary(reviewResults));
     core.setOutput('issues_found', totalIssues.toString());
     core.setOutput('files_reviewed', filesToReview.length.toString());
+    core.setOutput('run_metadata', JSON.stringify(runMetadata));
+
+    // 워크플로우 실행 페이지의 Job Summary에 리포트 작성
+    if (inputs.jobSummary) {
+      await writeJobSummary(inputs, reviewSummary, filesToReview.length, codeReviewer, Date.now() - runState.startedAt);
+    }
 
     core.info(`Code review completed. Found ${totalIssues} issues in ${filesToReview.length} files`);
 
+    runState.outcome = 'success';
+    runState.filesReviewed = filesToReview.length;
+    runState.issuesFound = totalIssues;
+
+    // 심각도 게이트: 리뷰와 댓글 작성이 모두 끝난 뒤 기준 이상의 이슈가 남아 있으면 실패 처리
+    if (inputs.failOnSeverity) {
+      enforceSeverityGate(inputs.failOnSeverity, reviewResults);
+    }
+    // 위험도 게이트: 위험도 점수가 max_risk_score를 넘으면 실패 처리
+    if (inputs.maxRiskScore !== null) {
+      enforceRiskGate(inputs.maxRiskScore, risk);
+    }
+
   } catch (error) {
-    // 전체 액션 실패 처리
+    // 설정 오류가 아닌 경우 디버그 번들 작성 (버그 리포트 첨부용)
+    if (!(error instanceof ConfigError)) {
+      try {
+        const bundlePath = debugBundle.write(error);
+        core.setOutput('debug_bundle_path', bundlePath);
+        core.info(`Debug bundle written to ${bundlePath} (upload it with actions/upload-artifact when reporting a bug)`);
+      } catch (bundleError) {
+        core.warning(`Failed to write debug bundle: ${bundleError.message}`);
+      }
+    }
+
+    // 리뷰 중 실패하면 in_progress로 남은 Check Run을 failure로 완료
+    if (checkRunPublisher) {
+      await checkRunPublisher.fail(error.message)
+        .catch(checkError => core.warning(`Failed to complete check run: ${checkError.message}`));
+    }
+
+    // 전체 액션 실패 처리 (심각도 게이트 실패와 구분되는 종료 코드)
+    runState.outcome = 'failure';
+    core.setOutput('failure_reason', error instanceof ConfigError ? 'config' : 'error');
     core.setFailed(`Action failed: ${error.message}`);
+    process.exitCode = EXIT_CODES.error;
     core.error(error.stack);
+  } finally {
+    // 토큰 사용량과 예상 비용 (실패한 실행도 이미 사용한 만큼 보고)
+    const usage = codeReviewer ? summarizeUsage(codeReviewer.usageByModel, codeReviewer.batch) : { tokens: 0, costUsd: null };
+    core.setOutput('tokens_used', usage.tokens.toString());
+    core.setOutput('estimated_cost_usd', usage.costUsd !== null ? usage.costUsd.toFixed(4) : '');
+
+    // 중앙 모니터링용 실행 메타데이터 전송 (선택)
+    if (inputs && inputs.telemetryUrl) {
+      const telemetry = new TelemetryReporter(inputs.telemetryUrl, inputs.telemetrySecret);
+      await telemetry.send(github.context, {
+        outcome: runState.outcome,
+        durationMs: Date.now() - runState.startedAt,
+        filesReviewed: runState.filesReviewed,
+        issuesFound: runState.issuesFound,
+        usage: codeReviewer ? codeReviewer.usage : null,
+        estimatedCostUsd: usage.costUsd
+      });
+    }
+  }
+}
+
+/**
+ * 액션 입력값 수집 및 검증
+ * 잘못된 값은 ConfigError로 보고하여 런타임 장애와 구분
+ * @returns {Object} 입력값 객체
+ */
+function readInputs() {
+  let inputs;
+  try {
+    inputs = {
+      provider: (core.getInput('provider') || 'anthropic').toLowerCase(),
+      anthropicApiKey: core.getInput('anthropic_api_key'),
+      awsRegion: core.getInput('aws_region') || process.env.AWS_REGION || process.env.AWS_DEFAULT_REGION || '',
+      vertexRegion: core.getInput('vertex_region') || process.env.CLOUD_ML_REGION || 'us-east5',
+      vertexProjectId: core.getInput('vertex_project_id') || process.env.GOOGLE_CLOUD_PROJECT || process.env.GCLOUD_PROJECT || '',
+      openaiBaseUrl: core.getInput('openai_base_url'),
+      openaiApiKey: core.getInput('openai_api_key'),
+      openaiModel: core.getInput('openai_model'),
+      ollamaHost: core.getInput('ollama_host') || process.env.OLLAMA_HOST || DEFAULT_OLLAMA_HOST,
+      ollamaModel: core.getInput('ollama_model'),
+      ollamaNumCtx: parseInt(core.getInput('ollama_num_ctx') || String(DEFAULT_OLLAMA_NUM_CTX), 10),
+      executionMode: (core.getInput('execution_mode') || 'realtime').toLowerCase(),
+      batchTimeoutMinutes: parseInt(core.getInput('batch_timeout_minutes') || '330', 10),
+      promptCaching: core.getInput('prompt_caching') !== 'false',
+      githubToken: core.getInput('github_token', { required: true }),
+      reviewType: core.getInput('review_type') || 'full',
+      filePatterns: core.getInput('file_patterns') || '**/*.js,**/*.ts,**/*.jsx,**/*.tsx,**/*.py,**/*.java,**/*.go,**/*.rs',
+      excludePatterns: core.getInput('exclude_patterns') || '**/node_modules/**,**/dist/**,**/build/**',
+      maxFiles: parseInt(core.getInput('max_files') || '10'),
+      maxIssuesPerFile: Math.max(1, Math.min(10, parseInt(core.getInput('max_issues_per_file') || '3'))), // 1-10 범위로 제한
+      language: core.getInput('language') || 'en',
+      languageMapping: parseLanguageMapping(core.getInput('language')),
+      glossaryPath: core.getInput('glossary_path'),
+      tone: core.getInput('tone').toLowerCase(),
+      explain: core.getInput('explain') === 'true',
+      // explain 모드에서는 기본 링크 사용, reference_links로 타입별 재정의
+      referenceLinks: ReferenceLinks.parse(core.getInput('reference_links'), core.getInput('explain') === 'true'),
+      personas: (core.getInput('persona') || '').split(',').map(p => p.trim().toLowerCase()).filter(Boolean),
+      reviewPasses: (core.getInput('review_passes') || '').split(',').map(p => p.trim().toLowerCase()).filter(Boolean),
+      models: (core.getInput('model') || '').split(',').map(model => model.trim()).filter(Boolean),
+      typeModels: CustomReviewTypes.parseTypeModels(core.getInput('type_models')),
+      passModels: parsePassModels(core.getInput('pass_models')),
+      arbitrationModel: core.getInput('arbitration_model'),
+      verbosity: (core.getInput('verbosity') || 'full').toLowerCase(),
+      commentMode: (core.getInput('comment_mode') || 'summary').toLowerCase(),
+      updateComment: core.getInput('update_comment') !== 'false',
+      incrementalReview: core.getInput('incremental_review') === 'true',
+      commitByCommit: core.getInput('commit_by_commit') === 'true',
+      baseRef: core.getInput('base_ref').trim(),
+      headRef: core.getInput('head_ref').trim() || 'HEAD',
+      suggestFixes: core.getInput('suggest_fixes') === 'true',
+      displayLabels: DisplayLabels.parse(core.getInput('display_labels')),
+      commentFindingTemplate: core.getInput('comment_finding_template'),
+      promptTemplatesDir: core.getInput('prompt_templates_dir') || PromptTemplates.DEFAULT_TEMPLATES_DIR,
+      reviewTypesDir: core.getInput('review_types_dir') || CustomReviewTypes.DEFAULT_TYPES_DIR,
+      commentFileTemplate: core.getInput('comment_file_template'),
+      plainTextSinks: (core.getInput('plain_text_sinks') || '').split(',').map(t => t.trim().toLowerCase()).filter(Boolean),
+      severityFilter: core.getInput('severity_filter') || 'medium',
+      progressInterval: parseInt(core.getInput('progress_interval') || '30'),
+      telemetryUrl: core.getInput('telemetry_url'),
+      telemetrySecret: core.getInput('telemetry_secret'),
+      slackWebhookUrl: core.getInput('slack_webhook_url'),
+      slackBotToken: core.getInput('slack_bot_token'),
+      slackChannel: core.getInput('slack_channel'),
+      slackMinSeverity: core.getInput('slack_min_severity') || 'high',
+      slackInteractive: core.getInput('slack_interactive') === 'true',
+      suppressionsPath: core.getInput('suppressions_path') || SuppressionStore.DEFAULT_SUPPRESSIONS_PATH,
+      memoryPath: core.getInput('memory_path') || ReviewMemory.DEFAULT_MEMORY_PATH,
+      memoryCases: parseInt(core.getInput('memory_cases') || '3'),
+      teamsWebhookUrl: core.getInput('teams_webhook_url'),
+      teamsMinSeverity: core.getInput('teams_min_severity') || 'high',
+      discordWebhookUrl: core.getInput('discord_webhook_url'),
+      discordMinSeverity: core.getInput('discord_min_severity') || 'high',
+      smtpHost: core.getInput('smtp_host'),
+      smtpPort: parseInt(core.getInput('smtp_port') || '587'),
+      smtpUsername: core.getInput('smtp_username'),
+      smtpPassword: core.getInput('smtp_password'),
+      smtpAllowInsecureAuth: core.getInput('smtp_allow_insecure_auth') === 'true',
+      emailFrom: core.getInput('email_from'),
+      emailTo: (core.getInput('email_to') || '').split(',').map(address => address.trim()).filter(Boolean),
+      emailMinSeverity: core.getInput('email_min_severity') || 'low',
+      webhookUrl: core.getInput('webhook_url'),
+      webhookSecret: core.getInput('webhook_secret'),
+      jiraBaseUrl: core.getInput('jira_base_url'),
+      jiraEmail: core.getInput('jira_email'),
+      jiraApiToken: core.getInput('jira_api_token'),
+      jiraProjectKey: core.getInput('jira_project_key'),
+      jiraIssueType: core.getInput('jira_issue_type') || 'Bug',
+      jiraMinSeverity: core.getInput('jira_min_severity') || 'critical',
+      linearApiKey: core.getInput('linear_api_key'),
+      linearTeamKey: core.getInput('linear_team_key'),
+      linearLabel: core.getInput('linear_label'),
+      linearMinSeverity: core.getInput('linear_min_severity') || 'critical',
+      pagerdutyRoutingKey: core.getInput('pagerduty_routing_key'),
+      pagerdutyBranches: (core.getInput('pagerduty_branches') || 'main,master,release/**').split(',').map(p => p.trim()).filter(Boolean),
+      notifyUrl: core.getInput('notify_url'),
+      notifyFormat: (core.getInput('notify_format') || 'auto').toLowerCase(),
+      notifyMinSeverity: core.getInput('notify_min_severity') || 'high',
+      notify: (core.getInput('notify') || '').split(',').map(t => t.trim().toLowerCase()).filter(Boolean),
+      notifyRoutes: NotificationRouter.parseRoutes(core.getInput('notify_routes'), NOTIFY_TARGETS),
+      audit: core.getInput('audit') === 'true',
+      auditMaxFiles: parseInt(core.getInput('audit_max_files') || '200'),
+      auditBatchSize: parseInt(core.getInput('audit_batch_size') || '5'),
+      auditTokenBudget: parseInt(core.getInput('audit_token_budget') || '500000'),
+      auditIssueLabel: core.getInput('audit_issue_label') || 'claude-audit',
+      riskPaths: (core.getInput('risk_paths') || '').split(',').map(p => p.trim()).filter(Boolean),
+      riskLabel: core.getInput('risk_label') === 'true',
+      findingLabels: core.getInput('finding_labels') === 'true',
+      findingLabelRules: FindingLabeler.parseLabelRules(core.getInput('finding_label_rules')),
+      // 업로드하려면 SARIF 파일이 필요하므로 sarif_upload는 sarif를 포함
+      sarif: core.getInput('sarif') === 'true' || core.getInput('sarif_upload') === 'true',
+      sarifUpload: core.getInput('sarif_upload') === 'true',
+      htmlReport: core.getInput('html_report') === 'true',
+      junit: core.getInput('junit') === 'true',
+      junitFailSeverity: core.getInput('junit_fail_severity') || 'high',
+      checkRun: core.getInput('check_run') === 'true',
+      jobSummary: core.getInput('job_summary') !== 'false',
+      checkFailSeverity: core.getInput('check_fail_severity') || 'high',
+      submitReview: core.getInput('submit_review') === 'true',
+      submitReviewSeverity: core.getInput('submit_review_severity') || 'critical',
+      submitReviewApprove: core.getInput('submit_review_approve') || 'clean',
+      suggestReviewers: core.getInput('suggest_reviewers') || 'off',
+      reviewerRoutes: ReviewerSuggester.parseReviewerRoutes(core.getInput('reviewer_routes')),
+      maxReviewers: parseInt(core.getInput('max_reviewers') || '2'),
+      failOnSeverity: core.getInput('fail_on_severity').toLowerCase(),
+      maxRiskScore: core.getInput('max_risk_score') ? Number(core.getInput('max_risk_score')) : null,
+      baselinePath: core.getInput('baseline_path') || FindingBaseline.DEFAULT_BASELINE_PATH,
+      baselineUpdate: core.getInput('baseline_update') === 'true',
+      testCommit: core.getInput('test_commit') === 'true',
+      architectureReview: core.getInput('architecture_review') === 'true',
+      architectureLayers: ArchitectureReviewer.parseLayers(core.getInput('architecture_layers')),
+      docDrift: core.getInput('doc_drift') === 'true',
+      releaseNotes: core.getInput('release_notes') === 'true',
+      teamRoutes: TeamRouter.parseTeamRoutes(core.getInput('team_routes')),
+      goApiReview: core.getInput('go_api_review') === 'true',
+      dependencyReview: core.getInput('dependency_review') === 'true',
+      monorepoScope: core.getInput('monorepo_scope') === 'true',
+      conversation: core.getInput('conversation') === 'true',
+      threadFollowUps: core.getInput('thread_followups') === 'true',
+      packageFailSeverity: core.getInput('package_fail_severity') || 'high',
+      i18nCatalogs: (core.getInput('i18n_catalogs') || '').split(',').map(p => p.trim()).filter(Boolean),
+      codeownersPath: core.getInput('codeowners_path'),
+      docPaths: (core.getInput('doc_paths') || '').split(',').map(p => p.trim()).filter(Boolean),
+      projectLicense: core.getInput('project_license'),
+      licenseHeader: core.getInput('license_header'),
+      commitReview: core.getInput('commit_review') === 'true',
+      commitMessagePattern: core.getInput('commit_message_pattern'),
+      prConventionCheck: core.getInput('pr_convention_check') === 'true',
+      prTitlePattern: core.getInput('pr_title_pattern'),
+      prRequiredSections: (core.getInput('pr_required_sections') || '').split(',').map(name => name.trim()).filter(Boolean),
+      prTicketPattern: core.getInput('pr_ticket_pattern'),
+      changelogEntry: core.getInput('changelog_entry') === 'true',
+      changelogPath: core.getInput('changelog_path'),
+      changelogSuggestion: core.getInput('changelog_suggestion') === 'true',
+      describePr: core.getInput('describe_pr') === 'true',
+      describeMinLength: parseInt(core.getInput('describe_min_length') || '50'),
+      describeMode: core.getInput('describe_mode') || 'comment'
+    };
+  } catch (error) {
+    // 필수 입력값 누락
+    throw new ConfigError(error.message);
+  }
+
+  // 저장소 설정 파일의 값이 워크플로우 입력값보다 우선 (검증은 병합한 값으로)
+  inputs.repoConfig = RepoConfig.load(core.getInput('config_path') || RepoConfig.DEFAULT_CONFIG_PATH);
+  const applied = inputs.repoConfig.apply(inputs);
+  if (applied.length > 0) {
+    core.info(`Applied ${inputs.repoConfig.source}: ${applied.join(', ')}`);
+  }
+
+  if (!PROVIDERS.includes(inputs.provider)) {
+    throw new ConfigError(`Invalid provider: ${inputs.provider} (supported: ${PROVIDERS.join(', ')})`);
+  }
+  if (inputs.provider === 'anthropic' && !inputs.anthropicApiKey) {
+    throw new ConfigError('Input required and not supplied: anthropic_api_key');
+  }
+  if (inputs.provider === 'bedrock' && !inputs.awsRegion) {
+    throw new ConfigError('provider: bedrock requires aws_region (or the AWS_REGION environment variable)');
+  }
+  if (inputs.provider === 'vertex' && !inputs.vertexProjectId) {
+    throw new ConfigError('provider: vertex requires vertex_project_id (or the GOOGLE_CLOUD_PROJECT environment variable)');
+  }
+  if (inputs.provider === 'openai') {
+    try {
+      new URL(inputs.openaiBaseUrl);
+    } catch (error) {
+      throw new ConfigError(`provider: openai requires a valid openai_base_url (got "${inputs.openaiBaseUrl}")`);
+    }
+  }
+  if (inputs.provider === 'ollama' && !inputs.ollamaModel) {
+    throw new ConfigError('provider: ollama requires ollama_model');
+  }
+  if (!EXECUTION_MODES.includes(inputs.executionMode)) {
+    throw new ConfigError(`Invalid execution_mode: ${inputs.executionMode} (supported: ${EXECUTION_MODES.join(', ')})`);
+  }
+  if (inputs.executionMode === 'batch' && inputs.provider !== 'anthropic') {
+    throw new ConfigError(`execution_mode: batch requires provider: anthropic (got ${inputs.provider})`);
+  }
+  if (isNaN(inputs.batchTimeoutMinutes) || inputs.batchTimeoutMinutes < 1) {
+    throw new ConfigError(`Invalid batch_timeout_minutes: ${core.getInput('batch_timeout_minutes')}`);
+  }
+  if (isNaN(inputs.ollamaNumCtx) || inputs.ollamaNumCtx < 1024) {
+    throw new ConfigError(`Invalid ollama_num_ctx: ${core.getInput('ollama_num_ctx')} (expected at least 1024)`);
+  }
+  // 모델 이름은 제공자 형식(Bedrock ARN, Vertex @버전 등)을 허용하되 공백과 오타성 문자는 거부
+  const modelInputs = [
+    ...inputs.models.map(model => ['model', model]),
+    ...Object.values(inputs.typeModels).map(model => ['type_models', model]),
+    ...Object.values(inputs.passModels).map(model => ['pass_models', model]),
+    ...(inputs.arbitrationModel ? [['arbitration_model', inputs.arbitrationModel]] : [])
+  ];
+  for (const [name, model] of modelInputs) {
+    if (!MODEL_NAME_PATTERN.test(model)) {
+      throw new ConfigError(`Invalid ${name}: "${model}"`);
+    }
+    if (inputs.provider === 'anthropic' && !model.startsWith('claude-')) {
+      throw new ConfigError(`Invalid ${name}: "${model}" is not a Claude model ID (expected claude-*)`);
+    }
+  }
+  if (isNaN(inputs.maxFiles) || inputs.maxFiles < 1) {
+    throw new ConfigError(`Invalid max_files: ${core.getInput('max_files')}`);
+  }
+  if (isNaN(inputs.maxIssuesPerFile)) {
+    throw new ConfigError(`Invalid max_issues_per_file: ${core.getInput('max_issues_per_file')}`);
+  }
+  if (isNaN(inputs.progressInterval) || inputs.progressInterval < 0) {
+    throw new ConfigError(`Invalid progress_interval: ${core.getInput('progress_interval')}`);
+  }
+  if (inputs.tone && !CodeReviewer.SUPPORTED_TONES.includes(inputs.tone)) {
+    throw new ConfigError(`Invalid tone: ${inputs.tone} (supported: ${CodeReviewer.SUPPORTED_TONES.join(', ')})`);
+  }
+  const unknownPersonas = inputs.personas.filter(persona => !CodeReviewer.SUPPORTED_PERSONAS.includes(persona));
+  if (unknownPersonas.length > 0) {
+    throw new ConfigError(`Unknown persona: ${unknownPersonas.join(', ')} (supported: ${CodeReviewer.SUPPORTED_PERSONAS.join(', ')})`);
+  }
+  const unknownPasses = inputs.reviewPasses.filter(pass => !CodeReviewer.SUPPORTED_PASSES.includes(pass));
+  if (unknownPasses.length > 0) {
+    throw new ConfigError(`Unknown review pass: ${unknownPasses.join(', ')} (supported: ${CodeReviewer.SUPPORTED_PASSES.join(', ')})`);
+  }
+  if (inputs.reviewPasses.length > 0 && inputs.personas.length > 0) {
+    throw new ConfigError('persona and review_passes cannot be used together (choose personas or specialized passes)');
+  }
+  const unknownSinks = inputs.plainTextSinks.filter(sink => !PLAIN_TEXT_SINKS.includes(sink));
+  if (unknownSinks.length > 0) {
+    throw new ConfigError(`Unsupported plain_text_sinks: ${unknownSinks.join(', ')} (supported: ${PLAIN_TEXT_SINKS.join(', ')})`);
+  }
+  if (isNaN(inputs.memoryCases) || inputs.memoryCases < 0) {
+    throw new ConfigError(`Invalid memory_cases: ${core.getInput('memory_cases')}`);
+  }
+  if (inputs.commitMessagePattern) {
+    try {
+      new RegExp(inputs.commitMessagePattern);
+    } catch (error) {
+      throw new ConfigError(`Invalid commit_message_pattern: ${error.message}`);
+    }
+  }
+  for (const [name, pattern] of [['pr_title_pattern', inputs.prTitlePattern], ['pr_ticket_pattern', inputs.prTicketPattern]]) {
+    try {
+      new RegExp(pattern || '');
+    } catch (error) {
+      throw new ConfigError(`Invalid ${name}: ${error.message}`);
+    }
+  }
+  if (!['comment', 'body'].includes(inputs.describeMode)) {
+    throw new ConfigError(`Invalid describe_mode: ${inputs.describeMode} (supported: comment, body)`);
+  }
+  if (isNaN(inputs.describeMinLength) || inputs.describeMinLength < 0) {
+    throw new ConfigError(`Invalid describe_min_length: ${core.getInput('describe_min_length')}`);
+  }
+  if (core.getInput('head_ref').trim() && !inputs.baseRef) {
+    throw new ConfigError('head_ref requires base_ref (the commit range to review is base_ref..head_ref)');
+  }
+  if (isNaN(inputs.auditMaxFiles) || inputs.auditMaxFiles < 1) {
+    throw new ConfigError(`Invalid audit_max_files: ${core.getInput('audit_max_files')}`);
+  }
+  if (isNaN(inputs.auditBatchSize) || inputs.auditBatchSize < 1) {
+    throw new ConfigError(`Invalid audit_batch_size: ${core.getInput('audit_batch_size')}`);
+  }
+  if (isNaN(inputs.auditTokenBudget) || inputs.auditTokenBudget < 0) {
+    throw new ConfigError(`Invalid audit_token_budget: ${core.getInput('audit_token_budget')}`);
+  }
+  if (!['summary', 'top', 'full'].includes(inputs.verbosity)) {
+    throw new ConfigError(`Invalid verbosity: ${inputs.verbosity} (supported: summary, top, full)`);
+  }
+  if (!['summary', 'inline', 'none'].includes(inputs.commentMode)) {
+    throw new ConfigError(`Invalid comment_mode: ${inputs.commentMode} (supported: summary, inline, none)`);
+  }
+  if (inputs.conversation && inputs.commentMode === 'none') {
+    throw new ConfigError('conversation requires a review comment (comment_mode: summary or inline); the conversation state is stored in it');
+  }
+  if (inputs.suggestFixes && inputs.commentMode !== 'inline') {
+    throw new ConfigError('suggest_fixes requires comment_mode: inline (suggestion blocks only work in line comments)');
+  }
+  for (const severity of [inputs.severityFilter, ...inputs.repoConfig.sectionSeverities()]) {
+    if (!['low', 'medium', 'high', 'critical'].includes(severity.toLowerCase())) {
+      throw new ConfigError(`Invalid severity_filter: ${severity}`);
+    }
+  }
+  const notifySeverities = [
+    ['slack_min_severity', inputs.slackMinSeverity],
+    ['teams_min_severity', inputs.teamsMinSeverity],
+    ['discord_min_severity', inputs.discordMinSeverity],
+    ['notify_min_severity', inputs.notifyMinSeverity],
+    ['email_min_severity', inputs.emailMinSeverity],
+    ['jira_min_severity', inputs.jiraMinSeverity],
+    ['linear_min_severity', inputs.linearMinSeverity],
+    ['package_fail_severity', inputs.packageFailSeverity],
+    ['check_fail_severity', inputs.checkFailSeverity],
+    ['submit_review_severity', inputs.submitReviewSeverity],
+    ['junit_fail_severity', inputs.junitFailSeverity]
+  ];
+  for (const [name, value] of notifySeverities) {
+    if (!['low', 'medium', 'high', 'critical'].includes(value.toLowerCase())) {
+      throw new ConfigError(`Invalid ${name}: ${value}`);
+    }
+  }
+  if (!ReviewSubmitter.APPROVE_POLICIES.includes(inputs.submitReviewApprove)) {
+    throw new ConfigError(`Invalid submit_review_approve: ${inputs.submitReviewApprove} (supported: ${ReviewSubmitter.APPROVE_POLICIES.join(', ')})`);
+  }
+  if (!['off', 'suggest', 'request'].includes(inputs.suggestReviewers)) {
+    throw new ConfigError(`Invalid suggest_reviewers: ${inputs.suggestReviewers} (supported: off, suggest, request)`);
+  }
+  if (isNaN(inputs.maxReviewers) || inputs.maxReviewers < 1) {
+    throw new ConfigError(`Invalid max_reviewers: ${core.getInput('max_reviewers')}`);
+  }
+  if (inputs.failOnSeverity && !['low', 'medium', 'high', 'critical'].includes(inputs.failOnSeverity)) {
+    throw new ConfigError(`Invalid fail_on_severity: ${inputs.failOnSeverity}`);
+  }
+  if (inputs.maxRiskScore !== null && (!Number.isInteger(inputs.maxRiskScore) || inputs.maxRiskScore < 0 || inputs.maxRiskScore > 100)) {
+    throw new ConfigError(`Invalid max_risk_score: ${core.getInput('max_risk_score')} (expected an integer from 0 to 100)`);
+  }
+  const unknownTargets = inputs.notify.filter(target => !NOTIFY_TARGETS.includes(target));
+  if (unknownTargets.length > 0) {
+    throw new ConfigError(`Unknown notify target: ${unknownTargets.join(', ')} (supported: ${NOTIFY_TARGETS.join(', ')})`);
+  }
+  // 라우팅 규칙에만 등장하는 대상도 전송에 필요한 설정이 있어야 함
+  const routedTargets = inputs.notifyRoutes.flatMap(route => route.targets.map(({ target }) => target));
+  const requiredTargets = new Set([...inputs.notify, ...routedTargets]);
+  if (requiredTargets.has('slack') && !inputs.slackWebhookUrl && !inputs.slackBotToken) {
+    throw new ConfigError('notify includes slack but neither slack_webhook_url nor slack_bot_token is set');
+  }
+  if (inputs.slackBotToken && !inputs.slackChannel) {
+    throw new ConfigError('slack_bot_token requires slack_channel');
+  }
+  if (requiredTargets.has('teams') && !inputs.teamsWebhookUrl) {
+    throw new ConfigError('notify includes teams but teams_webhook_url is not set');
+  }
+  if (requiredTargets.has('discord') && !inputs.discordWebhookUrl) {
+    throw new ConfigError('notify includes discord but discord_webhook_url is not set');
+  }
+  if (requiredTargets.has('url') && !inputs.notifyUrl) {
+    throw new ConfigError('notify includes url but notify_url is not set');
+  }
+  if (inputs.notifyFormat !== 'auto' && !NOTIFIER_FORMATS[inputs.notifyFormat]) {
+    throw new ConfigError(`Invalid notify_format: ${inputs.notifyFormat} (supported: auto, ${Object.keys(NOTIFIER_FORMATS).join(', ')})`);
+  }
+  if (inputs.notifyUrl && inputs.notifyFormat === 'auto' && !detectFormat(inputs.notifyUrl)) {
+    throw new ConfigError('Cannot determine the message format from notify_url; set notify_format');
+  }
+  if (requiredTargets.has('email') && (!inputs.smtpHost || inputs.emailTo.length === 0)) {
+    throw new ConfigError('notify includes email but smtp_host or email_to is not set');
+  }
+  if (requiredTargets.has('webhook') && !inputs.webhookUrl) {
+    throw new ConfigError('notify includes webhook but webhook_url is not set');
+  }
+  if (inputs.smtpHost && (isNaN(inputs.smtpPort) || !inputs.emailFrom)) {
+    throw new ConfigError('Email digest requires a numeric smtp_port and email_from');
+  }
+  if (inputs.jiraBaseUrl && (!inputs.jiraEmail || !inputs.jiraApiToken || !inputs.jiraProjectKey)) {
+    throw new ConfigError('Jira integration requires jira_email, jira_api_token and jira_project_key');
+  }
+  if (inputs.linearApiKey && !inputs.linearTeamKey) {
+    throw new ConfigError('Linear integration requires linear_team_key');
+  }
+
+  return inputs;
+}
+
+/**
+ * 저장소 지도를 만들어 변경 파일의 아키텍처 리뷰 실행 (실패는 경고만)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Array} files - 리뷰 대상 파일
+ * @param {FileAnalyzer} fileAnalyzer - 파일 분석기
+ * @param {CodeReviewer} codeReviewer - 코드 리뷰어
+ * @returns {Promise<Array>} 파일별 결과 (심각도 필터, 지문 적용)
+ */
+async function reviewArchitecture(inputs, files, fileAnalyzer, codeReviewer) {
+  try {
+    const map = await RepoMap.build(fileAnalyzer.git);
+    core.info(`Repository map: ${map.packages.size} packages`);
+    const diffs = await Promise.all(files.map(async file => ({
+      filename: file.filename,
+      diff: file.patch || await fileAnalyzer.getFileDiff(file)
+    })));
+
+    const results = await new ArchitectureReviewer({ codeReviewer, layers: inputs.architectureLayers }).review(map, diffs);
+    const filtered = await finalizeExtraResults(inputs, results, files, fileAnalyzer);
+    core.info(`Architecture review: ${filtered.reduce((sum, result) => sum + result.issues.length, 0)} findings`);
+    return filtered;
+  } catch (error) {
+    core.warning(`Failed to run the architecture review: ${error.message}`);
+    return [];
+  }
+}
+
+/**
+ * 변경된 공개 API/플래그/설정 옵션에 맞춰 갱신되지 않은 문서 검사 (실패는 경고만)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Array} files - 리뷰 대상 파일
+ * @param {Array} changedFiles - PR에서 변경된 모든 파일 (문서 포함)
+ * @param {FileAnalyzer} fileAnalyzer - 파일 분석기
+ * @returns {Promise<Array>} 파일별 결과 (심각도 필터, 지문 적용)
+ */
+async function detectDocDrift(inputs, files, changedFiles, fileAnalyzer) {
+  try {
+    const trackedFiles = (await fileAnalyzer.git.raw(['ls-files'])).split('\n').filter(Boolean);
+    const diffs = await Promise.all(files.map(async file => ({
+      filename: file.filename,
+      diff: file.patch || await fileAnalyzer.getFileDiff(file)
+    })));
+
+    const results = new DocDriftDetector({ docPatterns: inputs.docPaths })
+      .detect(diffs, changedFiles.map(file => file.filename), trackedFiles);
+    const filtered = await finalizeExtraResults(inputs, results, files, fileAnalyzer);
+    core.info(`Documentation drift: ${filtered.reduce((sum, result) => sum + result.issues.length, 0)} findings`);
+    return filtered;
+  } catch (error) {
+    core.warning(`Failed to check documentation drift: ${error.message}`);
+    return [];
+  }
+}
+
+/**
+ * 파일별 리뷰 밖에서 만든 결과에 심각도 필터, 코드 조각, 지문 적용
+ * @param {Object} inputs - 액션 입력값
+ * @param {Array} results - 파일별 결과
+ * @param {Array} files - 리뷰 대상 파일
+ * @param {FileAnalyzer} fileAnalyzer - 파일 분석기
+ * @returns {Promise<Array>} 이슈가 남은 결과
+ */
+async function finalizeExtraResults(inputs, results, files, fileAnalyzer) {
+  for (const result of results) {
+    const content = await fileAnalyzer.getFileContent(files.find(file => file.filename === result.file));
+    result.issues = result.issues.filter(issue =>
+      getSeverityLevel(issue.severity) >= getSeverityLevel(inputs.severityFilter)
+    );
+    result.issues.forEach(issue => {
+      issue.snippet = extractSnippet(content, issue.line);
+      issue.fingerprint = fingerprintFinding(result.file, issue);
+    });
+  }
+  return results.filter(result => result.issues.length > 0);
+}
+
+/**
+ * 추가 리뷰 결과를 같은 파일의 결과에 합치거나 새 결과로 추가
+ * @param {Array} reviewResults - 파일별 리뷰 결과 (수정됨)
+ * @param {Array} extraResults - 추가 결과
+ */
+function mergeExtraResults(reviewResults, extraResults) {
+  extraResults.forEach(result => {
+    const existing = reviewResults.find(item => item.file === result.file);
+    if (existing) {
+      existing.issues.push(...result.issues);
+    } else {
+      reviewResults.push(result);
+    }
+  });
+}
+
+/**
+ * base와 head의 Go 공개 API를 비교해 호환성 검토 (실패는 경고만)
+ * @param {Object} context - GitHub 컨텍스트
+ * @param {FileAnalyzer} fileAnalyzer - 파일 분석기 (git 사용)
+ * @param {CodeReviewer} codeReviewer - 코드 리뷰어
+ * @returns {Promise<Object|null>} { changes, requiresMajor, majorBumped } (API 변경이 없으면 null)
+ */
+async function reviewGoApi(context, fileAnalyzer, codeReviewer) {
+  try {
+    const compatibility = await new GoApiReviewer({ codeReviewer, git: fileAnalyzer.git }).review(context);
+    const breaking = compatibility ? compatibility.changes.filter(change => change.breaking).length : 0;
+    core.info(`Go API compatibility: ${compatibility ? compatibility.changes.length : 0} exported API changes, ${breaking} breaking`);
+    core.setOutput('api_breaking_changes', breaking);
+    return compatibility;
+  } catch (error) {
+    core.warning(`Failed to review Go API compatibility: ${error.message}`);
+    return null;
+  }
+}
+
+/**
+ * 변경된 의존성 매니페스트의 의존성 검토 (실패는 경고만)
+ * @param {Object} context - GitHub 컨텍스트
+ * @param {Array} changedFiles - 변경된 전체 파일 (필터링 전)
+ * @param {FileAnalyzer} fileAnalyzer - 파일 분석기 (git 사용)
+ * @param {CodeReviewer} codeReviewer - 코드 리뷰어
+ * @param {LicensePolicy|null} [licensePolicy] - 저장소 라이선스 (지정하면 의존성 라이선스 호환성도 검토)
+ * @returns {Promise<Object|null>} { summary, changes } (의존성 변경이 없으면 null)
+ */
+async function reviewDependencies(context, changedFiles, fileAnalyzer, codeReviewer, licensePolicy = null) {
+  try {
+    const dependencies = await new DependencyReviewer({
+      codeReviewer,
+      git: fileAnalyzer.git,
+      checkLicenses: Boolean(licensePolicy),
+      projectLicense: licensePolicy ? licensePolicy.license : null
+    })
+      .review(context, changedFiles.map(file => file.filename));
+    const changes = dependencies ? dependencies.changes : [];
+    const risky = changes.filter(change => change.risk === 'high').length;
+    core.info(`Dependency review: ${changes.length} dependency changes, ${changes.filter(change => change.major).length} major updates, ${risky} high risk`);
+    core.setOutput('dependency_changes', changes.length);
+    core.setOutput('risky_dependencies', risky);
+    return dependencies;
+  } catch (error) {
+    core.warning(`Failed to review dependency changes: ${error.message}`);
+    return null;
+  }
+}
+
+/**
+ * PR 커밋 메시지 리뷰 (실패는 경고만)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트
+ * @param {Array} changedFiles - 변경된 전체 파일
+ * @param {CodeReviewer} codeReviewer - 코드 리뷰어
+ * @returns {Promise<Array>} 문제가 있는 커밋 목록
+ */
+async function reviewCommitMessages(inputs, context, changedFiles, codeReviewer) {
+  try {
+    const reviewer = new CommitMessageReviewer({
+      codeReviewer,
+      octokit: github.getOctokit(inputs.githubToken),
+      context,
+      pattern: inputs.commitMessagePattern
+    });
+    const findings = await reviewer.review(changedFiles.map(file => file.filename));
+    core.info(`Commit message review: ${findings.length} commits need attention`);
+    core.setOutput('commit_issues', findings.length);
+    return findings;
+  } catch (error) {
+    core.warning(`Failed to review commit messages: ${error.message}`);
+    return [];
+  }
+}
+
+/**
+ * PR 제목/설명 규칙 검사 (실패는 경고만)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트
+ * @param {Array} changedFiles - 변경된 전체 파일
+ * @param {CodeReviewer} codeReviewer - 코드 리뷰어
+ * @returns {Promise<Object|null>} 규칙 위반과 제안 (위반이 없으면 null)
+ */
+async function checkPrConventions(inputs, context, changedFiles, codeReviewer) {
+  try {
+    const checker = new PrConventionChecker({
+      codeReviewer,
+      context,
+      titlePattern: inputs.prTitlePattern,
+      requiredSections: inputs.prRequiredSections,
+      ticketPattern: inputs.prTicketPattern
+    });
+    const result = await checker.check(changedFiles.map(file => file.filename));
+    const problems = result ? result.problems.length : 0;
+    core.info(`PR convention check: ${problems} problems`);
+    core.setOutput('pr_convention_issues', problems);
+    return result;
+  } catch (error) {
+    core.warning(`Failed to check PR conventions: ${error.message}`);
+    return null;
+  }
+}
+
+/**
+ * 변경 로그 항목 제안 (실패는 경고만)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트
+ * @param {Array} changedFiles - 변경된 전체 파일 (필터링 전)
+ * @param {FileAnalyzer} fileAnalyzer - 파일 분석기 (patch가 없는 파일의 diff 조회용)
+ * @param {CodeReviewer} codeReviewer - 코드 리뷰어
+ * @returns {Promise<Object|null>} 제안 항목 (제안할 항목이 없으면 null)
+ */
+async function suggestChangelogEntry(inputs, context, changedFiles, fileAnalyzer, codeReviewer) {
+  try {
+    const suggester = new ChangelogSuggester({
+      codeReviewer,
+      octokit: github.getOctokit(inputs.githubToken),
+      context,
+      changelogPath: inputs.changelogPath
+    });
+    const files = await Promise.all(changedFiles.map(async file => ({
+      filename: file.filename,
+      status: file.status,
+      patch: file.patch,
+      diff: file.patch || await fileAnalyzer.getFileDiff(file)
+    })));
+    const suggestion = await suggester.suggest(files);
+    if (!suggestion) {
+      core.info('Changelog: no entry needed (no user-facing change or already recorded)');
+      return null;
+    }
+    core.info(`Changelog entry suggested (${suggestion.category}): ${suggestion.entry}`);
+    core.setOutput('changelog_entry', `${suggestion.category}: ${suggestion.entry}`);
+
+    if (inputs.changelogSuggestion && suggestion.path) {
+      suggestion.url = await suggester.postSuggestion(suggestion, changedFiles.find(file => file.filename === suggestion.path));
+      core.info(suggestion.url
+        ? `Changelog suggestion posted: ${suggestion.url}`
+        : `Changelog suggestion not posted: ${suggestion.path} insertion point is outside the PR diff`);
+    }
+    return suggestion;
+  } catch (error) {
+    core.warning(`Failed to suggest a changelog entry: ${error.message}`);
+    return null;
+  }
+}
+
+/**
+ * PR 설명 초안을 만들어 제안 댓글로 게시하거나 (본문이 충분하면 건너뜀) PR 본문의 관리 영역에 작성 (실패는 경고만)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트
+ * @param {Array} changedFiles - 변경된 전체 파일 (필터링 전)
+ * @param {FileAnalyzer} fileAnalyzer - 파일 분석기 (patch가 없는 파일의 diff 조회용)
+ * @param {CodeReviewer} codeReviewer - 코드 리뷰어
+ */
+async function suggestDescription(inputs, context, changedFiles, fileAnalyzer, codeReviewer) {
+  const pullRequest = context.payload.pull_request;
+  const describer = new PrDescriber({
+    codeReviewer,
+    octokit: github.getOctokit(inputs.githubToken),
+    context,
+    minBodyLength: inputs.describeMinLength
+  });
+  // body 방식은 관리 영역만 다시 쓰므로 본문 길이와 관계없이 매번 갱신
+  if (inputs.describeMode === 'comment' && !describer.shouldDescribe(pullRequest)) {
+    core.info('PR description is long enough; skipping description suggestion');
+    return;
+  }
+
+  try {
+    const files = await Promise.all(changedFiles.map(async file => ({
+      filename: file.filename,
+      status: file.status,
+      diff: file.patch || await fileAnalyzer.getFileDiff(file)
+    })));
+    const description = await describer.describe(pullRequest, files);
+    if (inputs.describeMode === 'body') {
+      const updated = await describer.updateBody(PrDescriber.buildSection(description));
+      core.info(`PR description section ${updated.action}: ${updated.url}`);
+      return;
+    }
+    const comment = await describer.publish(PrDescriber.buildComment(description));
+    core.info(`PR description suggestion ${comment.action}: ${comment.url}`);
+  } catch (error) {
+    core.warning(`Failed to ${inputs.describeMode === 'body' ? 'update the PR description' : 'suggest a PR description'}: ${error.message}`);
   }
 }
 
 /**
- * 심각도 레벨을 숫자로 변환
- * @param {string} severity - 심각도 문자열 (low, medium, high, critical)
- * @returns {number} 심각도 레벨 (1-4)
+ * 이슈를 담당 팀에 배정하고 팀별 스레드 게시 (실패는 경고만)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트 (pull_request 이벤트)
+ * @param {Array} reviewResults - 파일별 리뷰 결과
  */
-function getSeverityLevel(severity) {
-  const levels = { 
-    low: 1, 
-    medium: 2, 
-    high: 3, 
-    critical: 4 
-  };
-  return levels[severity.toLowerCase()] || 1;
+async function notifyTeams(inputs, context, reviewResults) {
+  try {
+    const router = new TeamRouter({
+      routes: inputs.teamRoutes,
+      codeowners: await Codeowners.load(inputs.codeownersPath),
+      labels: inputs.displayLabels
+    });
+    const teams = router.route(reviewResults);
+    const { created, updated, resolved } = await router.publish(github.getOctokit(inputs.githubToken), context, teams);
+    core.info(`Team threads: ${teams.size} teams (created ${created.length}, updated ${updated.length}, resolved ${resolved.length})`);
+  } catch (error) {
+    core.warning(`Failed to post team threads: ${error.message}`);
+  }
+}
+
+/**
+ * 릴리즈 노트를 생성해 릴리즈에 붙이고 출력값 설정
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트 (release 또는 태그 push 이벤트)
+ * @param {CodeReviewer} codeReviewer - 코드 리뷰어
+ */
+async function publishReleaseNotes(inputs, context, codeReviewer) {
+  const generator = new ReleaseNotesGenerator({
+    codeReviewer,
+    octokit: github.getOctokit(inputs.githubToken),
+    context
+  });
+  const release = await generator.generate();
+  core.info(`Release notes for ${release.previousTag}...${release.tag}: ${release.commits.length} commits, ${release.contributors.length} contributors`);
+
+  const notes = ReleaseNotesGenerator.buildNotes(release, context.repo);
+  const published = await generator.attach(release.tag, notes);
+  core.info(`Release ${published.action}: ${published.url}`);
+  core.setOutput('release_notes', notes);
+  core.setOutput('release_url', published.url);
+}
+
+/**
+ * PR 위험도 계산, 출력값 설정, 라벨 적용 (라벨 실패는 경고만)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Array} changedFiles - 변경된 전체 파일 (필터링 전)
+ * @param {Array} reviewResults - 파일별 리뷰 결과
+ * @param {Object} context - GitHub 컨텍스트
+ * @param {CommentManager} commentManager - 댓글 관리자 (라벨 적용용)
+ * @returns {Promise<Object>} 위험도 { score, level, factors }
+ */
+async function publishRisk(inputs, changedFiles, reviewResults, context, commentManager) {
+  const risk = new RiskScorer({ riskPaths: inputs.riskPaths }).score(changedFiles, reviewResults);
+  core.info(`Risk score: ${risk.score}/100 (${risk.level}) — ${risk.factors.map(factor => `${factor.name} +${factor.points}: ${factor.detail}`).join('; ')}`);
+  core.setOutput('risk_score', risk.score);
+  core.setOutput('risk_level', risk.level);
+
+  if (inputs.riskLabel && context.eventName === 'pull_request') {
+    try {
+      await commentManager.applyRiskLabel(risk.level);
+    } catch (error) {
+      core.warning(error.message);
+    }
+  }
+  return risk;
+}
+
+/**
+ * 이슈 타입, 심각도, 위험도 규칙에 따라 PR 라벨 갱신 (실패는 경고만)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트 (pull_request 이벤트)
+ * @param {Array} reviewResults - 파일별 리뷰 결과
+ * @param {string} riskLevel - PR 위험도 등급
+ * @param {boolean} partial - 증분 리뷰 여부 (라벨을 제거하지 않음)
+ */
+async function applyFindingLabels(inputs, context, reviewResults, riskLevel, partial) {
+  try {
+    const labeler = new FindingLabeler(github.getOctokit(inputs.githubToken), context, inputs.findingLabelRules);
+    const { added, removed } = await labeler.apply(reviewResults, riskLevel, { partial });
+    core.info(`Finding labels: ${added.length > 0 ? `added ${added.join(', ')}` : 'none added'}${removed.length > 0 ? `, removed ${removed.join(', ')}` : ''}`);
+    core.setOutput('applied_labels', labeler.labelsFor(reviewResults, riskLevel).join(','));
+  } catch (error) {
+    core.warning(`Failed to apply finding labels: ${error.message}`);
+  }
+}
+
+/**
+ * 후속 워크플로우 단계용 이슈 목록 파일과 심각도별 개수 출력값 설정
+ * @param {Object} summary - buildReviewSummary() 결과
+ * @param {string|number} runId - 워크플로우 실행 ID
+ * @returns {string} 이슈 목록 파일 경로
+ */
+function publishFindings(summary, runId) {
+  const findingsPath = writeFindingsFile(summary, runId);
+  core.setOutput('findings_path', findingsPath);
+  core.setOutput('total_findings', summary.totalIssues.toString());
+  ['critical', 'high', 'medium', 'low'].forEach(severity => {
+    core.setOutput(`${severity}_count`, summary.counts[severity].toString());
+  });
+  return findingsPath;
+}
+
+/**
+ * SARIF 리포트 작성, 출력값 설정, Code Scanning 업로드 (업로드 실패는 경고만)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트
+ * @param {Object} summary - buildReviewSummary() 결과
+ * @returns {Promise<string|null>} SARIF 파일 경로 (sarif가 꺼져 있으면 null)
+ */
+async function publishSarif(inputs, context, summary) {
+  if (!inputs.sarif) {
+    return null;
+  }
+  const sarifPath = writeSarifReport(summary, context.runId, inputs.referenceLinks);
+  core.setOutput('sarif_path', sarifPath);
+
+  if (inputs.sarifUpload) {
+    try {
+      const uploadId = await uploadSarif(github.getOctokit(inputs.githubToken), context, sarifPath);
+      core.info(`SARIF uploaded to Code Scanning (upload ${uploadId})`);
+      core.setOutput('sarif_upload_id', uploadId);
+    } catch (error) {
+      core.warning(`SARIF upload failed (needs security-events: write): ${error.message}`);
+    }
+  }
+  return sarifPath;
+}
+
+/**
+ * Job Summary($GITHUB_STEP_SUMMARY)에 리뷰 리포트 작성 (실패는 경고만)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} summary - buildReviewSummary() 결과
+ * @param {number} filesReviewed - 리뷰한 파일 수
+ * @param {CodeReviewer} codeReviewer - 코드 리뷰어 (토큰 사용량)
+ * @param {number} durationMs - 실행 시간
+ * @returns {Promise<void>}
+ */
+async function writeJobSummary(inputs, summary, filesReviewed, codeReviewer, durationMs) {
+  try {
+    const markdown = buildJobSummary({
+      summary,
+      filesReviewed,
+      usageByModel: codeReviewer.usageByModel,
+      batch: codeReviewer.batch,
+      durationMs,
+      labels: inputs.displayLabels
+    });
+    await core.summary.addRaw(markdown).write();
+  } catch (error) {
+    core.warning(`Failed to write job summary: ${error.message}`);
+  }
+}
+
+/**
+ * 리뷰 시작 시 in_progress Check Run 생성 (실패하면 경고만 남기고 완료 시 새로 생성)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트
+ * @param {number} totalFiles - 리뷰할 파일 수
+ * @returns {Promise<CheckRunPublisher|null>} Check Run을 만든 게시자 (check_run 미사용 또는 실패 시 null)
+ */
+async function startCheckRun(inputs, context, totalFiles) {
+  if (!inputs.checkRun) {
+    return null;
+  }
+  try {
+    const publisher = new CheckRunPublisher(github.getOctokit(inputs.githubToken), context, {
+      failSeverity: inputs.checkFailSeverity
+    });
+    const checkRun = await publisher.start(totalFiles);
+    core.info(`Check run "${publisher.name}" started: ${checkRun.url}`);
+    return publisher;
+  } catch (error) {
+    core.warning(`Failed to start check run (needs checks: write): ${error.message}`);
+    return null;
+  }
+}
+
+/**
+ * Check Run 게시 및 출력값 설정 (게시 실패는 경고만)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트
+ * @param {Array} reviewResults - 파일별 리뷰 결과
+ * @param {number} filesReviewed - 리뷰한 파일 수
+ * @param {CheckRunPublisher} [started] - startCheckRun()으로 만든 게시자 (있으면 그 Check Run을 완료)
+ * @returns {Promise<void>}
+ */
+async function publishCheckRun(inputs, context, reviewResults, filesReviewed, started = null) {
+  if (!inputs.checkRun) {
+    return;
+  }
+  try {
+    const publisher = started || new CheckRunPublisher(github.getOctokit(inputs.githubToken), context, {
+      failSeverity: inputs.checkFailSeverity
+    });
+    const checkRun = await publisher.publish(reviewResults, filesReviewed);
+    core.info(`Check run "${publisher.name}" completed with ${checkRun.conclusion}: ${checkRun.url}`);
+    core.setOutput('check_run_url', checkRun.url);
+    core.setOutput('check_conclusion', checkRun.conclusion);
+  } catch (error) {
+    core.warning(`Failed to create check run (needs checks: write): ${error.message}`);
+  }
+}
+
+/**
+ * 정책에 따라 승인 또는 변경 요청 리뷰 제출 (실패는 경고만)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트 (pull_request 이벤트)
+ * @param {Array} reviewResults - 파일별 리뷰 결과
+ * @param {boolean} partial - 증분 리뷰 여부 (승인하지 않음)
+ */
+async function submitReviewVerdict(inputs, context, reviewResults, partial) {
+  const submitter = new ReviewSubmitter(github.getOctokit(inputs.githubToken), context, {
+    requestChangesSeverity: inputs.submitReviewSeverity,
+    approve: inputs.submitReviewApprove
+  });
+  try {
+    const review = await submitter.submit(reviewResults, { partial });
+    core.setOutput('review_verdict', review.event);
+    core.info(review.url ? `Submitted ${review.event} review: ${review.url}` : 'Review verdict: no approval or change request to submit');
+  } catch (error) {
+    core.warning(`Failed to submit the review verdict (approving needs "Allow GitHub Actions to create and approve pull requests"): ${error.message}`);
+  }
+}
+
+/**
+ * 이슈 위치로 리뷰어를 추천하고 request 모드면 리뷰 요청 (실패는 경고만)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트 (pull_request 이벤트)
+ * @param {Array} reviewResults - 파일별 리뷰 결과
+ * @returns {Promise<Array>} 추천 리뷰어 [{ reviewer, score, reasons, requested }]
+ */
+async function suggestReviewers(inputs, context, reviewResults) {
+  try {
+    const suggester = new ReviewerSuggester({
+      octokit: github.getOctokit(inputs.githubToken),
+      context,
+      routes: inputs.reviewerRoutes,
+      codeowners: await Codeowners.load(inputs.codeownersPath),
+      maxReviewers: inputs.maxReviewers
+    });
+    const suggestions = await suggester.suggest(reviewResults);
+    core.setOutput('suggested_reviewers', suggestions.map(item => item.reviewer).join(','));
+    if (suggestions.length === 0) {
+      core.info('Reviewer suggestion: no candidates for the findings');
+      return [];
+    }
+
+    const requested = inputs.suggestReviewers === 'request' ? await suggester.request(suggestions) : [];
+    core.info(`Suggested reviewers: ${suggestions.map(item => item.reviewer).join(', ')}${requested.length > 0 ? ` (requested ${requested.join(', ')})` : ''}`);
+    return suggestions.map(item => ({ ...item, requested: requested.includes(item.reviewer) }));
+  } catch (error) {
+    core.warning(`Failed to suggest reviewers: ${error.message}`);
+    return [];
+  }
+}
+
+/**
+ * 저장소 전체 보안 감사 실행 및 다이제스트 이슈 갱신
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트
+ * @param {CodeReviewer} codeReviewer - 코드 리뷰어
+ * @returns {Promise<Object>} { filesAudited, openFindings }
+ */
+async function runAudit(inputs, context, codeReviewer) {
+  // 지난 감사의 기준선과 커서는 열린 다이제스트 이슈 본문에 저장되어 있음
+  const digest = new AuditDigest(github.getOctokit(inputs.githubToken), context.repo, {
+    label: inputs.auditIssueLabel,
+    labels: inputs.displayLabels
+  });
+  const issue = await digest.findIssue();
+  const auditor = new RepoAuditor({
+    fileAnalyzer: new FileAnalyzer({ ...inputs, maxFiles: Infinity }),
+    codeReviewer,
+    // 배치 실행 모드는 감사 대상 전체를 한 Message Batch로 보냄
+    batchSize: inputs.executionMode === 'batch' ? inputs.auditMaxFiles : inputs.auditBatchSize,
+    tokenBudget: inputs.auditTokenBudget,
+    severityFilter: inputs.severityFilter,
+    maxFiles: inputs.auditMaxFiles,
+    resumeAfter: AuditDigest.parseCursor(issue && issue.body)
+  });
+  const audit = await auditor.run();
+
+  // 무시/일시 중지한 이슈는 다이제스트에서도 제외
+  const suppressions = await SuppressionStore.loadFile(inputs.suppressionsPath);
+  const { results } = suppressions.filterResults(audit.results);
+  // 기준선 생성: 저장소 전체의 현재 이슈로 기준선 파일을 다시 씀 (다이제스트는 기준선과 관계없이 모든 이슈를 추적)
+  if (inputs.baselineUpdate) {
+    const baseline = FindingBaseline.fromResults(results);
+    core.setOutput('baseline_path', await baseline.write(inputs.baselinePath));
+    core.info(`Wrote baseline of ${baseline.findings.length} findings to ${inputs.baselinePath} (commit it so later reviews report only new findings)`);
+  }
+
+  const runMetadata = buildRunMetadata({ context, inputs, codeReviewer });
+  const summary = buildReviewSummary(results, context, runMetadata);
+  const reportPath = writeJsonReport(summary, context.runId);
+  core.setOutput('report_path', reportPath);
+  publishFindings(summary, context.runId);
+  if (inputs.htmlReport) {
+    core.setOutput('html_report_path', writeHtmlReport(summary, context.runId, { labels: inputs.displayLabels }));
+  }
+  if (inputs.junit) {
+    core.setOutput('junit_path', writeJunitReport(summary, context.runId, inputs.junitFailSeverity));
+  }
+  await publishSarif(inputs, context, summary);
+
+  const comparison = AuditDigest.compare(AuditDigest.parseBaseline(issue && issue.body), results, audit.auditedFiles);
+  const openFindings = comparison.added.length + comparison.persisting.length;
+  const body = digest.buildBody(comparison, {
+    auditedFiles: audit.auditedFiles,
+    skippedFiles: audit.skippedFiles,
+    totalFiles: audit.totalFiles,
+    cursor: audit.cursor,
+    runUrl: context.runId ? `${context.serverUrl}/${context.repo.owner}/${context.repo.repo}/actions/runs/${context.runId}` : null,
+    date: new Date().toISOString()
+  });
+  const published = await digest.publish(issue, body, openFindings);
+
+  if (published) {
+    core.info(`Audit digest issue #${published.number} ${published.action}: ${published.url}`);
+    core.setOutput('audit_issue_url', published.url);
+  }
+  core.setOutput('audit_new_findings', comparison.added.length.toString());
+  core.setOutput('audit_fixed_findings', comparison.fixed.length.toString());
+  core.setOutput('issues_found', openFindings.toString());
+  core.setOutput('files_reviewed', audit.auditedFiles.length.toString());
+  core.setOutput('run_metadata', JSON.stringify(runMetadata));
+  core.info(`Audit completed: ${comparison.added.length} new, ${comparison.persisting.length} persisting, ${comparison.fixed.length} fixed`);
+
+  return { filesAudited: audit.auditedFiles.length, openFindings };
+}
+
+/**
+ * 기준선 적용: 기준선에 있는 이슈를 제외하거나, baseline_update면 현재 이슈를 기준선에 추가해 파일로 작성
+ * @param {Object} inputs - 입력값
+ * @param {Array} reviewResults - 억제 후 리뷰 결과
+ * @returns {Promise<Array>} 보고할 리뷰 결과
+ */
+async function applyBaseline(inputs, reviewResults) {
+  const baseline = await FindingBaseline.load(inputs.baselinePath);
+  if (inputs.baselineUpdate) {
+    const updated = baseline.withResults(reviewResults);
+    core.setOutput('baseline_path', await updated.write(inputs.baselinePath));
+    core.info(`Added ${updated.findings.length - baseline.findings.length} findings to ${inputs.baselinePath} (commit it so later reviews skip them)`);
+    return reviewResults;
+  }
+
+  const { results, baselinedCount } = baseline.filterResults(reviewResults);
+  core.setOutput('baselined_findings', baselinedCount.toString());
+  if (baselinedCount > 0) {
+    core.info(`Skipped ${baselinedCount} pre-existing findings recorded in ${inputs.baselinePath}`);
+  }
+  return results;
+}
+
+/**
+ * 심각도 게이트: 기준 이상의 이슈가 있으면 종료 코드 1로 실패 처리 (브랜치 보호로 병합 차단용)
+ * @param {string} failOnSeverity - 실패로 처리할 최소 심각도
+ * @param {Array} reviewResults - 필터링과 억제 후 리뷰 결과
+ * @returns {number} 기준 이상의 이슈 개수
+ */
+function enforceSeverityGate(failOnSeverity, reviewResults) {
+  const threshold = getSeverityLevel(failOnSeverity);
+  const blocking = reviewResults.reduce(
+    (sum, result) => sum + result.issues.filter(issue => getSeverityLevel(issue.severity) >= threshold).length,
+    0
+  );
+  core.setOutput('blocking_issues', blocking.toString());
+  if (blocking > 0) {
+    core.setOutput('failure_reason', 'findings');
+    core.setFailed(`Review gate failed: ${blocking} findings at or above ${failOnSeverity} severity (fail_on_severity)`);
+    process.exitCode = EXIT_CODES.findings;
+  }
+  return blocking;
+}
+
+/**
+ * 위험도 게이트: 위험도 점수가 기준을 넘으면 단계를 실패 처리
+ * @param {number} maxRiskScore - 허용하는 최대 위험도 점수
+ * @param {Object} risk - 위험도 { score, level, factors }
+ * @returns {boolean} 게이트 통과 여부
+ */
+function enforceRiskGate(maxRiskScore, risk) {
+  if (risk.score <= maxRiskScore) {
+    return true;
+  }
+  core.setOutput('failure_reason', 'risk');
+  core.setFailed(`Risk gate failed: risk score ${risk.score} exceeds ${maxRiskScore} (max_risk_score)`);
+  process.exitCode = EXIT_CODES.findings;
+  return false;
+}
+
+/**
+ * 저장소에 정의한 리뷰 타입 로드 (알 수 없는 review_type은 설정 오류)
+ * @param {Object} inputs - 입력값
+ * @returns {Promise<CustomReviewTypes>} 사용자 정의 리뷰 타입
+ */
+async function loadCustomReviewTypes(inputs) {
+  const customTypes = await CustomReviewTypes.load(inputs.reviewTypesDir);
+  if (customTypes.names().length > 0) {
+    core.info(`Custom review types from ${customTypes.source}: ${customTypes.names().join(', ')}`);
+  }
+  const known = type => CustomReviewTypes.BUILTIN_REVIEW_TYPES.includes(type) || customTypes.has(type);
+  const unknownModelType = Object.keys(inputs.typeModels).find(type => !known(type));
+  if (unknownModelType) {
+    throw new ConfigError(`Unknown review type "${unknownModelType}" in type_models (built in: ${CustomReviewTypes.BUILTIN_REVIEW_TYPES.join(', ')}${customTypes.names().length > 0 ? `; custom: ${customTypes.names().join(', ')}` : ''})`);
+  }
+  const unknown = [inputs.reviewType, ...inputs.repoConfig.sectionReviewTypes()].find(type => !known(type));
+  if (unknown) {
+    throw new ConfigError(`Unknown review_type "${unknown}" (built in: ${CustomReviewTypes.BUILTIN_REVIEW_TYPES.join(', ')}${customTypes.names().length > 0 ? `; custom: ${customTypes.names().join(', ')}` : ''})`);
+  }
+  return customTypes;
+}
+
+/**
+ * 파일로 지정한 설정까지 포함한 전체 설정 검증 (환경 점검용)
+ * @returns {Promise<Object>} 입력값 객체
+ */
+async function loadConfig() {
+  const inputs = readInputs();
+  if (inputs.glossaryPath) {
+    await loadGlossary(inputs.glossaryPath);
+  }
+  await loadCommentTemplates(inputs);
+  await PromptTemplates.load(inputs.promptTemplatesDir);
+  await loadCustomReviewTypes(inputs);
+  await FindingBaseline.load(inputs.baselinePath);
+  if (inputs.memoryCases > 0) {
+    await loadReviewMemory(inputs);
+  }
+  return inputs;
+}
+
+/**
+ * 환경 점검 실행 및 결과 출력 (실패 항목이 있으면 액션 실패 처리)
+ * @returns {Promise<Array>} 점검 결과
+ */
+async function runDoctor() {
+  const checks = await new Doctor({ loadConfig }).run();
+  core.info(Doctor.format(checks));
+  if (checks.some(check => check.status === 'fail')) {
+    core.setFailed('Environment check failed. Fix the items marked ❌ above.');
+  }
+  return checks;
+}
+
+/**
+ * 용어집 로드 (형식 오류는 설정 오류로 처리)
+ * @param {string} glossaryPath - 용어집 파일 경로
+ * @returns {Promise<Glossary>} 용어집
+ */
+async function loadGlossary(glossaryPath) {
+  try {
+    return await Glossary.loadFile(glossaryPath);
+  } catch (error) {
+    throw new ConfigError(error.message);
+  }
+}
+
+/**
+ * i18n 리뷰용 프레임워크와 번역 카탈로그 로드 (실패하면 카탈로그 없이 리뷰)
+ * @param {Object} inputs - 액션 입력값
+ * @param {FileAnalyzer} fileAnalyzer - 파일 분석기 (git 사용)
+ * @returns {Promise<I18nCatalog|null>} 카탈로그
+ */
+async function loadI18nCatalog(inputs, fileAnalyzer) {
+  try {
+    const catalog = await I18nCatalog.load(fileAnalyzer.git, inputs.i18nCatalogs);
+    core.info(`i18n: framework ${catalog.framework || 'not detected'}, ${catalog.catalogs.length} catalogs (default locale: ${catalog.defaultLocale || 'unknown'})`);
+    return catalog;
+  } catch (error) {
+    core.warning(`Failed to load i18n catalogs: ${error.message}`);
+    return null;
+  }
+}
+
+/**
+ * PR 댓글의 /claude review 명령 처리 (명령이 아니거나 실행할 수 없으면 null)
+ * 명령 옵션(--type, --severity)은 입력값을 덮어씀
+ * @param {Object} inputs - 액션 입력값 (명령 옵션으로 수정됨)
+ * @param {Object} context - GitHub 컨텍스트 (issue_comment 이벤트)
+ * @returns {Promise<Object|null>} 명령 댓글의 PR에 대한 pull_request 이벤트 컨텍스트
+ */
+async function handleReviewCommand(inputs, context) {
+  const octokit = github.getOctokit(inputs.githubToken);
+  let command;
+  try {
+    command = await ReviewCommand.load(octokit, context);
+  } catch (error) {
+    core.warning(error.message);
+    return null;
+  }
+  if (!command) {
+    core.info('Comment is not a /claude review command on a pull request, skipping');
+    return null;
+  }
+
+  Object.assign(inputs, command.options);
+  core.info(`/claude review by @${command.comment.user.login}${Object.keys(command.options).length > 0 ? ` (${Object.entries(command.options).map(([key, value]) => `${key}=${value}`).join(', ')})` : ''}`);
+  return command.pullRequestContext(octokit, context);
+}
+
+/**
+ * 리뷰 댓글 스레드의 질문이나 후속 답글에 답변 (답변할 댓글이 아니거나 권한이 없으면 건너뜀)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트 (pull_request_review_comment 이벤트)
+ * @param {CodeReviewer} codeReviewer - 코드 리뷰어
+ */
+async function answerThreadQuestion(inputs, context, codeReviewer) {
+  const explainer = new ThreadExplainer({
+    codeReviewer,
+    octokit: github.getOctokit(inputs.githubToken),
+    context,
+    followUps: inputs.threadFollowUps
+  });
+  const { question, root, skipped } = await explainer.load();
+  if (skipped) {
+    core.info(`Skipping review comment: ${skipped}`);
+    return;
+  }
+
+  core.info(`Answering a question on ${root.path}:${root.line || root.original_line} (thread ${root.id})`);
+  const answer = await explainer.answer(root, question);
+  core.info(`Answer posted: ${await explainer.reply(root, answer)}`);
+}
+
+/**
+ * 마지막으로 리뷰한 커밋 이후의 변경분 계산 (실패하면 PR 전체를 리뷰)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트 (pull_request 이벤트)
+ * @returns {Promise<IncrementalReview|null>} 변경분
+ */
+async function loadIncrementalReview(inputs, context) {
+  try {
+    const incremental = await IncrementalReview.load(github.getOctokit(inputs.githubToken), context);
+    if (incremental.isIncremental()) {
+      core.info(`Incremental review: ${incremental.files.length} files changed since ${incremental.base.substring(0, 7)}`);
+      core.setOutput('incremental_base', incremental.base);
+    } else {
+      core.info(`Incremental review: reviewing the whole pull request (${incremental.reason})`);
+    }
+    return incremental;
+  } catch (error) {
+    core.warning(`Failed to compute the incremental diff, reviewing the whole pull request: ${error.message}`);
+    return null;
+  }
+}
+
+/**
+ * 이 PR의 이전 리뷰 대화와 새 메인테이너 답글 로드 (실패하면 대화 없이 리뷰)
+ * @param {Object} inputs - 액션 입력값
+ * @param {Object} context - GitHub 컨텍스트 (pull_request 이벤트)
+ * @returns {Promise<ConversationStore|null>} 대화 상태
+ */
+async function loadConversation(inputs, context) {
+  try {
+    const conversation = await ConversationStore.load(github.getOctokit(inputs.githubToken), context);
+    const findings = Object.values(conversation.state.findings);
+    core.info(`Conversation: round ${conversation.state.rounds.length + 1}, ${findings.length} tracked findings, ${conversation.activity.size} with new replies`);
+    return conversation;
+  } catch (error) {
+    core.warning(`Failed to load the review conversation: ${error.message}`);
+    return null;
+  }
+}
+
+/**
+ * 모노레포 워크스페이스 패키지와 패키지 간 의존성 로드 (실패하면 패키지 범위 없이 리뷰)
+ * @param {FileAnalyzer} fileAnalyzer - 파일 분석기 (git 사용)
+ * @returns {Promise<WorkspacePackages|null>} 패키지 목록
+ */
+async function loadWorkspace(fileAnalyzer) {
+  try {
+    const workspace = await WorkspacePackages.load(fileAnalyzer.git, await RepoMap.build(fileAnalyzer.git));
+    core.info(`Monorepo: ${workspace.packages.length} workspace packages`);
+    return workspace;
+  } catch (error) {
+    core.warning(`Failed to load workspace packages: ${error.message}`);
+    return null;
+  }
+}
+
+/**
+ * 영향을 받은 패키지별 판정을 출력값으로 설정
+ * @param {Object} inputs - 액션 입력값
+ * @param {WorkspacePackages} workspace - 영향 범위를 계산한 패키지 목록
+ * @param {Array} reviewResults - 파일별 리뷰 결과
+ * @returns {Array} 패키지별 판정 (WorkspacePackages.verdicts() 결과)
+ */
+function publishPackageVerdicts(inputs, workspace, reviewResults) {
+  const verdicts = workspace.verdicts(reviewResults, inputs.packageFailSeverity);
+  const failed = verdicts.filter(verdict => verdict.verdict === 'fail');
+  core.info(`Package verdicts: ${verdicts.length - failed.length} pass, ${failed.length} fail`);
+  core.setOutput('package_verdicts', JSON.stringify(Object.fromEntries(verdicts.map(({ name, ...verdict }) => [name, verdict]))));
+  core.setOutput('failed_packages', failed.map(verdict => verdict.name).join(','));
+  return verdicts;
+}
+
+/**
+ * 과거 리뷰 결정 로드 (메모리 파일 + 억제 목록의 무시 항목)
+ * @param {Object} inputs - 액션 입력값
+ * @returns {Promise<ReviewMemory|null>} 사례가 없으면 null (프롬프트 변경 없음)
+ */
+async function loadReviewMemory(inputs) {
+  try {
+    const memory = await ReviewMemory.loadFile(inputs.memoryPath);
+    memory.addSuppressions(await SuppressionStore.loadFile(inputs.suppressionsPath));
+    if (memory.entries.length === 0) {
+      return null;
+    }
+    core.info(`Loaded ${memory.entries.length} past review decisions`);
+    return memory;
+  } catch (error) {
+    throw new ConfigError(error.message);
+  }
+}
+
+/**
+ * 사용자 지정 댓글 템플릿 로드 (지정하지 않은 템플릿은 기본 레이아웃 사용)
+ * @param {Object} inputs - 액션 입력값
+ * @returns {Promise<Object>} { finding?, file }
+ */
+async function loadCommentTemplates(inputs) {
+  const templates = {};
+  try {
+    if (inputs.commentFindingTemplate) {
+      templates.finding = await loadTemplate(inputs.commentFindingTemplate);
+    }
+    if (inputs.commentFileTemplate) {
+      templates.file = await loadTemplate(inputs.commentFileTemplate);
+    }
+  } catch (error) {
+    throw new ConfigError(error.message);
+  }
+  return templates;
+}
+
+/**
+ * 기본 알림 대상 목록
+ * notify 입력값이 비어 있으면 웹훅 URL이 설정된 대상을 사용
+ * @param {Object} inputs - 액션 입력값
+ * @returns {Array<string>} 알림 대상
+ */
+function getNotifyTargets(inputs) {
+  return inputs.notify.length > 0
+    ? inputs.notify
+    : [
+      (inputs.slackWebhookUrl || inputs.slackBotToken) && 'slack',
+      inputs.teamsWebhookUrl && 'teams',
+      inputs.discordWebhookUrl && 'discord',
+      inputs.smtpHost && inputs.emailTo.length > 0 && 'email',
+      inputs.webhookUrl && 'webhook',
+      inputs.notifyUrl && 'url'
+    ].filter(Boolean);
+}
+
+/**
+ * 알림 대상별 Notifier 생성
+ * @param {Object} inputs - 액션 입력값
+ * @param {string} target - 알림 대상
+ * @param {Object} [overrides] - { minSeverity, channel } 라우팅 규칙에서 지정한 값
+ * @returns {Object} notify(summary) 메서드를 가진 객체
+ */
+function createNotifier(inputs, target, overrides = {}) {
+  switch (target) {
+    case 'slack':
+      return new SlackNotifier({
+        webhookUrl: inputs.slackWebhookUrl,
+        botToken: inputs.slackBotToken,
+        channel: overrides.channel || inputs.slackChannel,
+        minSeverity: overrides.minSeverity || inputs.slackMinSeverity,
+        interactive: inputs.slackInteractive,
+        labels: inputs.displayLabels
+      });
+    case 'teams':
+      return new TeamsNotifier({
+        webhookUrl: inputs.teamsWebhookUrl,
+        minSeverity: overrides.minSeverity || inputs.teamsMinSeverity,
+        labels: inputs.displayLabels
+      });
+    case 'discord':
+      return new DiscordNotifier({
+        webhookUrl: inputs.discordWebhookUrl,
+        minSeverity: overrides.minSeverity || inputs.discordMinSeverity,
+        labels: inputs.displayLabels
+      });
+    case 'email':
+      return new EmailNotifier({
+        smtp: {
+          host: inputs.smtpHost,
+          port: inputs.smtpPort,
+          username: inputs.smtpUsername,
+          password: inputs.smtpPassword,
+          allowInsecureAuth: inputs.smtpAllowInsecureAuth
+        },
+        from: inputs.emailFrom,
+        to: inputs.emailTo,
+        minSeverity: overrides.minSeverity || inputs.emailMinSeverity,
+        labels: inputs.displayLabels,
+        plainText: inputs.plainTextSinks.includes('email')
+      });
+    case 'webhook':
+      return new WebhookNotifier({
+        url: inputs.webhookUrl,
+        secret: inputs.webhookSecret,
+        plainText: inputs.plainTextSinks.includes('webhook'),
+        labels: inputs.displayLabels
+      });
+    case 'url':
+      return createFormattedNotifier({
+        url: inputs.notifyUrl,
+        format: inputs.notifyFormat,
+        minSeverity: overrides.minSeverity || inputs.notifyMinSeverity,
+        labels: inputs.displayLabels
+      });
+    default:
+      throw new ConfigError(`Unknown notify target: ${target}`);
+  }
+}
+
+/**
+ * 라우팅 규칙을 적용해 알림 전송
+ * 규칙에 맞는 이슈는 규칙의 대상에만 (대상별 임계값 무시),
+ * 나머지 이슈는 기본 대상에 기존 임계값대로 전송
+ * @param {Object} inputs - 액션 입력값
+ * @param {Array} reviewResults - 파일별 리뷰 결과
+ * @param {Object} context - GitHub Actions 컨텍스트
+ * @param {Object} runMetadata - 실행 메타데이터
+ */
+async function dispatchNotifications(inputs, reviewResults, context, runMetadata) {
+  const router = new NotificationRouter(inputs.notifyRoutes);
+  const { routed, unrouted } = router.route(reviewResults);
+
+  const deliveries = getNotifyTargets(inputs).map(target => ({
+    notifier: createNotifier(inputs, target),
+    results: unrouted
+  }));
+  routed.forEach((results, key) => {
+    const [target, channel] = key.split(':');
+    core.info(`Routing ${results.reduce((sum, r) => sum + r.issues.length, 0)} findings to ${key}`);
+    deliveries.push({
+      notifier: createNotifier(inputs, target, { minSeverity: 'low', channel }),
+      results
+    });
+  });
+
+  await Promise.all(deliveries.map(({ notifier, results }) =>
+    notifier.notify(buildReviewSummary(results, context, runMetadata))
+  ));
 }
 
 /**
@@ -191,4 +2119,5 @@ if (require.main === module) {
 }
 
 // 테스트를 위해 run 함수 export
-module.exports = run;
\ No newline at end of file
+module.exports = run;
+module.exports.runDoctor = runDoctor;
\ No newline at end of file
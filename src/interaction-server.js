@@ -0,0 +1,334 @@
+/**
+ * Interaction Server Module
+ * Slack 인터랙티브 버튼(무시 / 이슈 생성 / 일시 중지) 콜백을 처리하는 서버 모드
+ *
+ * 액션은 실행이 끝나면 종료되므로 버튼 콜백은 별도로 띄운 이 서버가 받습니다.
+ *   npm run serve
+ *
+ * 환경 변수:
+ * - SLACK_SIGNING_SECRET (필수): Slack 앱 Signing Secret
+ * - GITHUB_TOKEN (필수): 억제 파일 커밋, 이슈 생성, PR 댓글 수정 권한이 있는 토큰
+ * - PORT: 수신 포트 (기본 3000)
+ * - SUPPRESSIONS_PATH: 억제 파일 경로 (기본 .claude-review/suppressions.json)
+ * - SUPPRESSIONS_BRANCH: 억제 파일을 커밋할 브랜치 (기본: 저장소 기본 브랜치)
+ * - SNOOZE_DAYS: 일시 중지 기간 (기본 7일)
+ * - JIRA_BASE_URL, JIRA_EMAIL, JIRA_API_TOKEN, JIRA_PROJECT_KEY: 설정 시 GitHub 대신 Jira 이슈 생성
+ */
+
+const http = require('http');
+const crypto = require('crypto');
+const github = require('@actions/github');
+const CommentManager = require('./comment-manager');
+const JiraClient = require('./jira-client');
+const SuppressionStore = require('./suppression-store');
+const { ACTIONS } = require('./slack-notifier');
+
+// Slack 요청 서명 허용 시간 (재전송 공격 방지)
+const SIGNATURE_TOLERANCE_SECONDS = 5 * 60;
+
+// 요청 본문 최대 크기 (서명 검증 전에 읽으므로 큰 본문으로 메모리를 고갈시키지 못하게 제한)
+const MAX_BODY_BYTES = 1024 * 1024;
+
+class InteractionServer {
+  /**
+   * InteractionServer 생성자
+   * @param {Object} config - 설정
+   * @param {string} config.signingSecret - Slack Signing Secret
+   * @param {string} config.githubToken - GitHub 토큰
+   * @param {string} [config.suppressionsPath] - 억제 파일 경로
+   * @param {string} [config.suppressionsBranch] - 억제 파일 브랜치
+   * @param {number} [config.snoozeDays] - 일시 중지 기간 (일)
+   * @param {JiraClient} [config.jira] - Jira 클라이언트 (없으면 GitHub 이슈 생성)
+   */
+  constructor({ signingSecret, githubToken, suppressionsPath, suppressionsBranch, snoozeDays = 7, jira = null }) {
+    this.signingSecret = signingSecret;
+    this.githubToken = githubToken;
+    this.octokit = github.getOctokit(githubToken);
+    this.suppressionsPath = suppressionsPath || SuppressionStore.DEFAULT_SUPPRESSIONS_PATH;
+    this.suppressionsBranch = suppressionsBranch;
+    this.snoozeDays = snoozeDays;
+    this.jira = jira;
+  }
+
+  /**
+   * Slack 요청 서명 검증 (v0 서명 방식)
+   * @param {string} rawBody - 요청 원문
+   * @param {Object} headers - 요청 헤더
+   * @returns {boolean} 유효하면 true
+   */
+  verifySignature(rawBody, headers) {
+    const timestamp = headers['x-slack-request-timestamp'];
+    const signature = headers['x-slack-signature'];
+    if (!timestamp || !signature) {
+      return false;
+    }
+    if (Math.abs(Date.now() / 1000 - Number(timestamp)) > SIGNATURE_TOLERANCE_SECONDS) {
+      return false;
+    }
+
+    const expected = 'v0=' + crypto
+      .createHmac('sha256', this.signingSecret)
+      .update(`v0:${timestamp}:${rawBody}`)
+      .digest('hex');
+
+    const a = Buffer.from(expected);
+    const b = Buffer.from(signature);
+    return a.length === b.length && crypto.timingSafeEqual(a, b);
+  }
+
+  /**
+   * HTTP 요청 처리
+   * @param {http.IncomingMessage} req - 요청
+   * @param {http.ServerResponse} res - 응답
+   */
+  async handleRequest(req, res) {
+    if (req.method === 'GET' && req.url === '/healthz') {
+      res.writeHead(200).end('ok');
+      return;
+    }
+    if (req.method !== 'POST') {
+      res.writeHead(405).end();
+      return;
+    }
+
+    if (Number(req.headers['content-length']) > MAX_BODY_BYTES) {
+      res.writeHead(413, { connection: 'close' }).end('payload too large');
+      req.destroy();
+      return;
+    }
+
+    const chunks = [];
+    let size = 0;
+    for await (const chunk of req) {
+      size += chunk.length;
+      if (size > MAX_BODY_BYTES) {
+        res.writeHead(413, { connection: 'close' }).end('payload too large');
+        req.destroy();
+        return;
+      }
+      chunks.push(chunk);
+    }
+    const rawBody = Buffer.concat(chunks).toString('utf8');
+
+    if (!this.verifySignature(rawBody, req.headers)) {
+      res.writeHead(401).end('invalid signature');
+      return;
+    }
+
+    let payload;
+    try {
+      payload = JSON.parse(new URLSearchParams(rawBody).get('payload'));
+    } catch (error) {
+      res.writeHead(400).end('invalid payload');
+      return;
+    }
+
+    // Slack은 3초 안에 응답을 받아야 하므로 먼저 승인하고 결과는 response_url로 전달
+    res.writeHead(200).end();
+
+    const action = (payload.actions || [])[0];
+    if (!action || !Object.values(ACTIONS).includes(action.action_id)) {
+      return;
+    }
+
+    const user = payload.user ? (payload.user.username || payload.user.name || payload.user.id) : 'unknown';
+    try {
+      const message = await this.handleAction(action.action_id, JSON.parse(action.value), user);
+      await this.reply(payload.response_url, message);
+    } catch (error) {
+      console.error(`Failed to handle ${action.action_id}: ${error.message}`);
+      await this.reply(payload.response_url, `:warning: Failed to handle action: ${error.message}`);
+    }
+  }
+
+  /**
+   * 버튼 동작 처리
+   * @param {string} actionId - 버튼 action_id
+   * @param {Object} value - 버튼 value (slack-notifier.js buildActions 참고)
+   * @param {string} user - 버튼을 누른 Slack 사용자
+   * @returns {Promise<string>} Slack에 보낼 결과 메시지
+   */
+  async handleAction(actionId, value, user) {
+    const [owner, repo] = value.r.split('/');
+    const finding = {
+      fingerprint: value.f,
+      file: value.p,
+      line: value.l,
+      severity: value.s,
+      type: value.c,
+      title: value.t
+    };
+    const location = finding.line ? `${finding.file}:${finding.line}` : finding.file;
+
+    let note;
+    let message;
+    if (actionId === ACTIONS.createIssue) {
+      const reference = await this.createIssue(owner, repo, finding, value);
+      note = `📌 Tracked as ${reference} by ${user} via Slack`;
+      message = `:memo: Created ${reference} for *${finding.title}* (\`${location}\`)`;
+    } else {
+      const snoozed = actionId === ACTIONS.snooze;
+      const until = snoozed ? new Date(Date.now() + this.snoozeDays * 24 * 60 * 60 * 1000).toISOString() : null;
+      await this.suppress(owner, repo, finding, snoozed ? 'snoozed' : 'dismissed', until, user);
+      note = snoozed
+        ? `💤 Snoozed until ${until.substring(0, 10)} by ${user} via Slack`
+        : `🙈 Dismissed by ${user} via Slack`;
+      message = snoozed
+        ? `:zzz: Snoozed *${finding.title}* (\`${location}\`) for ${this.snoozeDays} days`
+        : `:see_no_evil: Dismissed *${finding.title}* (\`${location}\`)`;
+    }
+
+    if (value.n) {
+      const commentManager = new CommentManager(this.githubToken, { repo: { owner, repo } });
+      await commentManager.annotateFinding(value.n, finding.fingerprint, note);
+    }
+    return message;
+  }
+
+  /**
+   * 억제 파일에 항목을 기록하고 저장소에 커밋
+   * @param {string} owner - 저장소 소유자
+   * @param {string} repo - 저장소 이름
+   * @param {Object} finding - 이슈 정보
+   * @param {string} status - dismissed | snoozed
+   * @param {string|null} until - 일시 중지 만료 시각
+   * @param {string} user - 처리한 사용자
+   */
+  async suppress(owner, repo, finding, status, until, user) {
+    const location = {
+      owner,
+      repo,
+      path: this.suppressionsPath,
+      branch: this.suppressionsBranch
+    };
+    const store = await SuppressionStore.loadFromRepo(this.octokit, location);
+    store.upsert({
+      fingerprint: finding.fingerprint,
+      status,
+      until,
+      by: user,
+      file: finding.file,
+      title: finding.title
+    });
+    await store.saveToRepo(this.octokit, location, `chore: ${status} review finding ${finding.fingerprint}`);
+  }
+
+  /**
+   * 이슈 생성 (Jira 설정 시 Jira, 아니면 GitHub 이슈)
+   * @param {string} owner - 저장소 소유자
+   * @param {string} repo - 저장소 이름
+   * @param {Object} finding - 이슈 정보
+   * @param {Object} value - 버튼 value
+   * @returns {Promise<string>} 생성된 이슈 참조 (예: #12, ENG-3)
+   */
+  async createIssue(owner, repo, finding, value) {
+    const link = { title: value.n ? `${value.r}#${value.n}` : value.r, url: value.u };
+
+    if (this.jira) {
+      const keys = await this.jira.syncFindings([{ file: finding.file, issues: [finding] }], link);
+      if (keys.length === 0) {
+        throw new Error('Jira issue was not created');
+      }
+      return keys[0];
+    }
+
+    const location = finding.line ? `${finding.file}:${finding.line}` : finding.file;
+    const { data } = await this.octokit.rest.issues.create({
+      owner,
+      repo,
+      title: `[${finding.severity}] ${finding.title} (${finding.file})`,
+      body: `**Claude AI Code Review** found a ${finding.severity} ${finding.type} issue.\n\n` +
+        `**Location:** \`${location}\`\n` +
+        `**Source:** [${link.title}](${link.url})\n\n` +
+        `---\n_Fingerprint: ${finding.fingerprint}_`
+    });
+    return `#${data.number}`;
+  }
+
+  /**
+   * response_url로 결과 메시지 전송 (원본 메시지는 유지)
+   * @param {string} responseUrl - Slack response_url
+   * @param {string} text - 메시지
+   */
+  async reply(responseUrl, text) {
+    if (!responseUrl) {
+      return;
+    }
+    try {
+      await fetch(responseUrl, {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({ response_type: 'in_channel', replace_original: false, text }),
+        signal: AbortSignal.timeout(10000)
+      });
+    } catch (error) {
+      console.error(`Failed to reply to Slack: ${error.message}`);
+    }
+  }
+
+  /**
+   * 서버 시작
+   * @param {number} port - 수신 포트
+   * @returns {http.Server} 서버
+   */
+  listen(port) {
+    const server = http.createServer((req, res) => {
+      this.handleRequest(req, res).catch(error => {
+        console.error(`Request failed: ${error.message}`);
+        if (!res.headersSent) {
+          res.writeHead(500).end();
+        }
+      });
+    });
+    server.listen(port, () => console.log(`Claude review interaction server listening on port ${port}`));
+    return server;
+  }
+}
+
+/**
+ * 환경 변수로 서버 생성
+ * @param {Object} env - 환경 변수
+ * @returns {InteractionServer} 서버
+ */
+function createFromEnv(env = process.env) {
+  if (!env.SLACK_SIGNING_SECRET || !env.GITHUB_TOKEN) {
+    throw new Error('SLACK_SIGNING_SECRET and GITHUB_TOKEN are required');
+  }
+
+  const snoozeDays = parseInt(env.SNOOZE_DAYS || '7', 10);
+  if (isNaN(snoozeDays) || snoozeDays < 1) {
+    throw new Error('SNOOZE_DAYS must be a positive integer');
+  }
+
+  const jiraConfigured = env.JIRA_BASE_URL && env.JIRA_EMAIL && env.JIRA_API_TOKEN && env.JIRA_PROJECT_KEY;
+  return new InteractionServer({
+    signingSecret: env.SLACK_SIGNING_SECRET,
+    githubToken: env.GITHUB_TOKEN,
+    suppressionsPath: env.SUPPRESSIONS_PATH,
+    suppressionsBranch: env.SUPPRESSIONS_BRANCH || undefined,
+    snoozeDays,
+    // 버튼으로 요청한 이슈는 심각도와 관계없이 생성
+    jira: jiraConfigured
+      ? new JiraClient({
+        baseUrl: env.JIRA_BASE_URL,
+        email: env.JIRA_EMAIL,
+        apiToken: env.JIRA_API_TOKEN,
+        projectKey: env.JIRA_PROJECT_KEY,
+        issueType: env.JIRA_ISSUE_TYPE || 'Bug',
+        minSeverity: 'low'
+      })
+      : null
+  });
+}
+
+if (require.main === module) {
+  try {
+    createFromEnv().listen(parseInt(process.env.PORT || '3000', 10));
+  } catch (error) {
+    console.error(error.message);
+    process.exit(1);
+  }
+}
+
+module.exports = InteractionServer;
+module.exports.createFromEnv = createFromEnv;
@@ -0,0 +1,179 @@
+/**
+ * Jira Integration Module
+ * 심각한 이슈를 Jira 이슈로 등록하는 모듈
+ *
+ * 주요 기능:
+ * - 지문(fingerprint) 라벨로 기존 Jira 이슈 검색 (중복 생성 방지)
+ * - 코드 발췌, 개선 제안, PR 링크를 포함한 설명 작성
+ * - PR을 원격 링크로 연결 (기존 이슈가 다른 PR에서 다시 발견되면 그 PR도 연결)
+ */
+
+const core = require('@actions/core');
+const { getSeverityLevel } = require('./review-summary');
+
+// Jira 라벨 접두사 (지문으로 중복 판별)
+const FINGERPRINT_LABEL_PREFIX = 'claude-review-';
+
+class JiraClient {
+  /**
+   * JiraClient 생성자
+   * @param {Object} config - 설정
+   * @param {string} config.baseUrl - Jira 주소 (예: https://org.atlassian.net)
+   * @param {string} config.email - API 토큰 소유자 이메일
+   * @param {string} config.apiToken - Jira API 토큰
+   * @param {string} config.projectKey - 이슈를 생성할 프로젝트 키
+   * @param {string} [config.issueType] - 이슈 유형
+   * @param {string} [config.minSeverity] - 이 심각도 이상 이슈만 등록
+   */
+  constructor({ baseUrl, email, apiToken, projectKey, issueType = 'Bug', minSeverity = 'critical' }) {
+    this.baseUrl = baseUrl.replace(/\/+$/, '');
+    this.authorization = `Basic ${Buffer.from(`${email}:${apiToken}`).toString('base64')}`;
+    this.projectKey = projectKey;
+    this.issueType = issueType;
+    this.minSeverity = minSeverity;
+  }
+
+  /**
+   * Jira REST API 호출
+   * @param {string} method - HTTP 메서드
+   * @param {string} path - API 경로
+   * @param {Object} [body] - 요청 본문
+   * @returns {Promise<Object>} 응답 JSON
+   */
+  async request(method, path, body) {
+    const response = await fetch(`${this.baseUrl}${path}`, {
+      method,
+      headers: {
+        Authorization: this.authorization,
+        Accept: 'application/json',
+        'Content-Type': 'application/json'
+      },
+      body: body ? JSON.stringify(body) : undefined,
+      signal: AbortSignal.timeout(15000)
+    });
+
+    const text = await response.text();
+    if (!response.ok) {
+      const error = new Error(`Jira API ${response.status}: ${text.substring(0, 200)}`);
+      error.status = response.status;
+      throw error;
+    }
+    return text ? JSON.parse(text) : {};
+  }
+
+  /**
+   * 지문 라벨로 기존 이슈 검색
+   * Jira Cloud의 /search/jql을 우선 사용하고, 없으면 기존 /search로 재시도 (Data Center)
+   * @param {string} fingerprint - 이슈 지문
+   * @returns {Promise<string|null>} 기존 이슈 키
+   */
+  async findExisting(fingerprint) {
+    const jql = `project = "${this.projectKey}" AND labels = "${FINGERPRINT_LABEL_PREFIX}${fingerprint}"`;
+    const query = `?jql=${encodeURIComponent(jql)}&maxResults=1&fields=key`;
+
+    let result;
+    try {
+      result = await this.request('GET', `/rest/api/2/search/jql${query}`);
+    } catch (error) {
+      if (error.status !== 404) {
+        throw error;
+      }
+      result = await this.request('GET', `/rest/api/2/search${query}`);
+    }
+
+    const issues = result.issues || [];
+    return issues.length > 0 ? issues[0].key : null;
+  }
+
+  /**
+   * PR 또는 커밋을 이슈의 원격 링크로 연결
+   * globalId가 같으면 Jira가 기존 링크를 갱신하므로 재실행해도 중복되지 않음
+   * @param {string} key - Jira 이슈 키
+   * @param {Object} link - { title, url } PR 또는 커밋 링크
+   */
+  async linkSource(key, link) {
+    try {
+      await this.request('POST', `/rest/api/2/issue/${key}/remotelink`, {
+        globalId: link.url,
+        object: { url: link.url, title: link.title.substring(0, 255) }
+      });
+    } catch (error) {
+      core.warning(`Failed to link ${key} to ${link.url}: ${error.message}`);
+    }
+  }
+
+  /**
+   * Jira 위키 마크업 형식의 이슈 설명 생성
+   * @param {Object} finding - { file, ...issue }
+   * @param {Object} link - { title, url } PR 또는 커밋 링크
+   * @returns {string} 설명
+   */
+  buildDescription(finding, link) {
+    const location = finding.line ? `${finding.file}:${finding.line}` : finding.file;
+    let description = `*Claude AI Code Review* found a ${finding.severity} ${finding.type} issue.\n\n`;
+    description += `*Location:* {{${location}}}\n`;
+    description += `*Source:* [${link.title}|${link.url}]\n\n`;
+    description += `h3. Problem\n${finding.description || finding.title}\n\n`;
+
+    if (finding.snippet) {
+      description += `h3. Code\n{code:title=${finding.file} (line ${finding.snippet.startLine})}\n${finding.snippet.code}\n{code}\n\n`;
+    }
+    if (finding.suggestion) {
+      description += `h3. Suggested fix\n${finding.suggestion}\n\n`;
+    }
+    if (finding.codeExample) {
+      description += `{code}\n${finding.codeExample}\n{code}\n\n`;
+    }
+
+    description += `----\n_Fingerprint: ${finding.fingerprint}_`;
+    return description;
+  }
+
+  /**
+   * 임계값 이상 이슈를 Jira에 등록 (지문이 같은 기존 이슈가 있으면 건너뜀)
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @param {Object} link - { title, url } PR 또는 커밋 링크
+   * @returns {Promise<Array>} 생성되거나 이미 존재하는 이슈 키 목록
+   */
+  async syncFindings(reviewResults, link) {
+    const threshold = getSeverityLevel(this.minSeverity);
+    const findings = [];
+    reviewResults.forEach(result => {
+      result.issues
+        .filter(issue => getSeverityLevel(issue.severity) >= threshold)
+        .forEach(issue => findings.push({ file: result.file, ...issue }));
+    });
+
+    const keys = [];
+    for (const finding of findings) {
+      try {
+        const existing = await this.findExisting(finding.fingerprint);
+        if (existing) {
+          core.info(`Jira issue ${existing} already tracks ${finding.file}: ${finding.title}`);
+          await this.linkSource(existing, link);
+          keys.push(existing);
+          continue;
+        }
+
+        const created = await this.request('POST', '/rest/api/2/issue', {
+          fields: {
+            project: { key: this.projectKey },
+            issuetype: { name: this.issueType },
+            summary: `[${finding.severity}] ${finding.title} (${finding.file})`.substring(0, 255),
+            description: this.buildDescription(finding, link),
+            labels: ['claude-review', `${FINGERPRINT_LABEL_PREFIX}${finding.fingerprint}`]
+          }
+        });
+        core.info(`Created Jira issue ${created.key} for ${finding.file}: ${finding.title}`);
+        await this.linkSource(created.key, link);
+        keys.push(created.key);
+      } catch (error) {
+        core.warning(`Failed to sync Jira issue for ${finding.file}: ${error.message}`);
+      }
+    }
+
+    return keys;
+  }
+}
+
+module.exports = JiraClient;
@@ -0,0 +1,253 @@
+/**
+ * Job Summary Module
+ * 리뷰 결과를 GitHub Actions Job Summary($GITHUB_STEP_SUMMARY)용 마크다운 리포트로 만드는 모듈
+ *
+ * - 판정과 심각도별 통계, 파일별 결과 표, 파일별 이슈 목록, 토큰 사용량과 예상 비용
+ * - 긴 섹션은 <details>로 접어 워크플로우 실행 페이지에서 스크롤이 길어지지 않게 함
+ */
+
+const DisplayLabels = require('./display-labels');
+const { getSeverityLevel } = require('./review-summary');
+
+// 모델별 가격 (USD / 100만 토큰, 가장 긴 접두사 일치 우선)
+const MODEL_PRICING = [
+  ['claude-opus-4-5', 5, 25],
+  ['claude-opus-4', 15, 75],
+  ['claude-3-opus', 15, 75],
+  ['claude-sonnet-4', 3, 15],
+  ['claude-3-7-sonnet', 3, 15],
+  ['claude-3-5-sonnet', 3, 15],
+  ['claude-haiku-4-5', 1, 5],
+  ['claude-3-5-haiku', 0.8, 4],
+  ['claude-3-haiku', 0.25, 1.25]
+];
+
+// Message Batches API 가격 (공개 가격 대비)
+const BATCH_DISCOUNT = 0.5;
+// 프롬프트 캐시 가격 (입력 토큰 가격 대비, 5분 캐시 쓰기와 읽기)
+const CACHE_WRITE_MULTIPLIER = 1.25;
+const CACHE_READ_MULTIPLIER = 0.1;
+
+// 이 수보다 파일이 많으면 파일별 결과 표도 접음
+const MAX_OPEN_FILE_ROWS = 20;
+// 이슈 목록에 표시할 개선 방안 최대 길이 (Job Summary는 단계당 1MiB 제한)
+const MAX_SUGGESTION_LENGTH = 300;
+
+// 판정별 표시 이름
+const VERDICT_LABELS = {
+  changes_requested: '수정 필요',
+  needs_attention: '확인 필요',
+  approved: '문제 없음'
+};
+
+const SEVERITIES = ['critical', 'high', 'medium', 'low'];
+
+/**
+ * 모델 사용량의 예상 비용 계산
+ * @param {string} model - 모델 ID
+ * @param {Object} usage - { inputTokens, outputTokens, cacheReadTokens, cacheWriteTokens }
+ * @returns {number|null} 예상 비용 (USD, 가격을 모르는 모델이면 null)
+ */
+function estimateCost(model, usage) {
+  const pricing = MODEL_PRICING
+    .filter(([prefix]) => (model || '').startsWith(prefix))
+    .sort((a, b) => b[0].length - a[0].length)[0];
+  if (!pricing) {
+    return null;
+  }
+  const cachedInput = (usage.cacheWriteTokens || 0) * CACHE_WRITE_MULTIPLIER + (usage.cacheReadTokens || 0) * CACHE_READ_MULTIPLIER;
+  return ((usage.inputTokens + cachedInput) * pricing[1] + usage.outputTokens * pricing[2]) / 1000000;
+}
+
+/**
+ * 실행 방식(Batch API 할인)을 반영한 모델 사용량의 예상 비용
+ * @param {string} model - 모델 ID
+ * @param {Object} usage - { inputTokens, outputTokens, cacheReadTokens, cacheWriteTokens }
+ * @param {boolean} [batch] - Message Batches API로 실행했는지
+ * @returns {number|null} 예상 비용 (USD, 가격을 모르는 모델이면 null)
+ */
+function modelCost(model, usage, batch = false) {
+  const cost = estimateCost(model, usage);
+  return cost !== null && batch ? cost * BATCH_DISCOUNT : cost;
+}
+
+/**
+ * 실행 전체의 토큰 수와 예상 비용 합계 (액션 출력값, 댓글 푸터, 텔레메트리용)
+ * @param {Map<string, Object>} usageByModel - 모델 → { requests, inputTokens, outputTokens, cacheReadTokens, cacheWriteTokens }
+ * @param {boolean} [batch] - Message Batches API로 실행했는지
+ * @returns {Object} { tokens: 입력(캐시 포함)+출력 토큰, costUsd: 가격을 아는 모델의 합계 (모두 모르면 null) }
+ */
+function summarizeUsage(usageByModel, batch = false) {
+  let tokens = 0;
+  let costUsd = null;
+  usageByModel.forEach((usage, model) => {
+    tokens += usage.inputTokens + (usage.cacheReadTokens || 0) + (usage.cacheWriteTokens || 0) + usage.outputTokens;
+    const cost = modelCost(model, usage, batch);
+    if (cost !== null) {
+      costUsd = (costUsd || 0) + cost;
+    }
+  });
+  return { tokens, costUsd };
+}
+
+/**
+ * Job Summary 마크다운 생성
+ * @param {Object} params - 파라미터
+ * @param {Object} params.summary - buildReviewSummary() 결과
+ * @param {number} params.filesReviewed - 리뷰한 파일 수
+ * @param {Map<string, Object>} [params.usageByModel] - 모델 → { requests, inputTokens, outputTokens, cacheReadTokens, cacheWriteTokens }
+ * @param {boolean} [params.batch] - Message Batches API로 실행했는지 (예상 비용 50% 할인)
+ * @param {number} [params.durationMs] - 실행 시간
+ * @param {DisplayLabels} [params.labels] - 심각도/타입 표시 매핑
+ * @returns {string} 마크다운
+ */
+function buildJobSummary({ summary, filesReviewed, usageByModel = new Map(), batch = false, durationMs = null, labels = new DisplayLabels() }) {
+  const severityHeader = severity => labels.withIcon(labels.severityIcon(severity), labels.severityLabel(severity));
+
+  let markdown = `## 🤖 Claude AI 코드 리뷰\n\n`;
+  markdown += `**대상:** [${escapeCell(summary.title)}](${summary.url})\n`;
+  markdown += `**판정:** ${labels.withIcon(labels.verdictIcon(summary.verdict), VERDICT_LABELS[summary.verdict])}\n`;
+  markdown += `**검토한 파일:** ${filesReviewed}개 | **발견된 이슈:** ${summary.totalIssues}개`;
+  markdown += durationMs !== null ? ` | **실행 시간:** ${formatDuration(durationMs)}\n\n` : '\n\n';
+
+  // 심각도별 통계
+  markdown += '### 📋 심각도별 이슈\n\n';
+  markdown += `| ${SEVERITIES.map(severityHeader).join(' | ')} |\n`;
+  markdown += `|${SEVERITIES.map(() => '---').join('|')}|\n`;
+  markdown += `| ${SEVERITIES.map(severity => summary.counts[severity]).join(' | ')} |\n\n`;
+
+  // 파일별 결과 (심각한 이슈가 많은 파일부터)
+  if (summary.results.length > 0) {
+    const results = [...summary.results].sort((a, b) => compareResults(b, a));
+    let table = `| 파일 | 점수 | ${SEVERITIES.map(severityHeader).join(' | ')} |\n`;
+    table += `|------|------|${SEVERITIES.map(() => '---').join('|')}|\n`;
+    results.forEach(result => {
+      const counts = SEVERITIES.map(severity => result.issues.filter(issue => issue.severity === severity).length);
+      table += `| \`${escapeCell(result.file)}\` | ${typeof result.score === 'number' ? `${result.score}/10` : '-'} | ${counts.join(' | ')} |\n`;
+    });
+    const clean = filesReviewed - summary.results.length;
+
+    markdown += '### 📁 파일별 결과\n\n';
+    markdown += results.length > MAX_OPEN_FILE_ROWS
+      ? `<details>\n<summary>이슈가 있는 파일 ${results.length}개</summary>\n\n${table}\n</details>\n\n`
+      : `${table}\n`;
+    if (clean > 0) {
+      markdown += `이슈가 없는 파일: ${clean}개\n\n`;
+    }
+
+    // 파일별 이슈 목록은 항상 접어 둠
+    markdown += '### 🔎 이슈 상세\n\n';
+    results.forEach(result => {
+      const issues = [...result.issues].sort((a, b) => getSeverityLevel(b.severity) - getSeverityLevel(a.severity));
+      markdown += `<details>\n<summary><code>${escapeHtml(result.file)}</code> (${issues.length}개 이슈)</summary>\n\n`;
+      issues.forEach(issue => {
+        const location = issue.line ? `L${issue.line}` : '-';
+        markdown += `- ${labels.withIcon(labels.severityIcon(issue.severity), `**${escapeHtml(oneLine(issue.title))}**`)} (${location}, ${labels.typeLabel(issue.type)})`;
+        markdown += issue.suggestion ? `\n  ${escapeHtml(truncate(oneLine(issue.suggestion), MAX_SUGGESTION_LENGTH))}\n` : '\n';
+      });
+      markdown += '\n</details>\n\n';
+    });
+  }
+
+  // 토큰 사용량과 예상 비용
+  if (usageByModel.size > 0) {
+    let total = 0;
+    let unknownPrice = false;
+    // 프롬프트 캐시를 사용했을 때만 캐시 열 표시
+    const cached = [...usageByModel.values()].some(usage => usage.cacheReadTokens || usage.cacheWriteTokens);
+    markdown += '### 💰 토큰 사용량\n\n';
+    markdown += `| 모델 | 요청 | 입력 토큰 |${cached ? ' 캐시 읽기 | 캐시 쓰기 |' : ''} 출력 토큰 | 예상 비용 |\n`;
+    markdown += `|------|------|-----------|${cached ? '-----------|-----------|' : ''}-----------|-----------|\n`;
+    usageByModel.forEach((usage, model) => {
+      const cost = modelCost(model, usage, batch);
+      if (cost === null) {
+        unknownPrice = true;
+      } else {
+        total += cost;
+      }
+      const cacheCells = cached
+        ? ` ${(usage.cacheReadTokens || 0).toLocaleString('en-US')} | ${(usage.cacheWriteTokens || 0).toLocaleString('en-US')} |`
+        : '';
+      markdown += `| \`${model}\` | ${usage.requests} | ${usage.inputTokens.toLocaleString('en-US')} |${cacheCells} ${usage.outputTokens.toLocaleString('en-US')} | ${cost === null ? '-' : formatCost(cost)} |\n`;
+    });
+    markdown += `\n**예상 비용 합계:** ${formatCost(total)}${unknownPrice ? ' (가격을 알 수 없는 모델 제외)' : ''}${batch ? ' (Batch API 50% 할인 적용)' : ''}\n`;
+    markdown += '\n> 예상 비용은 공개 가격표 기준 추정치이며 실제 청구 금액과 다를 수 있습니다.\n';
+  }
+
+  return markdown;
+}
+
+/**
+ * 파일 결과 정렬 비교 (critical 수, high 수, ... 순)
+ * @param {Object} a - 파일 결과
+ * @param {Object} b - 파일 결과
+ * @returns {number} 비교 결과
+ */
+function compareResults(a, b) {
+  for (const severity of SEVERITIES) {
+    const diff = a.issues.filter(issue => issue.severity === severity).length -
+      b.issues.filter(issue => issue.severity === severity).length;
+    if (diff !== 0) {
+      return diff;
+    }
+  }
+  return b.file.localeCompare(a.file);
+}
+
+/**
+ * 표 셀에 넣을 문자열 이스케이프 (파이프와 줄바꿈)
+ * @param {string} text - 문자열
+ * @returns {string} 이스케이프된 문자열
+ */
+function escapeCell(text) {
+  return String(text).replace(/\|/g, '\\|').replace(/\r?\n/g, ' ');
+}
+
+/**
+ * HTML 특수 문자 이스케이프 (<details> 안의 텍스트용)
+ * @param {string} text - 문자열
+ * @returns {string} 이스케이프된 문자열
+ */
+function escapeHtml(text) {
+  return String(text).replace(/&/g, '&amp;').replace(/</g, '&lt;').replace(/>/g, '&gt;');
+}
+
+/**
+ * 여러 줄 문자열을 한 줄로 합침
+ * @param {string} text - 문자열
+ * @returns {string} 한 줄 문자열
+ */
+function oneLine(text) {
+  return String(text).replace(/\s*\r?\n\s*/g, ' ').trim();
+}
+
+/**
+ * 최대 길이를 넘는 문자열 자르기
+ * @param {string} text - 문자열
+ * @param {number} max - 최대 길이
+ * @returns {string} 잘린 문자열
+ */
+function truncate(text, max) {
+  return text.length > max ? `${text.substring(0, max - 1)}…` : text;
+}
+
+/**
+ * 비용 표시 (1센트 미만은 소수점 넷째 자리까지)
+ * @param {number} cost - 비용 (USD)
+ * @returns {string} 표시 문자열
+ */
+function formatCost(cost) {
+  return cost < 0.01 ? `$${cost.toFixed(4)}` : `$${cost.toFixed(2)}`;
+}
+
+/**
+ * 실행 시간 표시
+ * @param {number} durationMs - 실행 시간 (밀리초)
+ * @returns {string} 예: 1분 23초
+ */
+function formatDuration(durationMs) {
+  const seconds = Math.round(durationMs / 1000);
+  return seconds >= 60 ? `${Math.floor(seconds / 60)}분 ${seconds % 60}초` : `${seconds}초`;
+}
+
+module.exports = { MODEL_PRICING, estimateCost, summarizeUsage, formatCost, buildJobSummary };
@@ -0,0 +1,104 @@
+/**
+ * JSON Report Module
+ * 리뷰 결과를 기계가 읽을 수 있는 JSON 리포트로 변환하는 모듈
+ *
+ * 웹훅, 파일 출력 등 외부 시스템 연동에서 공통으로 사용하는 형식입니다.
+ */
+
+const fs = require('fs');
+const os = require('os');
+const path = require('path');
+const { getSeverityLevel } = require('./review-summary');
+
+// 리포트 형식 버전 (필드 구조가 바뀌면 증가)
+const REPORT_SCHEMA_VERSION = 1;
+
+/**
+ * JSON 리포트 생성
+ * @param {Object} summary - buildReviewSummary() 결과
+ * @returns {Object} 리포트 객체
+ */
+function buildJsonReport(summary) {
+  return {
+    schema: REPORT_SCHEMA_VERSION,
+    generatedAt: new Date().toISOString(),
+    repository: summary.repository,
+    title: summary.title,
+    url: summary.url,
+    verdict: summary.verdict,
+    counts: summary.counts,
+    totalIssues: summary.totalIssues,
+    metadata: summary.runMetadata || null,
+    files: summary.results.map(result => ({
+      file: result.file,
+      summary: result.summary,
+      score: typeof result.score === 'number' ? result.score : null,
+      issues: result.issues.map(issue => ({
+        fingerprint: issue.fingerprint || null,
+        line: issue.line,
+        severity: issue.severity,
+        type: issue.type,
+        persona: issue.persona || null,
+        commit: issue.commit || null,
+        title: issue.title,
+        description: issue.description,
+        suggestion: issue.suggestion,
+        codeExample: issue.codeExample,
+        testFile: issue.testFile || null,
+        why: issue.why || null,
+        reference: issue.reference || null,
+        snippet: issue.snippet || null
+      }))
+    }))
+  };
+}
+
+/**
+ * 후속 워크플로우 단계용 평탄한 이슈 목록 생성 (심각도 높은 순, jq 등으로 바로 필터링 가능)
+ * @param {Object} summary - buildReviewSummary() 결과
+ * @returns {Array} [{ file, line, severity, type, title, description, suggestion, fingerprint, persona, commit }]
+ */
+function buildFindingsList(summary) {
+  return summary.results
+    .flatMap(result => result.issues.map(issue => ({
+      file: result.file,
+      line: issue.line || null,
+      severity: issue.severity,
+      type: issue.type,
+      title: issue.title,
+      description: issue.description,
+      suggestion: issue.suggestion || null,
+      fingerprint: issue.fingerprint || null,
+      persona: issue.persona || null,
+      commit: issue.commit || null
+    })))
+    .sort((a, b) => getSeverityLevel(b.severity) - getSeverityLevel(a.severity));
+}
+
+/**
+ * JSON 리포트를 파일로 작성 (아티팩트 업로드용)
+ * @param {Object} summary - buildReviewSummary() 결과
+ * @param {string|number} runId - 워크플로우 실행 ID
+ * @returns {string} 작성한 파일 경로
+ */
+function writeJsonReport(summary, runId) {
+  const dir = process.env.RUNNER_TEMP || os.tmpdir();
+  const filePath = path.join(dir, `claude-review-report-${runId || Date.now()}.json`);
+  fs.writeFileSync(filePath, JSON.stringify(buildJsonReport(summary), null, 2));
+  return filePath;
+}
+
+/**
+ * 평탄한 이슈 목록을 파일로 작성 (findings_path 출력값)
+ * @param {Object} summary - buildReviewSummary() 결과
+ * @param {string|number} runId - 워크플로우 실행 ID
+ * @returns {string} 작성한 파일 경로
+ */
+function writeFindingsFile(summary, runId) {
+  const dir = process.env.RUNNER_TEMP || os.tmpdir();
+  const filePath = path.join(dir, `claude-review-findings-${runId || Date.now()}.json`);
+  fs.writeFileSync(filePath, JSON.stringify(buildFindingsList(summary), null, 2));
+  return filePath;
+}
+
+module.exports = { REPORT_SCHEMA_VERSION, buildJsonReport, writeJsonReport, buildFindingsList, writeFindingsFile };
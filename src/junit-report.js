@@ -0,0 +1,91 @@
+/**
+ * JUnit Report Module
+ * 리뷰 결과를 JUnit XML로 변환해 Jenkins, Allure, GitHub 테스트 리포터 같은 CI 대시보드에 표시하는 모듈
+ *
+ * - 파일마다 testsuite, 이슈마다 testcase
+ * - fail_severity 이상의 이슈는 <failure>, 그보다 낮은 이슈는 통과한 testcase로 기록 (내용은 system-out)
+ * - 이슈가 없으면 통과한 testcase 하나를 기록 (테스트가 0개인 리포트를 오류로 보는 도구 대응)
+ */
+
+const fs = require('fs');
+const os = require('os');
+const path = require('path');
+const { getSeverityLevel } = require('./review-summary');
+
+// 최상위 testsuites 이름
+const SUITE_NAME = 'Claude Code Review';
+
+/**
+ * JUnit XML 생성
+ * @param {Object} summary - buildReviewSummary() 결과
+ * @param {string} [failSeverity] - 이 심각도 이상의 이슈를 실패로 기록 (기본값: high)
+ * @returns {string} JUnit XML 문서
+ */
+function buildJunitReport(summary, failSeverity = 'high') {
+  const threshold = getSeverityLevel(failSeverity);
+  const isFailure = issue => getSeverityLevel(issue.severity) >= threshold;
+
+  const suites = summary.results.map(result => {
+    const issues = [...result.issues].sort((a, b) => (a.line || 0) - (b.line || 0));
+    const failures = issues.filter(isFailure).length;
+    const cases = issues.map(issue => {
+      const name = `[${issue.severity}] ${issue.title}${issue.line ? ` (line ${issue.line})` : ''}`;
+      const details = [
+        `${result.file}${issue.line ? `:${issue.line}` : ''}`,
+        `Severity: ${issue.severity} | Type: ${issue.type}`,
+        issue.description,
+        issue.suggestion ? `Suggestion: ${issue.suggestion}` : ''
+      ].filter(Boolean).join('\n');
+
+      const body = isFailure(issue)
+        ? `      <failure message="${escape(issue.title)}" type="${escape(issue.severity)}">${escape(details)}</failure>\n`
+        : `      <system-out>${escape(details)}</system-out>\n`;
+      return `    <testcase name="${escape(name)}" classname="${escape(result.file)}" file="${escape(result.file)}"${issue.line ? ` line="${issue.line}"` : ''} time="0">\n${body}    </testcase>`;
+    });
+
+    return `  <testsuite name="${escape(result.file)}" tests="${issues.length}" failures="${failures}" errors="0" skipped="0" time="0">\n${cases.join('\n')}\n  </testsuite>`;
+  });
+
+  if (suites.length === 0) {
+    suites.push(`  <testsuite name="${SUITE_NAME}" tests="1" failures="0" errors="0" skipped="0" time="0">\n    <testcase name="No findings" classname="${SUITE_NAME}" time="0"/>\n  </testsuite>`);
+  }
+
+  const tests = Math.max(1, summary.totalIssues);
+  const failures = summary.results.reduce((sum, result) => sum + result.issues.filter(isFailure).length, 0);
+  return `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="${escape(`${SUITE_NAME}: ${summary.title}`)}" tests="${tests}" failures="${failures}" errors="0" time="0">
+${suites.join('\n')}
+</testsuites>
+`;
+}
+
+/**
+ * XML 특수문자 이스케이프 (XML 1.0에서 허용되지 않는 제어 문자 제거)
+ * @param {string} text - 원본 문자열
+ * @returns {string} 이스케이프된 문자열
+ */
+function escape(text) {
+  return String(text === null || text === undefined ? '' : text)
+    .replace(/[\u0000-\u0008\u000B\u000C\u000E-\u001F]/g, '')
+    .replace(/&/g, '&amp;')
+    .replace(/</g, '&lt;')
+    .replace(/>/g, '&gt;')
+    .replace(/"/g, '&quot;')
+    .replace(/'/g, '&apos;');
+}
+
+/**
+ * JUnit XML을 파일로 작성
+ * @param {Object} summary - buildReviewSummary() 결과
+ * @param {string|number} runId - 워크플로우 실행 ID
+ * @param {string} [failSeverity] - 실패로 기록할 최소 심각도
+ * @returns {string} 작성한 파일 경로
+ */
+function writeJunitReport(summary, runId, failSeverity = 'high') {
+  const dir = process.env.RUNNER_TEMP || os.tmpdir();
+  const filePath = path.join(dir, `claude-review-junit-${runId || Date.now()}.xml`);
+  fs.writeFileSync(filePath, buildJunitReport(summary, failSeverity));
+  return filePath;
+}
+
+module.exports = { buildJunitReport, writeJunitReport };
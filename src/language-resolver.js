@@ -0,0 +1,125 @@
+/**
+ * Language Resolver Module
+ * PR 작성자에 따라 리뷰 언어를 정하는 모듈
+ *
+ * language 입력값은 단일 언어 코드(예: ko) 또는 작성자별 매핑을 받습니다.
+ *   alice: ja
+ *   @acme/platform-kr: ko
+ *   default: en
+ *
+ * - 사용자 이름 규칙이 팀 규칙보다 우선하며, 팀 규칙은 위에서부터 확인합니다.
+ * - 팀 멤버십 조회에는 read:org 권한이 있는 토큰이 필요합니다 (실패 시 건너뜀).
+ */
+
+const core = require('@actions/core');
+const { SUPPORTED_LANGUAGES } = require('./code-reviewer');
+const { ConfigError } = require('./errors');
+
+/**
+ * 언어 코드 정규화 (대소문자 구분 없이 지원 언어와 비교)
+ * @param {string} code - 언어 코드
+ * @returns {string|null} 지원 언어 코드
+ */
+function normalizeLanguage(code) {
+  const lower = String(code || '').trim().toLowerCase();
+  return SUPPORTED_LANGUAGES.find(language => language.toLowerCase() === lower) || null;
+}
+
+/**
+ * language 입력값 파싱
+ * @param {string} text - 언어 코드 또는 "대상: 언어" 목록 (줄바꿈 또는 쉼표 구분)
+ * @returns {Object} { defaultLanguage, users: Map<login, 언어>, teams: [{ org, slug, language }] }
+ */
+function parseLanguageMapping(text) {
+  const value = (text || 'en').trim();
+  const mapping = { defaultLanguage: 'en', users: new Map(), teams: [] };
+
+  // 단일 언어 코드 (기존 형식)
+  if (!value.includes(':')) {
+    const language = normalizeLanguage(value);
+    if (!language) {
+      throw new ConfigError(`Unsupported language: ${value} (supported: ${SUPPORTED_LANGUAGES.join(', ')})`);
+    }
+    mapping.defaultLanguage = language;
+    return mapping;
+  }
+
+  value.split(/[\n,]/).map(entry => entry.trim()).filter(Boolean).forEach(entry => {
+    const separator = entry.lastIndexOf(':');
+    if (separator === -1) {
+      throw new ConfigError(`Invalid language mapping entry (expected "<user|@org/team|default>: <language>"): ${entry}`);
+    }
+    const target = entry.substring(0, separator).trim();
+    const language = normalizeLanguage(entry.substring(separator + 1));
+    if (!language) {
+      throw new ConfigError(`Unsupported language in mapping entry "${entry}" (supported: ${SUPPORTED_LANGUAGES.join(', ')})`);
+    }
+
+    if (target === 'default' || target === '*') {
+      mapping.defaultLanguage = language;
+    } else if (target.startsWith('@') && target.includes('/')) {
+      const [org, slug] = target.substring(1).split('/');
+      mapping.teams.push({ org, slug, language });
+    } else {
+      mapping.users.set(target.replace(/^@/, '').toLowerCase(), language);
+    }
+  });
+
+  return mapping;
+}
+
+/**
+ * 이벤트 작성자 (PR 작성자, 없으면 실행한 사용자)
+ * @param {Object} context - GitHub Actions 컨텍스트
+ * @returns {string|null} GitHub 사용자 이름
+ */
+function getAuthor(context) {
+  const pullRequest = context.payload && context.payload.pull_request;
+  if (pullRequest && pullRequest.user) {
+    return pullRequest.user.login;
+  }
+  return context.actor || null;
+}
+
+/**
+ * 작성자에 맞는 리뷰 언어 결정
+ * @param {Object} mapping - parseLanguageMapping() 결과
+ * @param {Object} context - GitHub Actions 컨텍스트
+ * @param {Object} octokit - GitHub API 클라이언트 (팀 규칙이 있을 때만 사용)
+ * @returns {Promise<string>} 언어 코드
+ */
+async function resolveLanguage(mapping, context, octokit) {
+  const author = getAuthor(context);
+  if (!author) {
+    return mapping.defaultLanguage;
+  }
+
+  const userLanguage = mapping.users.get(author.toLowerCase());
+  if (userLanguage) {
+    core.info(`Review language for ${author}: ${userLanguage}`);
+    return userLanguage;
+  }
+
+  for (const team of mapping.teams) {
+    try {
+      const { data } = await octokit.rest.teams.getMembershipForUserInOrg({
+        org: team.org,
+        team_slug: team.slug,
+        username: author
+      });
+      if (data.state === 'active') {
+        core.info(`Review language for ${author} (@${team.org}/${team.slug}): ${team.language}`);
+        return team.language;
+      }
+    } catch (error) {
+      // 404는 멤버가 아님, 그 외는 권한 부족 등
+      if (error.status !== 404) {
+        core.warning(`Could not check membership of @${team.org}/${team.slug}: ${error.message}`);
+      }
+    }
+  }
+
+  return mapping.defaultLanguage;
+}
+
+module.exports = { parseLanguageMapping, resolveLanguage, getAuthor };
@@ -0,0 +1,104 @@
+/**
+ * License Policy Module
+ * 라이선스/컴플라이언스 리뷰(review_type: compliance)에 사용할 저장소 라이선스와 헤더 관례를 관리하는 모듈
+ *
+ * - 저장소 라이선스는 project_license 입력값, LICENSE/COPYING 파일 본문, package.json의 license 순으로 결정
+ * - license_header를 지정하면 새 파일에 그 헤더가 있어야 하는 것으로 안내 (없으면 헤더 누락은 보고하지 않음)
+ */
+
+const fs = require('fs').promises;
+const path = require('path');
+
+// 저장소 라이선스 파일 후보
+const LICENSE_FILES = ['LICENSE', 'LICENSE.md', 'LICENSE.txt', 'LICENCE', 'COPYING', 'COPYING.md'];
+
+// 라이선스 본문 → SPDX 식별자 (더 구체적인 라이선스 먼저)
+const LICENSE_SIGNATURES = [
+  ['AGPL-3.0', /GNU AFFERO GENERAL PUBLIC LICENSE/i],
+  ['LGPL-3.0', /GNU LESSER GENERAL PUBLIC LICENSE\s+Version 3/i],
+  ['LGPL-2.1', /GNU LESSER GENERAL PUBLIC LICENSE\s+Version 2\.1/i],
+  ['GPL-3.0', /GNU GENERAL PUBLIC LICENSE\s+Version 3/i],
+  ['GPL-2.0', /GNU GENERAL PUBLIC LICENSE\s+Version 2/i],
+  ['Apache-2.0', /Apache License,?\s+Version 2\.0/i],
+  ['MPL-2.0', /Mozilla Public License,?\s+(?:v\.\s*|Version\s+)?2\.0/i],
+  ['BSD-3-Clause', /Redistribution and use in source and binary forms[\s\S]*Neither the name/i],
+  ['BSD-2-Clause', /Redistribution and use in source and binary forms/i],
+  ['ISC', /Permission to use, copy, modify, and\/or distribute this software/i],
+  ['MIT', /Permission is hereby granted, free of charge/i],
+  ['Unlicense', /This is free and unencumbered software released into the public domain/i]
+];
+
+class LicensePolicy {
+  /**
+   * LicensePolicy 생성자
+   * @param {Object} [options] - 옵션
+   * @param {string|null} [options.license] - 저장소 라이선스 SPDX 식별자 (모르면 null)
+   * @param {string|null} [options.source] - 라이선스를 결정한 근거 (입력값, 파일 경로)
+   * @param {string|null} [options.header] - 새 파일에 있어야 하는 라이선스 헤더
+   */
+  constructor({ license = null, source = null, header = null } = {}) {
+    this.license = license;
+    this.source = source;
+    this.header = header;
+  }
+
+  /**
+   * 저장소 라이선스와 헤더 관례 로드
+   * @param {Object} [options] - 옵션
+   * @param {string} [options.license] - 입력값으로 지정한 SPDX 식별자 (지정하면 감지하지 않음)
+   * @param {string} [options.header] - 새 파일에 있어야 하는 라이선스 헤더
+   * @param {string} [options.root] - 저장소 루트 (기본값: 현재 디렉터리)
+   * @returns {Promise<LicensePolicy>} 라이선스 정책
+   */
+  static async load({ license = '', header = '', root = process.cwd() } = {}) {
+    if (license) {
+      return new LicensePolicy({ license, source: 'project_license', header: header || null });
+    }
+
+    for (const name of LICENSE_FILES) {
+      const text = await fs.readFile(path.join(root, name), 'utf8').catch(() => null);
+      const detected = text && LicensePolicy.detect(text);
+      if (detected) {
+        return new LicensePolicy({ license: detected, source: name, header: header || null });
+      }
+    }
+
+    // 라이선스 파일이 없으면 package.json의 license 필드 (잘못된 JSON은 라이선스를 모르는 것으로 처리)
+    const manifest = await fs.readFile(path.join(root, 'package.json'), 'utf8').catch(() => '{}');
+    let declared = null;
+    try {
+      declared = JSON.parse(manifest).license;
+    } catch (error) {
+      declared = null;
+    }
+    return typeof declared === 'string' && declared
+      ? new LicensePolicy({ license: declared, source: 'package.json', header: header || null })
+      : new LicensePolicy({ header: header || null });
+  }
+
+  /**
+   * 라이선스 본문에서 SPDX 식별자 감지
+   * @param {string} text - 라이선스 파일 본문
+   * @returns {string|null} SPDX 식별자 (모르는 라이선스면 null)
+   */
+  static detect(text) {
+    const signature = LICENSE_SIGNATURES.find(([, pattern]) => pattern.test(text));
+    return signature ? signature[0] : null;
+  }
+
+  /**
+   * 컴플라이언스 리뷰 프롬프트에 넣을 지시사항
+   * @returns {string} 지시사항
+   */
+  buildInstruction() {
+    let instruction = this.license
+      ? `\n\n이 저장소의 라이선스는 ${this.license}입니다 (${this.source}). 이 라이선스와 함께 배포할 수 없는 라이선스(예: 허용형 라이선스 저장소의 GPL/AGPL 코드)의 헤더나 코드는 high로 보고하세요.`
+      : '\n\n이 저장소의 라이선스를 알 수 없습니다. 다른 프로젝트의 라이선스 헤더나 카피레프트(GPL/AGPL) 코드는 확인이 필요하다고 보고하세요.';
+    instruction += this.header
+      ? `\n새로 추가된 파일은 다음 라이선스 헤더를 포함해야 합니다. 없거나 다르면 code_example에 추가할 헤더를 넣어 보고하세요:\n\`\`\`\n${this.header}\n\`\`\``
+      : '\n라이선스 헤더 관례가 지정되지 않았으므로 헤더가 없는 것 자체는 보고하지 마세요.';
+    return instruction;
+  }
+}
+
+module.exports = LicensePolicy;
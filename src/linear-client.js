@@ -0,0 +1,185 @@
+/**
+ * Linear Integration Module
+ * 심각한 이슈를 Linear 이슈로 등록하는 모듈 (Jira 연동과 동일한 흐름)
+ *
+ * 주요 기능:
+ * - 팀 키/라벨 이름을 Linear ID로 변환
+ * - 심각도를 Linear 우선순위로 매핑
+ * - 설명에 포함한 지문(fingerprint)으로 기존 이슈 검색 (중복 생성 방지)
+ */
+
+const core = require('@actions/core');
+const { getSeverityLevel } = require('./review-summary');
+
+const LINEAR_API_URL = 'https://api.linear.app/graphql';
+
+// 심각도 → Linear 우선순위 (1: Urgent, 2: High, 3: Medium, 4: Low)
+const PRIORITY_BY_SEVERITY = {
+  critical: 1,
+  high: 2,
+  medium: 3,
+  low: 4
+};
+
+class LinearClient {
+  /**
+   * LinearClient 생성자
+   * @param {Object} config - 설정
+   * @param {string} config.apiKey - Linear API 키
+   * @param {string} config.teamKey - 이슈를 생성할 팀 키 (예: ENG)
+   * @param {string} [config.label] - 생성한 이슈에 붙일 라벨 이름
+   * @param {string} [config.minSeverity] - 이 심각도 이상 이슈만 등록
+   */
+  constructor({ apiKey, teamKey, label, minSeverity = 'critical' }) {
+    this.apiKey = apiKey;
+    this.teamKey = teamKey;
+    this.label = label;
+    this.minSeverity = minSeverity;
+  }
+
+  /**
+   * GraphQL 요청
+   * @param {string} query - GraphQL 쿼리
+   * @param {Object} variables - 변수
+   * @returns {Promise<Object>} data 필드
+   */
+  async graphql(query, variables) {
+    const response = await fetch(LINEAR_API_URL, {
+      method: 'POST',
+      headers: {
+        Authorization: this.apiKey,
+        'Content-Type': 'application/json'
+      },
+      body: JSON.stringify({ query, variables }),
+      signal: AbortSignal.timeout(15000)
+    });
+
+    const result = await response.json();
+    if (!response.ok || result.errors) {
+      const message = result.errors ? result.errors.map(e => e.message).join('; ') : `HTTP ${response.status}`;
+      throw new Error(`Linear API error: ${message}`);
+    }
+    return result.data;
+  }
+
+  /**
+   * 팀 키와 라벨 이름을 ID로 변환
+   * @returns {Promise<Object>} { teamId, labelId }
+   */
+  async resolveIds() {
+    const data = await this.graphql(`
+      query Resolve($teamKey: String!, $label: String!) {
+        teams(filter: { key: { eq: $teamKey } }) { nodes { id } }
+        issueLabels(filter: { name: { eq: $label } }) { nodes { id } }
+      }`, { teamKey: this.teamKey, label: this.label || '' });
+
+    if (data.teams.nodes.length === 0) {
+      throw new Error(`Linear team not found: ${this.teamKey}`);
+    }
+    return {
+      teamId: data.teams.nodes[0].id,
+      labelId: this.label && data.issueLabels.nodes.length > 0 ? data.issueLabels.nodes[0].id : null
+    };
+  }
+
+  /**
+   * 지문으로 기존 이슈 검색
+   * @param {string} teamId - 팀 ID
+   * @param {string} fingerprint - 이슈 지문
+   * @returns {Promise<string|null>} 기존 이슈 식별자 (예: ENG-123)
+   */
+  async findExisting(teamId, fingerprint) {
+    const data = await this.graphql(`
+      query Existing($teamId: ID!, $marker: String!) {
+        issues(first: 1, filter: { team: { id: { eq: $teamId } }, description: { contains: $marker } }) {
+          nodes { identifier }
+        }
+      }`, { teamId, marker: `Fingerprint: ${fingerprint}` });
+
+    const nodes = data.issues.nodes;
+    return nodes.length > 0 ? nodes[0].identifier : null;
+  }
+
+  /**
+   * Markdown 형식의 이슈 설명 생성
+   * @param {Object} finding - { file, ...issue }
+   * @param {Object} link - { title, url } PR 또는 커밋 링크
+   * @returns {string} 설명
+   */
+  buildDescription(finding, link) {
+    const location = finding.line ? `${finding.file}:${finding.line}` : finding.file;
+    let description = `**Claude AI Code Review** found a ${finding.severity} ${finding.type} issue.\n\n`;
+    description += `**Location:** \`${location}\`\n`;
+    description += `**Source:** [${link.title}](${link.url})\n\n`;
+    description += `### Problem\n${finding.description || finding.title}\n\n`;
+
+    if (finding.snippet) {
+      description += `### Code\n\`\`\`\n${finding.snippet.code}\n\`\`\`\n\n`;
+    }
+    if (finding.suggestion) {
+      description += `### Suggested fix\n${finding.suggestion}\n\n`;
+    }
+    if (finding.codeExample) {
+      description += `\`\`\`\n${finding.codeExample}\n\`\`\`\n\n`;
+    }
+
+    description += `---\n_Fingerprint: ${finding.fingerprint}_`;
+    return description;
+  }
+
+  /**
+   * 임계값 이상 이슈를 Linear에 등록 (지문이 같은 기존 이슈가 있으면 건너뜀)
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @param {Object} link - { title, url } PR 또는 커밋 링크
+   * @returns {Promise<Array>} 생성되거나 이미 존재하는 이슈 식별자 목록
+   */
+  async syncFindings(reviewResults, link) {
+    const threshold = getSeverityLevel(this.minSeverity);
+    const findings = [];
+    reviewResults.forEach(result => {
+      result.issues
+        .filter(issue => getSeverityLevel(issue.severity) >= threshold)
+        .forEach(issue => findings.push({ file: result.file, ...issue }));
+    });
+
+    if (findings.length === 0) {
+      return [];
+    }
+
+    const { teamId, labelId } = await this.resolveIds();
+    const identifiers = [];
+
+    for (const finding of findings) {
+      try {
+        const existing = await this.findExisting(teamId, finding.fingerprint);
+        if (existing) {
+          core.info(`Linear issue ${existing} already tracks ${finding.file}: ${finding.title}`);
+          identifiers.push(existing);
+          continue;
+        }
+
+        const data = await this.graphql(`
+          mutation Create($input: IssueCreateInput!) {
+            issueCreate(input: $input) { success issue { identifier } }
+          }`, {
+          input: {
+            teamId,
+            title: `[${finding.severity}] ${finding.title} (${finding.file})`,
+            description: this.buildDescription(finding, link),
+            priority: PRIORITY_BY_SEVERITY[finding.severity] || 3,
+            labelIds: labelId ? [labelId] : []
+          }
+        });
+        const identifier = data.issueCreate.issue.identifier;
+        core.info(`Created Linear issue ${identifier} for ${finding.file}: ${finding.title}`);
+        identifiers.push(identifier);
+      } catch (error) {
+        core.warning(`Failed to sync Linear issue for ${finding.file}: ${error.message}`);
+      }
+    }
+
+    return identifiers;
+  }
+}
+
+module.exports = LinearClient;
@@ -0,0 +1,165 @@
+/**
+ * Message Batches Module
+ * Anthropic Message Batches API로 요청을 모아 보내는 Claude 클라이언트
+ *
+ * 급하지 않은 리뷰(정기 감사 등)에서 응답 지연 대신 약 50% 할인된 가격으로 실행하기 위한 모드입니다.
+ * claude-client.js의 다른 제공자와 같은 인터페이스(messages.create(params).withResponse())를 따르므로
+ * 리뷰 파이프라인은 그대로 동작합니다.
+ *
+ * 동작 방식:
+ * - 요청은 바로 보내지 않고 대기열에 쌓았다가, 새 요청이 IDLE_MS 동안 없으면 한 배치로 제출
+ *   (파일별 리뷰는 병렬로 시작되므로 대부분 한 배치에 모임. 중재 패스처럼 앞선 응답이 필요한 요청은 다음 배치)
+ * - 배치가 끝날 때까지 상태를 주기적으로 조회하고, 결과 JSONL을 custom_id로 각 요청에 돌려줌
+ * - 제한 시간 안에 끝나지 않으면 배치를 취소하고 남은 요청은 실패 처리
+ */
+
+const core = require('@actions/core');
+
+const DEFAULT_BASE_URL = 'https://api.anthropic.com';
+const ANTHROPIC_VERSION = '2023-06-01';
+
+// 마지막 요청 후 배치를 제출하기까지 기다리는 시간
+const IDLE_MS = 5000;
+// 배치 상태 조회 간격
+const POLL_INTERVAL_MS = 30000;
+
+// 배치 결과의 오류 타입별 HTTP 상태 (실시간 요청과 같은 기준으로 모델 대체를 판단하도록)
+const ERROR_STATUS = {
+  invalid_request_error: 400,
+  rate_limit_error: 429,
+  api_error: 500,
+  overloaded_error: 529
+};
+
+class MessageBatchClient {
+  /**
+   * MessageBatchClient 생성자
+   * @param {Object} options - 옵션
+   * @param {string} options.apiKey - Anthropic API 키
+   * @param {number} options.timeoutMs - 배치 하나를 기다리는 최대 시간
+   * @param {string} [options.baseUrl] - API 주소 (ANTHROPIC_BASE_URL 환경 변수 또는 공개 API)
+   */
+  constructor({ apiKey, timeoutMs, baseUrl = process.env.ANTHROPIC_BASE_URL || DEFAULT_BASE_URL }) {
+    this.apiKey = apiKey;
+    this.timeoutMs = timeoutMs;
+    this.baseUrl = baseUrl.replace(/\/+$/, '');
+    this.pending = [];
+    this.timer = null;
+    this.nextId = 0;
+    this.messages = {
+      create: params => ({
+        withResponse: () => this.enqueue(params)
+      })
+    };
+  }
+
+  /**
+   * 요청을 대기열에 추가 (새 요청이 없으면 IDLE_MS 뒤에 제출)
+   * @param {Object} params - messages.create() 파라미터
+   * @returns {Promise<Object>} { data: 응답 메시지, response: { headers } }
+   */
+  enqueue(params) {
+    return new Promise((resolve, reject) => {
+      this.pending.push({ customId: `request-${++this.nextId}`, params, resolve, reject });
+      clearTimeout(this.timer);
+      this.timer = setTimeout(() => this.flush(), IDLE_MS);
+    });
+  }
+
+  /**
+   * Message Batches API 호출
+   * @param {string} method - HTTP 메서드
+   * @param {string} path - API 경로 또는 전체 URL
+   * @param {Object} [body] - 요청 본문
+   * @returns {Promise<string>} 응답 본문
+   */
+  async request(method, path, body) {
+    const response = await fetch(path.startsWith('http') ? path : `${this.baseUrl}${path}`, {
+      method,
+      headers: {
+        'x-api-key': this.apiKey,
+        'anthropic-version': ANTHROPIC_VERSION,
+        'content-type': 'application/json'
+      },
+      body: body ? JSON.stringify(body) : undefined,
+      signal: AbortSignal.timeout(60000)
+    });
+    const text = await response.text();
+    if (!response.ok) {
+      const error = new Error(`Message Batches API ${response.status}: ${text.substring(0, 200)}`);
+      error.status = response.status;
+      throw error;
+    }
+    return text;
+  }
+
+  /**
+   * 대기 중인 요청을 한 배치로 제출하고 결과를 각 요청에 전달
+   */
+  async flush() {
+    const requests = this.pending;
+    this.pending = [];
+    if (requests.length === 0) {
+      return;
+    }
+
+    try {
+      let batch = JSON.parse(await this.request('POST', '/v1/messages/batches', {
+        requests: requests.map(({ customId, params }) => ({ custom_id: customId, params }))
+      }));
+      core.info(`Submitted message batch ${batch.id} with ${requests.length} requests`);
+
+      const deadline = Date.now() + this.timeoutMs;
+      while (batch.processing_status !== 'ended') {
+        if (Date.now() >= deadline) {
+          await this.request('POST', `/v1/messages/batches/${batch.id}/cancel`).catch(() => {});
+          throw new Error(`Message batch ${batch.id} did not finish within ${Math.round(this.timeoutMs / 60000)} minutes (canceled)`);
+        }
+        await new Promise(resolve => setTimeout(resolve, POLL_INTERVAL_MS));
+        batch = JSON.parse(await this.request('GET', `/v1/messages/batches/${batch.id}`));
+        const counts = batch.request_counts;
+        core.info(`Message batch ${batch.id}: ${batch.processing_status} (${counts.succeeded} succeeded, ${counts.processing} processing, ${counts.errored} errored)`);
+      }
+
+      const results = new Map((await this.request('GET', batch.results_url))
+        .split('\n')
+        .filter(line => line.trim())
+        .map(line => JSON.parse(line))
+        .map(item => [item.custom_id, item.result]));
+      const response = { headers: { get: name => (name === 'request-id' ? batch.id : null) } };
+      requests.forEach(({ customId, resolve, reject }) => {
+        const result = results.get(customId);
+        if (result && result.type === 'succeeded') {
+          resolve({ data: result.message, response });
+        } else {
+          reject(MessageBatchClient.resultError(batch.id, result));
+        }
+      });
+    } catch (error) {
+      requests.forEach(({ reject }) => reject(error));
+    }
+  }
+
+  /**
+   * 실패한 배치 결과를 오류로 변환
+   * errored 결과는 일반 API 오류와 같은 본문(error.error.type)을 유지해 모델 대체 판단에 사용
+   * @param {string} batchId - 배치 ID
+   * @param {Object} [result] - 요청 결과 (errored, canceled, expired)
+   * @returns {Error} 오류
+   */
+  static resultError(batchId, result) {
+    if (!result) {
+      return new Error(`No result for request in message batch ${batchId}`);
+    }
+    if (result.type !== 'errored') {
+      return new Error(`Request ${result.type} in message batch ${batchId}`);
+    }
+    const body = result.error || {};
+    const error = new Error(`${body.error ? `${body.error.type}: ${body.error.message}` : 'errored'} (message batch ${batchId})`);
+    error.error = body;
+    error.status = body.error ? ERROR_STATUS[body.error.type] : undefined;
+    return error;
+  }
+}
+
+module.exports = MessageBatchClient;
@@ -0,0 +1,166 @@
+/**
+ * Notification Router Module
+ * 심각도, 이슈 타입, 파일 경로 규칙에 따라 이슈별 알림 대상을 정하는 모듈
+ *
+ * 규칙 형식 (notify_routes 입력값, 한 줄에 하나):
+ *   severity=critical type=security path=auth/** -> slack:#security
+ *   type=style -> none
+ *
+ * - severity: 이 심각도 이상 (생략 시 모든 심각도)
+ * - type: 쉼표로 구분한 이슈 타입 (생략 시 모든 타입)
+ * - path: 파일 경로 glob (생략 시 모든 파일)
+ * - 대상: 쉼표로 구분한 알림 대상, slack은 slack:#채널 형식으로 채널 지정 가능, none은 알림 없음
+ *
+ * 이슈마다 위에서부터 처음 일치한 규칙 하나만 적용되며,
+ * 어떤 규칙에도 맞지 않는 이슈는 기존 설정(notify, *_min_severity)대로 전송됩니다.
+ */
+
+const { minimatch } = require('minimatch');
+const { getSeverityLevel } = require('./review-summary');
+const { ConfigError } = require('./errors');
+
+const ROUTE_KEYS = ['severity', 'type', 'path'];
+
+/**
+ * 규칙의 조건 부분 파싱 ("severity=high type=security path=auth/**")
+ * @param {string} text - 조건 텍스트
+ * @param {string} line - 오류 메시지에 표시할 규칙 줄
+ * @param {string} kind - 오류 메시지에 표시할 규칙 종류
+ * @returns {Object} { severity, types, path }
+ */
+function parseConditions(text, line, kind) {
+  const conditions = { severity: null, types: null, path: null };
+  text.trim().split(/\s+/).filter(Boolean).forEach(condition => {
+    const [key, value] = condition.split('=');
+    if (!ROUTE_KEYS.includes(key) || !value) {
+      throw new ConfigError(`Invalid ${kind} condition "${condition}" in: ${line}`);
+    }
+    if (key === 'severity') {
+      if (!['low', 'medium', 'high', 'critical'].includes(value.toLowerCase())) {
+        throw new ConfigError(`Invalid severity "${value}" in ${kind}: ${line}`);
+      }
+      conditions.severity = value.toLowerCase();
+    } else if (key === 'type') {
+      conditions.types = value.toLowerCase().split(',').filter(Boolean);
+    } else {
+      conditions.path = value;
+    }
+  });
+  return conditions;
+}
+
+/**
+ * 이슈가 규칙의 조건에 맞는지 확인
+ * @param {Object} route - parseConditions() 결과를 포함한 규칙
+ * @param {string} file - 파일 경로
+ * @param {Object} issue - 이슈
+ * @returns {boolean} 모든 조건에 맞으면 true
+ */
+function matchesConditions(route, file, issue) {
+  return (!route.severity || getSeverityLevel(issue.severity) >= getSeverityLevel(route.severity)) &&
+    (!route.types || route.types.includes(String(issue.type).toLowerCase())) &&
+    (!route.path || minimatch(file, route.path, { dot: true }));
+}
+
+/**
+ * 규칙 텍스트 파싱
+ * @param {string} text - 여러 줄 규칙 (빈 줄과 #으로 시작하는 줄은 무시)
+ * @param {Array<string>} knownTargets - 허용되는 알림 대상
+ * @returns {Array} 규칙 배열 { severity, types, path, targets: [{ target, channel }] }
+ */
+function parseRoutes(text, knownTargets) {
+  return (text || '')
+    .split('\n')
+    .map(line => line.trim())
+    .filter(line => line && !line.startsWith('#'))
+    .map(line => {
+      const arrow = line.indexOf('->');
+      if (arrow === -1) {
+        throw new ConfigError(`Invalid notify route (missing "->"): ${line}`);
+      }
+
+      const route = { ...parseConditions(line.substring(0, arrow), line, 'notify route'), targets: [] };
+
+      line.substring(arrow + 2).split(',').map(t => t.trim()).filter(Boolean).forEach(spec => {
+        const [target, channel] = spec.split(':');
+        const name = target.toLowerCase();
+        if (name === 'none') {
+          return;
+        }
+        if (!knownTargets.includes(name)) {
+          throw new ConfigError(`Unknown notify target "${target}" in notify route: ${line}`);
+        }
+        if (channel && name !== 'slack') {
+          throw new ConfigError(`Only slack targets accept a channel in notify route: ${line}`);
+        }
+        route.targets.push({ target: name, channel: channel || null });
+      });
+
+      return route;
+    });
+}
+
+class NotificationRouter {
+  /**
+   * NotificationRouter 생성자
+   * @param {Array} routes - parseRoutes() 결과
+   */
+  constructor(routes = []) {
+    this.routes = routes;
+  }
+
+  /**
+   * 이슈에 처음 일치하는 규칙 조회
+   * @param {string} file - 파일 경로
+   * @param {Object} issue - 이슈
+   * @returns {Object|null} 규칙
+   */
+  match(file, issue) {
+    return this.routes.find(route => matchesConditions(route, file, issue)) || null;
+  }
+
+  /**
+   * 리뷰 결과를 알림 대상별로 분배
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @returns {Object} { routed: Map<'대상' | '대상:채널', 결과>, unrouted: 규칙에 맞지 않는 결과 }
+   */
+  route(reviewResults) {
+    // 키별로 파일 단위 결과를 다시 묶음
+    const buckets = new Map();
+    const add = (key, result, issue) => {
+      if (!buckets.has(key)) {
+        buckets.set(key, new Map());
+      }
+      const files = buckets.get(key);
+      if (!files.has(result.file)) {
+        files.set(result.file, { ...result, issues: [] });
+      }
+      files.get(result.file).issues.push(issue);
+    };
+
+    reviewResults.forEach(result => {
+      result.issues.forEach(issue => {
+        const route = this.match(result.file, issue);
+        if (!route) {
+          add(null, result, issue);
+          return;
+        }
+        route.targets.forEach(({ target, channel }) => {
+          add(channel ? `${target}:${channel}` : target, result, issue);
+        });
+      });
+    });
+
+    const unrouted = buckets.has(null) ? [...buckets.get(null).values()] : [];
+    buckets.delete(null);
+    const routed = new Map();
+    buckets.forEach((files, key) => routed.set(key, [...files.values()]));
+
+    return { routed, unrouted };
+  }
+}
+
+module.exports = NotificationRouter;
+module.exports.parseRoutes = parseRoutes;
+module.exports.parseConditions = parseConditions;
+module.exports.matchesConditions = matchesConditions;
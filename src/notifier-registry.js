@@ -0,0 +1,71 @@
+/**
+ * Notifier Registry Module
+ * 메시지 형식별 Notifier 등록과 notify_url 하나로 원하는 형식의 알림을 보내는 모듈
+ *
+ * 모든 Notifier는 같은 인터페이스를 따릅니다:
+ *   new Notifier({ webhookUrl, minSeverity, labels })
+ *   notify(summary) - buildReviewSummary() 결과를 받아 전송 (실패는 경고로만 기록)
+ *
+ * 새 형식은 NOTIFIER_FORMATS에 생성 함수를 추가하면 notify_format으로 선택할 수 있습니다.
+ * notify_format이 auto이면 URL 호스트로 형식을 판단합니다.
+ */
+
+const SlackNotifier = require('./slack-notifier');
+const TeamsNotifier = require('./teams-notifier');
+const DiscordNotifier = require('./discord-notifier');
+const WebhookNotifier = require('./webhook-notifier');
+
+// 형식별 Notifier 생성 함수
+const NOTIFIER_FORMATS = {
+  slack: ({ url, minSeverity, labels }) => new SlackNotifier({ webhookUrl: url, minSeverity, labels }),
+  teams: ({ url, minSeverity, labels }) => new TeamsNotifier({ webhookUrl: url, minSeverity, labels }),
+  discord: ({ url, minSeverity, labels }) => new DiscordNotifier({ webhookUrl: url, minSeverity, labels }),
+  // 전체 JSON 리포트 (이슈 유무와 관계없이 매 실행 전송)
+  json: ({ url, labels }) => new WebhookNotifier({ url, labels })
+};
+
+// URL 호스트로 형식 판단 (auto)
+const FORMAT_HOSTS = [
+  { format: 'slack', pattern: /(^|\.)slack\.com$/ },
+  { format: 'discord', pattern: /(^|\.)(discord|discordapp)\.com$/ },
+  { format: 'teams', pattern: /(^|\.)(webhook\.office\.com|logic\.azure\.com|powerautomate\.com)$/ }
+];
+
+/**
+ * URL에서 메시지 형식 추정
+ * @param {string} url - 알림 URL
+ * @returns {string|null} 형식 (알 수 없으면 null)
+ */
+function detectFormat(url) {
+  let host;
+  try {
+    host = new URL(url).hostname.toLowerCase();
+  } catch (error) {
+    return null;
+  }
+  const match = FORMAT_HOSTS.find(({ pattern }) => pattern.test(host));
+  return match ? match.format : null;
+}
+
+/**
+ * 형식에 맞는 Notifier 생성
+ * @param {Object} options - 옵션
+ * @param {string} options.url - 알림 URL
+ * @param {string} [options.format] - 메시지 형식 (auto 또는 NOTIFIER_FORMATS의 키)
+ * @param {string} [options.minSeverity] - 이 심각도 이상 이슈가 있을 때만 전송 (json 형식은 무시)
+ * @param {DisplayLabels} [options.labels] - 심각도 아이콘/표시 이름
+ * @returns {Object} notify(summary) 메서드를 가진 객체
+ */
+function createFormattedNotifier({ url, format = 'auto', minSeverity = 'high', labels }) {
+  const resolved = format === 'auto' ? detectFormat(url) : format;
+  if (!NOTIFIER_FORMATS[resolved]) {
+    throw new Error(`Cannot determine notify format for ${url}`);
+  }
+  return NOTIFIER_FORMATS[resolved]({ url, minSeverity, labels });
+}
+
+module.exports = {
+  NOTIFIER_FORMATS,
+  detectFormat,
+  createFormattedNotifier
+};
@@ -0,0 +1,138 @@
+/**
+ * PagerDuty Integration Module
+ * 보호 브랜치 대상 리뷰에서 심각한 보안 이슈가 발견되면 보안 온콜을 호출하는 모듈
+ *
+ * 주요 기능:
+ * - 대상 브랜치(PR base 또는 push 브랜치)가 보호 브랜치 패턴에 해당하는지 확인
+ * - critical 보안 이슈마다 Events API v2 trigger 이벤트 전송
+ * - 이슈 지문 기반 dedup_key로 같은 이슈의 중복 호출 방지
+ */
+
+const core = require('@actions/core');
+const { minimatch } = require('minimatch');
+const { postJson } = require('./webhook-client');
+
+const PAGERDUTY_EVENTS_URL = 'https://events.pagerduty.com/v2/enqueue';
+
+class PagerDutyClient {
+  /**
+   * PagerDutyClient 생성자
+   * @param {Object} config - 설정
+   * @param {string} config.routingKey - Events API v2 통합 키
+   * @param {Array<string>} config.branches - 보호 브랜치 패턴 (glob)
+   */
+  constructor({ routingKey, branches }) {
+    this.routingKey = routingKey;
+    this.branches = branches;
+  }
+
+  /**
+   * 이벤트가 향하는 브랜치 이름
+   * PR은 병합 대상(base) 브랜치, push는 푸시된 브랜치
+   * @param {Object} context - GitHub Actions 컨텍스트
+   * @returns {string|null} 브랜치 이름
+   */
+  getTargetBranch(context) {
+    const payload = context.payload || {};
+    if (payload.pull_request) {
+      return payload.pull_request.base.ref;
+    }
+    if (context.ref && context.ref.startsWith('refs/heads/')) {
+      return context.ref.substring('refs/heads/'.length);
+    }
+    return null;
+  }
+
+  /**
+   * 보호 브랜치 대상 여부
+   * @param {Object} context - GitHub Actions 컨텍스트
+   * @returns {boolean} 보호 브랜치이면 true
+   */
+  isProtectedTarget(context) {
+    const branch = this.getTargetBranch(context);
+    return Boolean(branch) && this.branches.some(pattern => minimatch(branch, pattern));
+  }
+
+  /**
+   * 호출 대상 이슈 선별 (critical 보안 이슈)
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @returns {Array} { file, ...issue } 배열
+   */
+  selectFindings(reviewResults) {
+    const findings = [];
+    reviewResults.forEach(result => {
+      result.issues
+        .filter(issue => issue.severity === 'critical' && issue.type === 'security')
+        .forEach(issue => findings.push({ file: result.file, ...issue }));
+    });
+    return findings;
+  }
+
+  /**
+   * Events API v2 trigger 이벤트 생성
+   * @param {Object} finding - { file, ...issue }
+   * @param {Object} context - GitHub Actions 컨텍스트
+   * @param {Object} link - { title, url } PR 또는 커밋 링크
+   * @returns {Object} 이벤트 페이로드
+   */
+  buildEvent(finding, context, link) {
+    const repository = `${context.repo.owner}/${context.repo.repo}`;
+    const location = finding.line ? `${finding.file}:${finding.line}` : finding.file;
+
+    return {
+      routing_key: this.routingKey,
+      event_action: 'trigger',
+      // 같은 리포지토리의 같은 이슈는 하나의 인시던트로 묶음
+      dedup_key: `claude-review:${repository}:${finding.fingerprint}`,
+      payload: {
+        summary: `Critical security finding in ${repository}: ${finding.title} (${location})`.substring(0, 1024),
+        source: repository,
+        severity: 'critical',
+        component: finding.file,
+        group: this.getTargetBranch(context),
+        class: 'security',
+        custom_details: {
+          description: finding.description,
+          suggestion: finding.suggestion,
+          location,
+          source: link.title
+        }
+      },
+      links: [{ href: link.url, text: link.title }],
+      client: 'Claude AI Code Review',
+      client_url: link.url
+    };
+  }
+
+  /**
+   * 조건 충족 시 PagerDuty 이벤트 전송
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @param {Object} context - GitHub Actions 컨텍스트
+   * @param {Object} link - { title, url } PR 또는 커밋 링크
+   * @returns {Promise<number>} 전송한 이벤트 수
+   */
+  async alert(reviewResults, context, link) {
+    if (!this.isProtectedTarget(context)) {
+      core.info(`PagerDuty skipped: ${this.getTargetBranch(context) || 'unknown branch'} is not a protected branch`);
+      return 0;
+    }
+
+    const findings = this.selectFindings(reviewResults);
+    let sent = 0;
+    for (const finding of findings) {
+      try {
+        await postJson(PAGERDUTY_EVENTS_URL, this.buildEvent(finding, context, link));
+        sent++;
+      } catch (error) {
+        core.warning(`Failed to trigger PagerDuty event for ${finding.file}: ${error.message}`);
+      }
+    }
+
+    if (sent > 0) {
+      core.info(`Triggered ${sent} PagerDuty events for critical security findings`);
+    }
+    return sent;
+  }
+}
+
+module.exports = PagerDutyClient;
@@ -0,0 +1,105 @@
+/**
+ * Reviewer Personas Module
+ * 관점별 리뷰어 페르소나 (프롬프트, 이슈 타입 가중치, 기본 어조 묶음)
+ *
+ * persona 입력값에 하나를 지정하면 해당 관점으로 리뷰하고,
+ * 여러 개를 쉼표로 지정하면 페르소나마다 따로 리뷰한 뒤 결과를 합칩니다 (멀티 에이전트 모드).
+ */
+
+const { getSeverityLevel } = require('./review-summary');
+
+const PERSONAS = {
+  // 애플리케이션 보안 엔지니어
+  appsec: {
+    name: 'Application Security Engineer',
+    prompt: `당신은 애플리케이션 보안 엔지니어입니다. 공격자의 관점에서 다음 코드 변경사항을 리뷰해주세요.
+
+리뷰 관점:
+- 인젝션 (SQL, 명령어, 템플릿), XSS, SSRF
+- 인증/인가 우회 및 권한 상승
+- 비밀값 하드코딩 및 민감 정보 로깅
+- 안전하지 않은 역직렬화와 암호화 사용
+- 신뢰할 수 없는 입력의 검증 누락`,
+    weights: { security: 3, bug: 1, performance: 0.5, maintainability: 0.5, style: 0 },
+    tone: 'concise'
+  },
+
+  // SRE / 성능 엔지니어
+  sre: {
+    name: 'SRE / Performance Engineer',
+    prompt: `당신은 SRE이자 성능 엔지니어입니다. 운영 환경에서의 안정성과 성능 관점으로 다음 코드 변경사항을 리뷰해주세요.
+
+리뷰 관점:
+- 타임아웃, 재시도, 서킷 브레이커 누락
+- 리소스 누수 (커넥션, 파일 핸들, 고루틴/스레드)
+- N+1 쿼리, 불필요한 할당, 핫 패스의 비효율
+- 관측성 (로그, 메트릭, 트레이스) 부족
+- 장애 시 동작과 점진적 성능 저하`,
+    weights: { performance: 3, bug: 2, security: 1, maintainability: 0.5, style: 0 },
+    tone: 'terse-senior'
+  },
+
+  // API 설계 리뷰어
+  'api-design': {
+    name: 'API Design Reviewer',
+    prompt: `당신은 API 설계 리뷰어입니다. 공개 인터페이스를 사용하는 개발자 관점으로 다음 코드 변경사항을 리뷰해주세요.
+
+리뷰 관점:
+- 하위 호환성을 깨는 변경
+- 이름, 파라미터 순서, 반환 타입의 일관성
+- 오류 표현 방식과 상태 코드
+- 페이지네이션, 버전 관리, 멱등성
+- 문서화되지 않은 동작`,
+    weights: { maintainability: 3, bug: 2, style: 1, security: 1, performance: 0.5 },
+    tone: 'educational'
+  },
+
+  // 접근성 전문가
+  accessibility: {
+    name: 'Accessibility Specialist',
+    prompt: `당신은 웹 접근성 전문가입니다. WCAG 2.2 기준으로 다음 코드 변경사항을 리뷰해주세요.
+
+리뷰 관점:
+- 대체 텍스트, 레이블, ARIA 속성의 올바른 사용
+- 키보드 탐색과 포커스 관리
+- 색상 대비와 색상에만 의존하는 정보 전달
+- 스크린 리더를 위한 시맨틱 마크업
+- 동적 콘텐츠 변경 알림`,
+    weights: { bug: 3, maintainability: 2, style: 1, security: 0.5, performance: 0.5 },
+    tone: 'mentor'
+  }
+};
+
+/**
+ * 이슈 타입 가중치 적용 (가중치 0인 타입 제외, 가중 심각도 순 정렬)
+ * @param {Array} issues - 이슈 목록
+ * @param {Object} weights - 타입별 가중치 (없는 타입은 1)
+ * @returns {Array} 가중치가 적용된 이슈 목록
+ */
+function applyWeights(issues, weights) {
+  return issues
+    .filter(issue => weightOf(issue, weights) > 0)
+    .sort((a, b) => weightedSeverity(b, weights) - weightedSeverity(a, weights));
+}
+
+/**
+ * 이슈 타입의 가중치
+ * @param {Object} issue - 이슈
+ * @param {Object} weights - 타입별 가중치 (없는 타입은 1)
+ * @returns {number} 가중치
+ */
+function weightOf(issue, weights) {
+  return weights[issue.type] !== undefined ? weights[issue.type] : 1;
+}
+
+/**
+ * 가중치를 곱한 심각도 (페르소나별 정렬 기준)
+ * @param {Object} issue - 이슈
+ * @param {Object} weights - 타입별 가중치 (없는 타입은 1)
+ * @returns {number} 심각도 × 가중치
+ */
+function weightedSeverity(issue, weights) {
+  return getSeverityLevel(issue.severity) * weightOf(issue, weights);
+}
+
+module.exports = { PERSONAS, applyWeights, weightedSeverity };
@@ -0,0 +1,76 @@
+/**
+ * Plain Text Renderer Module
+ * 리뷰 요약을 Markdown/HTML 없는 순수 텍스트로 변환하는 모듈
+ *
+ * Markdown을 표시하지 못하는 대상(일부 상태 API, 메일→티켓 게이트웨이 등)에 사용합니다.
+ */
+
+const DisplayLabels = require('./display-labels');
+const { getSeverityLevel } = require('./review-summary');
+
+// 판정별 표시 문구
+const VERDICT_TEXT = {
+  changes_requested: 'Changes requested',
+  needs_attention: 'Needs attention',
+  approved: 'Looks good'
+};
+
+/**
+ * 모델이 생성한 문자열에서 Markdown/HTML 표기 제거
+ * @param {string} text - 원본 문자열
+ * @returns {string} 순수 텍스트
+ */
+function stripMarkup(text) {
+  return String(text || '')
+    .replace(/<[^>]+>/g, '')
+    .replace(/\[([^\]]+)\]\(([^)]+)\)/g, '$1 ($2)')
+    .replace(/^#{1,6}\s+/gm, '')
+    .replace(/```[\w-]*\n?/g, '')
+    .replace(/(\*\*|__|`)/g, '')
+    .trim();
+}
+
+/**
+ * 리뷰 요약을 순수 텍스트로 렌더링
+ * @param {Object} summary - buildReviewSummary() 결과
+ * @param {DisplayLabels} [labels] - 심각도 표시 이름 (아이콘은 사용하지 않음)
+ * @returns {string} 텍스트
+ */
+function renderPlainText(summary, labels = new DisplayLabels()) {
+  const lines = [
+    'Claude AI Code Review',
+    summary.title,
+    summary.url,
+    '',
+    `Verdict: ${VERDICT_TEXT[summary.verdict] || summary.verdict}`,
+    ['critical', 'high', 'medium', 'low'].map(severity => `${labels.severityLabel(severity)} ${summary.counts[severity]}`).join(' / '),
+    `${summary.totalIssues} issues in ${summary.filesWithIssues} files`,
+    ''
+  ];
+
+  summary.results.forEach(result => {
+    lines.push(result.file);
+    [...result.issues]
+      .sort((a, b) => getSeverityLevel(b.severity) - getSeverityLevel(a.severity))
+      .forEach(issue => {
+        lines.push(`  [${labels.severityLabel(issue.severity)}] ${issue.line ? `L${issue.line} ` : ''}${stripMarkup(issue.title)}`);
+        if (issue.description) {
+          lines.push(`    ${stripMarkup(issue.description)}`);
+        }
+        if (issue.why) {
+          lines.push(`    Why: ${stripMarkup(issue.why)}`);
+        }
+        if (issue.reference) {
+          lines.push(`    See: ${issue.reference}`);
+        }
+        if (issue.suggestion) {
+          lines.push(`    Fix: ${stripMarkup(issue.suggestion)}`);
+        }
+      });
+    lines.push('');
+  });
+
+  return lines.join('\n');
+}
+
+module.exports = { renderPlainText, stripMarkup };
@@ -0,0 +1,185 @@
+/**
+ * PR Convention Checker Module
+ * PR 제목이 팀 패턴을 따르는지, 설명에 필수 섹션(테스트 계획 등)과 티켓 링크가 있는지 검사하고
+ * 규칙을 어기면 규칙에 맞는 제목과 빠진 섹션 초안을 제안하는 모듈
+ *
+ * 커밋 메시지 리뷰와 같이 위반 여부는 규칙으로 판단하고, 제안 작성만 Claude에 맡깁니다.
+ * - 제목 패턴: 정규식 (없으면 Conventional Commits)
+ * - 필수 섹션: 마크다운 제목(## Test plan) 또는 굵은 글씨(**Test plan**:)로 시작하고 내용이 있어야 함
+ * - 티켓 링크: 본문이나 제목에 정규식과 일치하는 문자열이 있어야 함
+ */
+
+const { CONVENTIONAL_PATTERN } = require('./commit-message-reviewer');
+
+// 프롬프트에 넣을 PR 본문과 변경 파일 최대 크기
+const MAX_BODY_CHARS = 4000;
+const MAX_FILES = 100;
+
+const SUGGEST_PROMPT = `당신은 팀의 PR 작성 규칙을 안내하는 리뷰어입니다. 다음 Pull Request의 제목과 설명이 규칙을 어겼습니다. 규칙에 맞는 제목과 빠진 설명 섹션의 초안을 제안해주세요.
+
+작성 규칙:
+- title: 제목 규칙을 어겼을 때만 규칙에 맞게 다시 쓴 제목 (지켰으면 빈 문자열)
+- sections: 빠졌거나 비어 있는 필수 섹션마다 { "name": 섹션 이름, "content": 마크다운 초안 }
+- 초안은 PR 제목, 기존 설명, 변경된 파일에서 알 수 있는 내용만 사용하고 모르는 내용은 "TODO:"로 남기세요
+- 티켓 번호나 링크는 지어내지 마세요`;
+
+class PrConventionChecker {
+  /**
+   * PrConventionChecker 생성자
+   * @param {Object} options - 옵션
+   * @param {CodeReviewer} options.codeReviewer - Claude API 호출용 리뷰어
+   * @param {Object} options.context - GitHub Actions 컨텍스트 (pull_request 이벤트)
+   * @param {string} [options.titlePattern] - PR 제목 정규식 (없으면 Conventional Commits)
+   * @param {Array<string>} [options.requiredSections] - 설명에 있어야 하는 섹션 이름
+   * @param {string} [options.ticketPattern] - 티켓 링크 정규식 (없으면 검사하지 않음)
+   */
+  constructor({ codeReviewer, context, titlePattern, requiredSections = [], ticketPattern }) {
+    this.codeReviewer = codeReviewer;
+    this.context = context;
+    this.titlePattern = titlePattern ? new RegExp(titlePattern) : CONVENTIONAL_PATTERN;
+    this.convention = titlePattern ? `팀 패턴 /${titlePattern}/` : 'Conventional Commits';
+    this.requiredSections = requiredSections;
+    this.ticketPattern = ticketPattern ? new RegExp(ticketPattern) : null;
+  }
+
+  /**
+   * 설명에서 섹션 내용 찾기
+   * @param {string} body - PR 본문
+   * @param {string} name - 섹션 이름 (대소문자 무시)
+   * @returns {string|null} 섹션 내용 (HTML 주석 제외, 섹션이 없으면 null)
+   */
+  static findSection(body, name) {
+    const lines = String(body || '').replace(/\r\n/g, '\n').split('\n');
+    const wanted = name.trim().toLowerCase();
+    // 마크다운 제목 줄, 또는 굵은 글씨로 시작하는 줄 (**Test plan**: 같은 줄의 내용도 섹션 내용)
+    const headingOf = line => {
+      const match = line.match(/^\s*(?:#{1,6}\s+(.+?)\s*#*|\*\*(.+?):?\*\*:?(.*))\s*$/);
+      return match
+        ? { name: (match[1] || match[2]).replace(/:$/, '').trim().toLowerCase(), inline: match[3] || '' }
+        : null;
+    };
+
+    const start = lines.findIndex(line => {
+      const heading = headingOf(line);
+      return heading && heading.name === wanted;
+    });
+    if (start === -1) {
+      return null;
+    }
+    const rest = lines.slice(start + 1);
+    const end = rest.findIndex(line => headingOf(line) !== null);
+    return [headingOf(lines[start]).inline, ...(end === -1 ? rest : rest.slice(0, end))]
+      .join('\n')
+      .replace(/<!--[\s\S]*?-->/g, '')
+      .trim();
+  }
+
+  /**
+   * 규칙 기반 검사
+   * @param {Object} pullRequest - PR 페이로드 (title, body)
+   * @returns {Object} { title: 제목 문제 목록, sections: 빠진 섹션 이름, problems: 전체 문제 목록 }
+   */
+  checkRules(pullRequest) {
+    const title = [];
+    if (!this.titlePattern.test(pullRequest.title || '')) {
+      title.push(`제목이 ${this.convention} 형식이 아닙니다`);
+    }
+    const sections = this.requiredSections.filter(name => !PrConventionChecker.findSection(pullRequest.body, name));
+    const problems = [...title, ...sections.map(name => `설명에 "${name}" 섹션이 없거나 비어 있습니다`)];
+    if (this.ticketPattern && !this.ticketPattern.test(`${pullRequest.title || ''}\n${pullRequest.body || ''}`)) {
+      problems.push(`티켓 링크가 없습니다 (/${this.ticketPattern.source}/)`);
+    }
+    return { title, sections, problems };
+  }
+
+  /**
+   * PR 제목과 설명 검사 (규칙을 어기면 제안 작성)
+   * @param {Array<string>} changedFiles - PR에서 변경된 파일 경로
+   * @returns {Promise<Object|null>} { title, problems, suggestedTitle, sections: [{ name, content }] } (문제가 없으면 null)
+   */
+  async check(changedFiles) {
+    const pullRequest = this.context.payload.pull_request;
+    const rules = this.checkRules(pullRequest);
+    if (rules.problems.length === 0) {
+      return null;
+    }
+
+    const result = { title: pullRequest.title || '', problems: rules.problems, suggestedTitle: '', sections: [] };
+    // 티켓 링크만 빠졌으면 지어낼 수 없으므로 제안 없이 보고
+    if (rules.title.length === 0 && rules.sections.length === 0) {
+      return result;
+    }
+    const responseText = await this.codeReviewer.sendMessage(
+      `PR #${pullRequest.number} title and description`,
+      this.buildPrompt(pullRequest, rules, changedFiles),
+      this.codeReviewer.model
+    );
+    const suggestion = PrConventionChecker.parseResponse(responseText);
+    return {
+      ...result,
+      suggestedTitle: rules.title.length > 0 ? suggestion.title : '',
+      // 빠진 섹션의 초안만 설정한 섹션 이름으로 표시
+      sections: rules.sections
+        .map(name => {
+          const section = suggestion.sections.find(candidate => candidate.name.toLowerCase() === name.toLowerCase());
+          return section ? { name, content: section.content } : null;
+        })
+        .filter(Boolean)
+    };
+  }
+
+  /**
+   * 제안 프롬프트 생성
+   * @param {Object} pullRequest - PR 페이로드
+   * @param {Object} rules - checkRules() 결과
+   * @param {Array<string>} changedFiles - 변경된 파일 경로
+   * @returns {string} 프롬프트
+   */
+  buildPrompt(pullRequest, rules, changedFiles) {
+    const titleRule = this.titlePattern === CONVENTIONAL_PATTERN
+      ? `${this.convention} (${CONVENTIONAL_PATTERN.source})`
+      : this.convention;
+    const body = String(pullRequest.body || '').replace(/<!--[\s\S]*?-->/g, '').trim();
+
+    return `${SUGGEST_PROMPT} ${this.codeReviewer.getLanguageInstruction()}
+
+제목 규칙: ${titleRule}
+필수 섹션: ${this.requiredSections.length > 0 ? this.requiredSections.join(', ') : '없음'}
+규칙 위반: ${rules.problems.join('; ')}
+
+PR 제목: ${pullRequest.title || ''}
+
+PR 설명:
+${body ? body.substring(0, MAX_BODY_CHARS) : '(비어 있음)'}
+
+변경된 파일:
+${changedFiles.slice(0, MAX_FILES).join('\n')}
+
+**중요**: 완전한 JSON만 반환하세요.
+
+형식:
+{"title":"다시 쓴 제목","sections":[{"name":"섹션 이름","content":"마크다운 초안"}]}`;
+  }
+
+  /**
+   * 응답 JSON 파싱
+   * @param {string} responseText - 응답 텍스트
+   * @returns {Object} { title, sections: [{ name, content }] }
+   */
+  static parseResponse(responseText) {
+    const firstBrace = responseText.indexOf('{');
+    const lastBrace = responseText.lastIndexOf('}');
+    if (firstBrace === -1 || lastBrace <= firstBrace) {
+      throw new Error('No JSON structure found in PR convention response');
+    }
+    const parsed = JSON.parse(responseText.substring(firstBrace, lastBrace + 1));
+    return {
+      title: typeof parsed.title === 'string' ? parsed.title.split('\n')[0].trim() : '',
+      sections: (Array.isArray(parsed.sections) ? parsed.sections : [])
+        .filter(section => section && typeof section.name === 'string' && typeof section.content === 'string' && section.content.trim())
+        .map(section => ({ name: section.name.trim(), content: section.content.trim() }))
+    };
+  }
+}
+
+module.exports = PrConventionChecker;
@@ -0,0 +1,261 @@
+/**
+ * PR Describer Module
+ * diff로 구조화된 PR 설명 초안을 만들어 제안 댓글로 게시하거나 PR 본문의 표시된 영역에 작성하는 모듈
+ *
+ * 초안은 요약, 주요 변경사항, 위험 요소, 호환성을 깨는 변경, 테스트 노트, 변경 로그 항목으로 구성됩니다.
+ * - comment 방식: PR 설명이 비어 있거나 너무 짧을 때 복사해 쓸 수 있는 댓글로만 제안
+ * - body 방식: PR 본문의 시작/끝 마커 사이 영역만 매번 새로 작성 (영역이 없으면 본문 끝에 추가)하므로
+ *   사람이 쓴 본문은 그대로 유지됨
+ */
+
+const DESCRIPTION_MARKER = '<!-- claude-review:pr-description -->';
+// PR 본문에서 액션이 관리하는 영역
+const SECTION_START = '<!-- claude-review:description:start -->';
+const SECTION_END = '<!-- claude-review:description:end -->';
+
+// 프롬프트에 넣을 diff 전체 최대 길이와 파일 수
+const MAX_DIFF_CHARS = 12000;
+const MAX_FILES = 50;
+
+const DESCRIBE_PROMPT = `당신은 PR 작성자를 돕는 시니어 개발자입니다. 다음 Pull Request의 변경사항을 읽고 리뷰어가 바로 이해할 수 있는 PR 설명 초안을 작성해주세요.
+
+작성 규칙:
+- summary: 이 PR이 무엇을 왜 바꾸는지 1-3문장
+- changes: 주목할 만한 변경사항 (파일 나열이 아니라 동작 단위로)
+- risk_notes: 리뷰어가 특히 주의해서 볼 위험 요소 (보안, 데이터 마이그레이션, 성능, 동시성 등, 없으면 빈 배열)
+- breaking_changes: 공개 API, 설정, 데이터 형식의 호환성을 깨는 변경 (없으면 빈 배열)
+- test_notes: 리뷰어가 확인해야 할 테스트 방법과 추가된 테스트
+- changelog: 변경 로그에 넣을 한 줄 항목 (Added/Changed/Fixed/Removed 중 하나로 시작)
+- diff에 없는 내용은 추측하지 마세요`;
+
+class PrDescriber {
+  /**
+   * PrDescriber 생성자
+   * @param {Object} options - 옵션
+   * @param {CodeReviewer} options.codeReviewer - Claude API 호출용 리뷰어 (언어, 사용량 기록 공유)
+   * @param {Object} options.octokit - GitHub API 클라이언트
+   * @param {Object} options.context - GitHub Actions 컨텍스트 (pull_request 이벤트)
+   * @param {number} options.minBodyLength - 이 길이 미만의 PR 본문이면 초안 작성
+   */
+  constructor({ codeReviewer, octokit, context, minBodyLength }) {
+    this.codeReviewer = codeReviewer;
+    this.octokit = octokit;
+    this.context = context;
+    this.minBodyLength = minBodyLength;
+  }
+
+  /**
+   * 초안이 필요한지 확인 (템플릿 주석을 제외한 본문 길이 기준)
+   * @param {Object} pullRequest - PR 페이로드
+   * @returns {boolean} 본문이 비었거나 너무 짧으면 true
+   */
+  shouldDescribe(pullRequest) {
+    const body = (pullRequest.body || '').replace(/<!--[\s\S]*?-->/g, '').trim();
+    return body.length < this.minBodyLength;
+  }
+
+  /**
+   * 설명 초안 프롬프트 생성
+   * @param {Object} pullRequest - PR 페이로드
+   * @param {Array} files - [{ filename, status, diff }]
+   * @returns {string} 프롬프트
+   */
+  buildPrompt(pullRequest, files) {
+    let budget = MAX_DIFF_CHARS;
+    const sections = [];
+    files.slice(0, MAX_FILES).forEach(file => {
+      const diff = budget > 0 ? file.diff.substring(0, budget) : '';
+      budget -= diff.length;
+      sections.push(`### ${file.filename} (${file.status})${diff ? `\n\`\`\`diff\n${diff}${diff.length < file.diff.length ? '\n// ... (truncated)' : ''}\n\`\`\`` : ''}`);
+    });
+    if (files.length > MAX_FILES) {
+      sections.push(`… 외 ${files.length - MAX_FILES}개 파일`);
+    }
+
+    return `${DESCRIBE_PROMPT} ${this.codeReviewer.getLanguageInstruction()}
+
+PR 제목: ${pullRequest.title}
+
+변경된 파일:
+${sections.join('\n\n')}
+
+**중요**: 완전한 JSON만 반환하세요.
+
+형식:
+{"summary":"요약","changes":["변경사항"],"risk_notes":["위험 요소"],"breaking_changes":["호환성을 깨는 변경"],"test_notes":["테스트 노트"],"changelog":"변경 로그 항목"}`;
+  }
+
+  /**
+   * 설명 초안 생성
+   * @param {Object} pullRequest - PR 페이로드
+   * @param {Array} files - [{ filename, status, diff }]
+   * @returns {Promise<Object>} { summary, changes, riskNotes, breakingChanges, testNotes, changelog }
+   */
+  async describe(pullRequest, files) {
+    const responseText = await this.codeReviewer.sendMessage(
+      `PR #${pullRequest.number} description`,
+      this.buildPrompt(pullRequest, files),
+      this.codeReviewer.model
+    );
+    return PrDescriber.parseResponse(responseText);
+  }
+
+  /**
+   * 응답 JSON 파싱
+   * @param {string} responseText - 응답 텍스트
+   * @returns {Object} 설명 초안
+   */
+  static parseResponse(responseText) {
+    const firstBrace = responseText.indexOf('{');
+    const lastBrace = responseText.lastIndexOf('}');
+    if (firstBrace === -1 || lastBrace <= firstBrace) {
+      throw new Error('No JSON structure found in description response');
+    }
+    const parsed = JSON.parse(responseText.substring(firstBrace, lastBrace + 1));
+    const list = value => (Array.isArray(value) ? value.filter(item => typeof item === 'string' && item.trim()) : []);
+    if (typeof parsed.summary !== 'string' || !parsed.summary.trim()) {
+      throw new Error('Description response has no summary');
+    }
+    return {
+      summary: parsed.summary.trim(),
+      changes: list(parsed.changes),
+      riskNotes: list(parsed.risk_notes),
+      breakingChanges: list(parsed.breaking_changes),
+      testNotes: list(parsed.test_notes),
+      changelog: typeof parsed.changelog === 'string' ? parsed.changelog.trim() : ''
+    };
+  }
+
+  /**
+   * 설명 초안 마크다운 생성
+   * @param {Object} description - 설명 초안
+   * @param {string} [heading] - 섹션 제목 수준 (PR 본문 영역은 본문 제목보다 낮은 ###)
+   * @returns {string} 마크다운
+   */
+  static buildDraft(description, heading = '##') {
+    const bullets = items => items.map(item => `- ${item}`).join('\n');
+    const draft = [`${heading} 요약\n\n${description.summary}`];
+    if (description.changes.length > 0) {
+      draft.push(`${heading} 주요 변경사항\n\n${bullets(description.changes)}`);
+    }
+    if (description.riskNotes.length > 0) {
+      draft.push(`${heading} 위험 요소\n\n${bullets(description.riskNotes)}`);
+    }
+    draft.push(`${heading} 호환성을 깨는 변경\n\n${description.breakingChanges.length > 0 ? bullets(description.breakingChanges) : '- 없음'}`);
+    if (description.testNotes.length > 0) {
+      draft.push(`${heading} 테스트 노트\n\n${bullets(description.testNotes)}`);
+    }
+    return draft.join('\n\n');
+  }
+
+  /**
+   * PR 본문에 넣을 영역 생성 (시작/끝 마커 포함)
+   * @param {Object} description - 설명 초안
+   * @returns {string} 본문 영역
+   */
+  static buildSection(description) {
+    const lines = [
+      SECTION_START,
+      '## 🤖 변경 요약 (자동 생성)',
+      '',
+      PrDescriber.buildDraft(description, '###')
+    ];
+    if (description.changelog) {
+      lines.push('', `**변경 로그:** ${description.changelog}`);
+    }
+    lines.push('', '<sub>이 영역은 새 커밋마다 다시 작성됩니다. 직접 쓴 내용은 이 영역 밖에 작성하세요.</sub>', SECTION_END);
+    return lines.join('\n');
+  }
+
+  /**
+   * PR 본문의 관리 영역을 새 영역으로 교체 (영역이 없으면 본문 끝에 추가)
+   * @param {string|null} body - 현재 PR 본문
+   * @param {string} section - buildSection() 결과
+   * @returns {string} 새 본문
+   */
+  static mergeBody(body, section) {
+    const text = String(body || '').replace(/\r\n/g, '\n');
+    const start = text.indexOf(SECTION_START);
+    const end = start === -1 ? -1 : text.indexOf(SECTION_END, start);
+    if (start !== -1 && end !== -1) {
+      return text.substring(0, start) + section + text.substring(end + SECTION_END.length);
+    }
+    return text.trim() ? `${text.trimEnd()}\n\n${section}` : section;
+  }
+
+  /**
+   * 제안 댓글 본문 생성 (초안은 복사하기 쉽도록 코드 블록으로 감쌈)
+   * @param {Object} description - 설명 초안
+   * @returns {string} 댓글 본문
+   */
+  static buildComment(description) {
+    const draft = PrDescriber.buildDraft(description);
+    const lines = [
+      DESCRIPTION_MARKER,
+      '## 📝 PR 설명 제안',
+      '',
+      'PR 설명이 비어 있거나 짧아서 변경사항을 바탕으로 초안을 작성했습니다. 필요한 부분을 수정해 PR 본문에 붙여 넣으세요.',
+      '',
+      '````markdown',
+      draft,
+      '````'
+    ];
+    if (description.changelog) {
+      lines.push('', '**변경 로그 항목 제안:**', '', `> ${description.changelog}`);
+    }
+    lines.push('', '---', '*PR 본문이 충분히 작성되면 이 제안은 더 이상 갱신되지 않습니다.*');
+    return lines.join('\n');
+  }
+
+  /**
+   * PR 본문의 관리 영역 갱신 (이벤트 이후 수정된 본문을 덮어쓰지 않도록 최신 본문을 다시 조회)
+   * @param {string} section - buildSection() 결과
+   * @returns {Promise<Object>} { url, action: updated|unchanged }
+   */
+  async updateBody(section) {
+    const pull = {
+      owner: this.context.repo.owner,
+      repo: this.context.repo.repo,
+      pull_number: this.context.payload.pull_request.number
+    };
+    const { data: pullRequest } = await this.octokit.rest.pulls.get(pull);
+    const body = PrDescriber.mergeBody(pullRequest.body, section);
+    if (body === String(pullRequest.body || '').replace(/\r\n/g, '\n')) {
+      return { url: pullRequest.html_url, action: 'unchanged' };
+    }
+    await this.octokit.rest.pulls.update({ ...pull, body });
+    return { url: pullRequest.html_url, action: 'updated' };
+  }
+
+  /**
+   * 제안 댓글 생성 또는 갱신 (PR당 하나)
+   * @param {string} body - 댓글 본문
+   * @returns {Promise<Object>} { url, action: created|updated }
+   */
+  async publish(body) {
+    const issue = {
+      owner: this.context.repo.owner,
+      repo: this.context.repo.repo,
+      issue_number: this.context.payload.pull_request.number
+    };
+    const comments = await this.octokit.paginate(this.octokit.rest.issues.listComments, { ...issue, per_page: 100 });
+    const existing = comments.find(comment => (comment.body || '').includes(DESCRIPTION_MARKER));
+
+    if (existing) {
+      const { data } = await this.octokit.rest.issues.updateComment({
+        owner: issue.owner,
+        repo: issue.repo,
+        comment_id: existing.id,
+        body
+      });
+      return { url: data.html_url, action: 'updated' };
+    }
+    const { data } = await this.octokit.rest.issues.createComment({ ...issue, body });
+    return { url: data.html_url, action: 'created' };
+  }
+}
+
+module.exports = PrDescriber;
+module.exports.DESCRIPTION_MARKER = DESCRIPTION_MARKER;
+module.exports.SECTION_START = SECTION_START;
+module.exports.SECTION_END = SECTION_END;
@@ -0,0 +1,133 @@
+/**
+ * Progress Tracker Module
+ * 오래 걸리는 리뷰의 진행 상황을 주기적으로 출력하는 모듈
+ *
+ * 주요 기능:
+ * - 완료된 파일 수 및 발견된 이슈 수 집계
+ * - 평균 처리 시간 기반 남은 시간 추정
+ * - 주기적 하트비트 로그 출력 및 리스너 알림 (check_run 사용 시 Check Run 진행 상황 갱신)
+ */
+
+const core = require('@actions/core');
+
+class ProgressTracker {
+  /**
+   * ProgressTracker 생성자
+   * @param {number} totalFiles - 리뷰할 전체 파일 수
+   * @param {number} intervalSeconds - 하트비트 간격 (초, 0이면 비활성화)
+   */
+  constructor(totalFiles, intervalSeconds = 30) {
+    this.totalFiles = totalFiles;
+    this.intervalMs = Math.max(0, intervalSeconds) * 1000;
+    this.completedFiles = 0;
+    this.findings = 0;
+    this.startedAt = null;
+    this.timer = null;
+    // 진행 상황을 전달받을 리스너 (예: Check Run 업데이트)
+    this.listeners = [];
+  }
+
+  /**
+   * 진행 상황 리스너 등록
+   * @param {Function} listener - snapshot 객체를 인자로 받는 함수
+   */
+  onProgress(listener) {
+    this.listeners.push(listener);
+  }
+
+  /**
+   * 하트비트 시작
+   */
+  start() {
+    this.startedAt = Date.now();
+    if (this.intervalMs > 0) {
+      this.timer = setInterval(() => this.emit(), this.intervalMs);
+      // 하트비트 때문에 프로세스가 종료되지 않는 일이 없도록 함
+      this.timer.unref();
+    }
+  }
+
+  /**
+   * 파일 하나의 리뷰 완료 기록
+   * @param {number} issueCount - 해당 파일에서 발견된 이슈 수
+   */
+  fileCompleted(issueCount = 0) {
+    this.completedFiles++;
+    this.findings += issueCount;
+  }
+
+  /**
+   * 하트비트 중지 및 마지막 진행 상황 출력
+   */
+  stop() {
+    if (this.timer) {
+      clearInterval(this.timer);
+      this.timer = null;
+    }
+    this.emit();
+  }
+
+  /**
+   * 현재 진행 상황 스냅샷
+   * @returns {Object} 진행 상황
+   */
+  snapshot() {
+    const elapsedMs = this.startedAt ? Date.now() - this.startedAt : 0;
+    const remainingFiles = this.totalFiles - this.completedFiles;
+    // 완료된 파일의 평균 처리 속도로 남은 시간 추정
+    const remainingMs = this.completedFiles > 0 && remainingFiles > 0
+      ? Math.round((elapsedMs / this.completedFiles) * remainingFiles)
+      : null;
+
+    return {
+      completedFiles: this.completedFiles,
+      totalFiles: this.totalFiles,
+      findings: this.findings,
+      elapsedMs,
+      remainingMs
+    };
+  }
+
+  /**
+   * 진행 상황 한 줄 요약
+   * @param {Object} snapshot - 진행 상황 스냅샷
+   * @returns {string} 예: "reviewed 12/48 files, 3 findings, ~4m remaining"
+   */
+  format(snapshot) {
+    let line = `reviewed ${snapshot.completedFiles}/${snapshot.totalFiles} files, ${snapshot.findings} findings`;
+    if (snapshot.remainingMs !== null) {
+      line += `, ~${this.formatDuration(snapshot.remainingMs)} remaining`;
+    }
+    return line;
+  }
+
+  /**
+   * 밀리초를 사람이 읽기 쉬운 형태로 변환
+   * @param {number} ms - 밀리초
+   * @returns {string} 예: "45s", "4m"
+   */
+  formatDuration(ms) {
+    const seconds = Math.round(ms / 1000);
+    if (seconds < 60) {
+      return `${seconds}s`;
+    }
+    return `${Math.round(seconds / 60)}m`;
+  }
+
+  /**
+   * 진행 상황 출력 및 리스너 알림
+   */
+  emit() {
+    const snapshot = this.snapshot();
+    core.info(`Progress: ${this.format(snapshot)}`);
+
+    for (const listener of this.listeners) {
+      // 리스너 실패가 리뷰를 중단시키지 않도록 함
+      Promise.resolve()
+        .then(() => listener(snapshot))
+        .catch(error => core.warning(`Progress listener failed: ${error.message}`));
+    }
+  }
+}
+
+module.exports = ProgressTracker;
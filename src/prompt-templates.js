@@ -0,0 +1,123 @@
+/**
+ * Prompt Templates Module
+ * 저장소에 체크인한 템플릿(.claude-review/prompts/*.tmpl)으로 시스템/사용자 프롬프트를 바꾸는 모듈
+ *
+ * - system.tmpl: 시스템 프롬프트, user.tmpl: 파일마다 보내는 리뷰 프롬프트
+ * - 문법은 댓글 레이아웃 템플릿과 같은 Mustache 일부 ({{name}}, {{#name}}...{{/name}}, {{^name}}...{{/name}})
+ * - {{default}}는 액션의 기본 프롬프트이므로 팀 가이드라인만 덧붙이는 템플릿을 만들 수 있음
+ *
+ * 예: user.tmpl
+ *   {{default}}
+ *
+ *   사내 가이드라인:
+ *   - 모든 외부 호출에는 타임아웃을 지정해야 합니다.
+ *   {{#diff}}- 변경된 줄에 대한 이슈를 우선 보고하세요.{{/diff}}
+ */
+
+const fs = require('fs').promises;
+const path = require('path');
+const { renderTemplate } = require('./comment-template');
+const { ConfigError } = require('./errors');
+
+const DEFAULT_TEMPLATES_DIR = '.claude-review/prompts';
+
+// 템플릿 파일별 사용할 수 있는 값
+const TEMPLATE_VARIABLES = {
+  system: ['default', 'tone', 'language'],
+  user: [
+    'default', 'filename', 'directory', 'extension', 'lines', 'content', 'diff',
+    'reviewType', 'language', 'languageInstruction', 'maxIssues', 'persona', 'pass', 'instructions'
+  ]
+};
+
+const TEMPLATE_EXTENSION = '.tmpl';
+
+class PromptTemplates {
+  /**
+   * PromptTemplates 생성자
+   * @param {Object} [templates] - { system, user } 템플릿 문자열 (없는 항목은 기본 프롬프트 사용)
+   * @param {string} [source] - 템플릿을 읽은 디렉터리 (로그용)
+   */
+  constructor(templates = {}, source = DEFAULT_TEMPLATES_DIR) {
+    Object.keys(templates).forEach(name => PromptTemplates.validate(name, templates[name]));
+    this.system = templates.system || null;
+    this.user = templates.user || null;
+    this.source = source;
+  }
+
+  /**
+   * 템플릿 디렉터리 로드 (디렉터리가 없으면 기본 프롬프트만 사용)
+   * @param {string} [dir] - 템플릿 디렉터리
+   * @returns {Promise<PromptTemplates>} 로드된 템플릿
+   */
+  static async load(dir = DEFAULT_TEMPLATES_DIR) {
+    let entries;
+    try {
+      entries = await fs.readdir(dir);
+    } catch (error) {
+      if (error.code === 'ENOENT') {
+        return new PromptTemplates({}, dir);
+      }
+      throw new ConfigError(`Cannot read prompt templates ${dir}: ${error.message}`);
+    }
+
+    const templates = {};
+    for (const entry of entries.filter(name => name.endsWith(TEMPLATE_EXTENSION)).sort()) {
+      const name = entry.slice(0, -TEMPLATE_EXTENSION.length);
+      if (!TEMPLATE_VARIABLES[name]) {
+        throw new ConfigError(`Unknown prompt template ${path.join(dir, entry)} (supported: ${Object.keys(TEMPLATE_VARIABLES).map(key => key + TEMPLATE_EXTENSION).join(', ')})`);
+      }
+      templates[name] = await fs.readFile(path.join(dir, entry), 'utf8');
+    }
+    return new PromptTemplates(templates, dir);
+  }
+
+  /**
+   * 템플릿에서 사용한 값 이름 검증 (오타로 값이 조용히 비는 것을 방지)
+   * @param {string} name - 템플릿 이름 (system, user)
+   * @param {string} template - 템플릿
+   * @throws {ConfigError} 알 수 없는 템플릿이나 값 이름
+   */
+  static validate(name, template) {
+    const variables = TEMPLATE_VARIABLES[name];
+    if (!variables) {
+      throw new ConfigError(`Unknown prompt template ${name}${TEMPLATE_EXTENSION}`);
+    }
+    const unknown = [...String(template).matchAll(/\{\{[#^/]?(\w+)\}\}/g)]
+      .map(match => match[1])
+      .filter((variable, index, all) => !variables.includes(variable) && all.indexOf(variable) === index);
+    if (unknown.length > 0) {
+      throw new ConfigError(`Unknown variable in ${name}${TEMPLATE_EXTENSION}: ${unknown.join(', ')} (supported: ${variables.join(', ')})`);
+    }
+  }
+
+  /**
+   * 사용 중인 템플릿 파일 이름 목록
+   * @returns {Array<string>} 예: ['system.tmpl']
+   */
+  names() {
+    return ['system', 'user'].filter(name => this[name]).map(name => name + TEMPLATE_EXTENSION);
+  }
+
+  /**
+   * 시스템 프롬프트 렌더링
+   * @param {Object} values - TEMPLATE_VARIABLES.system 값 (default는 기본 시스템 프롬프트)
+   * @returns {string} 시스템 프롬프트
+   */
+  renderSystem(values) {
+    return this.system ? renderTemplate(this.system, values).trim() : values.default;
+  }
+
+  /**
+   * 리뷰 프롬프트 렌더링
+   * @param {Object} values - TEMPLATE_VARIABLES.user 값 (default는 기본 리뷰 프롬프트)
+   * @returns {string} 리뷰 프롬프트
+   */
+  renderUser(values) {
+    return this.user ? renderTemplate(this.user, values).trim() : values.default;
+  }
+}
+
+module.exports = PromptTemplates;
+module.exports.DEFAULT_TEMPLATES_DIR = DEFAULT_TEMPLATES_DIR;
+module.exports.TEMPLATE_VARIABLES = TEMPLATE_VARIABLES;
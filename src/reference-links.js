@@ -0,0 +1,70 @@
+/**
+ * Reference Links Module
+ * 이슈 타입별 "더 알아보기" 링크를 관리하는 모듈
+ *
+ * 개선 방안이 팀 자체 표준 문서(사내 핸드북 등)를 가리키도록 타입별 링크를 재정의할 수 있습니다.
+ * reference_links 입력값 형식 (한 줄에 하나):
+ *   security: https://handbook.example.com/security
+ *   style: https://handbook.example.com/style-guide
+ *   performance: none        ← 해당 타입은 링크 없음
+ */
+
+const { ConfigError } = require('./errors');
+
+// 이슈 타입별 기본 링크 (explain 모드에서 사용)
+const DEFAULT_REFERENCES = {
+  bug: 'https://google.github.io/eng-practices/review/reviewer/looking-for.html#functionality',
+  security: 'https://owasp.org/www-project-top-ten/',
+  style: 'https://google.github.io/styleguide/',
+  maintainability: 'https://google.github.io/eng-practices/review/reviewer/looking-for.html#complexity',
+  accessibility: 'https://www.w3.org/WAI/WCAG22/quickref/'
+};
+
+// 재정의할 수 있는 타입 (code-reviewer가 정규화하는 타입과 동일)
+const KNOWN_TYPES = ['bug', 'security', 'performance', 'style', 'maintainability', 'testing', 'documentation', 'i18n', 'accessibility', 'compliance', 'general'];
+
+class ReferenceLinks {
+  /**
+   * ReferenceLinks 생성자
+   * @param {Object} overrides - { 타입: URL 또는 null } 재정의
+   * @param {boolean} useDefaults - 기본 링크 사용 여부
+   */
+  constructor(overrides = {}, useDefaults = false) {
+    this.links = { ...(useDefaults ? DEFAULT_REFERENCES : {}), ...overrides };
+  }
+
+  /**
+   * reference_links 입력값 파싱
+   * @param {string} text - 여러 줄 매핑
+   * @param {boolean} useDefaults - 기본 링크 사용 여부
+   * @returns {ReferenceLinks} 링크 매핑
+   */
+  static parse(text, useDefaults = false) {
+    const overrides = {};
+
+    (text || '').split('\n').map(line => line.trim()).filter(Boolean).forEach(line => {
+      const separator = line.indexOf(':');
+      const type = separator === -1 ? '' : line.substring(0, separator).trim().toLowerCase();
+      const url = line.substring(separator + 1).trim();
+
+      if (!KNOWN_TYPES.includes(type) || !(url === 'none' || /^https?:\/\/\S+$/.test(url))) {
+        throw new ConfigError(`Invalid reference_links entry (expected "<type>: <http(s) URL|none>"): ${line}`);
+      }
+      overrides[type] = url === 'none' ? null : url;
+    });
+
+    return new ReferenceLinks(overrides, useDefaults);
+  }
+
+  /**
+   * 이슈 타입의 링크 조회
+   * @param {string} type - 이슈 타입
+   * @returns {string|null} 링크 (없으면 null)
+   */
+  resolve(type) {
+    return this.links[type] || null;
+  }
+}
+
+module.exports = ReferenceLinks;
+module.exports.DEFAULT_REFERENCES = DEFAULT_REFERENCES;
@@ -0,0 +1,273 @@
+/**
+ * Release Notes Generator Module
+ * release 이벤트나 태그 push에서 이전 태그부터의 커밋 범위로 분류된 릴리즈 노트를 만들어 GitHub 릴리즈 초안에 붙이는 모듈
+ *
+ * 커밋 분류(새 기능, 버그 수정, 호환성을 깨는 변경, 기타)는 Claude에 맡기고 기여자 목록은 API 데이터로 만듭니다.
+ * 노트는 마커로 감싼 구역으로 릴리즈 본문에 넣으므로 다시 실행하면 그 구역만 교체되고 사람이 쓴 내용은 유지됩니다.
+ */
+
+const NOTES_START = '<!-- claude-review:release-notes -->';
+const NOTES_END = '<!-- /claude-review:release-notes -->';
+
+// 프롬프트에 넣을 최대 커밋 수 (compare API는 250개까지 반환)
+const MAX_COMMITS = 250;
+
+const NOTES_PROMPT = `당신은 릴리즈 매니저입니다. 다음 커밋 목록으로 사용자가 읽을 릴리즈 노트를 작성해주세요.
+
+작성 규칙:
+- features: 새 기능, fixes: 버그 수정, breaking_changes: 공개 API, 설정, 데이터 형식의 호환성을 깨는 변경, other: 그 밖에 사용자가 알아야 할 변경
+- 커밋 메시지를 그대로 옮기지 말고 사용자 관점의 한 문장으로 쓰세요
+- 관련된 커밋은 하나의 항목으로 합치고, 항목 끝에 커밋 sha를 괄호로 붙이세요 (예: "... (a1b2c3d)")
+- 머지, 버전 올림, CI, 리팩터링처럼 사용자에게 영향이 없는 커밋은 제외하세요
+- 커밋 목록에 없는 내용은 추측하지 마세요`;
+
+class ReleaseNotesGenerator {
+  /**
+   * ReleaseNotesGenerator 생성자
+   * @param {Object} options - 옵션
+   * @param {CodeReviewer} options.codeReviewer - Claude API 호출용 리뷰어
+   * @param {Object} options.octokit - GitHub API 클라이언트
+   * @param {Object} options.context - GitHub Actions 컨텍스트 (release 또는 태그 push 이벤트)
+   */
+  constructor({ codeReviewer, octokit, context }) {
+    this.codeReviewer = codeReviewer;
+    this.octokit = octokit;
+    this.context = context;
+  }
+
+  /**
+   * 릴리즈 노트를 만들 이벤트인지 확인
+   * @param {Object} context - GitHub Actions 컨텍스트
+   * @returns {boolean} release 이벤트 또는 태그 push면 true
+   */
+  static isReleaseEvent(context) {
+    return context.eventName === 'release' ||
+      (context.eventName === 'push' && (context.ref || '').startsWith('refs/tags/'));
+  }
+
+  /**
+   * 현재 태그
+   * @returns {string} 태그 이름
+   */
+  currentTag() {
+    const release = this.context.payload.release;
+    return release ? release.tag_name : this.context.ref.substring('refs/tags/'.length);
+  }
+
+  /**
+   * 이전 태그 찾기 (현재 태그가 아닌 가장 최근 게시 릴리즈, 없으면 태그 목록의 다음 태그)
+   * @param {string} tag - 현재 태그
+   * @returns {Promise<string|null>} 이전 태그
+   */
+  async previousTag(tag) {
+    const { owner, repo } = this.context.repo;
+    const releases = await this.octokit.rest.repos.listReleases({ owner, repo, per_page: 30 });
+    const previous = releases.data.find(release => !release.draft && !release.prerelease && release.tag_name !== tag);
+    if (previous) {
+      return previous.tag_name;
+    }
+
+    const tags = await this.octokit.rest.repos.listTags({ owner, repo, per_page: 30 });
+    const index = tags.data.findIndex(item => item.name === tag);
+    const candidate = tags.data[index === -1 ? 0 : index + 1];
+    return candidate && candidate.name !== tag ? candidate.name : null;
+  }
+
+  /**
+   * 커밋 범위 조회 (머지 커밋 제외)
+   * @param {string} base - 이전 태그
+   * @param {string} head - 현재 태그
+   * @returns {Promise<Array>} [{ sha, message, author }]
+   */
+  async listCommits(base, head) {
+    const { data } = await this.octokit.rest.repos.compareCommitsWithBasehead({
+      ...this.context.repo,
+      basehead: `${base}...${head}`,
+      per_page: 100
+    });
+    return data.commits
+      .filter(commit => (commit.parents || []).length < 2)
+      .slice(-MAX_COMMITS)
+      .map(commit => ({
+        sha: commit.sha,
+        message: commit.commit.message,
+        author: commit.author && commit.author.login ? commit.author.login : null
+      }));
+  }
+
+  /**
+   * 기여자 목록 (봇 제외, 커밋 수 내림차순)
+   * @param {Array} commits - 커밋 목록
+   * @returns {Array<string>} GitHub 로그인
+   */
+  static contributors(commits) {
+    const counts = new Map();
+    commits
+      .filter(commit => commit.author && !commit.author.endsWith('[bot]'))
+      .forEach(commit => counts.set(commit.author, (counts.get(commit.author) || 0) + 1));
+    return [...counts.entries()].sort((a, b) => b[1] - a[1]).map(([login]) => login);
+  }
+
+  /**
+   * 릴리즈 노트 생성
+   * @returns {Promise<Object>} { tag, previousTag, commits, notes, contributors }
+   */
+  async generate() {
+    const tag = this.currentTag();
+    const previousTag = await this.previousTag(tag);
+    if (!previousTag) {
+      throw new Error(`No previous tag found before ${tag}`);
+    }
+
+    const commits = await this.listCommits(previousTag, tag);
+    const contributors = ReleaseNotesGenerator.contributors(commits);
+    if (commits.length === 0) {
+      return { tag, previousTag, commits, notes: { features: [], fixes: [], breakingChanges: [], other: [] }, contributors };
+    }
+
+    const responseText = await this.codeReviewer.sendMessage(
+      `release notes ${previousTag}...${tag}`,
+      this.buildPrompt(tag, previousTag, commits),
+      this.codeReviewer.model
+    );
+    return { tag, previousTag, commits, notes: ReleaseNotesGenerator.parseResponse(responseText), contributors };
+  }
+
+  /**
+   * 릴리즈 노트 프롬프트 생성
+   * @param {string} tag - 현재 태그
+   * @param {string} previousTag - 이전 태그
+   * @param {Array} commits - 커밋 목록
+   * @returns {string} 프롬프트
+   */
+  buildPrompt(tag, previousTag, commits) {
+    const commitLines = commits.map(commit => JSON.stringify({
+      sha: commit.sha.substring(0, 7),
+      message: commit.message.substring(0, 500)
+    }));
+
+    return `${NOTES_PROMPT} ${this.codeReviewer.getLanguageInstruction()}
+
+릴리즈: ${previousTag} → ${tag}
+
+커밋:
+${commitLines.join('\n')}
+
+**중요**: 완전한 JSON만 반환하세요. 해당 항목이 없으면 빈 배열로 두세요.
+
+형식:
+{"features":["항목"],"fixes":["항목"],"breaking_changes":["항목"],"other":["항목"]}`;
+  }
+
+  /**
+   * 응답 JSON 파싱
+   * @param {string} responseText - 응답 텍스트
+   * @returns {Object} { features, fixes, breakingChanges, other }
+   */
+  static parseResponse(responseText) {
+    const firstBrace = responseText.indexOf('{');
+    const lastBrace = responseText.lastIndexOf('}');
+    if (firstBrace === -1 || lastBrace <= firstBrace) {
+      throw new Error('No JSON structure found in release notes response');
+    }
+    const parsed = JSON.parse(responseText.substring(firstBrace, lastBrace + 1));
+    const list = value => (Array.isArray(value) ? value.filter(item => typeof item === 'string' && item.trim()).map(item => item.trim()) : []);
+    return {
+      features: list(parsed.features),
+      fixes: list(parsed.fixes),
+      breakingChanges: list(parsed.breaking_changes),
+      other: list(parsed.other)
+    };
+  }
+
+  /**
+   * 릴리즈 노트 마크다운 생성 (마커 포함)
+   * @param {Object} release - generate() 결과
+   * @param {Object} repo - { owner, repo }
+   * @returns {string} 마크다운
+   */
+  static buildNotes({ tag, previousTag, commits, notes, contributors }, repo) {
+    const sections = [
+      ['⚠️ 호환성을 깨는 변경', notes.breakingChanges],
+      ['🚀 새 기능', notes.features],
+      ['🐛 버그 수정', notes.fixes],
+      ['📦 기타 변경', notes.other]
+    ].filter(([, items]) => items.length > 0);
+
+    const lines = [NOTES_START, '## 📋 릴리즈 노트', ''];
+    if (sections.length === 0) {
+      lines.push('사용자에게 영향이 있는 변경이 없습니다.', '');
+    }
+    sections.forEach(([heading, items]) => {
+      lines.push(`### ${heading}`, '', ...items.map(item => `- ${item}`), '');
+    });
+    if (contributors.length > 0) {
+      lines.push('### 🙌 기여자', '', contributors.map(login => `@${login}`).join(', '), '');
+    }
+    lines.push(
+      `**전체 변경사항:** [${previousTag}...${tag}](https://github.com/${repo.owner}/${repo.repo}/compare/${previousTag}...${tag}) (커밋 ${commits.length}개)`,
+      NOTES_END
+    );
+    return lines.join('\n');
+  }
+
+  /**
+   * 기존 릴리즈 본문에 노트 구역을 넣거나 교체
+   * @param {string} body - 기존 본문
+   * @param {string} notes - buildNotes() 결과
+   * @returns {string} 새 본문
+   */
+  static mergeBody(body, notes) {
+    const current = body || '';
+    const start = current.indexOf(NOTES_START);
+    const end = current.indexOf(NOTES_END);
+    if (start !== -1 && end > start) {
+      return current.substring(0, start) + notes + current.substring(end + NOTES_END.length);
+    }
+    return current.trim() ? `${current.trimEnd()}\n\n${notes}` : notes;
+  }
+
+  /**
+   * 릴리즈에 노트 붙이기 (태그 push로 릴리즈가 없으면 초안 릴리즈 생성)
+   * @param {string} tag - 태그
+   * @param {string} notes - buildNotes() 결과
+   * @returns {Promise<Object>} { url, action: created|updated }
+   */
+  async attach(tag, notes) {
+    const { owner, repo } = this.context.repo;
+    let release = this.context.payload.release || null;
+    if (!release) {
+      try {
+        release = (await this.octokit.rest.repos.getReleaseByTag({ owner, repo, tag })).data;
+      } catch (error) {
+        if (error.status !== 404) {
+          throw error;
+        }
+      }
+    }
+
+    if (release) {
+      // 이벤트 페이로드의 본문은 오래되었을 수 있으므로 최신 본문을 다시 조회
+      const { data: latest } = await this.octokit.rest.repos.getRelease({ owner, repo, release_id: release.id });
+      const { data } = await this.octokit.rest.repos.updateRelease({
+        owner,
+        repo,
+        release_id: release.id,
+        body: ReleaseNotesGenerator.mergeBody(latest.body, notes)
+      });
+      return { url: data.html_url, action: 'updated' };
+    }
+
+    const { data } = await this.octokit.rest.repos.createRelease({
+      owner,
+      repo,
+      tag_name: tag,
+      name: tag,
+      body: notes,
+      draft: true
+    });
+    return { url: data.html_url, action: 'created' };
+  }
+}
+
+module.exports = ReleaseNotesGenerator;
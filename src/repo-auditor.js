@@ -0,0 +1,143 @@
+/**
+ * Repo Auditor Module
+ * 변경분이 아닌 저장소 전체 파일을 보안 관점으로 감사하는 모듈 (예약 실행용)
+ *
+ * 전체 저장소는 PR보다 훨씬 크므로 파일을 배치 단위로 리뷰하고,
+ * 토큰 예산이나 최대 파일 수를 넘으면 남은 파일은 다음 감사로 미룹니다.
+ * 파일은 경로 순으로 감사하고 마지막으로 감사한 파일(커서)부터 이어 가므로, 여러 번 실행하면 저장소 전체를 돌게 됩니다.
+ */
+
+const core = require('@actions/core');
+const { extractSnippet, fingerprintFinding } = require('./fingerprint');
+const { getSeverityLevel } = require('./review-summary');
+
+// 감사에 사용하는 리뷰 타입
+const AUDIT_REVIEW_TYPE = 'security';
+
+class RepoAuditor {
+  /**
+   * RepoAuditor 생성자
+   * @param {Object} options - 옵션
+   * @param {FileAnalyzer} options.fileAnalyzer - 파일 분석기 (패턴과 크기 필터)
+   * @param {CodeReviewer} options.codeReviewer - 코드 리뷰어
+   * @param {number} options.batchSize - 동시에 리뷰할 파일 수
+   * @param {number} options.tokenBudget - 최대 사용 토큰 (입력+출력, 0이면 제한 없음)
+   * @param {string} options.severityFilter - 보고할 최소 심각도
+   * @param {number} [options.maxFiles] - 한 번에 감사할 최대 파일 수
+   * @param {string|null} [options.resumeAfter] - 지난 감사의 커서 (이 경로 다음 파일부터 감사)
+   */
+  constructor({ fileAnalyzer, codeReviewer, batchSize, tokenBudget, severityFilter, maxFiles = Infinity, resumeAfter = null }) {
+    this.fileAnalyzer = fileAnalyzer;
+    this.codeReviewer = codeReviewer;
+    this.batchSize = batchSize;
+    this.tokenBudget = tokenBudget;
+    this.severityFilter = severityFilter;
+    this.maxFiles = maxFiles;
+    this.resumeAfter = resumeAfter;
+    // 패턴과 크기 필터를 통과한 감사 대상 전체 파일 수
+    this.totalFiles = 0;
+  }
+
+  /**
+   * 이번에 감사할 파일 목록 (패턴, 크기 필터 적용 후 경로 순으로 커서 다음부터 최대 파일 수만큼)
+   * @returns {Promise<Array>} 파일 목록
+   */
+  async listFiles() {
+    const output = await this.fileAnalyzer.git.raw(['ls-files']);
+    const files = output.split('\n').filter(Boolean).map(filename => ({ filename, status: 'unchanged' }));
+    const ordered = (await this.fileAnalyzer.filterFiles(files)).sort((a, b) => a.filename.localeCompare(b.filename));
+    this.totalFiles = ordered.length;
+
+    // 커서 다음 파일부터 시작하고 끝에 닿으면 처음으로 돌아감
+    const start = this.resumeAfter ? ordered.findIndex(file => file.filename.localeCompare(this.resumeAfter) > 0) : 0;
+    const rotated = start > 0 ? [...ordered.slice(start), ...ordered.slice(0, start)] : ordered;
+    return rotated.slice(0, this.maxFiles);
+  }
+
+  /**
+   * 사용한 토큰 수
+   * @returns {number} 입력(캐시 읽기/쓰기 포함)+출력 토큰
+   */
+  tokensUsed() {
+    const usage = this.codeReviewer.usage;
+    return usage.inputTokens + usage.cacheReadTokens + usage.cacheWriteTokens + usage.outputTokens;
+  }
+
+  /**
+   * 감사 실행
+   * @returns {Promise<Object>} { results, auditedFiles, skippedFiles, budgetExhausted, totalFiles, cursor }
+   */
+  async run() {
+    const files = await this.listFiles();
+    core.info(`Auditing ${files.length} files in batches of ${this.batchSize}`);
+
+    const results = [];
+    const auditedFiles = [];
+    let index = 0;
+    while (index < files.length) {
+      if (this.tokenBudget > 0 && this.tokensUsed() >= this.tokenBudget) {
+        break;
+      }
+
+      const batch = files.slice(index, index + this.batchSize);
+      index += batch.length;
+      const batchResults = await Promise.all(batch.map(file => this.auditFile(file)));
+      batchResults.forEach((result, i) => {
+        // 리뷰에 실패한 파일은 감사한 것으로 치지 않음 (기준선의 이슈를 해결됨으로 오인하지 않도록)
+        if (result !== undefined) {
+          auditedFiles.push(batch[i].filename);
+        }
+        if (result) {
+          results.push(result);
+        }
+      });
+      core.info(`Audit progress: ${index}/${files.length} files, ${this.tokensUsed()} tokens used`);
+    }
+
+    const skippedFiles = files.slice(index).map(file => file.filename);
+    if (skippedFiles.length > 0) {
+      core.warning(`Token budget of ${this.tokenBudget} reached; ${skippedFiles.length} files were not audited this run`);
+    }
+    // 대상 전체를 한 번에 감사했으면 다음 감사도 처음부터, 아니면 마지막으로 감사한 파일 다음부터
+    let cursor = null;
+    if (index < this.totalFiles) {
+      cursor = index > 0 ? files[index - 1].filename : this.resumeAfter;
+    }
+    return { results, auditedFiles, skippedFiles, budgetExhausted: skippedFiles.length > 0, totalFiles: this.totalFiles, cursor };
+  }
+
+  /**
+   * 파일 하나 감사
+   * @param {Object} file - 파일 정보
+   * @returns {Promise<Object|null|undefined>} 이슈가 있으면 결과, 없으면 null, 실패하면 undefined
+   */
+  async auditFile(file) {
+    try {
+      const content = await this.fileAnalyzer.getFileContent(file);
+      const review = await this.codeReviewer.reviewFile({
+        filename: file.filename,
+        content,
+        diff: '',
+        reviewType: AUDIT_REVIEW_TYPE
+      });
+
+      const issues = review.issues.filter(issue =>
+        getSeverityLevel(issue.severity) >= getSeverityLevel(this.severityFilter)
+      );
+      issues.forEach(issue => {
+        issue.snippet = extractSnippet(content, issue.line);
+        issue.fingerprint = fingerprintFinding(file.filename, issue);
+      });
+
+      return issues.length > 0
+        ? { file: file.filename, issues, summary: review.summary, score: review.overallScore }
+        : null;
+    } catch (error) {
+      core.warning(`Failed to audit file ${file.filename}: ${error.message}`);
+      return undefined;
+    }
+  }
+}
+
+module.exports = RepoAuditor;
+module.exports.AUDIT_REVIEW_TYPE = AUDIT_REVIEW_TYPE;
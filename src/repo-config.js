@@ -0,0 +1,545 @@
+/**
+ * Repo Config Module
+ * 저장소에 체크인한 설정 파일(.claude-review.yml)을 읽어 액션 입력값을 덮어쓰는 모듈
+ *
+ * 우선순위 (위가 우선):
+ *   1. 설정 파일의 paths 섹션 (파일 경로나 CODEOWNERS 담당자가 일치하는 섹션, 아래 섹션일수록 우선)
+ *   2. 설정 파일의 최상위 값
+ *   3. 워크플로우 입력값 (with:)
+ *   4. action.yml 기본값
+ *
+ * 설정 예:
+ *   review_type: full
+ *   severity_filter: medium
+ *   file_patterns: ["src/**", "lib/**"]
+ *   paths:
+ *     - path: "src/legacy/**"
+ *       severity_filter: high
+ *     - path: ["vendor/**", "proto/*.pb.go"]
+ *       skip: true
+ *     - owner: "@org/platform"
+ *       instructions: |
+ *         외부 호출에는 타임아웃과 재시도 정책을 명시
+ *
+ * 의존성을 늘리지 않도록 설정 파일에 필요한 YAML 부분집합(블록 매핑/시퀀스, 흐름 시퀀스, 스칼라, 블록 스칼라)만 파싱합니다.
+ */
+
+const fs = require('fs');
+const { minimatch } = require('minimatch');
+const { ConfigError } = require('./errors');
+const { parseLanguageMapping } = require('./language-resolver');
+
+const DEFAULT_CONFIG_PATH = '.claude-review.yml';
+
+// 최상위에서 지원하는 키
+const TOP_LEVEL_KEYS = [
+  'review_type', 'file_patterns', 'exclude_patterns', 'severity_filter', 'language',
+  'max_files', 'max_issues_per_file', 'tone', 'paths'
+];
+// paths 섹션에서 지원하는 키
+const SECTION_KEYS = ['path', 'owner', 'review_type', 'severity_filter', 'instructions', 'skip'];
+
+class RepoConfig {
+  /**
+   * RepoConfig 생성자
+   * @param {Object} config - 파싱한 설정 (키는 snake_case)
+   * @param {string|null} source - 설정 파일 경로 (파일이 없으면 null)
+   */
+  constructor(config = {}, source = null) {
+    RepoConfig.validate(config, source);
+    this.config = config;
+    this.source = source;
+    // paths 섹션 (path, owner는 항상 배열, owner는 소문자)
+    this.sections = (config.paths || []).map(section => ({
+      ...section,
+      path: toList(section.path),
+      owner: toList(section.owner).map(owner => owner.toLowerCase())
+    }));
+    // owner 섹션에 사용할 CODEOWNERS 담당자 매핑 (useCodeowners()로 설정)
+    this.codeowners = null;
+  }
+
+  /**
+   * 설정 파일 로드 (파일이 없으면 빈 설정)
+   * @param {string} [filePath] - 설정 파일 경로
+   * @returns {RepoConfig} 설정
+   */
+  static load(filePath = DEFAULT_CONFIG_PATH) {
+    let text;
+    try {
+      text = fs.readFileSync(filePath, 'utf8');
+    } catch (error) {
+      if (error.code === 'ENOENT') {
+        return new RepoConfig();
+      }
+      throw new ConfigError(`Cannot read ${filePath}: ${error.message}`);
+    }
+    let parsed;
+    try {
+      parsed = RepoConfig.parseYaml(text);
+    } catch (error) {
+      throw new ConfigError(`Invalid ${filePath}: ${error.message}`);
+    }
+    if (parsed !== null && (typeof parsed !== 'object' || Array.isArray(parsed))) {
+      throw new ConfigError(`Invalid ${filePath}: expected a mapping at the top level`);
+    }
+    return new RepoConfig(parsed || {}, filePath);
+  }
+
+  /**
+   * 알 수 없는 키와 잘못된 형식 검사
+   * @param {Object} config - 파싱한 설정
+   * @param {string|null} source - 설정 파일 경로 (오류 메시지용)
+   */
+  static validate(config, source) {
+    const where = source || DEFAULT_CONFIG_PATH;
+    const unknown = Object.keys(config).filter(key => !TOP_LEVEL_KEYS.includes(key));
+    if (unknown.length > 0) {
+      throw new ConfigError(`Unknown key in ${where}: ${unknown.join(', ')} (supported: ${TOP_LEVEL_KEYS.join(', ')})`);
+    }
+    if (config.paths !== undefined && !Array.isArray(config.paths)) {
+      throw new ConfigError(`Invalid ${where}: paths must be a list of sections`);
+    }
+    (config.paths || []).forEach((section, index) => {
+      if (!section || typeof section !== 'object' || Array.isArray(section) || (!section.path && !section.owner)) {
+        throw new ConfigError(`Invalid ${where}: paths[${index}] must be a mapping with a path or an owner`);
+      }
+      const unknownSectionKeys = Object.keys(section).filter(key => !SECTION_KEYS.includes(key));
+      if (unknownSectionKeys.length > 0) {
+        throw new ConfigError(`Unknown key in ${where} paths[${index}]: ${unknownSectionKeys.join(', ')} (supported: ${SECTION_KEYS.join(', ')})`);
+      }
+      // skip은 리뷰 대상을 고르기 전에 경로로만 적용되므로 담당자 조건과 함께 쓸 수 없음
+      if (section.owner && section.skip === true) {
+        throw new ConfigError(`Invalid ${where}: paths[${index}] cannot combine owner with skip`);
+      }
+    });
+  }
+
+  /**
+   * 설정 파일의 최상위 값으로 입력값 덮어쓰기
+   * @param {Object} inputs - 액션 입력값 (직접 수정)
+   * @returns {Array<string>} 덮어쓴 키 목록 (로그용)
+   */
+  apply(inputs) {
+    const config = this.config;
+    const applied = [];
+    const set = (key, field, value) => {
+      inputs[field] = value;
+      applied.push(key);
+    };
+
+    if (config.review_type !== undefined) {
+      set('review_type', 'reviewType', String(config.review_type));
+    }
+    if (config.file_patterns !== undefined) {
+      set('file_patterns', 'filePatterns', toList(config.file_patterns).join(','));
+    }
+    if (config.exclude_patterns !== undefined) {
+      set('exclude_patterns', 'excludePatterns', toList(config.exclude_patterns).join(','));
+    }
+    if (config.severity_filter !== undefined) {
+      set('severity_filter', 'severityFilter', String(config.severity_filter));
+    }
+    if (config.language !== undefined) {
+      // 단일 언어 코드 또는 작성자별 매핑 ({ alice: ja, default: en })
+      const language = typeof config.language === 'object' && config.language !== null
+        ? Object.entries(config.language).map(([target, code]) => `${target}: ${code}`).join('\n')
+        : String(config.language);
+      inputs.language = language;
+      set('language', 'languageMapping', parseLanguageMapping(language));
+    }
+    if (config.max_files !== undefined) {
+      if (!Number.isInteger(config.max_files) || config.max_files < 1) {
+        throw new ConfigError(`Invalid max_files in ${this.source}: ${config.max_files}`);
+      }
+      set('max_files', 'maxFiles', config.max_files);
+    }
+    if (config.max_issues_per_file !== undefined) {
+      if (!Number.isInteger(config.max_issues_per_file)) {
+        throw new ConfigError(`Invalid max_issues_per_file in ${this.source}: ${config.max_issues_per_file}`);
+      }
+      // 1-10 범위로 제한 (입력값과 동일)
+      set('max_issues_per_file', 'maxIssuesPerFile', Math.max(1, Math.min(10, config.max_issues_per_file)));
+    }
+    if (config.tone !== undefined) {
+      set('tone', 'tone', String(config.tone).toLowerCase());
+    }
+
+    // skip 섹션은 제외 패턴에 추가해 max_files 계산 전에 걸러냄
+    const skipped = this.sections.filter(section => section.skip === true).flatMap(section => section.path);
+    if (skipped.length > 0) {
+      inputs.excludePatterns = [inputs.excludePatterns, ...skipped].filter(Boolean).join(',');
+      applied.push('paths.skip');
+    }
+    return applied;
+  }
+
+  /**
+   * owner 섹션이 있는지 확인 (CODEOWNERS를 읽어야 하는지)
+   * @returns {boolean} owner를 지정한 섹션이 있으면 true
+   */
+  hasOwnerSections() {
+    return this.sections.some(section => section.owner.length > 0);
+  }
+
+  /**
+   * owner 섹션에 사용할 CODEOWNERS 담당자 매핑 설정
+   * @param {Codeowners} codeowners - CODEOWNERS 담당자 매핑
+   */
+  useCodeowners(codeowners) {
+    this.codeowners = codeowners;
+  }
+
+  /**
+   * 파일에 적용할 paths 섹션 설정 (일치하는 섹션을 위에서부터 병합)
+   * - path와 owner를 함께 지정하면 둘 다 일치해야 함
+   * - owner는 파일의 CODEOWNERS 담당자 중 하나와 일치하면 됨
+   * - instructions는 덮어쓰지 않고 일치하는 섹션 순서대로 이어 붙임
+   * @param {string} filename - 파일 경로
+   * @returns {Object} { reviewType, severityFilter, instructions } (지정되지 않은 값은 없음)
+   */
+  sectionFor(filename) {
+    const overrides = {};
+    const owners = this.codeowners ? this.codeowners.ownersOf(filename).map(owner => owner.toLowerCase()) : [];
+    const instructions = [];
+    this.sections
+      .filter(section => (section.path.length === 0 || section.path.some(pattern => minimatch(filename, pattern, { dot: true }))) &&
+        (section.owner.length === 0 || section.owner.some(owner => owners.includes(owner))))
+      .forEach(section => {
+        if (section.review_type !== undefined) {
+          overrides.reviewType = String(section.review_type);
+        }
+        if (section.severity_filter !== undefined) {
+          overrides.severityFilter = String(section.severity_filter);
+        }
+        if (section.instructions !== undefined && String(section.instructions).trim()) {
+          instructions.push(String(section.instructions).trim());
+        }
+      });
+    if (instructions.length > 0) {
+      overrides.instructions = instructions.join('\n\n');
+    }
+    return overrides;
+  }
+
+  /**
+   * paths 섹션에 지정된 심각도 목록 (입력값 검증용)
+   * @returns {Array<string>} 심각도 값
+   */
+  sectionSeverities() {
+    return this.sections.filter(section => section.severity_filter !== undefined).map(section => String(section.severity_filter));
+  }
+
+  /**
+   * paths 섹션에 지정된 리뷰 타입 목록 (입력값 검증용)
+   * @returns {Array<string>} 리뷰 타입 값
+   */
+  sectionReviewTypes() {
+    return this.sections.filter(section => section.review_type !== undefined).map(section => String(section.review_type));
+  }
+
+  /**
+   * YAML 부분집합 파싱
+   * @param {string} text - YAML 문서
+   * @returns {*} 파싱 결과 (빈 문서면 null)
+   */
+  static parseYaml(text) {
+    const lines = text.replace(/\r\n?/g, '\n').split('\n').map((raw, index) => ({ raw, number: index + 1 }));
+    const tabbed = lines.find(line => /^ *\t/.test(line.raw) && line.raw.trim() !== '');
+    if (tabbed) {
+      throw new Error(`line ${tabbed.number}: tabs are not allowed for indentation`);
+    }
+    const parser = { lines, position: 0 };
+    skipBlank(parser);
+    if (parser.position >= lines.length) {
+      return null;
+    }
+    const value = parseBlock(parser, indentOf(lines[parser.position].raw));
+    skipBlank(parser);
+    if (parser.position < lines.length) {
+      throw new Error(`line ${lines[parser.position].number}: unexpected indentation`);
+    }
+    return value;
+  }
+}
+
+/**
+ * 쉼표 구분 문자열 또는 목록을 배열로 변환
+ * @param {string|Array} value - 값
+ * @returns {Array<string>} 목록
+ */
+function toList(value) {
+  if (Array.isArray(value)) {
+    return value.map(item => String(item).trim()).filter(Boolean);
+  }
+  return String(value || '').split(',').map(item => item.trim()).filter(Boolean);
+}
+
+/**
+ * 줄 들여쓰기 (공백 수, 탭은 허용하지 않음)
+ * @param {string} raw - 원본 줄
+ * @returns {number} 들여쓰기
+ */
+function indentOf(raw) {
+  return raw.length - raw.replace(/^ */, '').length;
+}
+
+/**
+ * 주석 제거 (따옴표 밖의 " #" 이후)
+ * @param {string} text - 줄 내용
+ * @returns {string} 주석을 제거한 내용
+ */
+function stripComment(text) {
+  let quote = null;
+  for (let i = 0; i < text.length; i++) {
+    const char = text[i];
+    if (quote) {
+      if (char === '\\' && quote === '"') {
+        i++;
+      } else if (char === quote) {
+        quote = null;
+      }
+    } else if (char === '"' || char === '\'') {
+      quote = char;
+    } else if (char === '#' && (i === 0 || /\s/.test(text[i - 1]))) {
+      return text.substring(0, i).trimEnd();
+    }
+  }
+  return text.trimEnd();
+}
+
+/**
+ * 빈 줄과 주석 줄 건너뛰기
+ * @param {Object} parser - 파서 상태
+ */
+function skipBlank(parser) {
+  while (parser.position < parser.lines.length && stripComment(parser.lines[parser.position].raw).trim() === '') {
+    parser.position++;
+  }
+}
+
+/**
+ * 들여쓰기 블록 파싱 (매핑 또는 시퀀스)
+ * @param {Object} parser - 파서 상태
+ * @param {number} indent - 블록 들여쓰기
+ * @returns {Object|Array} 파싱 결과
+ */
+function parseBlock(parser, indent) {
+  const line = parser.lines[parser.position];
+  const content = stripComment(line.raw).trim();
+  return content === '-' || content.startsWith('- ') ? parseSequence(parser, indent) : parseMapping(parser, indent);
+}
+
+/**
+ * 블록 매핑 파싱
+ * @param {Object} parser - 파서 상태
+ * @param {number} indent - 매핑 들여쓰기
+ * @returns {Object} 매핑
+ */
+function parseMapping(parser, indent) {
+  const mapping = {};
+  for (skipBlank(parser); parser.position < parser.lines.length; skipBlank(parser)) {
+    const line = parser.lines[parser.position];
+    const lineIndent = indentOf(line.raw);
+    if (lineIndent < indent) {
+      break;
+    }
+    if (lineIndent > indent) {
+      throw new Error(`line ${line.number}: unexpected indentation`);
+    }
+    const content = stripComment(line.raw).trim();
+    const match = content.match(/^("(?:[^"\\]|\\.)*"|'(?:[^']|'')*'|[^:#'"][^:]*?)\s*:(?:\s+(.*))?$/);
+    if (!match) {
+      throw new Error(`line ${line.number}: expected "key: value"`);
+    }
+    const key = parseScalar(match[1], line.number);
+    parser.position++;
+    mapping[key] = parseValue(parser, indent, match[2], line, true);
+  }
+  return mapping;
+}
+
+/**
+ * 블록 시퀀스 파싱
+ * @param {Object} parser - 파서 상태
+ * @param {number} indent - 시퀀스 들여쓰기
+ * @returns {Array} 시퀀스
+ */
+function parseSequence(parser, indent) {
+  const sequence = [];
+  for (skipBlank(parser); parser.position < parser.lines.length; skipBlank(parser)) {
+    const line = parser.lines[parser.position];
+    const lineIndent = indentOf(line.raw);
+    const content = stripComment(line.raw).trim();
+    if (lineIndent < indent || (lineIndent === indent && !(content === '-' || content.startsWith('- ')))) {
+      break;
+    }
+    if (lineIndent > indent) {
+      throw new Error(`line ${line.number}: unexpected indentation`);
+    }
+    const rest = content.substring(1).trim();
+    if (/^("(?:[^"\\]|\\.)*"|'(?:[^']|'')*'|[^:#'"[{][^:]*?)\s*:(\s|$)/.test(rest)) {
+      // "- key: value" 항목은 "- "를 공백으로 바꾼 매핑으로 파싱
+      const itemIndent = indent + line.raw.substring(indent).indexOf(rest);
+      parser.lines[parser.position] = { raw: ' '.repeat(itemIndent) + rest, number: line.number };
+      sequence.push(parseMapping(parser, itemIndent));
+    } else {
+      parser.position++;
+      sequence.push(parseValue(parser, indent, rest, line, false));
+    }
+  }
+  return sequence;
+}
+
+/**
+ * 키 또는 시퀀스 항목 뒤의 값 파싱 (인라인 값, 블록 스칼라, 하위 블록)
+ * @param {Object} parser - 파서 상태
+ * @param {number} indent - 부모 들여쓰기
+ * @param {string|undefined} inline - 같은 줄의 값
+ * @param {Object} line - 부모 줄
+ * @param {boolean} allowSameIndentSequence - 매핑 값의 시퀀스를 키와 같은 들여쓰기로 허용
+ * @returns {*} 값
+ */
+function parseValue(parser, indent, inline, line, allowSameIndentSequence) {
+  if (inline !== undefined && inline !== '') {
+    if (/^[|>][+-]?$/.test(inline)) {
+      return parseBlockScalar(parser, indent, inline);
+    }
+    return parseInline(inline, line.number);
+  }
+  skipBlank(parser);
+  if (parser.position >= parser.lines.length) {
+    return null;
+  }
+  const next = parser.lines[parser.position];
+  const nextIndent = indentOf(next.raw);
+  const nextContent = stripComment(next.raw).trim();
+  if (nextIndent > indent) {
+    return parseBlock(parser, nextIndent);
+  }
+  if (allowSameIndentSequence && nextIndent === indent && (nextContent === '-' || nextContent.startsWith('- '))) {
+    return parseSequence(parser, indent);
+  }
+  return null;
+}
+
+/**
+ * 블록 스칼라 파싱 (| 줄바꿈 유지, > 줄바꿈을 공백으로)
+ * @param {Object} parser - 파서 상태
+ * @param {number} indent - 부모 들여쓰기
+ * @param {string} indicator - | 또는 > (+/- chomping 포함)
+ * @returns {string} 문자열
+ */
+function parseBlockScalar(parser, indent, indicator) {
+  const collected = [];
+  while (parser.position < parser.lines.length) {
+    const raw = parser.lines[parser.position].raw;
+    if (raw.trim() !== '' && indentOf(raw) <= indent) {
+      break;
+    }
+    collected.push(raw);
+    parser.position++;
+  }
+  const contentIndent = Math.min(...collected.filter(raw => raw.trim() !== '').map(indentOf));
+  const body = collected.map(raw => raw.substring(contentIndent));
+  while (body.length > 0 && body[body.length - 1].trim() === '') {
+    body.pop();
+  }
+  const text = indicator.startsWith('|')
+    ? body.join('\n')
+    : body.join('\n').replace(/([^\n])\n(?=[^\n])/g, '$1 ');
+  return indicator.endsWith('-') ? text : `${text}\n`;
+}
+
+/**
+ * 인라인 값 파싱 (흐름 시퀀스, 빈 흐름 매핑, 스칼라)
+ * @param {string} text - 값
+ * @param {number} lineNumber - 줄 번호 (오류 메시지용)
+ * @returns {*} 값
+ */
+function parseInline(text, lineNumber) {
+  if (text.startsWith('[')) {
+    if (!text.endsWith(']')) {
+      throw new Error(`line ${lineNumber}: unterminated flow sequence`);
+    }
+    const inner = text.slice(1, -1).trim();
+    return inner === '' ? [] : splitFlow(inner).map(item => parseScalar(item.trim(), lineNumber));
+  }
+  if (text === '{}') {
+    return {};
+  }
+  if (text.startsWith('{')) {
+    throw new Error(`line ${lineNumber}: flow mappings are not supported; use an indented block`);
+  }
+  return parseScalar(text, lineNumber);
+}
+
+/**
+ * 흐름 시퀀스 항목 분리 (따옴표 안의 쉼표 무시)
+ * @param {string} text - 대괄호 안의 문자열
+ * @returns {Array<string>} 항목
+ */
+function splitFlow(text) {
+  const items = [];
+  let quote = null;
+  let current = '';
+  for (let i = 0; i < text.length; i++) {
+    const char = text[i];
+    if (quote) {
+      if (char === '\\' && quote === '"') {
+        current += char + text[++i];
+        continue;
+      }
+      if (char === quote) {
+        quote = null;
+      }
+    } else if (char === '"' || char === '\'') {
+      quote = char;
+    } else if (char === ',') {
+      items.push(current);
+      current = '';
+      continue;
+    }
+    current += char;
+  }
+  items.push(current);
+  return items;
+}
+
+/**
+ * 스칼라 파싱 (따옴표 문자열, 불리언, null, 숫자, 일반 문자열)
+ * @param {string} text - 값
+ * @param {number} lineNumber - 줄 번호 (오류 메시지용)
+ * @returns {*} 값
+ */
+function parseScalar(text, lineNumber) {
+  if (text.startsWith('"')) {
+    if (!/^"(?:[^"\\]|\\.)*"$/.test(text)) {
+      throw new Error(`line ${lineNumber}: unterminated double-quoted string`);
+    }
+    try {
+      return JSON.parse(text);
+    } catch (error) {
+      throw new Error(`line ${lineNumber}: invalid escape in double-quoted string`);
+    }
+  }
+  if (text.startsWith('\'')) {
+    if (!/^'(?:[^']|'')*'$/.test(text)) {
+      throw new Error(`line ${lineNumber}: unterminated single-quoted string`);
+    }
+    return text.slice(1, -1).replace(/''/g, '\'');
+  }
+  if (/^(true|false)$/i.test(text)) {
+    return text.toLowerCase() === 'true';
+  }
+  if (/^(null|~)$/i.test(text)) {
+    return null;
+  }
+  if (/^-?\d+(\.\d+)?$/.test(text)) {
+    return Number(text);
+  }
+  return text;
+}
+
+module.exports = RepoConfig;
+module.exports.DEFAULT_CONFIG_PATH = DEFAULT_CONFIG_PATH;
@@ -0,0 +1,216 @@
+/**
+ * Repo Map Module
+ * 저장소를 디렉터리(패키지) 단위로 요약한 지도와 패키지 간 의존성 그래프를 만드는 모듈
+ *
+ * 아키텍처 리뷰에서 변경이 기존 경계에 맞는지 판단하는 근거로 사용합니다.
+ * import 구문은 정규식으로 읽으며 JavaScript/TypeScript(상대 경로), Python, Go(같은 모듈)만 해석합니다.
+ * 외부 패키지와 경로 별칭은 의존성 간선에 포함하지 않습니다.
+ */
+
+const fs = require('fs');
+const path = require('path');
+
+// 지도에 포함할 소스 확장자
+const SOURCE_EXTENSIONS = ['.js', '.jsx', '.mjs', '.cjs', '.ts', '.tsx', '.py', '.go'];
+// 읽을 최대 소스 파일 수 (대형 저장소 보호)
+const MAX_SOURCE_FILES = 3000;
+// 패키지마다 표시할 주요 타입 수
+const MAX_SYMBOLS = 6;
+
+// 주요 타입/함수 선언 패턴
+const SYMBOL_PATTERNS = [
+  /^\s*(?:export\s+)?(?:default\s+)?(?:abstract\s+)?class\s+([A-Z]\w*)/gm,
+  /^\s*(?:export\s+)?interface\s+([A-Z]\w*)/gm,
+  /^type\s+([A-Z]\w*)\s+(?:struct|interface)/gm,
+  /^class\s+([A-Z]\w*)/gm
+];
+
+class RepoMap {
+  /**
+   * RepoMap 생성자
+   * @param {Map<string, Object>} packages - 디렉터리 → { files, symbols, imports: Set<디렉터리> }
+   * @param {Object} index - import 해석용 색인 (buildIndex() 결과)
+   */
+  constructor(packages, index) {
+    this.packages = packages;
+    this.index = index;
+  }
+
+  /**
+   * 작업 디렉터리의 추적 파일로 지도 생성
+   * @param {Object} git - simple-git 인스턴스
+   * @param {string} [root] - 저장소 루트 (기본값: 현재 디렉터리)
+   * @returns {Promise<RepoMap>} 지도
+   */
+  static async build(git, root = process.cwd()) {
+    const files = (await git.raw(['ls-files'])).split('\n').filter(Boolean);
+    const sources = files.filter(file => SOURCE_EXTENSIONS.includes(path.extname(file))).slice(0, MAX_SOURCE_FILES);
+    const index = RepoMap.buildIndex(files, root);
+
+    const packages = new Map();
+    sources.forEach(file => {
+      const dir = path.posix.dirname(file);
+      if (!packages.has(dir)) {
+        packages.set(dir, { files: 0, symbols: new Set(), imports: new Set() });
+      }
+      const entry = packages.get(dir);
+      entry.files++;
+
+      let content;
+      try {
+        content = fs.readFileSync(path.join(root, file), 'utf8');
+      } catch (error) {
+        return;
+      }
+      SYMBOL_PATTERNS.forEach(pattern => {
+        for (const match of content.matchAll(pattern)) {
+          entry.symbols.add(match[1]);
+        }
+      });
+      RepoMap.parseImports(file, content, index)
+        .filter(target => target !== dir)
+        .forEach(target => entry.imports.add(target));
+    });
+
+    return new RepoMap(packages, index);
+  }
+
+  /**
+   * import 해석용 색인 생성
+   * @param {Array<string>} files - 추적 파일 목록
+   * @param {string} root - 저장소 루트
+   * @returns {Object} { files, directories, goModule }
+   */
+  static buildIndex(files, root) {
+    return {
+      files: new Set(files),
+      directories: new Set(files.map(file => path.posix.dirname(file))),
+      goModule: RepoMap.readGoModule(root)
+    };
+  }
+
+  /**
+   * go.mod의 모듈 경로 읽기
+   * @param {string} root - 저장소 루트
+   * @returns {string|null} 모듈 경로
+   */
+  static readGoModule(root) {
+    try {
+      const match = fs.readFileSync(path.join(root, 'go.mod'), 'utf8').match(/^module\s+(\S+)/m);
+      return match ? match[1] : null;
+    } catch (error) {
+      return null;
+    }
+  }
+
+  /**
+   * 파일 내용에서 저장소 내부 import를 디렉터리로 해석
+   * @param {string} file - 파일 경로
+   * @param {string} content - 파일 내용 (또는 diff에서 추가된 줄)
+   * @param {Object} index - buildIndex() 결과
+   * @returns {Array<string>} 의존하는 디렉터리 목록
+   */
+  static parseImports(file, content, index) {
+    const { files, directories, goModule } = index;
+    const dir = path.posix.dirname(file);
+    const extension = path.extname(file);
+    const targets = [];
+
+    // 저장소에 실제로 있는 디렉터리 또는 파일(확장자 생략 가능)만 디렉터리로 변환
+    const toDirectory = resolved => {
+      const normalized = path.posix.normalize(resolved).replace(/\/$/, '');
+      if (normalized !== '.' && directories.has(normalized)) {
+        return normalized;
+      }
+      const candidate = ['', ...SOURCE_EXTENSIONS].map(ext => normalized + ext).find(name => files.has(name));
+      return candidate ? path.posix.dirname(candidate) : null;
+    };
+
+    if (extension === '.py') {
+      for (const match of content.matchAll(/^\s*(?:from\s+(\.*)([\w.]*)\s+import|import\s+([\w.]+))/gm)) {
+        const [, dots = '', fromModule, importModule] = match;
+        const modulePath = (fromModule || importModule || '').replace(/\./g, '/');
+        const base = dots.length > 0 ? path.posix.join(dir, ...Array(dots.length - 1).fill('..')) : '';
+        const target = toDirectory(path.posix.join(base || '.', modulePath));
+        if (target) {
+          targets.push(target);
+        }
+      }
+    } else if (extension === '.go') {
+      if (goModule) {
+        for (const match of content.matchAll(/"([^"\s]+)"/g)) {
+          if (match[1].startsWith(`${goModule}/`)) {
+            const target = toDirectory(match[1].substring(goModule.length + 1));
+            if (target) {
+              targets.push(target);
+            }
+          }
+        }
+      }
+    } else {
+      for (const match of content.matchAll(/(?:from\s+|require\(\s*|import\s*\(\s*|import\s+)['"](\.{1,2}\/[^'"]+)['"]/g)) {
+        const target = toDirectory(path.posix.join(dir, match[1]));
+        if (target) {
+          targets.push(target);
+        }
+      }
+    }
+    return [...new Set(targets)];
+  }
+
+  /**
+   * 한 디렉터리에서 다른 디렉터리로 가는 의존 경로 찾기 (BFS)
+   * @param {string} from - 시작 디렉터리
+   * @param {string} to - 도착 디렉터리
+   * @returns {Array<string>|null} 경로 (없으면 null)
+   */
+  findPath(from, to) {
+    const previous = new Map([[from, null]]);
+    const queue = [from];
+    while (queue.length > 0) {
+      const current = queue.shift();
+      if (current === to) {
+        const route = [];
+        for (let node = to; node !== null; node = previous.get(node)) {
+          route.unshift(node);
+        }
+        return route;
+      }
+      const entry = this.packages.get(current);
+      (entry ? [...entry.imports] : []).forEach(next => {
+        if (!previous.has(next)) {
+          previous.set(next, current);
+          queue.push(next);
+        }
+      });
+    }
+    return null;
+  }
+
+  /**
+   * 모델에 전달할 요약 문자열 (패키지, 주요 타입, 의존 패키지)
+   * @param {number} maxChars - 최대 길이
+   * @returns {string} 요약
+   */
+  format(maxChars = 8000) {
+    const lines = [...this.packages.entries()]
+      .sort(([a], [b]) => a.localeCompare(b))
+      .map(([dir, entry]) => {
+        const symbols = [...entry.symbols].slice(0, MAX_SYMBOLS);
+        const imports = [...entry.imports].sort();
+        return `${dir}/ (${entry.files} files)${symbols.length > 0 ? ` types: ${symbols.join(', ')}` : ''}${imports.length > 0 ? ` -> ${imports.join(', ')}` : ''}`;
+      });
+
+    let text = '';
+    for (const line of lines) {
+      if (text.length + line.length + 1 > maxChars) {
+        text += `... (${lines.length} packages, truncated)\n`;
+        break;
+      }
+      text += `${line}\n`;
+    }
+    return text;
+  }
+}
+
+module.exports = RepoMap;
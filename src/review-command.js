@@ -0,0 +1,165 @@
+/**
+ * Review Command Module
+ * PR 댓글의 `/claude review` 명령(issue_comment 이벤트)으로 리뷰를 다시 실행하는 모듈
+ *
+ * - 명령은 댓글 첫 줄이 `/claude review`로 시작해야 하며, 옵션으로 리뷰 타입과 심각도 필터를 바꿀 수 있음
+ *   예: /claude review --type security --severity high
+ * - 저장소에 write 이상 권한이 있는 사람(collaborator)만 실행할 수 있음
+ * - 명령 댓글에 👀(접수) 또는 😕(권한 없음, 잘못된 옵션) 반응을 남김
+ * - PR 정보를 조회해 pull_request 이벤트와 같은 컨텍스트로 바꾸므로 이후 리뷰 흐름은 PR 리뷰와 같음
+ */
+
+const { SEVERITY_LEVELS } = require('./review-summary');
+
+const COMMAND_PATTERN = /^\/claude\s+review\b(.*)$/i;
+
+// 명령을 실행할 수 있는 저장소 권한
+const ALLOWED_PERMISSIONS = ['admin', 'maintain', 'write'];
+
+// 지원하는 옵션 → 파싱 결과 키
+const FLAGS = {
+  '--type': 'reviewType',
+  '--severity': 'severityFilter'
+};
+
+/**
+ * 사용자가 저장소에 write 이상 권한이 있는지 확인
+ * @param {Object} octokit - GitHub API 클라이언트
+ * @param {Object} context - GitHub Actions 컨텍스트
+ * @param {string} username - 사용자 로그인
+ * @returns {Promise<boolean>} write, maintain, admin 권한이면 true
+ */
+async function hasWriteAccess(octokit, context, username) {
+  try {
+    const { data } = await octokit.rest.repos.getCollaboratorPermissionLevel({
+      owner: context.repo.owner,
+      repo: context.repo.repo,
+      username
+    });
+    return ALLOWED_PERMISSIONS.includes(data.permission);
+  } catch (error) {
+    // collaborator가 아니면 404
+    return false;
+  }
+}
+
+class ReviewCommand {
+  /**
+   * ReviewCommand 생성자
+   * @param {Object} options - 파싱한 옵션 { reviewType, severityFilter } (지정하지 않은 옵션은 없음)
+   * @param {Object} comment - 명령 댓글 (issue_comment 페이로드의 comment)
+   */
+  constructor(options, comment) {
+    this.options = options;
+    this.comment = comment;
+  }
+
+  /**
+   * 댓글 본문에서 명령 파싱
+   * @param {string} body - 댓글 본문
+   * @returns {Object|null} { options } 또는 { error } (명령이 아니면 null)
+   */
+  static parse(body) {
+    const match = String(body || '').trim().split('\n')[0].trim().match(COMMAND_PATTERN);
+    if (!match) {
+      return null;
+    }
+
+    const tokens = match[1].trim().split(/\s+/).filter(Boolean);
+    const options = {};
+    for (let i = 0; i < tokens.length; i++) {
+      const [flag, inline] = tokens[i].split('=');
+      const key = FLAGS[flag.toLowerCase()];
+      if (!key) {
+        return { error: `알 수 없는 옵션 ${flag} (지원: ${Object.keys(FLAGS).join(', ')})` };
+      }
+      const value = inline !== undefined ? inline : tokens[++i];
+      if (!value || value.startsWith('--')) {
+        return { error: `${flag}에 값이 없습니다` };
+      }
+      options[key] = value.toLowerCase();
+    }
+    if (options.severityFilter && !SEVERITY_LEVELS[options.severityFilter]) {
+      return { error: `잘못된 심각도 ${options.severityFilter} (지원: ${Object.keys(SEVERITY_LEVELS).join(', ')})` };
+    }
+    return { options };
+  }
+
+  /**
+   * issue_comment 이벤트에서 명령 로드 (PR 댓글의 새 명령이 아니면 null)
+   * @param {Object} octokit - GitHub API 클라이언트
+   * @param {Object} context - GitHub Actions 컨텍스트 (issue_comment 이벤트)
+   * @returns {Promise<ReviewCommand|null>} 명령
+   * @throws {Error} 잘못된 옵션이나 권한 없음 (명령 댓글에 😕 반응을 남긴 뒤)
+   */
+  static async load(octokit, context) {
+    const { action, issue, comment } = context.payload;
+    if (action !== 'created' || !issue || !issue.pull_request || !comment) {
+      return null;
+    }
+    const parsed = ReviewCommand.parse(comment.body);
+    if (!parsed) {
+      return null;
+    }
+
+    const command = new ReviewCommand(parsed.options || {}, comment);
+    if (parsed.error) {
+      await command.react(octokit, context, 'confused');
+      throw new Error(`Invalid /claude review command: ${parsed.error}`);
+    }
+    if (!await command.isAuthorized(octokit, context)) {
+      await command.react(octokit, context, 'confused');
+      throw new Error(`@${comment.user.login} is not allowed to run /claude review (requires ${ALLOWED_PERMISSIONS.join('/')} permission)`);
+    }
+    await command.react(octokit, context, 'eyes');
+    return command;
+  }
+
+  /**
+   * 명령을 작성한 사람의 저장소 권한 확인
+   * @param {Object} octokit - GitHub API 클라이언트
+   * @param {Object} context - GitHub Actions 컨텍스트
+   * @returns {Promise<boolean>} write 이상 권한이 있으면 true
+   */
+  async isAuthorized(octokit, context) {
+    return hasWriteAccess(octokit, context, this.comment.user.login);
+  }
+
+  /**
+   * 명령 댓글에 반응 추가 (실패해도 무시)
+   * @param {Object} octokit - GitHub API 클라이언트
+   * @param {Object} context - GitHub Actions 컨텍스트
+   * @param {string} content - 반응 (eyes, confused 등)
+   */
+  async react(octokit, context, content) {
+    await octokit.rest.reactions.createForIssueComment({
+      owner: context.repo.owner,
+      repo: context.repo.repo,
+      comment_id: this.comment.id,
+      content
+    }).catch(() => {});
+  }
+
+  /**
+   * 명령 댓글의 PR을 조회해 pull_request 이벤트 컨텍스트로 변환
+   * @param {Object} octokit - GitHub API 클라이언트
+   * @param {Object} context - GitHub Actions 컨텍스트 (issue_comment 이벤트)
+   * @returns {Promise<Object>} pull_request 이벤트와 같은 형태의 컨텍스트
+   */
+  async pullRequestContext(octokit, context) {
+    const { data: pullRequest } = await octokit.rest.pulls.get({
+      owner: context.repo.owner,
+      repo: context.repo.repo,
+      pull_number: context.payload.issue.number
+    });
+    // repo 등 getter를 유지하도록 같은 프로토타입으로 복사
+    return Object.assign(Object.create(Object.getPrototypeOf(context)), context, {
+      eventName: 'pull_request',
+      sha: pullRequest.head.sha,
+      payload: { ...context.payload, pull_request: pullRequest }
+    });
+  }
+}
+
+module.exports = ReviewCommand;
+module.exports.hasWriteAccess = hasWriteAccess;
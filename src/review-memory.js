@@ -0,0 +1,206 @@
+/**
+ * Review Memory Module
+ * 지난 이슈와 그 결과(수정됨, 무시됨, 이의 제기됨)를 저장하고,
+ * 리뷰할 파일과 비슷한 과거 사례를 찾아 프롬프트에 넣는 모듈
+ *
+ * 팀이 이미 "괜찮다"고 결정한 패턴을 리뷰어가 반복해서 지적하지 않도록 합니다.
+ * 임베딩 API 없이 동작하도록 유사도는 토큰 기반 TF-IDF 점수로 계산합니다.
+ *
+ * 저장 형식 (.claude-review/memory.json):
+ * {
+ *   "version": 1,
+ *   "entries": [
+ *     { "fingerprint": "...", "file": "src/db.js", "type": "security", "title": "...",
+ *       "summary": "...", "outcome": "fixed" | "dismissed" | "disputed", "reason": "...", "by": "...", "at": "ISO 날짜" }
+ *   ]
+ * }
+ */
+
+const fs = require('fs').promises;
+const path = require('path');
+
+const DEFAULT_MEMORY_PATH = '.claude-review/memory.json';
+const OUTCOMES = ['fixed', 'dismissed', 'disputed'];
+
+// 유사도 계산에서 제외할 흔한 단어
+const STOP_WORDS = new Set([
+  'the', 'and', 'for', 'with', 'this', 'that', 'from', 'are', 'not', 'use', 'using', 'should', 'can', 'may',
+  'const', 'let', 'var', 'function', 'return', 'import', 'export', 'class', 'new', 'def', 'self', 'func', 'public', 'private'
+]);
+
+// 과거 사례로 인정할 최소 유사도 점수
+const MIN_SCORE = 0.15;
+
+class ReviewMemory {
+  /**
+   * ReviewMemory 생성자
+   * @param {Array} entries - 저장된 사례 목록
+   * @param {string|null} sha - 저장소 파일 blob SHA (Contents API 갱신용)
+   */
+  constructor(entries = [], sha = null) {
+    this.entries = entries;
+    this.sha = sha;
+  }
+
+  /**
+   * 로컬 파일에서 로드 (파일이 없으면 빈 메모리)
+   * @param {string} filePath - 파일 경로
+   * @returns {Promise<ReviewMemory>} 메모리
+   */
+  static async loadFile(filePath = DEFAULT_MEMORY_PATH) {
+    try {
+      const data = JSON.parse(await fs.readFile(filePath, 'utf8'));
+      if (!Array.isArray(data.entries)) {
+        throw new Error('entries must be an array');
+      }
+      return new ReviewMemory(data.entries.filter(entry => OUTCOMES.includes(entry.outcome)));
+    } catch (error) {
+      if (error.code === 'ENOENT') {
+        return new ReviewMemory();
+      }
+      throw new Error(`Invalid review memory file ${filePath}: ${error.message}`);
+    }
+  }
+
+  /**
+   * 사례 추가 또는 갱신 (같은 지문은 가장 최근 결과 하나만 유지)
+   * @param {Object} entry - { fingerprint, file, type, title, summary, outcome, reason, by }
+   */
+  record(entry) {
+    if (!OUTCOMES.includes(entry.outcome)) {
+      throw new Error(`Invalid outcome "${entry.outcome}" (supported: ${OUTCOMES.join(', ')})`);
+    }
+    this.entries = this.entries.filter(item => item.fingerprint !== entry.fingerprint);
+    this.entries.push({ ...entry, at: entry.at || new Date().toISOString() });
+  }
+
+  /**
+   * 억제 목록의 무시(dismissed) 항목을 사례로 추가 (메모리에 이미 있는 지문은 유지)
+   * Slack에서 무시한 이슈도 별도 기록 없이 과거 결정으로 활용합니다.
+   * @param {SuppressionStore} suppressions - 억제 목록
+   */
+  addSuppressions(suppressions) {
+    const known = new Set(this.entries.map(entry => entry.fingerprint));
+    suppressions.suppressions
+      .filter(item => item.status === 'dismissed' && item.title && !known.has(item.fingerprint))
+      .forEach(item => {
+        this.entries.push({
+          fingerprint: item.fingerprint,
+          file: item.file || '',
+          type: item.type || 'general',
+          title: item.title,
+          outcome: 'dismissed',
+          reason: item.reason || '',
+          by: item.by,
+          at: item.createdAt
+        });
+      });
+  }
+
+  /**
+   * 리뷰할 파일과 비슷한 과거 사례 검색
+   * 사례의 제목/요약/사유 토큰이 파일 내용에 얼마나 나타나는지(IDF 가중)와 경로 근접도로 점수를 매깁니다.
+   * @param {string} filename - 파일 경로
+   * @param {string} content - 파일 내용
+   * @param {number} limit - 최대 사례 수
+   * @returns {Array} 점수 높은 순 사례 목록
+   */
+  findSimilar(filename, content, limit) {
+    if (this.entries.length === 0 || limit <= 0) {
+      return [];
+    }
+
+    const fileTokens = new Set([...tokenize(content), ...tokenize(filename)]);
+    const entryTokens = this.entries.map(entry => new Set(tokenize(`${entry.title} ${entry.summary || ''} ${entry.reason || ''}`)));
+
+    // 여러 사례에 흔한 토큰일수록 낮은 가중치
+    const documentFrequency = new Map();
+    entryTokens.forEach(tokens => tokens.forEach(token => documentFrequency.set(token, (documentFrequency.get(token) || 0) + 1)));
+    const idf = token => Math.log(1 + this.entries.length / documentFrequency.get(token));
+
+    return this.entries
+      .map((entry, index) => {
+        const tokens = [...entryTokens[index]];
+        const total = tokens.reduce((sum, token) => sum + idf(token), 0);
+        const matched = tokens.filter(token => fileTokens.has(token)).reduce((sum, token) => sum + idf(token), 0);
+        const score = (total > 0 ? matched / total : 0) + pathAffinity(filename, entry.file);
+        return { entry, score };
+      })
+      .filter(item => item.score >= MIN_SCORE)
+      .sort((a, b) => b.score - a.score)
+      .slice(0, limit)
+      .map(item => item.entry);
+  }
+
+  /**
+   * 과거 사례를 프롬프트 지시사항으로 변환
+   * @param {Array} cases - findSimilar() 결과
+   * @returns {string} 프롬프트에 추가할 지시사항 (사례가 없으면 빈 문자열)
+   */
+  static buildInstruction(cases) {
+    if (cases.length === 0) {
+      return '';
+    }
+
+    const lines = cases.map(entry => {
+      const reason = entry.reason ? ` — ${entry.reason}` : '';
+      return `- [${entry.outcome}] ${entry.type}: "${entry.title}" (${entry.file})${reason}`;
+    });
+    return `\n\n이 팀의 과거 리뷰 결정 (비슷한 사례):\n${lines.join('\n')}\n` +
+      'dismissed 또는 disputed 사례와 같은 패턴은 팀이 문제없다고 결정한 것이므로, 상황이 실질적으로 다르지 않다면 다시 지적하지 마세요. ' +
+      'fixed 사례는 팀이 실제로 수정한 유효한 지적입니다.';
+  }
+
+  /**
+   * 파일 내용 직렬화
+   * @returns {string} JSON 문자열
+   */
+  serialize() {
+    return JSON.stringify({ version: 1, entries: this.entries }, null, 2) + '\n';
+  }
+
+  /**
+   * 로컬 파일에 저장
+   * @param {string} filePath - 파일 경로
+   */
+  async saveFile(filePath = DEFAULT_MEMORY_PATH) {
+    await fs.mkdir(path.dirname(filePath), { recursive: true });
+    await fs.writeFile(filePath, this.serialize());
+  }
+}
+
+/**
+ * 유사도 계산용 토큰 분리 (camelCase, snake_case 분해, 소문자화)
+ * @param {string} text - 원문
+ * @returns {Array<string>} 토큰 목록
+ */
+function tokenize(text) {
+  return String(text || '')
+    .replace(/([a-z0-9])([A-Z])/g, '$1 $2')
+    .toLowerCase()
+    .split(/[^a-z0-9가-힣]+/)
+    .filter(token => token.length >= 3 && !STOP_WORDS.has(token));
+}
+
+/**
+ * 경로 근접도 가산점 (같은 파일 > 같은 디렉터리 > 같은 확장자)
+ * @param {string} filename - 리뷰할 파일
+ * @param {string} other - 사례의 파일
+ * @returns {number} 가산점
+ */
+function pathAffinity(filename, other) {
+  if (!other) {
+    return 0;
+  }
+  if (filename === other) {
+    return 0.3;
+  }
+  if (path.dirname(filename) === path.dirname(other)) {
+    return 0.1;
+  }
+  return path.extname(filename) === path.extname(other) ? 0.05 : 0;
+}
+
+module.exports = ReviewMemory;
+module.exports.DEFAULT_MEMORY_PATH = DEFAULT_MEMORY_PATH;
+module.exports.OUTCOMES = OUTCOMES;
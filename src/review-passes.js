@@ -0,0 +1,94 @@
+/**
+ * Review Passes Module
+ * 전문 리뷰 패스(보안, 성능, 정확성, 스타일)와 패스 결과를 하나로 합치는 중재(arbitration) 프롬프트
+ *
+ * review_passes 입력값을 지정하면 하나의 종합 프롬프트 대신 패스마다 좁은 관점으로 병렬 리뷰하고,
+ * 마지막 중재 패스가 중복 제거, 관점 간 충돌 해결, 오탐 제외를 거쳐 하나의 리뷰로 정리합니다.
+ * 패스마다 다른 모델을 지정할 수 있습니다 (예: 보안은 상위 모델, 스타일은 경량 모델).
+ */
+
+const { ConfigError } = require('./errors');
+
+// 다른 관점의 이슈는 다른 패스가 담당하므로 보고하지 않도록 하는 공통 지시
+const SCOPE_INSTRUCTION = '\n\n이 관점 밖의 이슈는 다른 전문 리뷰어가 담당하므로 보고하지 마세요.';
+
+const REVIEW_PASSES = {
+  // 보안 패스
+  security: {
+    name: 'Security Pass',
+    prompt: `당신은 보안 전문 리뷰어입니다. 다음 코드 변경사항에서 보안 취약점만 리뷰해주세요.
+
+리뷰 관점:
+- 인젝션 (SQL, 명령어, 경로), XSS, SSRF
+- 인증/인가 누락 및 우회
+- 비밀값 노출과 민감 정보 로깅
+- 신뢰할 수 없는 입력의 검증 누락
+- 안전하지 않은 암호화와 역직렬화${SCOPE_INSTRUCTION}`
+  },
+
+  // 성능 패스
+  performance: {
+    name: 'Performance Pass',
+    prompt: `당신은 성능 전문 리뷰어입니다. 다음 코드 변경사항에서 성능 문제만 리뷰해주세요.
+
+리뷰 관점:
+- 알고리즘 복잡도와 반복문 안의 비싼 연산
+- N+1 쿼리와 불필요한 네트워크 호출
+- 메모리 사용량과 리소스 누수
+- 캐싱 기회${SCOPE_INSTRUCTION}`
+  },
+
+  // 정확성 패스
+  correctness: {
+    name: 'Correctness Pass',
+    prompt: `당신은 정확성 전문 리뷰어입니다. 다음 코드 변경사항이 의도대로 동작하는지만 리뷰해주세요.
+
+리뷰 관점:
+- 논리 오류와 경계 조건 (off-by-one, 빈 값, null)
+- 오류 처리 누락과 삼켜지는 예외
+- 동시성 문제 (경쟁 조건, 누락된 await)
+- 호출하는 쪽과 맞지 않는 반환값과 타입${SCOPE_INSTRUCTION}`
+  },
+
+  // 스타일 패스
+  style: {
+    name: 'Style Pass',
+    prompt: `당신은 코드 스타일 전문 리뷰어입니다. 다음 코드 변경사항의 가독성과 유지보수성만 리뷰해주세요.
+
+리뷰 관점:
+- 네이밍과 주변 코드와의 일관성
+- 함수 길이와 구조
+- 중복 코드
+- 주석과 문서화${SCOPE_INSTRUCTION}`
+  }
+};
+
+// 중재 패스 프롬프트 (후보 이슈를 검토해 최종 리뷰 결정)
+const ARBITRATION_PROMPT = `당신은 코드 리뷰의 최종 중재자입니다. 여러 전문 리뷰어(패스)가 같은 파일을 각자의 관점으로 리뷰한 후보 이슈를 검토해 하나의 일관된 리뷰로 정리해주세요.
+
+중재 규칙:
+- 같은 문제를 가리키는 후보는 하나로 합치고, 가장 잘 설명한 후보의 id를 대표로, 나머지는 merged에 넣으세요
+- 패스 간 심각도 판단이 다르면 코드를 근거로 최종 심각도를 정하세요
+- 코드를 보고 오탐으로 판단되는 후보는 제외하세요
+- 서로 모순되는 제안이 있으면 더 안전한 쪽을 남기세요`;
+
+/**
+ * pass_models 입력값 파싱 ("<패스>: <모델>" 줄 목록)
+ * @param {string} text - 입력값
+ * @returns {Object} { 패스 키: 모델 }
+ */
+function parsePassModels(text) {
+  const models = {};
+  (text || '').split('\n').map(line => line.trim()).filter(Boolean).forEach(line => {
+    const separator = line.indexOf(':');
+    const pass = separator === -1 ? '' : line.substring(0, separator).trim().toLowerCase();
+    const model = line.substring(separator + 1).trim();
+    if (!REVIEW_PASSES[pass] || !model) {
+      throw new ConfigError(`Invalid pass_models entry (expected "<${Object.keys(REVIEW_PASSES).join('|')}>: <model>"): ${line}`);
+    }
+    models[pass] = model;
+  });
+  return models;
+}
+
+module.exports = { REVIEW_PASSES, ARBITRATION_PROMPT, parsePassModels };
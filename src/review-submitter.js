@@ -0,0 +1,102 @@
+/**
+ * Review Submitter Module
+ * 리뷰 결과로 PR에 정식 리뷰 상태(승인, 변경 요청)를 제출하는 모듈
+ *
+ * - 정책: request_changes_severity 이상의 이슈가 있으면 REQUEST_CHANGES,
+ *   승인 조건(clean: 이슈 없음, below_severity: 기준 미만 이슈만)을 만족하면 APPROVE, 그 외에는 제출하지 않음
+ * - 같은 계정의 가장 최근 리뷰 상태가 유효하므로, 이슈가 고쳐진 뒤 승인하면 이전 변경 요청이 대체됨
+ * - 브랜치 보호의 필수 리뷰어(Require approvals)와 연동할 때 사용
+ */
+
+const { getSeverityLevel, countBySeverity } = require('./review-summary');
+
+// 승인 조건
+const APPROVE_POLICIES = ['clean', 'below_severity', 'never'];
+
+class ReviewSubmitter {
+  /**
+   * ReviewSubmitter 생성자
+   * @param {Object} octokit - GitHub API 클라이언트
+   * @param {Object} context - GitHub Actions 컨텍스트 (pull_request 이벤트)
+   * @param {Object} [options] - 옵션
+   * @param {string} [options.requestChangesSeverity] - 이 심각도 이상의 이슈가 있으면 변경 요청 (기본값: critical)
+   * @param {string} [options.approve] - 승인 조건 (clean, below_severity, never)
+   */
+  constructor(octokit, context, { requestChangesSeverity = 'critical', approve = 'clean' } = {}) {
+    this.octokit = octokit;
+    this.context = context;
+    this.requestChangesSeverity = requestChangesSeverity;
+    this.approve = approve;
+  }
+
+  /**
+   * 리뷰 결과로 제출할 리뷰 상태 결정
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @param {Object} [options] - 옵션
+   * @param {boolean} [options.partial] - 일부 변경분만 리뷰했는지 (증분 리뷰, 승인하지 않음)
+   * @returns {string} APPROVE, REQUEST_CHANGES, COMMENT
+   */
+  decide(reviewResults, { partial = false } = {}) {
+    const issues = reviewResults.flatMap(result => result.issues);
+    const threshold = getSeverityLevel(this.requestChangesSeverity);
+    if (issues.some(issue => getSeverityLevel(issue.severity) >= threshold)) {
+      return 'REQUEST_CHANGES';
+    }
+    // 증분 리뷰는 이전에 리뷰한 파일의 이슈를 모르므로 승인하지 않음
+    if (partial || this.approve === 'never') {
+      return 'COMMENT';
+    }
+    if (this.approve === 'clean' && issues.length > 0) {
+      return 'COMMENT';
+    }
+    return 'APPROVE';
+  }
+
+  /**
+   * 리뷰 본문 생성
+   * @param {string} event - 리뷰 상태 (APPROVE, REQUEST_CHANGES)
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @returns {string} 리뷰 본문
+   */
+  buildBody(event, reviewResults) {
+    const counts = countBySeverity(reviewResults);
+    const breakdown = ['critical', 'high', 'medium', 'low']
+      .filter(severity => counts[severity] > 0)
+      .map(severity => `${severity} ${counts[severity]}`)
+      .join(', ');
+
+    if (event === 'REQUEST_CHANGES') {
+      return `Claude AI 리뷰: \`${this.requestChangesSeverity}\` 이상의 이슈가 있어 변경을 요청합니다 (${breakdown}). 리뷰 댓글을 확인해주세요.`;
+    }
+    return breakdown
+      ? `Claude AI 리뷰: \`${this.requestChangesSeverity}\` 이상의 이슈가 없어 승인합니다 (${breakdown}).`
+      : 'Claude AI 리뷰: 발견된 이슈가 없어 승인합니다.';
+  }
+
+  /**
+   * 리뷰 상태 제출 (COMMENT는 리뷰 댓글과 내용이 겹치므로 제출하지 않음)
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @param {Object} [options] - decide() 옵션
+   * @returns {Promise<Object>} { event, url } (제출하지 않으면 url이 null)
+   */
+  async submit(reviewResults, options = {}) {
+    const event = this.decide(reviewResults, options);
+    if (event === 'COMMENT') {
+      return { event, url: null };
+    }
+    const pullRequest = this.context.payload.pull_request;
+    const body = this.buildBody(event, reviewResults);
+    const { data } = await this.octokit.rest.pulls.createReview({
+      owner: this.context.repo.owner,
+      repo: this.context.repo.repo,
+      pull_number: pullRequest.number,
+      commit_id: pullRequest.head.sha,
+      event,
+      body
+    });
+    return { event, url: data.html_url };
+  }
+}
+
+module.exports = ReviewSubmitter;
+module.exports.APPROVE_POLICIES = APPROVE_POLICIES;
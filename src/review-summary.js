@@ -0,0 +1,126 @@
+/**
+ * Review Summary Module
+ * 알림/리포트에서 공통으로 사용하는 리뷰 결과 요약 생성 모듈
+ *
+ * 주요 기능:
+ * - 심각도 레벨 변환 및 통계
+ * - 리뷰 판정(verdict) 계산
+ * - 심각도 순 상위 이슈 선별
+ */
+
+// 심각도 이름과 레벨 매핑
+const SEVERITY_LEVELS = {
+  low: 1,
+  medium: 2,
+  high: 3,
+  critical: 4
+};
+
+/**
+ * 심각도 레벨을 숫자로 변환
+ * @param {string} severity - 심각도 문자열 (low, medium, high, critical)
+ * @returns {number} 심각도 레벨 (1-4)
+ */
+function getSeverityLevel(severity) {
+  return SEVERITY_LEVELS[(severity || '').toLowerCase()] || 1;
+}
+
+/**
+ * 심각도별 이슈 수 집계
+ * @param {Array} reviewResults - 파일별 리뷰 결과
+ * @returns {Object} { critical, high, medium, low }
+ */
+function countBySeverity(reviewResults) {
+  const counts = { critical: 0, high: 0, medium: 0, low: 0 };
+  reviewResults.forEach(result => {
+    result.issues.forEach(issue => {
+      counts[issue.severity] = (counts[issue.severity] || 0) + 1;
+    });
+  });
+  return counts;
+}
+
+/**
+ * 심각도 통계로 리뷰 판정 계산
+ * @param {Object} counts - 심각도별 이슈 수
+ * @returns {string} changes_requested, needs_attention, approved
+ */
+function getVerdict(counts) {
+  if (counts.critical > 0 || counts.high > 0) {
+    return 'changes_requested';
+  }
+  if (counts.medium > 0 || counts.low > 0) {
+    return 'needs_attention';
+  }
+  return 'approved';
+}
+
+/**
+ * 심각도가 높은 순서로 상위 이슈 선별
+ * @param {Array} reviewResults - 파일별 리뷰 결과
+ * @param {number} limit - 최대 개수
+ * @returns {Array} { file, ...issue } 배열
+ */
+function getTopFindings(reviewResults, limit = 3) {
+  const findings = [];
+  reviewResults.forEach(result => {
+    result.issues.forEach(issue => findings.push({ file: result.file, ...issue }));
+  });
+
+  return findings
+    .sort((a, b) => getSeverityLevel(b.severity) - getSeverityLevel(a.severity))
+    .slice(0, limit);
+}
+
+/**
+ * 요약에 지정 심각도 이상 이슈가 있는지 확인 (알림 임계값 판단용)
+ * @param {Object} summary - buildReviewSummary() 결과
+ * @param {string} minSeverity - 최소 심각도
+ * @returns {boolean} 존재 여부
+ */
+function hasFindingsAtOrAbove(summary, minSeverity) {
+  const threshold = getSeverityLevel(minSeverity);
+  return Object.entries(summary.counts)
+    .some(([severity, count]) => count > 0 && getSeverityLevel(severity) >= threshold);
+}
+
+/**
+ * 알림용 리뷰 요약 생성
+ * @param {Array} reviewResults - 파일별 리뷰 결과
+ * @param {Object} context - GitHub Actions 컨텍스트
+ * @param {Object} [runMetadata] - 실행 메타데이터 (리포트 포함용)
+ * @returns {Object} 요약 객체
+ */
+function buildReviewSummary(reviewResults, context, runMetadata = null) {
+  const payload = context.payload || {};
+  const pullRequest = payload.pull_request;
+  const counts = countBySeverity(reviewResults);
+  const repository = `${context.repo.owner}/${context.repo.repo}`;
+
+  return {
+    repository,
+    number: pullRequest ? pullRequest.number : null,
+    title: pullRequest ? `#${pullRequest.number} ${pullRequest.title}` : `${repository}@${(context.sha || '').substring(0, 7)}`,
+    url: pullRequest
+      ? pullRequest.html_url
+      : `https://github.com/${repository}/commit/${context.sha}`,
+    verdict: getVerdict(counts),
+    counts,
+    totalIssues: counts.critical + counts.high + counts.medium + counts.low,
+    filesWithIssues: reviewResults.length,
+    topFindings: getTopFindings(reviewResults, 3),
+    // 전체 이슈가 필요한 다이제스트형 알림용
+    results: reviewResults,
+    runMetadata
+  };
+}
+
+module.exports = {
+  SEVERITY_LEVELS,
+  getSeverityLevel,
+  countBySeverity,
+  getVerdict,
+  getTopFindings,
+  hasFindingsAtOrAbove,
+  buildReviewSummary
+};
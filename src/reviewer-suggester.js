@@ -0,0 +1,212 @@
+/**
+ * Reviewer Suggester Module
+ * 이슈 위치를 CODEOWNERS와 git blame에 대조해 가장 관련 있는 사람 리뷰어를 추천하거나 리뷰를 요청하는 모듈
+ *
+ * 규칙 형식 (reviewer_routes 입력값, 한 줄에 하나):
+ *   severity=critical type=security path=auth/** -> @org/security
+ *   -> codeowners, blame
+ *
+ * - 조건은 notify_routes와 같습니다 (severity, type, path)
+ * - 대상: @사용자 또는 @조직/팀, codeowners는 이슈 파일의 CODEOWNERS 담당자, blame은 이슈 줄을 마지막으로 수정한 사람
+ * - 일치하는 모든 규칙의 대상이 후보가 되며, 이슈 심각도를 합한 점수가 높은 순으로 추천
+ * - 규칙에 직접 적은 @사용자/@팀은 항상 추천하고, codeowners와 blame 후보는 최대 수(max_reviewers)까지만 추천
+ * - PR 작성자와 봇은 제외하고, 규칙을 지정하지 않으면 DEFAULT_ROUTES 사용
+ */
+
+const { ConfigError } = require('./errors');
+const { parseConditions, matchesConditions } = require('./notification-router');
+const { getSeverityLevel } = require('./review-summary');
+
+const DEFAULT_ROUTES = '-> codeowners, blame';
+
+// 대상 형식 (@사용자 또는 @조직/팀)
+const MENTION_PATTERN = /^@[\w.-]+(\/[\w.-]+)?$/;
+const DYNAMIC_TARGETS = ['codeowners', 'blame'];
+
+// blame을 조회할 최대 파일 수 (심각도가 높은 이슈의 파일부터)
+const MAX_BLAME_FILES = 10;
+
+/**
+ * 리뷰어 추천 규칙 파싱
+ * @param {string} text - 여러 줄 규칙 (빈 줄과 #으로 시작하는 줄은 무시, 비어 있으면 기본 규칙)
+ * @returns {Array} 규칙 배열 { severity, types, path, targets }
+ */
+function parseReviewerRoutes(text) {
+  return (String(text || '').trim() || DEFAULT_ROUTES)
+    .split('\n')
+    .map(line => line.trim())
+    .filter(line => line && !line.startsWith('#'))
+    .map(line => {
+      const arrow = line.indexOf('->');
+      if (arrow === -1) {
+        throw new ConfigError(`Invalid reviewer route (missing "->"): ${line}`);
+      }
+      const targets = line.substring(arrow + 2).split(',').map(target => target.trim()).filter(Boolean);
+      if (targets.length === 0) {
+        throw new ConfigError(`Reviewer route has no target: ${line}`);
+      }
+      targets.forEach(target => {
+        if (!DYNAMIC_TARGETS.includes(target) && !MENTION_PATTERN.test(target)) {
+          throw new ConfigError(`Invalid reviewer route target "${target}" (expected @user, @org/team, codeowners or blame): ${line}`);
+        }
+      });
+      return { ...parseConditions(line.substring(0, arrow), line, 'reviewer route'), targets };
+    });
+}
+
+class ReviewerSuggester {
+  /**
+   * ReviewerSuggester 생성자
+   * @param {Object} options - 옵션
+   * @param {Object} options.octokit - GitHub API 클라이언트
+   * @param {Object} options.context - GitHub Actions 컨텍스트 (pull_request 이벤트)
+   * @param {Array} options.routes - parseReviewerRoutes() 결과
+   * @param {Codeowners} options.codeowners - CODEOWNERS 담당자 매핑
+   * @param {number} [options.maxReviewers] - codeowners, blame 후보 중 추천할 최대 리뷰어 수 (사람과 팀 합계)
+   */
+  constructor({ octokit, context, routes, codeowners, maxReviewers = 2 }) {
+    this.octokit = octokit;
+    this.context = context;
+    this.routes = routes;
+    this.codeowners = codeowners;
+    this.maxReviewers = maxReviewers;
+  }
+
+  /**
+   * PR base 커밋 기준으로 파일의 줄별 마지막 수정자 조회 (GraphQL blame, 실패하면 빈 목록)
+   * @param {string} file - 파일 경로
+   * @returns {Promise<Array>} [{ start, end, login }]
+   */
+  async blame(file) {
+    try {
+      const data = await this.octokit.graphql(`
+        query($owner: String!, $repo: String!, $ref: String!, $path: String!) {
+          repository(owner: $owner, name: $repo) {
+            object(expression: $ref) {
+              ... on Commit {
+                blame(path: $path) {
+                  ranges { startingLine endingLine commit { author { user { login } } } }
+                }
+              }
+            }
+          }
+        }`, {
+        owner: this.context.repo.owner,
+        repo: this.context.repo.repo,
+        ref: this.context.payload.pull_request.base.sha,
+        path: file
+      });
+      const object = data.repository && data.repository.object;
+      return ((object && object.blame && object.blame.ranges) || [])
+        .filter(range => range.commit.author && range.commit.author.user)
+        .map(range => ({ start: range.startingLine, end: range.endingLine, login: range.commit.author.user.login }));
+    } catch (error) {
+      // 새 파일이거나 blame 권한이 없으면 blame 후보 없이 진행
+      return [];
+    }
+  }
+
+  /**
+   * 이슈별 후보 리뷰어 점수 계산
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @returns {Promise<Array>} [{ reviewer, score, reasons, explicit }] (점수 내림차순)
+   */
+  async rank(reviewResults) {
+    const author = this.context.payload.pull_request.user.login.toLowerCase();
+    const candidates = new Map();
+    const add = (reviewer, score, reason, explicit = false) => {
+      if (reviewer.substring(1).toLowerCase() === author || /\[bot\]$/i.test(reviewer)) {
+        return;
+      }
+      const key = reviewer.toLowerCase();
+      if (!candidates.has(key)) {
+        candidates.set(key, { reviewer, score: 0, reasons: new Set(), explicit: false });
+      }
+      candidates.get(key).explicit = candidates.get(key).explicit || explicit;
+      candidates.get(key).score += score;
+      candidates.get(key).reasons.add(reason);
+    };
+
+    // blame은 API 호출이 필요하므로 심각도가 높은 이슈의 파일부터 일부만 조회
+    const blameFiles = reviewResults
+      .filter(result => result.issues.some(issue => issue.line && this.routes.some(route =>
+        route.targets.includes('blame') && matchesConditions(route, result.file, issue))))
+      .sort((a, b) => Math.max(...b.issues.map(issue => getSeverityLevel(issue.severity))) -
+        Math.max(...a.issues.map(issue => getSeverityLevel(issue.severity))))
+      .slice(0, MAX_BLAME_FILES)
+      .map(result => result.file);
+    const blames = new Map();
+    for (const file of blameFiles) {
+      blames.set(file, await this.blame(file));
+    }
+
+    reviewResults.forEach(result => {
+      result.issues.forEach(issue => {
+        const score = getSeverityLevel(issue.severity);
+        this.routes.filter(route => matchesConditions(route, result.file, issue)).forEach(route => {
+          route.targets.forEach(target => {
+            if (target === 'codeowners') {
+              this.codeowners.ownersOf(result.file).forEach(owner => add(owner, score, `CODEOWNERS (${result.file})`));
+            } else if (target === 'blame') {
+              const range = (blames.get(result.file) || []).find(item => issue.line >= item.start && issue.line <= item.end);
+              if (range) {
+                add(`@${range.login}`, score, `최근 수정 (${result.file}:${issue.line})`);
+              }
+            } else {
+              add(target, score, `규칙 (${issue.severity} ${issue.type})`, true);
+            }
+          });
+        });
+      });
+    });
+
+    return [...candidates.values()]
+      .sort((a, b) => b.score - a.score)
+      .map(candidate => ({ ...candidate, reasons: [...candidate.reasons] }));
+  }
+
+  /**
+   * 추천 리뷰어 목록
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @returns {Promise<Array>} [{ reviewer, score, reasons, explicit }] (규칙의 대상과 나머지 후보 최대 maxReviewers명)
+   */
+  async suggest(reviewResults) {
+    const ranked = await this.rank(reviewResults);
+    return [
+      ...ranked.filter(candidate => candidate.explicit),
+      ...ranked.filter(candidate => !candidate.explicit).slice(0, this.maxReviewers)
+    ];
+  }
+
+  /**
+   * 추천 리뷰어에게 리뷰 요청 (이미 요청했거나 리뷰한 사람, 팀은 제외)
+   * @param {Array} suggestions - suggest() 결과
+   * @returns {Promise<Array<string>>} 새로 요청한 리뷰어
+   */
+  async request(suggestions) {
+    const pullRequest = this.context.payload.pull_request;
+    const request = { owner: this.context.repo.owner, repo: this.context.repo.repo, pull_number: pullRequest.number };
+    const { data: requested } = await this.octokit.rest.pulls.listRequestedReviewers(request);
+    const reviews = await this.octokit.paginate(this.octokit.rest.pulls.listReviews, { ...request, per_page: 100 });
+    const existing = new Set([
+      ...requested.users.map(user => `@${user.login}`.toLowerCase()),
+      ...requested.teams.map(team => `@${this.context.repo.owner}/${team.slug}`.toLowerCase()),
+      ...reviews.filter(review => review.user).map(review => `@${review.user.login}`.toLowerCase())
+    ]);
+
+    // 팀 리뷰 요청은 저장소와 같은 조직의 팀만 가능 (slug만 전달)
+    const organization = `@${this.context.repo.owner}/`.toLowerCase();
+    const added = suggestions.map(item => item.reviewer).filter(reviewer => !existing.has(reviewer.toLowerCase()) &&
+      (!reviewer.includes('/') || reviewer.toLowerCase().startsWith(organization)));
+    const reviewers = added.filter(reviewer => !reviewer.includes('/')).map(reviewer => reviewer.substring(1));
+    const teamReviewers = added.filter(reviewer => reviewer.includes('/')).map(reviewer => reviewer.split('/')[1]);
+    if (added.length > 0) {
+      await this.octokit.rest.pulls.requestReviewers({ ...request, reviewers, team_reviewers: teamReviewers });
+    }
+    return added;
+  }
+}
+
+module.exports = ReviewerSuggester;
+module.exports.parseReviewerRoutes = parseReviewerRoutes;
+module.exports.DEFAULT_ROUTES = DEFAULT_ROUTES;
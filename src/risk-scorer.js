@@ -0,0 +1,141 @@
+/**
+ * Risk Scorer Module
+ * PR의 위험도를 0-100 점수와 low/medium/high 등급으로 계산하는 모듈
+ *
+ * 리뷰어가 대기열에서 어떤 PR을 먼저 볼지 정할 수 있도록 다음 요소를 합산합니다.
+ * - 변경 규모 (최대 25점): 변경 라인 수 (로그 스케일)
+ * - 민감한 경로 (최대 25점): risk_paths 패턴에 맞는 파일 (인증, 마이그레이션, CI 설정 등)
+ * - 발견된 이슈 (최대 40점): 심각도별 가중치 합
+ * - 테스트 변경 (최대 10점): 코드 변경 대비 테스트 변경 비율 (커버리지 데이터 대신 근사치로 사용)
+ */
+
+const { minimatch } = require('minimatch');
+
+// 기본 민감 경로 패턴
+const DEFAULT_RISK_PATHS = [
+  '**/auth/**',
+  '**/security/**',
+  '**/migrations/**',
+  '**/*.sql',
+  '.github/workflows/**',
+  '**/Dockerfile',
+  '**/*.tf',
+  '**/package.json',
+  '**/go.mod',
+  '**/requirements.txt'
+];
+
+// 심각도별 점수
+const SEVERITY_POINTS = { critical: 20, high: 10, medium: 4, low: 1 };
+
+// 등급 기준 (점수 이상)
+const LEVEL_THRESHOLDS = { high: 60, medium: 30 };
+
+// 테스트 파일 판별 패턴
+const TEST_FILE_PATTERN = /(^|\/)(tests?|__tests__|spec)\/|[._-](test|spec)\.[^./]+$|_test\.go$/;
+
+class RiskScorer {
+  /**
+   * RiskScorer 생성자
+   * @param {Object} [options] - 옵션
+   * @param {Array<string>} [options.riskPaths] - 민감 경로 glob 패턴
+   */
+  constructor(options = {}) {
+    this.riskPaths = options.riskPaths && options.riskPaths.length > 0 ? options.riskPaths : DEFAULT_RISK_PATHS;
+  }
+
+  /**
+   * 위험도 계산
+   * @param {Array} changedFiles - 변경된 파일 목록 (filename, additions, deletions)
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @returns {Object} { score, level, factors: [{ name, points, detail }] }
+   */
+  score(changedFiles, reviewResults) {
+    const factors = [
+      this.scoreSize(changedFiles),
+      this.scorePaths(changedFiles),
+      this.scoreFindings(reviewResults),
+      this.scoreTests(changedFiles)
+    ];
+    const score = Math.min(100, factors.reduce((sum, factor) => sum + factor.points, 0));
+    return { score, level: RiskScorer.levelFor(score), factors };
+  }
+
+  /**
+   * 변경 규모 점수 (10줄 약 6점, 100줄 약 15점, 1000줄 이상 25점)
+   * @param {Array} changedFiles - 변경된 파일 목록
+   * @returns {Object} 요소 점수
+   */
+  scoreSize(changedFiles) {
+    const lines = changedFiles.reduce((sum, file) => sum + (file.additions || 0) + (file.deletions || 0), 0);
+    const points = Math.min(25, Math.round(25 * Math.log10(1 + lines) / 3));
+    return { name: 'size', points, detail: `${lines} lines in ${changedFiles.length} files` };
+  }
+
+  /**
+   * 민감 경로 점수 (파일당 10점)
+   * @param {Array} changedFiles - 변경된 파일 목록
+   * @returns {Object} 요소 점수
+   */
+  scorePaths(changedFiles) {
+    const sensitive = changedFiles
+      .map(file => file.filename)
+      .filter(filename => this.riskPaths.some(pattern => minimatch(filename, pattern, { dot: true })));
+    const points = Math.min(25, sensitive.length * 10);
+    return { name: 'paths', points, detail: sensitive.length > 0 ? sensitive.slice(0, 5).join(', ') : 'no sensitive paths' };
+  }
+
+  /**
+   * 발견된 이슈 점수
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @returns {Object} 요소 점수
+   */
+  scoreFindings(reviewResults) {
+    const counts = {};
+    reviewResults.forEach(result => {
+      result.issues.forEach(issue => {
+        counts[issue.severity] = (counts[issue.severity] || 0) + 1;
+      });
+    });
+    const total = Object.entries(counts).reduce((sum, [severity, count]) => sum + (SEVERITY_POINTS[severity] || 0) * count, 0);
+    const detail = Object.keys(SEVERITY_POINTS).filter(severity => counts[severity]).map(severity => `${counts[severity]} ${severity}`).join(', ');
+    return { name: 'findings', points: Math.min(40, total), detail: detail || 'no findings' };
+  }
+
+  /**
+   * 테스트 변경 점수 (코드만 바뀌고 테스트가 거의 바뀌지 않으면 가산)
+   * @param {Array} changedFiles - 변경된 파일 목록
+   * @returns {Object} 요소 점수
+   */
+  scoreTests(changedFiles) {
+    const linesOf = file => (file.additions || 0) + (file.deletions || 0);
+    const testFiles = changedFiles.filter(file => TEST_FILE_PATTERN.test(file.filename));
+    const sourceLines = changedFiles.filter(file => !testFiles.includes(file)).reduce((sum, file) => sum + linesOf(file), 0);
+    const testLines = testFiles.reduce((sum, file) => sum + linesOf(file), 0);
+
+    let points = 0;
+    if (sourceLines > 0 && testFiles.length === 0) {
+      points = 10;
+    } else if (sourceLines > 0 && testLines / sourceLines < 0.2) {
+      points = 5;
+    }
+    return { name: 'tests', points, detail: `${testFiles.length} test files changed (${testLines}/${sourceLines} test/code lines)` };
+  }
+
+  /**
+   * 점수를 등급으로 변환
+   * @param {number} score - 위험도 점수
+   * @returns {string} high, medium, low
+   */
+  static levelFor(score) {
+    if (score >= LEVEL_THRESHOLDS.high) {
+      return 'high';
+    }
+    return score >= LEVEL_THRESHOLDS.medium ? 'medium' : 'low';
+  }
+}
+
+module.exports = RiskScorer;
+module.exports.DEFAULT_RISK_PATHS = DEFAULT_RISK_PATHS;
+module.exports.RISK_LEVELS = ['low', 'medium', 'high'];
+module.exports.TEST_FILE_PATTERN = TEST_FILE_PATTERN;
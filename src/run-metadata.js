@@ -0,0 +1,114 @@
+/**
+ * Run Metadata Module
+ * 리뷰 결과를 재현하고 시간에 따라 비교할 수 있도록 실행 메타데이터를 생성하는 모듈
+ *
+ * 포함 항목:
+ * - 액션 버전
+ * - 사용한 모델
+ * - 프롬프트 템플릿 해시
+ * - 설정 해시 (비밀값 제외)
+ * - 커밋 SHA (base/head)
+ */
+
+const crypto = require('crypto');
+const { version } = require('../package.json');
+const { summarizeUsage } = require('./job-summary');
+
+// 해시 계산에서 제외할 비밀값 키
+const SECRET_KEY_PATTERN = /(key|token|secret|password)/i;
+
+/**
+ * 설정값 해시 계산 (키 순서와 무관하게 안정적인 값)
+ * @param {Object} inputs - 액션 입력값
+ * @returns {string} 12자리 sha256 해시
+ */
+function hashConfig(inputs) {
+  const stable = Object.keys(inputs)
+    .filter(key => !SECRET_KEY_PATTERN.test(key))
+    .sort()
+    .map(key => [key, inputs[key]]);
+
+  return crypto.createHash('sha256')
+    .update(JSON.stringify(stable))
+    .digest('hex')
+    .substring(0, 12);
+}
+
+/**
+ * 이벤트에서 비교 대상 커밋 SHA 추출
+ * @param {Object} context - GitHub Actions 컨텍스트
+ * @param {Object} [inputs] - 액션 입력값 (base_ref를 지정했으면 그 커밋 범위)
+ * @returns {Object} { base, head }
+ */
+function getCommitShas(context, inputs = {}) {
+  const payload = context.payload || {};
+
+  // base_ref/head_ref로 지정한 커밋 범위 리뷰
+  if (inputs.baseRef) {
+    return { base: inputs.baseRef, head: inputs.headRef || 'HEAD' };
+  }
+
+  if (payload.pull_request) {
+    return {
+      base: payload.pull_request.base.sha,
+      head: payload.pull_request.head.sha
+    };
+  }
+
+  if (context.eventName === 'push') {
+    return {
+      base: payload.before || null,
+      head: payload.after || context.sha
+    };
+  }
+
+  return { base: null, head: context.sha };
+}
+
+/**
+ * 실행 메타데이터 생성
+ * @param {Object} params - 파라미터
+ * @param {Object} params.context - GitHub Actions 컨텍스트
+ * @param {Object} params.inputs - 액션 입력값
+ * @param {Object} params.codeReviewer - CodeReviewer 인스턴스
+ * @returns {Object} 실행 메타데이터
+ */
+function buildRunMetadata({ context, inputs, codeReviewer }) {
+  const models = codeReviewer.modelsUsed.size > 0
+    ? Array.from(codeReviewer.modelsUsed)
+    : [codeReviewer.model];
+
+  return {
+    actionVersion: version,
+    models,
+    // 작성자별 매핑으로 결정된 실제 리뷰 언어
+    language: codeReviewer.language,
+    promptHash: codeReviewer.getPromptTemplateHash(inputs.reviewType),
+    configHash: hashConfig(inputs),
+    commits: getCommitShas(context, inputs),
+    // 지금까지의 토큰 수와 예상 비용 (USD, 가격을 모르는 모델만 사용했으면 null)
+    usage: summarizeUsage(codeReviewer.usageByModel, codeReviewer.batch)
+  };
+}
+
+/**
+ * 댓글 푸터용 한 줄 요약
+ * @param {Object} metadata - 실행 메타데이터
+ * @returns {string} 예: "action v1.0.2 · model claude-... · prompt 1a2b3c · config 4d5e6f · abc1234..def5678"
+ */
+function formatRunMetadata(metadata) {
+  // 커밋 범위 리뷰의 브랜치/태그 이름은 그대로 표시
+  const short = sha => (sha ? (/^[0-9a-f]{40}$/.test(sha) ? sha.substring(0, 7) : sha) : '-');
+  const parts = [
+    `action v${metadata.actionVersion}`,
+    `model ${metadata.models.join(', ')}`,
+    `prompt ${metadata.promptHash}`,
+    `config ${metadata.configHash}`,
+    metadata.commits.base
+      ? `${short(metadata.commits.base)}..${short(metadata.commits.head)}`
+      : short(metadata.commits.head)
+  ];
+  return parts.join(' · ');
+}
+
+module.exports = { buildRunMetadata, formatRunMetadata, hashConfig };
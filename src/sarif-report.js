@@ -0,0 +1,164 @@
+/**
+ * SARIF Report Module
+ * 리뷰 결과를 SARIF 2.1.0 형식으로 변환하고 GitHub Code Scanning에 업로드하는 모듈
+ *
+ * - 규칙 ID는 이슈 타입별 (claude-review/security 등)
+ * - 심각도 매핑: critical/high → error, medium → warning, low → note
+ * - security 타입은 security-severity 속성을 붙여 Security 탭에서 심각도로 분류되게 함
+ * - 실행 간 같은 이슈는 지문(partialFingerprints)으로 Code Scanning 알림과 연결
+ */
+
+const fs = require('fs');
+const os = require('os');
+const path = require('path');
+const zlib = require('zlib');
+const { version } = require('../package.json');
+
+const SARIF_SCHEMA = 'https://json.schemastore.org/sarif-2.1.0.json';
+// Code Scanning에 표시되는 도구 이름 (업로드 시 category로도 사용)
+const TOOL_NAME = 'Claude Code Review';
+const RULE_PREFIX = 'claude-review';
+
+// 이슈 심각도 → SARIF level
+const LEVELS = {
+  critical: 'error',
+  high: 'error',
+  medium: 'warning',
+  low: 'note'
+};
+
+// 이슈 심각도 → Code Scanning security-severity (CVSS 구간)
+const SECURITY_SEVERITIES = {
+  critical: '9.5',
+  high: '8.0',
+  medium: '5.5',
+  low: '2.0'
+};
+
+// 이슈 타입별 규칙 설명
+const RULE_DESCRIPTIONS = {
+  bug: 'Potential bug',
+  security: 'Security vulnerability',
+  performance: 'Performance issue',
+  style: 'Style or convention issue',
+  maintainability: 'Maintainability issue',
+  testing: 'Missing test coverage',
+  documentation: 'Stale or missing documentation',
+  i18n: 'Untranslated user-facing string',
+  accessibility: 'Accessibility barrier',
+  compliance: 'License or compliance issue',
+  general: 'Code review finding'
+};
+
+/**
+ * SARIF 리포트 생성
+ * @param {Object} summary - buildReviewSummary() 결과
+ * @param {ReferenceLinks} [references] - 이슈 타입별 참고 링크 (규칙 helpUri)
+ * @returns {Object} SARIF 2.1.0 로그
+ */
+function buildSarifReport(summary, references = null) {
+  const types = [...new Set(summary.results.flatMap(result => result.issues.map(issue => ruleType(issue.type))))].sort();
+  const rules = types.map(type => {
+    const helpUri = references ? references.resolve(type) : null;
+    return {
+      id: `${RULE_PREFIX}/${type}`,
+      name: type,
+      shortDescription: { text: RULE_DESCRIPTIONS[type] },
+      ...(helpUri ? { helpUri } : {}),
+      properties: { tags: type === 'security' ? ['security'] : [type] }
+    };
+  });
+
+  const results = summary.results.flatMap(result => result.issues.map(issue => {
+    const type = ruleType(issue.type);
+    const message = [issue.title, issue.description, issue.suggestion ? `Suggestion: ${issue.suggestion}` : '']
+      .filter(Boolean)
+      .join('\n\n');
+    return {
+      ruleId: `${RULE_PREFIX}/${type}`,
+      ruleIndex: types.indexOf(type),
+      level: LEVELS[issue.severity] || 'warning',
+      message: { text: message },
+      locations: [{
+        physicalLocation: {
+          artifactLocation: { uri: result.file, uriBaseId: '%SRCROOT%' },
+          // 줄 번호가 없는 이슈는 파일 첫 줄에 표시
+          region: { startLine: issue.line || 1 }
+        }
+      }],
+      ...(issue.fingerprint ? { partialFingerprints: { 'claudeReview/v1': issue.fingerprint } } : {}),
+      properties: {
+        severity: issue.severity,
+        ...(type === 'security' ? { 'security-severity': SECURITY_SEVERITIES[issue.severity] || SECURITY_SEVERITIES.medium } : {}),
+        ...(issue.persona ? { persona: issue.persona } : {})
+      }
+    };
+  }));
+
+  return {
+    $schema: SARIF_SCHEMA,
+    version: '2.1.0',
+    runs: [{
+      tool: {
+        driver: {
+          name: TOOL_NAME,
+          version,
+          informationUri: 'https://github.com/chimaek/claude-code-review-action',
+          rules
+        }
+      },
+      results,
+      properties: {
+        repository: summary.repository,
+        url: summary.url,
+        verdict: summary.verdict,
+        ...(summary.runMetadata ? { models: summary.runMetadata.models } : {})
+      }
+    }]
+  };
+}
+
+/**
+ * SARIF 규칙 타입 (알 수 없는 타입은 general)
+ * @param {string} type - 이슈 타입
+ * @returns {string} 규칙 타입
+ */
+function ruleType(type) {
+  return RULE_DESCRIPTIONS[type] ? type : 'general';
+}
+
+/**
+ * SARIF 리포트를 파일로 작성
+ * @param {Object} summary - buildReviewSummary() 결과
+ * @param {string|number} runId - 워크플로우 실행 ID
+ * @param {ReferenceLinks} [references] - 이슈 타입별 참고 링크
+ * @returns {string} 작성한 파일 경로
+ */
+function writeSarifReport(summary, runId, references = null) {
+  const dir = process.env.RUNNER_TEMP || os.tmpdir();
+  const filePath = path.join(dir, `claude-review-${runId || Date.now()}.sarif`);
+  fs.writeFileSync(filePath, JSON.stringify(buildSarifReport(summary, references), null, 2));
+  return filePath;
+}
+
+/**
+ * SARIF 파일을 GitHub Code Scanning에 업로드 (security-events: write 권한 필요)
+ * @param {Object} octokit - GitHub API 클라이언트
+ * @param {Object} context - GitHub Actions 컨텍스트
+ * @param {string} filePath - SARIF 파일 경로
+ * @returns {Promise<string>} 업로드 ID
+ */
+async function uploadSarif(octokit, context, filePath) {
+  const { data } = await octokit.rest.codeScanning.uploadSarif({
+    owner: context.repo.owner,
+    repo: context.repo.repo,
+    // pull_request 이벤트에서는 병합 커밋과 refs/pull/<번호>/merge (CodeQL과 같은 기준)
+    commit_sha: context.sha,
+    ref: context.ref,
+    sarif: zlib.gzipSync(fs.readFileSync(filePath)).toString('base64'),
+    tool_name: TOOL_NAME
+  });
+  return data.id;
+}
+
+module.exports = { buildSarifReport, writeSarifReport, uploadSarif };
@@ -0,0 +1,215 @@
+/**
+ * Slack Notifier Module
+ * 리뷰 요약을 Slack Incoming Webhook 또는 봇 토큰(chat.postMessage)으로 전송하는 모듈
+ *
+ * 주요 기능:
+ * - 심각도 임계값 기반 전송 여부 판단
+ * - Block Kit 형식 메시지 생성 (PR 링크, 판정, 심각도별 개수, 상위 3개 이슈)
+ * - 인터랙티브 버튼 (무시 / 이슈 생성 / 일시 중지) - 서버 모드에서 처리
+ */
+
+const core = require('@actions/core');
+const { postJson } = require('./webhook-client');
+const DisplayLabels = require('./display-labels');
+const { hasFindingsAtOrAbove } = require('./review-summary');
+
+// 판정별 표시 문구
+const VERDICT_LABELS = {
+  changes_requested: 'Changes requested',
+  needs_attention: 'Needs attention',
+  approved: 'Looks good'
+};
+
+// 인터랙티브 버튼 action_id (interaction-server.js에서 처리)
+const ACTIONS = {
+  dismiss: 'claude_review_dismiss',
+  createIssue: 'claude_review_create_issue',
+  snooze: 'claude_review_snooze'
+};
+
+// 봇 토큰 전송용 Web API 엔드포인트
+const POST_MESSAGE_URL = 'https://slack.com/api/chat.postMessage';
+
+class SlackNotifier {
+  /**
+   * SlackNotifier 생성자
+   * @param {Object} config - 설정
+   * @param {string} [config.webhookUrl] - Slack Incoming Webhook URL
+   * @param {string} [config.botToken] - Slack 봇 토큰 (xoxb-, 설정 시 웹훅 대신 chat.postMessage 사용)
+   * @param {string} [config.channel] - 전송 채널 (봇 토큰은 필수, 웹훅은 레거시 웹훅에서만 동작)
+   * @param {string} [config.minSeverity] - 이 심각도 이상 이슈가 있을 때만 전송
+   * @param {boolean} [config.interactive] - 이슈별 버튼 표시 (Slack 앱 Interactivity 필요)
+   * @param {DisplayLabels} [config.labels] - 심각도 아이콘/표시 이름
+   */
+  constructor({ webhookUrl, botToken, channel, minSeverity = 'high', interactive = false, labels = new DisplayLabels() }) {
+    this.webhookUrl = webhookUrl;
+    this.botToken = botToken;
+    this.channel = channel;
+    this.minSeverity = minSeverity;
+    this.interactive = interactive;
+    this.labels = labels;
+  }
+
+  /**
+   * 임계값 이상 이슈 존재 여부 확인
+   * @param {Object} summary - buildReviewSummary() 결과
+   * @returns {boolean} 전송 여부
+   */
+  shouldNotify(summary) {
+    return hasFindingsAtOrAbove(summary, this.minSeverity);
+  }
+
+  /**
+   * Block Kit 메시지 생성
+   * @param {Object} summary - 리뷰 요약
+   * @returns {Object} Slack 메시지 페이로드
+   */
+  buildMessage(summary) {
+    const { counts } = summary;
+    const blocks = [
+      {
+        type: 'header',
+        text: { type: 'plain_text', text: '🤖 Claude AI Code Review', emoji: true }
+      },
+      {
+        type: 'section',
+        text: {
+          type: 'mrkdwn',
+          text: `*<${summary.url}|${this.escape(summary.title)}>*\n${this.labels.withIcon(this.labels.verdictIcon(summary.verdict), VERDICT_LABELS[summary.verdict])}`
+        }
+      },
+      {
+        type: 'section',
+        fields: ['critical', 'high', 'medium', 'low'].map(severity => ({
+          type: 'mrkdwn',
+          text: `${this.labels.withIcon(this.labels.severityIcon(severity), `*${this.labels.severityLabel(severity)}:*`)} ${counts[severity]}`
+        }))
+      }
+    ];
+
+    if (summary.topFindings.length > 0) {
+      const lines = summary.topFindings.map(finding => {
+        const location = finding.line ? `${finding.file}:${finding.line}` : finding.file;
+        return `${this.labels.withIcon(this.labels.severityIcon(finding.severity), `*${this.escape(finding.title)}*`)} — \`${this.escape(location)}\``;
+      });
+      blocks.push({ type: 'divider' });
+
+      if (this.interactive) {
+        // 이슈마다 별도 섹션과 버튼 배치
+        blocks.push({ type: 'section', text: { type: 'mrkdwn', text: '*Top findings*' } });
+        summary.topFindings.forEach((finding, index) => {
+          blocks.push({ type: 'section', text: { type: 'mrkdwn', text: lines[index] } });
+          if (finding.fingerprint) {
+            blocks.push(this.buildActions(finding, summary));
+          }
+        });
+      } else {
+        blocks.push({
+          type: 'section',
+          text: { type: 'mrkdwn', text: `*Top findings*\n${lines.join('\n')}` }
+        });
+      }
+    }
+
+    blocks.push({
+      type: 'context',
+      elements: [{
+        type: 'mrkdwn',
+        text: `${summary.repository} · ${summary.totalIssues} issues in ${summary.filesWithIssues} files`
+      }]
+    });
+
+    const message = {
+      // 알림 미리보기용 대체 텍스트
+      text: `Claude AI review: ${summary.title} — ${summary.totalIssues} issues`,
+      blocks
+    };
+    if (this.channel) {
+      message.channel = this.channel;
+    }
+    return message;
+  }
+
+  /**
+   * 이슈별 인터랙티브 버튼 블록 생성
+   * 버튼 value에는 서버 모드가 처리에 필요한 최소 정보만 담음 (Slack 제한 2000자)
+   * @param {Object} finding - { file, ...issue }
+   * @param {Object} summary - 리뷰 요약
+   * @returns {Object} actions 블록
+   */
+  buildActions(finding, summary) {
+    const value = JSON.stringify({
+      r: summary.repository,
+      n: summary.number,
+      f: finding.fingerprint,
+      p: finding.file,
+      l: finding.line,
+      s: finding.severity,
+      c: finding.type,
+      t: String(finding.title || '').substring(0, 200),
+      u: summary.url
+    });
+
+    return {
+      type: 'actions',
+      block_id: `claude_review_${finding.fingerprint}`,
+      elements: [
+        { type: 'button', action_id: ACTIONS.dismiss, text: { type: 'plain_text', text: 'Dismiss' }, value },
+        { type: 'button', action_id: ACTIONS.createIssue, text: { type: 'plain_text', text: 'Create issue' }, style: 'primary', value },
+        { type: 'button', action_id: ACTIONS.snooze, text: { type: 'plain_text', text: 'Snooze' }, value }
+      ]
+    };
+  }
+
+  /**
+   * Slack mrkdwn 특수문자 이스케이프
+   * @param {string} text - 원본 문자열
+   * @returns {string} 이스케이프된 문자열
+   */
+  escape(text) {
+    return String(text || '')
+      .replace(/&/g, '&amp;')
+      .replace(/</g, '&lt;')
+      .replace(/>/g, '&gt;');
+  }
+
+  /**
+   * 봇 토큰으로 chat.postMessage 호출
+   * Web API는 실패해도 HTTP 200과 { ok: false, error }를 반환하므로 응답 본문을 확인
+   * @param {Object} message - buildMessage() 결과 (channel 포함)
+   */
+  async postMessage(message) {
+    const response = await postJson(POST_MESSAGE_URL, message, {
+      headers: { Authorization: `Bearer ${this.botToken}` }
+    });
+    const result = JSON.parse(response.body);
+    if (!result.ok) {
+      throw new Error(`chat.postMessage failed: ${result.error}`);
+    }
+  }
+
+  /**
+   * 조건 충족 시 Slack으로 전송
+   * @param {Object} summary - 리뷰 요약
+   */
+  async notify(summary) {
+    if (!this.shouldNotify(summary)) {
+      core.info(`Slack notification skipped: no findings at or above ${this.minSeverity}`);
+      return;
+    }
+
+    try {
+      if (this.botToken) {
+        await this.postMessage(this.buildMessage(summary));
+      } else {
+        await postJson(this.webhookUrl, this.buildMessage(summary));
+      }
+      core.info('Slack notification sent');
+    } catch (error) {
+      core.warning(`Failed to send Slack notification: ${error.message}`);
+    }
+  }
+}
+
+module.exports = SlackNotifier;
+module.exports.ACTIONS = ACTIONS;
@@ -0,0 +1,235 @@
+/**
+ * SMTP Client Module
+ * 외부 의존성 없이 메일을 전송하기 위한 최소 SMTP 클라이언트
+ *
+ * 지원 범위:
+ * - 암시적 TLS (465 포트) 및 STARTTLS 업그레이드
+ * - AUTH PLAIN 인증 (TLS 연결에서만. 평문 인증은 allowInsecureAuth로 명시적으로 허용해야 함)
+ * - text/plain + text/html multipart 메시지 또는 text/plain 단일 메시지 (UTF-8, base64)
+ */
+
+const net = require('net');
+const tls = require('tls');
+const os = require('os');
+const crypto = require('crypto');
+
+// 서버 응답 대기 최대 시간
+const TIMEOUT_MS = 30000;
+
+class SmtpClient {
+  /**
+   * SmtpClient 생성자
+   * @param {Object} config - 서버 설정
+   * @param {string} config.host - SMTP 호스트
+   * @param {number} config.port - SMTP 포트 (465면 암시적 TLS)
+   * @param {string} [config.username] - 인증 사용자
+   * @param {string} [config.password] - 인증 비밀번호
+   * @param {boolean} [config.allowInsecureAuth] - STARTTLS를 지원하지 않는 서버에 평문으로 인증 정보 전송 허용
+   */
+  constructor({ host, port = 587, username, password, allowInsecureAuth = false }) {
+    this.host = host;
+    this.port = port;
+    this.username = username;
+    this.password = password;
+    this.allowInsecureAuth = allowInsecureAuth;
+    this.socket = null;
+    this.buffer = '';
+    this.waiting = null;
+  }
+
+  /**
+   * 메일 전송
+   * @param {Object} message - 메일 내용
+   * @param {string} message.from - 발신자 주소
+   * @param {Array<string>} message.to - 수신자 주소 목록
+   * @param {string} message.subject - 제목
+   * @param {string} message.text - 텍스트 본문
+   * @param {string} [message.html] - HTML 본문 (없으면 text/plain 단일 파트)
+   */
+  async send({ from, to, subject, text, html }) {
+    await this.connect(this.port === 465);
+    try {
+      await this.expect(220);
+      let capabilities = await this.ehlo();
+
+      // 평문 연결이면 STARTTLS로 업그레이드
+      if (!(this.socket instanceof tls.TLSSocket) && capabilities.includes('STARTTLS')) {
+        await this.command('STARTTLS', 220);
+        await this.upgrade();
+        capabilities = await this.ehlo();
+      }
+
+      if (this.username) {
+        // AUTH PLAIN은 base64일 뿐이므로 TLS가 아니면 인증 정보가 그대로 노출됨
+        if (!(this.socket instanceof tls.TLSSocket) && !this.allowInsecureAuth) {
+          throw new Error(`SMTP server ${this.host}:${this.port} does not offer STARTTLS; refusing to send credentials in plaintext (use port 465 or set smtp_allow_insecure_auth: true)`);
+        }
+        const credentials = Buffer.from(`\0${this.username}\0${this.password || ''}`).toString('base64');
+        await this.command(`AUTH PLAIN ${credentials}`, 235);
+      }
+
+      await this.command(`MAIL FROM:<${from}>`, 250);
+      for (const recipient of to) {
+        await this.command(`RCPT TO:<${recipient}>`, [250, 251]);
+      }
+      await this.command('DATA', 354);
+      await this.command(`${this.buildMessage({ from, to, subject, text, html })}\r\n.`, 250);
+      await this.command('QUIT', 221).catch(() => {});
+    } finally {
+      this.socket.destroy();
+    }
+  }
+
+  /**
+   * MIME 메시지 생성 (마침표로 시작하는 줄은 dot-stuffing 처리)
+   * @param {Object} message - 메일 내용
+   * @returns {string} SMTP DATA 본문
+   */
+  buildMessage({ from, to, subject, text, html }) {
+    const boundary = `claude-review-${crypto.randomBytes(8).toString('hex')}`;
+    const encode = body => Buffer.from(body, 'utf8').toString('base64').replace(/.{76}/g, '$&\r\n');
+
+    const headers = [
+      `From: ${from}`,
+      `To: ${to.join(', ')}`,
+      `Subject: =?UTF-8?B?${Buffer.from(subject, 'utf8').toString('base64')}?=`,
+      `Date: ${new Date().toUTCString()}`,
+      `Message-ID: <${crypto.randomUUID()}@${os.hostname()}>`,
+      'MIME-Version: 1.0'
+    ];
+
+    // HTML이 없으면 텍스트 단일 파트 (HTML을 해석하지 못하는 메일→티켓 게이트웨이용)
+    const lines = !html ? [
+      ...headers,
+      'Content-Type: text/plain; charset=UTF-8',
+      'Content-Transfer-Encoding: base64',
+      '',
+      encode(text)
+    ] : [
+      ...headers,
+      `Content-Type: multipart/alternative; boundary="${boundary}"`,
+      '',
+      `--${boundary}`,
+      'Content-Type: text/plain; charset=UTF-8',
+      'Content-Transfer-Encoding: base64',
+      '',
+      encode(text),
+      `--${boundary}`,
+      'Content-Type: text/html; charset=UTF-8',
+      'Content-Transfer-Encoding: base64',
+      '',
+      encode(html),
+      `--${boundary}--`
+    ];
+
+    return lines.join('\r\n').replace(/\r\n\./g, '\r\n..');
+  }
+
+  /**
+   * 서버 연결
+   * @param {boolean} secure - 암시적 TLS 사용 여부
+   */
+  connect(secure) {
+    return new Promise((resolve, reject) => {
+      const options = { host: this.host, port: this.port, servername: this.host };
+      const socket = secure ? tls.connect(options, resolve) : net.connect(options, resolve);
+      socket.once('error', reject);
+      this.attach(socket);
+    });
+  }
+
+  /**
+   * 현재 연결을 TLS로 업그레이드 (STARTTLS)
+   */
+  upgrade() {
+    return new Promise((resolve, reject) => {
+      const plain = this.socket;
+      plain.removeAllListeners('data');
+      const secured = tls.connect({ socket: plain, servername: this.host }, resolve);
+      secured.once('error', reject);
+      this.attach(secured);
+    });
+  }
+
+  /**
+   * 소켓 이벤트 연결
+   * @param {Object} socket - net/tls 소켓
+   */
+  attach(socket) {
+    this.socket = socket;
+    this.buffer = '';
+    socket.setTimeout(TIMEOUT_MS, () => socket.destroy(new Error('SMTP timeout')));
+    socket.on('data', chunk => {
+      this.buffer += chunk.toString('utf8');
+      this.flush();
+    });
+    socket.on('error', error => {
+      if (this.waiting) {
+        this.waiting.reject(error);
+        this.waiting = null;
+      }
+    });
+  }
+
+  /**
+   * 완성된 응답이 있으면 대기 중인 요청에 전달
+   * 여러 줄 응답은 "250-..." 형식으로 이어지고 "250 ..."로 끝남
+   */
+  flush() {
+    if (!this.waiting) {
+      return;
+    }
+    const lines = this.buffer.split('\r\n');
+    for (let i = 0; i < lines.length - 1; i++) {
+      if (/^\d{3} /.test(lines[i]) || /^\d{3}$/.test(lines[i])) {
+        const response = lines.slice(0, i + 1);
+        this.buffer = lines.slice(i + 1).join('\r\n');
+        const waiting = this.waiting;
+        this.waiting = null;
+        waiting.resolve({ code: parseInt(lines[i].substring(0, 3)), lines: response });
+        return;
+      }
+    }
+  }
+
+  /**
+   * 다음 응답을 기다리고 응답 코드 확인
+   * @param {number|Array<number>} expected - 기대하는 응답 코드
+   * @returns {Promise<Object>} { code, lines }
+   */
+  expect(expected) {
+    const codes = Array.isArray(expected) ? expected : [expected];
+    return new Promise((resolve, reject) => {
+      this.waiting = { resolve, reject };
+      this.flush();
+    }).then(response => {
+      if (!codes.includes(response.code)) {
+        throw new Error(`SMTP error: ${response.lines.join(' ')}`);
+      }
+      return response;
+    });
+  }
+
+  /**
+   * 명령 전송 후 응답 확인
+   * @param {string} line - SMTP 명령
+   * @param {number|Array<number>} expected - 기대하는 응답 코드
+   * @returns {Promise<Object>} { code, lines }
+   */
+  command(line, expected) {
+    const response = this.expect(expected);
+    this.socket.write(`${line}\r\n`);
+    return response;
+  }
+
+  /**
+   * EHLO 전송 후 서버 기능 목록 반환
+   * @returns {Promise<Array<string>>} 대문자 기능 목록
+   */
+  async ehlo() {
+    const response = await this.command(`EHLO ${os.hostname()}`, 250);
+    return response.lines.map(line => line.substring(4).toUpperCase());
+  }
+}
+
+module.exports = SmtpClient;
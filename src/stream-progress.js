@@ -0,0 +1,65 @@
+/**
+ * Stream Progress Module
+ * 스트리밍으로 받는 리뷰 응답(JSON)을 조각 단위로 모으면서 완성된 이슈 수를 세는 모듈
+ *
+ * 긴 리뷰도 진행 상황을 하트비트 로그로 보여 주고, 연결이 끊겼을 때 이미 완성된 이슈가 있으면
+ * 받은 부분을 그대로 파싱(CodeReviewer.repairIncompleteJson)해 결과를 살릴 수 있는지 판단합니다.
+ * JSON 전체를 다시 파싱하지 않고 새로 받은 글자만 한 번씩 훑습니다.
+ */
+
+class StreamProgress {
+  constructor() {
+    this.text = '';
+    // 완성된 issues 배열 항목 수
+    this.findings = 0;
+    // 지금까지 받은 이벤트의 토큰 사용량과 모델 (스트림이 끊겨도 사용량을 기록할 수 있도록)
+    this.usage = null;
+    this.model = null;
+    // 스캐너 상태 (중괄호 깊이, 문자열 안 여부, 최상위 객체의 현재 키)
+    this.depth = 0;
+    this.inString = false;
+    this.escapeNext = false;
+    this.stringValue = '';
+    this.lastString = '';
+    this.key = null;
+  }
+
+  /**
+   * 새로 받은 텍스트 추가
+   * @param {string} delta - 텍스트 조각
+   */
+  push(delta) {
+    this.text += delta;
+    for (const char of delta) {
+      if (this.inString) {
+        if (this.escapeNext) {
+          this.escapeNext = false;
+        } else if (char === '\\') {
+          this.escapeNext = true;
+        } else if (char === '"') {
+          this.inString = false;
+          this.lastString = this.stringValue;
+        } else {
+          this.stringValue += char;
+        }
+        continue;
+      }
+      if (char === '"') {
+        this.inString = true;
+        this.stringValue = '';
+      } else if (char === ':' && this.depth === 1) {
+        // 최상위 객체에서 지금 값을 받고 있는 키 (summary, issues, overall_score 등)
+        this.key = this.lastString;
+      } else if (char === '{') {
+        this.depth++;
+      } else if (char === '}') {
+        this.depth--;
+        if (this.depth === 1 && this.key === 'issues') {
+          this.findings++;
+        }
+      }
+    }
+  }
+}
+
+module.exports = StreamProgress;
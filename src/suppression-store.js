@@ -0,0 +1,152 @@
+/**
+ * Suppression Store Module
+ * 사용자가 무시(dismiss)하거나 일시 중지(snooze)한 이슈를 지문 기준으로 저장하는 모듈
+ *
+ * 저장 형식 (.claude-review/suppressions.json):
+ * {
+ *   "version": 1,
+ *   "suppressions": [
+ *     { "fingerprint": "...", "status": "dismissed" | "snoozed", "until": "ISO 날짜", ... }
+ *   ]
+ * }
+ *
+ * 액션은 체크아웃된 파일을 읽어 이슈를 걸러내고,
+ * 서버 모드는 GitHub Contents API로 파일을 갱신합니다.
+ */
+
+const fs = require('fs').promises;
+
+const DEFAULT_SUPPRESSIONS_PATH = '.claude-review/suppressions.json';
+
+class SuppressionStore {
+  /**
+   * SuppressionStore 생성자
+   * @param {Array} suppressions - 저장된 항목 목록
+   * @param {string|null} sha - 저장소 파일 blob SHA (Contents API 갱신용)
+   */
+  constructor(suppressions = [], sha = null) {
+    this.suppressions = suppressions;
+    this.sha = sha;
+  }
+
+  /**
+   * 로컬 파일에서 로드 (파일이 없으면 빈 저장소)
+   * @param {string} filePath - 파일 경로
+   * @returns {Promise<SuppressionStore>} 저장소
+   */
+  static async loadFile(filePath = DEFAULT_SUPPRESSIONS_PATH) {
+    try {
+      const data = JSON.parse(await fs.readFile(filePath, 'utf8'));
+      return new SuppressionStore(data.suppressions || []);
+    } catch (error) {
+      if (error.code === 'ENOENT') {
+        return new SuppressionStore();
+      }
+      throw new Error(`Invalid suppressions file ${filePath}: ${error.message}`);
+    }
+  }
+
+  /**
+   * GitHub 저장소에서 로드 (Contents API)
+   * @param {Object} octokit - GitHub API 클라이언트
+   * @param {Object} location - { owner, repo, path, branch }
+   * @returns {Promise<SuppressionStore>} 저장소
+   */
+  static async loadFromRepo(octokit, { owner, repo, path = DEFAULT_SUPPRESSIONS_PATH, branch }) {
+    try {
+      const { data } = await octokit.rest.repos.getContent({ owner, repo, path, ref: branch });
+      const parsed = JSON.parse(Buffer.from(data.content, 'base64').toString('utf8'));
+      return new SuppressionStore(parsed.suppressions || [], data.sha);
+    } catch (error) {
+      if (error.status === 404) {
+        return new SuppressionStore();
+      }
+      throw error;
+    }
+  }
+
+  /**
+   * 지문에 해당하는 유효한 항목 조회 (만료된 snooze는 무시)
+   * @param {string} fingerprint - 이슈 지문
+   * @param {Date} now - 기준 시각
+   * @returns {Object|null} 항목
+   */
+  find(fingerprint, now = new Date()) {
+    return this.suppressions.find(entry =>
+      entry.fingerprint === fingerprint &&
+      (entry.status !== 'snoozed' || !entry.until || new Date(entry.until) > now)
+    ) || null;
+  }
+
+  /**
+   * 이슈가 현재 억제되어 있는지 확인
+   * @param {string} fingerprint - 이슈 지문
+   * @param {Date} now - 기준 시각
+   * @returns {boolean} 억제 여부
+   */
+  isSuppressed(fingerprint, now = new Date()) {
+    return this.find(fingerprint, now) !== null;
+  }
+
+  /**
+   * 항목 추가 또는 갱신 (같은 지문은 하나만 유지)
+   * @param {Object} entry - { fingerprint, status, until, by, reason, file, title }
+   */
+  upsert(entry) {
+    this.suppressions = this.suppressions.filter(item => item.fingerprint !== entry.fingerprint);
+    this.suppressions.push({ ...entry, createdAt: new Date().toISOString() });
+  }
+
+  /**
+   * 리뷰 결과에서 억제된 이슈 제거
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @returns {Object} { results, suppressedCount }
+   */
+  filterResults(reviewResults) {
+    let suppressedCount = 0;
+    const results = reviewResults
+      .map(result => {
+        const issues = result.issues.filter(issue => {
+          const suppressed = issue.fingerprint && this.isSuppressed(issue.fingerprint);
+          if (suppressed) {
+            suppressedCount++;
+          }
+          return !suppressed;
+        });
+        return { ...result, issues };
+      })
+      .filter(result => result.issues.length > 0);
+
+    return { results, suppressedCount };
+  }
+
+  /**
+   * 파일 내용 직렬화
+   * @returns {string} JSON 문자열
+   */
+  serialize() {
+    return JSON.stringify({ version: 1, suppressions: this.suppressions }, null, 2) + '\n';
+  }
+
+  /**
+   * GitHub 저장소에 저장 (Contents API 커밋)
+   * @param {Object} octokit - GitHub API 클라이언트
+   * @param {Object} location - { owner, repo, path, branch }
+   * @param {string} message - 커밋 메시지
+   */
+  async saveToRepo(octokit, { owner, repo, path = DEFAULT_SUPPRESSIONS_PATH, branch }, message) {
+    const { data } = await octokit.rest.repos.createOrUpdateFileContents({
+      owner,
+      repo,
+      path,
+      branch,
+      message,
+      content: Buffer.from(this.serialize()).toString('base64'),
+      sha: this.sha || undefined
+    });
+    this.sha = data.content.sha;
+  }
+}
+
+module.exports = SuppressionStore;
+module.exports.DEFAULT_SUPPRESSIONS_PATH = DEFAULT_SUPPRESSIONS_PATH;
@@ -0,0 +1,174 @@
+/**
+ * Team Router Module
+ * 이슈 분류(심각도, 타입, 경로)와 CODEOWNERS를 조합해 이슈를 담당 팀에 배정하고 팀별 스레드로 멘션하는 모듈
+ *
+ * 규칙 형식 (team_routes 입력값, 한 줄에 하나):
+ *   type=performance path=db/** -> @org/database
+ *   severity=high type=security -> @org/security, codeowners
+ *
+ * - 조건은 notify_routes와 같습니다 (severity, type, path)
+ * - 대상: @사용자 또는 @조직/팀, codeowners는 이슈 파일의 CODEOWNERS 담당자
+ * 이슈마다 위에서부터 처음 일치한 규칙 하나만 적용되며, 어떤 규칙에도 맞지 않는 이슈는 멘션하지 않습니다.
+ * 팀마다 PR 댓글 하나를 만들어 갱신하므로 요약 댓글 하나에 모두를 멘션하지 않고 담당 이슈만 보여 줍니다.
+ */
+
+const { ConfigError } = require('./errors');
+const { parseConditions, matchesConditions } = require('./notification-router');
+const { getSeverityLevel } = require('./review-summary');
+
+const THREAD_MARKER_PREFIX = '<!-- claude-review:team:';
+
+// 멘션 대상 형식 (@사용자 또는 @조직/팀)
+const MENTION_PATTERN = /^@[\w.-]+(\/[\w.-]+)?$/;
+
+/**
+ * 팀 라우팅 규칙 파싱
+ * @param {string} text - 여러 줄 규칙 (빈 줄과 #으로 시작하는 줄은 무시)
+ * @returns {Array} 규칙 배열 { severity, types, path, targets }
+ */
+function parseTeamRoutes(text) {
+  return (text || '')
+    .split('\n')
+    .map(line => line.trim())
+    .filter(line => line && !line.startsWith('#'))
+    .map(line => {
+      const arrow = line.indexOf('->');
+      if (arrow === -1) {
+        throw new ConfigError(`Invalid team route (missing "->"): ${line}`);
+      }
+      const targets = line.substring(arrow + 2).split(',').map(target => target.trim()).filter(Boolean);
+      if (targets.length === 0) {
+        throw new ConfigError(`Team route has no target: ${line}`);
+      }
+      targets.forEach(target => {
+        if (target !== 'codeowners' && !MENTION_PATTERN.test(target)) {
+          throw new ConfigError(`Invalid team route target "${target}" (expected @user, @org/team or codeowners): ${line}`);
+        }
+      });
+      return { ...parseConditions(line.substring(0, arrow), line, 'team route'), targets };
+    });
+}
+
+class TeamRouter {
+  /**
+   * TeamRouter 생성자
+   * @param {Object} options - 옵션
+   * @param {Array} options.routes - parseTeamRoutes() 결과
+   * @param {Codeowners} options.codeowners - CODEOWNERS 담당자 매핑
+   * @param {DisplayLabels} options.labels - 심각도/타입 표시
+   */
+  constructor({ routes, codeowners, labels }) {
+    this.routes = routes;
+    this.codeowners = codeowners;
+    this.labels = labels;
+  }
+
+  /**
+   * 이슈를 팀별로 배정
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @returns {Map<string, Array>} 팀 → [{ file, issue }] (심각도 내림차순)
+   */
+  route(reviewResults) {
+    const teams = new Map();
+    reviewResults.forEach(result => {
+      result.issues.forEach(issue => {
+        const route = this.routes.find(item => matchesConditions(item, result.file, issue));
+        if (!route) {
+          return;
+        }
+        const owners = route.targets.flatMap(target => (target === 'codeowners' ? this.codeowners.ownersOf(result.file) : [target]));
+        new Set(owners).forEach(team => {
+          if (!teams.has(team)) {
+            teams.set(team, []);
+          }
+          teams.get(team).push({ file: result.file, issue });
+        });
+      });
+    });
+    teams.forEach(findings => findings.sort((a, b) => getSeverityLevel(b.issue.severity) - getSeverityLevel(a.issue.severity)));
+    return teams;
+  }
+
+  /**
+   * 팀 스레드 본문 생성
+   * @param {string} team - 팀 (@조직/팀)
+   * @param {Array} findings - [{ file, issue }]
+   * @returns {string} 댓글 본문
+   */
+  buildThread(team, findings) {
+    const cell = text => String(text).replace(/\|/g, '\\|').replace(/\n/g, ' ');
+    const lines = [
+      TeamRouter.marker(team),
+      `## 👥 ${team} 담당 이슈`,
+      '',
+      `${team} 담당 영역에서 이슈 ${findings.length}개가 발견되었습니다. 전체 결과는 요약 댓글을 확인하세요.`,
+      '',
+      '| 심각도 | 위치 | 이슈 |',
+      '|--------|------|------|'
+    ];
+    findings.forEach(({ file, issue }) => {
+      const severity = this.labels.withIcon(this.labels.severityIcon(issue.severity), this.labels.severityLabel(issue.severity));
+      const location = issue.line ? `${file}:${issue.line}` : file;
+      lines.push(`| ${severity} | \`${cell(location)}\` | ${this.labels.withIcon(this.labels.typeIcon(issue.type), cell(issue.title))} |`);
+    });
+    return lines.join('\n');
+  }
+
+  /**
+   * 팀별 스레드 생성 또는 갱신 (이번에 담당 이슈가 없는 팀의 기존 스레드는 해결됨으로 갱신)
+   * @param {Object} octokit - GitHub API 클라이언트
+   * @param {Object} context - GitHub Actions 컨텍스트 (pull_request 이벤트)
+   * @param {Map<string, Array>} teams - route() 결과
+   * @returns {Promise<Object>} { created, updated, resolved } (팀 목록)
+   */
+  async publish(octokit, context, teams) {
+    const issue = {
+      owner: context.repo.owner,
+      repo: context.repo.repo,
+      issue_number: context.payload.pull_request.number
+    };
+    const comments = await octokit.paginate(octokit.rest.issues.listComments, { ...issue, per_page: 100 });
+    const threads = new Map();
+    comments.forEach(comment => {
+      const match = (comment.body || '').match(/^<!-- claude-review:team:(\S+) -->/);
+      if (match) {
+        threads.set(match[1], comment);
+      }
+    });
+
+    const summary = { created: [], updated: [], resolved: [] };
+    for (const [team, findings] of teams) {
+      const body = this.buildThread(team, findings);
+      const existing = threads.get(team);
+      if (!existing) {
+        // 멘션 알림은 새 댓글에서만 발생
+        await octokit.rest.issues.createComment({ ...issue, body });
+        summary.created.push(team);
+      } else if (existing.body !== body) {
+        await octokit.rest.issues.updateComment({ owner: issue.owner, repo: issue.repo, comment_id: existing.id, body });
+        summary.updated.push(team);
+      }
+    }
+
+    for (const [team, comment] of threads) {
+      const body = `${TeamRouter.marker(team)}\n## 👥 ${team} 담당 이슈\n\n✅ 현재 ${team} 담당 이슈가 없습니다.`;
+      if (!teams.has(team) && comment.body !== body) {
+        await octokit.rest.issues.updateComment({ owner: issue.owner, repo: issue.repo, comment_id: comment.id, body });
+        summary.resolved.push(team);
+      }
+    }
+    return summary;
+  }
+
+  /**
+   * 팀 스레드 식별 마커
+   * @param {string} team - 팀
+   * @returns {string} HTML 주석 마커
+   */
+  static marker(team) {
+    return `${THREAD_MARKER_PREFIX}${team} -->`;
+  }
+}
+
+module.exports = TeamRouter;
+module.exports.parseTeamRoutes = parseTeamRoutes;
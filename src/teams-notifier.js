@@ -0,0 +1,143 @@
+/**
+ * Microsoft Teams Notifier Module
+ * 리뷰 요약을 Teams Incoming Webhook으로 전송하는 모듈
+ *
+ * 주요 기능:
+ * - 심각도 임계값 기반 전송 여부 판단
+ * - Adaptive Card 형식 메시지 생성 (PR 링크, 판정, 심각도별 개수, 상위 3개 이슈)
+ */
+
+const core = require('@actions/core');
+const { postJson } = require('./webhook-client');
+const DisplayLabels = require('./display-labels');
+const { hasFindingsAtOrAbove } = require('./review-summary');
+
+// 판정별 표시 문구와 Adaptive Card 색상
+const VERDICTS = {
+  changes_requested: { label: 'Changes requested', color: 'Attention' },
+  needs_attention: { label: 'Needs attention', color: 'Warning' },
+  approved: { label: 'Looks good', color: 'Good' }
+};
+
+class TeamsNotifier {
+  /**
+   * TeamsNotifier 생성자
+   * @param {Object} config - 설정
+   * @param {string} config.webhookUrl - Teams Incoming Webhook (또는 Workflows) URL
+   * @param {string} [config.minSeverity] - 이 심각도 이상 이슈가 있을 때만 전송
+   * @param {DisplayLabels} [config.labels] - 심각도 아이콘/표시 이름
+   */
+  constructor({ webhookUrl, minSeverity = 'high', labels = new DisplayLabels() }) {
+    this.webhookUrl = webhookUrl;
+    this.minSeverity = minSeverity;
+    this.labels = labels;
+  }
+
+  /**
+   * 임계값 이상 이슈 존재 여부 확인
+   * @param {Object} summary - buildReviewSummary() 결과
+   * @returns {boolean} 전송 여부
+   */
+  shouldNotify(summary) {
+    return hasFindingsAtOrAbove(summary, this.minSeverity);
+  }
+
+  /**
+   * Adaptive Card 메시지 생성
+   * @param {Object} summary - 리뷰 요약
+   * @returns {Object} Teams 메시지 페이로드
+   */
+  buildMessage(summary) {
+    const verdict = VERDICTS[summary.verdict];
+    const body = [
+      {
+        type: 'TextBlock',
+        text: '🤖 Claude AI Code Review',
+        weight: 'Bolder',
+        size: 'Medium'
+      },
+      {
+        type: 'TextBlock',
+        text: `[${summary.title}](${summary.url})`,
+        wrap: true
+      },
+      {
+        type: 'TextBlock',
+        text: this.labels.withIcon(this.labels.verdictIcon(summary.verdict), verdict.label),
+        color: verdict.color,
+        weight: 'Bolder'
+      },
+      {
+        type: 'FactSet',
+        facts: ['critical', 'high', 'medium', 'low'].map(severity => ({
+          title: this.labels.withIcon(this.labels.severityIcon(severity), this.labels.severityLabel(severity)),
+          value: String(summary.counts[severity])
+        }))
+      }
+    ];
+
+    if (summary.topFindings.length > 0) {
+      body.push({
+        type: 'TextBlock',
+        text: 'Top findings',
+        weight: 'Bolder',
+        separator: true
+      });
+      summary.topFindings.forEach(finding => {
+        const location = finding.line ? `${finding.file}:${finding.line}` : finding.file;
+        body.push({
+          type: 'TextBlock',
+          text: `${this.labels.withIcon(this.labels.severityIcon(finding.severity), `**${finding.title}**`)} — \`${location}\``,
+          wrap: true,
+          spacing: 'Small'
+        });
+      });
+    }
+
+    body.push({
+      type: 'TextBlock',
+      text: `${summary.repository} · ${summary.totalIssues} issues in ${summary.filesWithIssues} files`,
+      isSubtle: true,
+      size: 'Small',
+      wrap: true
+    });
+
+    return {
+      type: 'message',
+      attachments: [{
+        contentType: 'application/vnd.microsoft.card.adaptive',
+        content: {
+          $schema: 'http://adaptivecards.io/schemas/adaptive-card.json',
+          type: 'AdaptiveCard',
+          version: '1.4',
+          body,
+          actions: [{
+            type: 'Action.OpenUrl',
+            title: 'View on GitHub',
+            url: summary.url
+          }]
+        }
+      }]
+    };
+  }
+
+  /**
+   * 조건 충족 시 Teams로 전송
+   * @param {Object} summary - 리뷰 요약
+   */
+  async notify(summary) {
+    if (!this.shouldNotify(summary)) {
+      core.info(`Teams notification skipped: no findings at or above ${this.minSeverity}`);
+      return;
+    }
+
+    try {
+      await postJson(this.webhookUrl, this.buildMessage(summary));
+      core.info('Teams notification sent');
+    } catch (error) {
+      core.warning(`Failed to send Teams notification: ${error.message}`);
+    }
+  }
+}
+
+module.exports = TeamsNotifier;
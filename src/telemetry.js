@@ -0,0 +1,70 @@
+/**
+ * Telemetry Module
+ * 여러 리포지토리에서 실행되는 액션을 중앙에서 모니터링하기 위한 실행 메타데이터 전송 모듈
+ *
+ * 전송 내용: 리포지토리, 실행 시간, 토큰 사용량, 결과
+ * 코드, 파일명, 리뷰 내용은 절대 전송하지 않습니다.
+ */
+
+const core = require('@actions/core');
+const { postJson } = require('./webhook-client');
+const { version } = require('../package.json');
+
+class TelemetryReporter {
+  /**
+   * TelemetryReporter 생성자
+   * @param {string} url - 메타데이터를 받을 엔드포인트
+   * @param {string} secret - 서명용 비밀값 (선택)
+   */
+  constructor(url, secret) {
+    this.url = url;
+    this.secret = secret;
+  }
+
+  /**
+   * 실행 메타데이터 생성
+   * @param {Object} context - GitHub Actions 컨텍스트
+   * @param {Object} run - 실행 결과
+   * @param {string} run.outcome - success, failure, skipped
+   * @param {number} run.durationMs - 실행 시간
+   * @param {number} run.filesReviewed - 리뷰한 파일 수
+   * @param {number} run.issuesFound - 발견된 이슈 수
+   * @param {Object} run.usage - 토큰 사용량
+   * @param {number|null} [run.estimatedCostUsd] - 예상 비용 (USD, 가격을 모르는 모델만 사용했으면 null)
+   * @returns {Object} 전송할 페이로드
+   */
+  buildPayload(context, run) {
+    return {
+      schema: 1,
+      actionVersion: version,
+      repository: `${context.repo.owner}/${context.repo.repo}`,
+      event: context.eventName,
+      workflow: context.workflow,
+      runId: context.runId,
+      runAttempt: process.env.GITHUB_RUN_ATTEMPT ? parseInt(process.env.GITHUB_RUN_ATTEMPT) : null,
+      outcome: run.outcome,
+      durationMs: run.durationMs,
+      filesReviewed: run.filesReviewed,
+      issuesFound: run.issuesFound,
+      usage: run.usage,
+      estimatedCostUsd: run.estimatedCostUsd === undefined ? null : run.estimatedCostUsd,
+      timestamp: new Date().toISOString()
+    };
+  }
+
+  /**
+   * 메타데이터 전송 (실패해도 액션은 계속 진행)
+   * @param {Object} context - GitHub Actions 컨텍스트
+   * @param {Object} run - 실행 결과
+   */
+  async send(context, run) {
+    try {
+      await postJson(this.url, this.buildPayload(context, run), { secret: this.secret });
+      core.info('Telemetry sent');
+    } catch (error) {
+      core.warning(`Failed to send telemetry: ${error.message}`);
+    }
+  }
+}
+
+module.exports = TelemetryReporter;
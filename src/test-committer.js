@@ -0,0 +1,95 @@
+/**
+ * Test Committer Module
+ * 테스트 누락 리뷰(review_type: tests)가 제안한 테스트 스켈레톤을 PR 브랜치에 새 파일로 커밋하는 모듈
+ *
+ * 기존 테스트 파일을 덮어쓰거나 이어 붙이면 컴파일이 깨질 수 있으므로 아직 없는 파일만 만듭니다.
+ * 같은 테스트 파일을 가리키는 스켈레톤이 여럿이면 가장 심각한 이슈의 것만 커밋하고 나머지는 댓글로만 남깁니다.
+ */
+
+const { getSeverityLevel } = require('./review-summary');
+
+class TestCommitter {
+  /**
+   * TestCommitter 생성자
+   * @param {Object} octokit - GitHub API 클라이언트
+   * @param {Object} context - GitHub Actions 컨텍스트 (pull_request 이벤트)
+   */
+  constructor(octokit, context) {
+    this.octokit = octokit;
+    this.context = context;
+  }
+
+  /**
+   * 테스트 파일별로 커밋할 스켈레톤 선택
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @returns {Map<string, Object>} 테스트 파일 경로 → { file, issue }
+   */
+  static collect(reviewResults) {
+    const skeletons = new Map();
+    reviewResults.forEach(result => {
+      result.issues
+        .filter(issue => issue.testFile && issue.codeExample)
+        .forEach(issue => {
+          const existing = skeletons.get(issue.testFile);
+          if (!existing || getSeverityLevel(issue.severity) > getSeverityLevel(existing.issue.severity)) {
+            skeletons.set(issue.testFile, { file: result.file, issue });
+          }
+        });
+    });
+    return skeletons;
+  }
+
+  /**
+   * 스켈레톤을 PR 브랜치에 커밋
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @returns {Promise<Object>} { committed, skipped } (테스트 파일 경로 목록)
+   */
+  async commit(reviewResults) {
+    const pullRequest = this.context.payload.pull_request;
+    const { owner, repo } = this.context.repo;
+    const committed = [];
+    const skipped = [];
+
+    // 포크 PR 브랜치에는 GITHUB_TOKEN으로 쓸 수 없음
+    if (pullRequest.head.repo && pullRequest.head.repo.full_name !== `${owner}/${repo}`) {
+      throw new Error('Cannot commit test skeletons to a pull request from a fork');
+    }
+
+    for (const [testFile, { file, issue }] of TestCommitter.collect(reviewResults)) {
+      if (await this.exists(testFile, pullRequest.head.ref)) {
+        skipped.push(testFile);
+        continue;
+      }
+      await this.octokit.rest.repos.createOrUpdateFileContents({
+        owner,
+        repo,
+        path: testFile,
+        branch: pullRequest.head.ref,
+        message: `test: add test skeleton for ${file}\n\n${issue.title}`,
+        content: Buffer.from(issue.codeExample.endsWith('\n') ? issue.codeExample : `${issue.codeExample}\n`).toString('base64')
+      });
+      committed.push(testFile);
+    }
+    return { committed, skipped };
+  }
+
+  /**
+   * 브랜치에 파일이 있는지 확인
+   * @param {string} path - 파일 경로
+   * @param {string} ref - 브랜치
+   * @returns {Promise<boolean>} 존재 여부
+   */
+  async exists(path, ref) {
+    try {
+      await this.octokit.rest.repos.getContent({ ...this.context.repo, path, ref });
+      return true;
+    } catch (error) {
+      if (error.status === 404) {
+        return false;
+      }
+      throw error;
+    }
+  }
+}
+
+module.exports = TestCommitter;
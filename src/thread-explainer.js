@@ -0,0 +1,261 @@
+/**
+ * Thread Explainer Module
+ * 액션이 작성한 인라인 리뷰 댓글에 `/claude explain` 또는 `/claude <질문>` 답글이 달리면
+ * 스레드 대화와 지적된 코드를 바탕으로 같은 스레드에 답변하는 모듈 (pull_request_review_comment 이벤트)
+ *
+ * - `/claude explain`만 쓰면 지적 내용을 더 자세히 설명하고, 뒤에 질문을 붙이면 그 질문에 답변
+ * - 후속 답글(followUps)을 켜면 명령이 없는 답글(반박, 대안 요청)에도 답변 (짧은 감사/수정 완료 답글은 제외)
+ * - 액션의 이슈 댓글(finding 마커가 있는 댓글)에서 시작한 스레드의 답글만 처리
+ * - API 비용이 드는 작업이므로 저장소에 write 이상 권한이 있는 사람과 PR 작성자만 질문할 수 있음
+ */
+
+const fs = require('fs').promises;
+const { FINDING_MARKER_PREFIX } = require('./comment-manager');
+const { hasWriteAccess } = require('./review-command');
+
+const COMMAND_PATTERN = /^\/claude\s+([\s\S]*)$/i;
+const ANSWER_MARKER = '<!-- claude-review:explain -->';
+
+// 답변이 필요 없는 짧은 확인 답글 (감사, 수정 완료, 동의)
+const ACKNOWLEDGEMENT_PATTERN = /^(thanks?|thank you|thx|ty|fixed|done|resolved|ok(ay)?|agreed|lgtm|good catch|nice catch|감사합니다|고맙습니다|수정했습니다|수정 완료|반영했습니다|반영 완료|확인했습니다|넵|네)[\s.!~👍🙏]*$/i;
+const MAX_ACKNOWLEDGEMENT_CHARS = 40;
+
+// 프롬프트에 넣을 지적된 줄 앞뒤 줄 수와 스레드 댓글 수
+const CONTEXT_LINES = 20;
+const MAX_THREAD_COMMENTS = 10;
+const MAX_COMMENT_CHARS = 2000;
+
+const EXPLAIN_QUESTION = '이 지적이 왜 문제인지, 어떤 상황에서 영향이 있는지, 어떻게 고치면 되는지 더 자세히 설명해주세요.';
+
+const EXPLAIN_PROMPT = `당신은 코드 리뷰 댓글을 작성한 시니어 개발자입니다. PR 작성자나 메인테이너가 리뷰 댓글 스레드에서 질문했습니다. 스레드 대화와 코드를 읽고 질문에 답변해주세요.
+
+답변 규칙:
+- 질문에 직접 답하고, 필요하면 짧은 코드 예시를 포함하세요
+- 개발자가 지적에 반박하면 코드를 다시 검토해 지적을 유지할지 철회할지 근거와 함께 분명히 밝히세요
+- 다른 방법을 물으면 대안과 각각의 장단점을 제시하세요
+- 코드를 다시 보니 지적이 틀렸거나 과했다면 솔직히 인정하세요
+- 주어진 코드와 대화에 없는 내용은 추측하지 마세요`;
+
+class ThreadExplainer {
+  /**
+   * ThreadExplainer 생성자
+   * @param {Object} options - 옵션
+   * @param {CodeReviewer} options.codeReviewer - Claude API 호출용 리뷰어 (언어, 사용량 기록 공유)
+   * @param {Object} options.octokit - GitHub API 클라이언트
+   * @param {Object} options.context - GitHub Actions 컨텍스트 (pull_request_review_comment 이벤트)
+   * @param {boolean} [options.followUps] - `/claude` 명령이 없는 답글에도 답변
+   */
+  constructor({ codeReviewer, octokit, context, followUps = false }) {
+    this.codeReviewer = codeReviewer;
+    this.octokit = octokit;
+    this.context = context;
+    this.followUps = followUps;
+  }
+
+  /**
+   * 댓글 본문에서 질문 파싱
+   * @param {string} body - 댓글 본문
+   * @returns {string|null} 질문 (명령이 아니면 null)
+   */
+  static parse(body) {
+    const match = String(body || '').trim().match(COMMAND_PATTERN);
+    if (!match) {
+      return null;
+    }
+    const text = match[1].trim();
+    const explain = text.match(/^explain\b([\s\S]*)$/i);
+    if (explain) {
+      return explain[1].trim() || EXPLAIN_QUESTION;
+    }
+    return text || null;
+  }
+
+  /**
+   * 명령이 없는 답글 중 답변할 후속 답글인지 확인
+   * @param {Object} comment - 답글
+   * @returns {boolean} 봇이 아니고, 짧은 확인 답글이 아니며, 액션의 답변이 아니면 true
+   */
+  static isFollowUp(comment) {
+    const body = String(comment.body || '').trim();
+    if (!body || body.includes(ANSWER_MARKER) || (comment.user && comment.user.type === 'Bot')) {
+      return false;
+    }
+    return !(body.length <= MAX_ACKNOWLEDGEMENT_CHARS && ACKNOWLEDGEMENT_PATTERN.test(body));
+  }
+
+  /**
+   * 이벤트의 답글이 처리할 질문인지 확인하고 질문 반환
+   * @returns {Promise<Object>} { question, root } 또는 { skipped: 건너뛴 이유 }
+   */
+  async load() {
+    const { action, comment, pull_request: pullRequest } = this.context.payload;
+    if (action !== 'created' || !comment || !comment.in_reply_to_id) {
+      return { skipped: 'not a reply to a review comment' };
+    }
+    let question = ThreadExplainer.parse(comment.body);
+    if (!question && this.followUps && ThreadExplainer.isFollowUp(comment)) {
+      question = comment.body.trim();
+    }
+    if (!question) {
+      return { skipped: this.followUps ? 'reply is an acknowledgement or bot comment' : 'reply is not a /claude command' };
+    }
+
+    const { data: root } = await this.octokit.rest.pulls.getReviewComment({
+      owner: this.context.repo.owner,
+      repo: this.context.repo.repo,
+      comment_id: comment.in_reply_to_id
+    });
+    if (!(root.body || '').includes(FINDING_MARKER_PREFIX)) {
+      return { skipped: 'thread was not started by a review finding' };
+    }
+
+    const author = comment.user.login;
+    if (author !== pullRequest.user.login && !await hasWriteAccess(this.octokit, this.context, author)) {
+      await this.react(comment.id, 'confused');
+      return { skipped: `@${author} is not allowed to ask (requires write permission or PR authorship)` };
+    }
+    await this.react(comment.id, 'eyes');
+    return { question, root };
+  }
+
+  /**
+   * 스레드 댓글 조회 (루트 댓글과 그 답글, 작성 순)
+   * @param {Object} root - 스레드 루트 댓글
+   * @returns {Promise<Array>} 댓글 목록
+   */
+  async getThread(root) {
+    const comments = await this.octokit.paginate(this.octokit.rest.pulls.listReviewComments, {
+      owner: this.context.repo.owner,
+      repo: this.context.repo.repo,
+      pull_number: this.context.payload.pull_request.number,
+      per_page: 100
+    });
+    const thread = comments
+      .filter(comment => comment.id === root.id || comment.in_reply_to_id === root.id)
+      .sort((a, b) => a.id - b.id);
+    return thread.some(comment => comment.id === root.id) ? thread : [root, ...thread];
+  }
+
+  /**
+   * 지적된 줄 주변 코드 (체크아웃한 파일에서 읽고, 없으면 댓글의 diff hunk 사용)
+   * @param {Object} root - 스레드 루트 댓글
+   * @returns {Promise<string>} 줄 번호를 붙인 코드
+   */
+  async getCodeContext(root) {
+    const line = root.line || root.original_line;
+    try {
+      const lines = (await fs.readFile(root.path, 'utf8')).split('\n');
+      const start = Math.max(1, (line || 1) - CONTEXT_LINES);
+      const end = Math.min(lines.length, (line || 1) + CONTEXT_LINES);
+      return lines.slice(start - 1, end)
+        .map((text, index) => `${start + index === line ? '>' : ' '}${String(start + index).padStart(5)}: ${text}`)
+        .join('\n');
+    } catch (error) {
+      return root.diff_hunk || '';
+    }
+  }
+
+  /**
+   * 답변 프롬프트 생성
+   * @param {Object} root - 스레드 루트 댓글
+   * @param {Array} thread - 스레드 댓글
+   * @param {string} code - 지적된 줄 주변 코드
+   * @param {string} question - 질문
+   * @returns {string} 프롬프트
+   */
+  buildPrompt(root, thread, code, question) {
+    const conversation = thread.slice(-MAX_THREAD_COMMENTS).map(comment => {
+      const speaker = (comment.body || '').includes(FINDING_MARKER_PREFIX) || (comment.body || '').includes(ANSWER_MARKER)
+        ? '리뷰어'
+        : `@${comment.user.login}`;
+      const body = (comment.body || '').replace(/<!--[\s\S]*?-->/g, '').trim();
+      return `[${speaker}]\n${body.substring(0, MAX_COMMENT_CHARS)}`;
+    });
+
+    return `${EXPLAIN_PROMPT} ${this.codeReviewer.getLanguageInstruction()}
+
+파일: ${root.path} (지적된 줄: ${root.line || root.original_line || '알 수 없음'}, '>'로 표시)
+\`\`\`
+${code}
+\`\`\`
+
+스레드 대화:
+${conversation.join('\n\n')}
+
+질문: ${question}
+
+**중요**: 완전한 JSON만 반환하세요.
+
+형식:
+{"answer":"마크다운 답변"}`;
+  }
+
+  /**
+   * 질문에 대한 답변 생성
+   * @param {Object} root - 스레드 루트 댓글
+   * @param {string} question - 질문
+   * @returns {Promise<string>} 답변 (마크다운)
+   */
+  async answer(root, question) {
+    const thread = await this.getThread(root);
+    const code = await this.getCodeContext(root);
+    const responseText = await this.codeReviewer.sendMessage(
+      `${root.path} thread ${root.id}`,
+      this.buildPrompt(root, thread, code, question),
+      this.codeReviewer.model
+    );
+    return ThreadExplainer.parseResponse(responseText);
+  }
+
+  /**
+   * 응답 JSON 파싱
+   * @param {string} responseText - 응답 텍스트
+   * @returns {string} 답변
+   */
+  static parseResponse(responseText) {
+    const firstBrace = responseText.indexOf('{');
+    const lastBrace = responseText.lastIndexOf('}');
+    if (firstBrace === -1 || lastBrace <= firstBrace) {
+      throw new Error('No JSON structure found in explain response');
+    }
+    const parsed = JSON.parse(responseText.substring(firstBrace, lastBrace + 1));
+    if (typeof parsed.answer !== 'string' || !parsed.answer.trim()) {
+      throw new Error('Explain response has no answer');
+    }
+    return parsed.answer.trim();
+  }
+
+  /**
+   * 스레드에 답변 게시
+   * @param {Object} root - 스레드 루트 댓글
+   * @param {string} answer - 답변
+   * @returns {Promise<string>} 답변 댓글 URL
+   */
+  async reply(root, answer) {
+    const { data } = await this.octokit.rest.pulls.createReplyForReviewComment({
+      owner: this.context.repo.owner,
+      repo: this.context.repo.repo,
+      pull_number: this.context.payload.pull_request.number,
+      comment_id: root.id,
+      body: `${ANSWER_MARKER}\n${answer}`
+    });
+    return data.html_url;
+  }
+
+  /**
+   * 질문 댓글에 반응 추가 (실패해도 무시)
+   * @param {number} commentId - 리뷰 댓글 ID
+   * @param {string} content - 반응 (eyes, confused 등)
+   */
+  async react(commentId, content) {
+    await this.octokit.rest.reactions.createForPullRequestReviewComment({
+      owner: this.context.repo.owner,
+      repo: this.context.repo.repo,
+      comment_id: commentId,
+      content
+    }).catch(() => {});
+  }
+}
+
+module.exports = ThreadExplainer;
+module.exports.ANSWER_MARKER = ANSWER_MARKER;
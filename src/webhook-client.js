@@ -0,0 +1,86 @@
+/**
+ * Webhook Client Module
+ * 외부 엔드포인트로 JSON을 전송하는 공용 HTTP 헬퍼
+ *
+ * 주요 기능:
+ * - JSON / 순수 텍스트 POST 요청 (타임아웃 포함)
+ * - HMAC-SHA256 서명 헤더 생성
+ */
+
+const crypto = require('crypto');
+
+// 외부 엔드포인트 응답 대기 최대 시간
+const DEFAULT_TIMEOUT_MS = 10000;
+
+/**
+ * 본문에 대한 HMAC-SHA256 서명 생성
+ * @param {string} body - 요청 본문
+ * @param {string} secret - 서명 비밀값
+ * @returns {string} "sha256=<hex>" 형식의 서명
+ */
+function signPayload(body, secret) {
+  const digest = crypto.createHmac('sha256', secret).update(body).digest('hex');
+  return `sha256=${digest}`;
+}
+
+/**
+ * 본문 POST 전송 (서명 포함)
+ * @param {string} url - 대상 URL
+ * @param {string} body - 요청 본문
+ * @param {string} contentType - Content-Type 헤더
+ * @param {Object} options - postJson() 옵션과 동일
+ * @returns {Promise<Object>} { status, body }
+ */
+async function post(url, body, contentType, options = {}) {
+  const headers = {
+    'Content-Type': contentType,
+    'User-Agent': 'claude-code-review-action',
+    ...options.headers
+  };
+
+  if (options.secret) {
+    headers[options.signatureHeader || 'X-Claude-Review-Signature'] = signPayload(body, options.secret);
+  }
+
+  const response = await fetch(url, {
+    method: 'POST',
+    headers,
+    body,
+    signal: AbortSignal.timeout(options.timeoutMs || DEFAULT_TIMEOUT_MS)
+  });
+
+  const text = await response.text();
+  if (!response.ok) {
+    throw new Error(`HTTP ${response.status}: ${text.substring(0, 200)}`);
+  }
+
+  return { status: response.status, body: text };
+}
+
+/**
+ * JSON 페이로드 POST 전송
+ * @param {string} url - 대상 URL
+ * @param {Object} payload - 전송할 객체
+ * @param {Object} options - 전송 옵션
+ * @param {string} [options.secret] - 설정 시 서명 헤더 추가
+ * @param {string} [options.signatureHeader] - 서명 헤더 이름
+ * @param {Object} [options.headers] - 추가 헤더
+ * @param {number} [options.timeoutMs] - 타임아웃 (밀리초)
+ * @returns {Promise<Object>} { status, body }
+ */
+async function postJson(url, payload, options = {}) {
+  return post(url, JSON.stringify(payload), 'application/json', options);
+}
+
+/**
+ * 순수 텍스트 POST 전송 (Markdown을 표시하지 못하는 엔드포인트용)
+ * @param {string} url - 대상 URL
+ * @param {string} text - 전송할 텍스트
+ * @param {Object} options - postJson() 옵션과 동일
+ * @returns {Promise<Object>} { status, body }
+ */
+async function postText(url, text, options = {}) {
+  return post(url, text, 'text/plain; charset=utf-8', options);
+}
+
+module.exports = { postJson, postText, signPayload };
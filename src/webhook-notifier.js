@@ -0,0 +1,52 @@
+/**
+ * Generic Webhook Notifier Module
+ * 전체 JSON 리포트를 서명과 함께 임의의 엔드포인트로 전송하는 모듈
+ *
+ * 액션이 직접 지원하지 않는 내부 시스템도 이 웹훅으로 리뷰 결과를 받을 수 있습니다.
+ * 수신 측은 X-Claude-Review-Signature 헤더의 HMAC-SHA256 값을 검증해야 합니다.
+ */
+
+const core = require('@actions/core');
+const { postJson, postText } = require('./webhook-client');
+const { buildJsonReport } = require('./json-report');
+const { renderPlainText } = require('./plain-text-renderer');
+
+class WebhookNotifier {
+  /**
+   * WebhookNotifier 생성자
+   * @param {Object} config - 설정
+   * @param {string} config.url - 리포트를 받을 엔드포인트
+   * @param {string} [config.secret] - 서명용 비밀값
+   * @param {boolean} [config.plainText] - JSON 대신 순수 텍스트 요약 전송
+   * @param {DisplayLabels} [config.labels] - 순수 텍스트의 심각도 표시 이름
+   */
+  constructor({ url, secret, plainText = false, labels }) {
+    this.url = url;
+    this.secret = secret;
+    this.plainText = plainText;
+    this.labels = labels;
+  }
+
+  /**
+   * 리포트 전송 (이슈 유무와 관계없이 매 실행 전송)
+   * @param {Object} summary - buildReviewSummary() 결과
+   */
+  async notify(summary) {
+    try {
+      const options = {
+        secret: this.secret,
+        headers: { 'X-Claude-Review-Event': 'review.completed' }
+      };
+      if (this.plainText) {
+        await postText(this.url, renderPlainText(summary, this.labels), options);
+      } else {
+        await postJson(this.url, buildJsonReport(summary), options);
+      }
+      core.info('Webhook report sent');
+    } catch (error) {
+      core.warning(`Failed to send webhook report: ${error.message}`);
+    }
+  }
+}
+
+module.exports = WebhookNotifier;
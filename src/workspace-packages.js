@@ -0,0 +1,250 @@
+/**
+ * Workspace Packages Module
+ * 모노레포의 워크스페이스 패키지를 찾아 변경의 영향을 받는 패키지를 계산하고 패키지별 판정을 만드는 모듈
+ *
+ * - 패키지: 저장소 루트가 아닌 디렉터리에 있는 매니페스트(package.json, go.mod, Cargo.toml, pyproject.toml 등)
+ * - 패키지 간 의존성: 매니페스트의 의존성(다른 워크스페이스 패키지 이름/경로)과 RepoMap의 import 그래프
+ * - 영향 범위: 파일이 바뀐 패키지와 그 패키지에 (간접적으로) 의존하는 패키지
+ * 루트의 잠금 파일이나 워크스페이스 설정이 바뀌면 모든 패키지가 영향을 받은 것으로 봅니다.
+ */
+
+const fs = require('fs');
+const path = require('path');
+const { getSeverityLevel } = require('./review-summary');
+
+// 패키지 경계를 나타내는 매니페스트 파일
+const MANIFESTS = ['package.json', 'go.mod', 'Cargo.toml', 'pyproject.toml', 'setup.py', 'pom.xml', 'build.gradle', 'build.gradle.kts'];
+// 바뀌면 모든 패키지에 영향을 주는 루트 파일
+const GLOBAL_FILES = [
+  'package.json', 'package-lock.json', 'yarn.lock', 'pnpm-lock.yaml', 'pnpm-workspace.yaml', 'lerna.json', 'nx.json',
+  'turbo.json', 'tsconfig.base.json', 'go.work', 'go.work.sum', 'Cargo.toml', 'Cargo.lock'
+];
+// 프롬프트에 표시할 최대 패키지 수
+const MAX_LISTED = 10;
+
+class WorkspacePackages {
+  /**
+   * WorkspacePackages 생성자
+   * @param {Array} packages - [{ name, path, dependencies: Array<패키지 이름> }]
+   */
+  constructor(packages = []) {
+    // 경로가 긴 패키지부터 확인해야 중첩 패키지의 파일이 안쪽 패키지에 속함
+    this.packages = [...packages].sort((a, b) => b.path.length - a.path.length);
+    this.byName = new Map(this.packages.map(pkg => [pkg.name, pkg]));
+    // 영향 범위 (setChangedFiles() 이후)
+    this.affected = new Map();
+  }
+
+  /**
+   * 저장소에서 워크스페이스 패키지 로드
+   * @param {Object} git - simple-git 인스턴스
+   * @param {RepoMap|null} [repoMap] - import 그래프 (없으면 매니페스트 의존성만 사용)
+   * @param {string} [root] - 저장소 루트 (기본값: 현재 디렉터리)
+   * @returns {Promise<WorkspacePackages>} 패키지 목록
+   */
+  static async load(git, repoMap = null, root = process.cwd()) {
+    const files = (await git.raw(['ls-files'])).split('\n').filter(Boolean);
+    const manifests = files.filter(file =>
+      MANIFESTS.includes(path.posix.basename(file)) && file.includes('/') && !file.includes('node_modules/')
+    );
+
+    // 디렉터리마다 하나의 패키지 (package.json을 다른 매니페스트보다 우선)
+    const found = new Map();
+    manifests.forEach(file => {
+      const dir = path.posix.dirname(file);
+      let content = '';
+      try {
+        content = fs.readFileSync(path.join(root, file), 'utf8');
+      } catch (error) {
+        return;
+      }
+      const parsed = WorkspacePackages.parseManifest(file, content);
+      if (!found.has(dir) || path.posix.basename(file) === 'package.json') {
+        found.set(dir, { path: dir, name: parsed.name || dir, manifestDependencies: parsed.dependencies });
+      }
+    });
+
+    const workspace = new WorkspacePackages([...found.values()].map(pkg => ({ name: pkg.name, path: pkg.path, dependencies: [] })));
+    const names = new Set(workspace.byName.keys());
+    found.forEach(pkg => {
+      const target = workspace.byName.get(pkg.name);
+      pkg.manifestDependencies.forEach(dependency => {
+        // 이름(npm, Go 모듈, Cargo) 또는 상대 경로(Cargo path, Go replace)로 지정된 의존성
+        let resolved = names.has(dependency) ? dependency : null;
+        if (!resolved && dependency.startsWith('.')) {
+          resolved = (workspace.packageOf(path.posix.normalize(path.posix.join(pkg.path, dependency, '_'))) || {}).name;
+        }
+        if (resolved && resolved !== pkg.name && !target.dependencies.includes(resolved)) {
+          target.dependencies.push(resolved);
+        }
+      });
+    });
+
+    // import 그래프의 디렉터리 간선을 패키지 간선으로 변환
+    if (repoMap) {
+      repoMap.packages.forEach((entry, dir) => {
+        const from = workspace.packageOf(`${dir}/_`);
+        entry.imports.forEach(targetDir => {
+          const to = workspace.packageOf(`${targetDir}/_`);
+          if (from && to && from !== to && !from.dependencies.includes(to.name)) {
+            from.dependencies.push(to.name);
+          }
+        });
+      });
+    }
+    return workspace;
+  }
+
+  /**
+   * 매니페스트에서 패키지 이름과 의존성 추출
+   * @param {string} file - 매니페스트 경로
+   * @param {string} content - 파일 내용
+   * @returns {Object} { name, dependencies: Array<이름 또는 상대 경로> }
+   */
+  static parseManifest(file, content) {
+    const basename = path.posix.basename(file);
+    if (basename === 'package.json') {
+      try {
+        const manifest = JSON.parse(content);
+        const dependencies = ['dependencies', 'devDependencies', 'peerDependencies', 'optionalDependencies']
+          .flatMap(field => Object.keys(manifest[field] || {}));
+        return { name: manifest.name || null, dependencies };
+      } catch (error) {
+        return { name: null, dependencies: [] };
+      }
+    }
+    if (basename === 'go.mod') {
+      const name = (content.match(/^module\s+(\S+)/m) || [])[1] || null;
+      const required = [...content.matchAll(/^\s*(?:require\s+)?([\w.-]+\.[\w.-]+\/\S+)\s+v[\d.]+\S*/gm)].map(match => match[1]);
+      const replaced = [...content.matchAll(/=>\s*(\.{1,2}\/\S+)/g)].map(match => match[1]);
+      return { name, dependencies: [...required, ...replaced] };
+    }
+    if (basename === 'Cargo.toml' || basename === 'pyproject.toml') {
+      const name = (content.match(/^\s*name\s*=\s*"([^"]+)"/m) || [])[1] || null;
+      const paths = [...content.matchAll(/path\s*=\s*"([^"]+)"/g)].map(match => match[1]);
+      return { name, dependencies: paths };
+    }
+    if (basename === 'pom.xml') {
+      return { name: (content.match(/<artifactId>([^<]+)<\/artifactId>/) || [])[1] || null, dependencies: [] };
+    }
+    return { name: null, dependencies: [] };
+  }
+
+  /**
+   * 파일이 속한 패키지 (가장 안쪽 패키지)
+   * @param {string} file - 파일 경로
+   * @returns {Object|null} 패키지 (루트 파일이면 null)
+   */
+  packageOf(file) {
+    return this.packages.find(pkg => file.startsWith(`${pkg.path}/`)) || null;
+  }
+
+  /**
+   * 패키지에 직접 의존하는 패키지 목록
+   * @param {string} name - 패키지 이름
+   * @returns {Array<string>} 의존하는 패키지 이름
+   */
+  dependentsOf(name) {
+    return this.packages.filter(pkg => pkg.dependencies.includes(name)).map(pkg => pkg.name);
+  }
+
+  /**
+   * 변경된 파일로 영향 범위 계산
+   * @param {Array<string>} changedFiles - 변경된 파일 경로
+   * @returns {Map<string, Object>} 패키지 이름 → { package, reason: changed|dependency|root, files, via }
+   */
+  setChangedFiles(changedFiles) {
+    const affected = new Map();
+    const globalChange = changedFiles.find(file => GLOBAL_FILES.includes(file));
+
+    changedFiles.forEach(file => {
+      const pkg = this.packageOf(file);
+      if (pkg) {
+        if (!affected.has(pkg.name)) {
+          affected.set(pkg.name, { package: pkg, reason: 'changed', files: [], via: null });
+        }
+        affected.get(pkg.name).files.push(file);
+      }
+    });
+
+    // 바뀐 패키지에 의존하는 패키지를 BFS로 추가 (via: 영향을 전달한 패키지)
+    const queue = [...affected.keys()];
+    while (queue.length > 0) {
+      const current = queue.shift();
+      this.dependentsOf(current).forEach(name => {
+        if (!affected.has(name)) {
+          affected.set(name, { package: this.byName.get(name), reason: 'dependency', files: [], via: current });
+          queue.push(name);
+        }
+      });
+    }
+
+    if (globalChange) {
+      this.packages.filter(pkg => !affected.has(pkg.name)).forEach(pkg => {
+        affected.set(pkg.name, { package: pkg, reason: 'root', files: [], via: globalChange });
+      });
+    }
+    this.affected = affected;
+    return affected;
+  }
+
+  /**
+   * 파일 리뷰 프롬프트에 넣을 패키지 범위 지시사항
+   * @param {string} filename - 파일 경로
+   * @returns {string} 지시사항 (패키지에 속하지 않으면 빈 문자열)
+   */
+  buildInstruction(filename) {
+    const pkg = this.packageOf(filename);
+    if (!pkg) {
+      return '';
+    }
+    const list = names => (names.length > MAX_LISTED
+      ? `${names.slice(0, MAX_LISTED).join(', ')} 외 ${names.length - MAX_LISTED}개`
+      : names.join(', '));
+    const dependents = this.dependentsOf(pkg.name);
+    const affectedDependents = dependents.filter(name => this.affected.has(name));
+
+    let instruction = '\n\n패키지 범위:\n';
+    instruction += `- 이 파일은 워크스페이스 패키지 \`${pkg.name}\` (${pkg.path}/)에 속합니다.\n`;
+    instruction += `- 이 패키지가 의존하는 패키지: ${pkg.dependencies.length > 0 ? list(pkg.dependencies) : '없음'}\n`;
+    instruction += `- 이 패키지에 의존하는 패키지: ${dependents.length > 0 ? list(dependents) : '없음'}\n`;
+    if (affectedDependents.length > 0) {
+      instruction += `- 이번 변경의 영향을 받는 의존 패키지: ${list(affectedDependents)}\n`;
+    }
+    instruction += '\n다른 패키지의 내부 파일을 상대 경로로 직접 참조하면 지적하고, 공개 API가 바뀌면 의존 패키지에 미칠 영향을 설명하세요.';
+    return instruction;
+  }
+
+  /**
+   * 영향을 받은 패키지별 판정
+   * @param {Array} reviewResults - 파일별 리뷰 결과
+   * @param {string} failSeverity - 이 심각도 이상의 이슈가 있으면 fail
+   * @returns {Array} [{ name, path, reason, via, files, issues, highestSeverity, verdict: pass|fail }] (이름순)
+   */
+  verdicts(reviewResults, failSeverity) {
+    return [...this.affected.values()]
+      .map(({ package: pkg, reason, files, via }) => {
+        const issues = reviewResults
+          .filter(result => {
+            const owner = this.packageOf(result.file);
+            return owner && owner.name === pkg.name;
+          })
+          .flatMap(result => result.issues);
+        const highest = issues.reduce((top, issue) =>
+          (!top || getSeverityLevel(issue.severity) > getSeverityLevel(top) ? issue.severity : top), null);
+        return {
+          name: pkg.name,
+          path: pkg.path,
+          reason,
+          via,
+          files: files.length,
+          issues: issues.length,
+          highestSeverity: highest,
+          verdict: highest && getSeverityLevel(highest) >= getSeverityLevel(failSeverity) ? 'fail' : 'pass'
+        };
+      })
+      .sort((a, b) => a.name.localeCompare(b.name));
+  }
+}
+
+module.exports = WorkspacePackages;
@@ -0,0 +1,239 @@
+#!/usr/bin/env node
+
+/**
+ * Benchmark Suite
+ * 큰 합성 PR 픽스처로 파일 목록 파싱, 프롬프트(컨텍스트) 조립, 지문 계산 속도를 측정하는 스크립트
+ *
+ * 결과는 test/fixtures/bench/baseline.json에 기록된 기준값과 비교해 출력하므로
+ * 성능 개선을 목적으로 한 변경이 실제로 빨라졌는지 숫자로 확인할 수 있습니다.
+ *
+ * 사용법:
+ *   npm run bench                                  # 측정 후 기준값과 비교
+ *   npm run bench -- --filter prompt               # 이름에 prompt가 들어간 벤치마크만 실행
+ *   npm run bench -- --save                        # 현재 결과를 기준값으로 저장
+ *   npm run bench -- --max-regression 25           # 기준값보다 25% 이상 느린 항목이 있으면 exit 1
+ *
+ * 옵션:
+ *   --files <n>            픽스처 파일 수 (기본 200)
+ *   --lines <n>            파일당 줄 수 (기본 2000)
+ *   --time <ms>            벤치마크당 측정 시간 (기본 1000)
+ *   --filter <text>        이름으로 벤치마크 선택
+ *   --save                 결과를 기준값으로 저장
+ *   --max-regression <%>   허용할 최대 성능 저하 비율
+ */
+
+const fs = require('fs');
+const path = require('path');
+const CodeReviewer = require('../src/code-reviewer');
+const FileAnalyzer = require('../src/file-analyzer');
+const { extractSnippet, fingerprintFinding } = require('../src/fingerprint');
+const { generateFiles, toPullFile, parseArgs: parseFixtureArgs } = require('./genfixtures');
+
+const BASELINE_FILE = path.join(__dirname, 'fixtures', 'bench', 'baseline.json');
+
+// 지문 벤치마크에서 파일당 가정하는 이슈 수 (max_issues_per_file 상한 수준)
+const ISSUES_PER_FILE = 10;
+
+// 벤치마크: 이름 → 픽스처를 받아 한 번 실행할 함수를 돌려주는 준비 함수
+const BENCHMARKS = {
+  // git diff --name-status 출력 파싱 (push 이벤트의 변경 파일 목록)
+  'diff/name-status': fixture => {
+    const analyzer = new FileAnalyzer({ filePatterns: '**/*', excludePatterns: '', maxFiles: 1000, githubToken: 'bench-token' });
+    return () => analyzer.parseDiffOutput(fixture.nameStatus);
+  },
+
+  // 파일 내용과 patch를 잘라 리뷰 프롬프트로 조립
+  'prompt/full': fixture => {
+    const reviewer = new CodeReviewer('bench-key', 'en', 5, {});
+    return () => fixture.files.forEach(file => reviewer.buildPrompt(file.filename, file.content, file.patch, 'full'));
+  },
+
+  // 설명 모드를 켠 보안 리뷰 프롬프트 조립 (한국어)
+  'prompt/explain': fixture => {
+    const reviewer = new CodeReviewer('bench-key', 'ko', 5, { explain: true });
+    return () => fixture.files.forEach(file => reviewer.buildPrompt(file.filename, file.content, file.patch, 'security'));
+  },
+
+  // 이슈 라인 주변 코드 발췌 + 지문 계산 (파일마다 이슈 ISSUES_PER_FILE개가 고르게 있다고 가정)
+  'fingerprint/snippet': fixture => () => fixture.files.forEach(file => {
+    issueLines(file).forEach(line => {
+      const issue = { title: 'Benchmark finding', type: 'bug', snippet: extractSnippet(file.content, line) };
+      fingerprintFinding(file.filename, issue);
+    });
+  }),
+
+  // 스니펫 없이 제목으로만 지문 계산
+  'fingerprint/title': fixture => () => fixture.files.forEach(file => {
+    issueLines(file).forEach(line => fingerprintFinding(file.filename, { title: `Finding at line ${line}`, type: 'style' }));
+  })
+};
+
+/**
+ * 파일 안에 고르게 분포한 이슈 라인 목록
+ * @param {Object} file - 픽스처 파일
+ * @returns {Array<number>} 라인 번호 목록
+ */
+function issueLines(file) {
+  const step = Math.max(1, Math.floor(file.lineCount / ISSUES_PER_FILE));
+  const lines = [];
+  for (let line = 1; line <= file.lineCount && lines.length < ISSUES_PER_FILE; line += step) {
+    lines.push(line);
+  }
+  return lines;
+}
+
+/**
+ * 명령행 옵션 파싱
+ * @param {Array<string>} args - 명령행 인자
+ * @returns {Object} 옵션
+ */
+function parseArgs(args) {
+  const options = { files: 200, lines: 2000, time: 1000, filter: '', save: false, maxRegression: null };
+  for (let i = 0; i < args.length; i++) {
+    const key = args[i];
+    if (key === '--save') {
+      options.save = true;
+    } else if (key === '--filter') {
+      options.filter = args[++i];
+    } else if (key === '--max-regression') {
+      options.maxRegression = Number(args[++i]);
+    } else if (['--files', '--lines', '--time'].includes(key)) {
+      options[key.slice(2)] = Number(args[++i]);
+    } else {
+      throw new Error(`Unknown option ${key}`);
+    }
+  }
+  return options;
+}
+
+/**
+ * 벤치마크용 픽스처 생성 (genfixtures와 같은 생성기, 디스크에 쓰지 않음)
+ * @param {Object} options - 옵션
+ * @returns {Object} { files, nameStatus }
+ */
+function buildFixture(options) {
+  const fixtureOptions = parseFixtureArgs(['--files', String(options.files), '--lines', String(options.lines), '--seed', '1']);
+  const files = generateFiles(fixtureOptions).map(file => ({
+    filename: file.filename,
+    content: `${file.lines.join('\n')}\n`,
+    lineCount: file.lines.length,
+    patch: toPullFile(file, 'bench').patch
+  }));
+  // 파일 수가 적으면 파싱 시간이 측정 오차에 묻히므로 이름 목록을 반복
+  const statuses = ['M', 'A', 'R100', 'D', 'C075'];
+  const nameStatus = [];
+  for (let round = 0; round < 25; round++) {
+    files.forEach((file, i) => {
+      const status = statuses[i % statuses.length];
+      nameStatus.push(status.length > 1 ? `${status}\told/${round}/${file.filename}\t${round}/${file.filename}` : `${status}\t${round}/${file.filename}`);
+    });
+  }
+  return { files, nameStatus: nameStatus.join('\n') };
+}
+
+/**
+ * 주어진 시간 동안 반복 실행해 한 번 실행에 걸리는 시간 측정
+ * @param {Function} fn - 측정할 함수
+ * @param {number} timeMs - 측정 시간
+ * @returns {Object} { runs, msPerOp }
+ */
+function measure(fn, timeMs) {
+  // JIT 최적화가 안정되도록 예열
+  const warmupEnd = Date.now() + Math.min(200, timeMs / 5);
+  while (Date.now() < warmupEnd) {
+    fn();
+  }
+
+  let runs = 0;
+  const start = process.hrtime.bigint();
+  const deadline = start + BigInt(timeMs) * 1000000n;
+  let now = start;
+  while (now < deadline) {
+    fn();
+    runs++;
+    now = process.hrtime.bigint();
+  }
+  return { runs, msPerOp: Number(now - start) / 1e6 / runs };
+}
+
+/**
+ * 기준값 대비 변화율 서식화
+ * @param {number} current - 현재 ms/op
+ * @param {number|undefined} baseline - 기준 ms/op
+ * @returns {string} 예: "+12.3% slower"
+ */
+function formatDelta(current, baseline) {
+  if (!baseline) {
+    return 'no baseline';
+  }
+  const change = ((current - baseline) / baseline) * 100;
+  if (Math.abs(change) < 0.05) {
+    return 'unchanged';
+  }
+  return `${change > 0 ? '+' : ''}${change.toFixed(1)}% ${change > 0 ? 'slower' : 'faster'}`;
+}
+
+function main(args) {
+  const options = parseArgs(args);
+  const names = Object.keys(BENCHMARKS).filter(name => name.includes(options.filter));
+  if (names.length === 0) {
+    console.log(`No benchmarks matched "${options.filter}". Available: ${Object.keys(BENCHMARKS).join(', ')}`);
+    return 1;
+  }
+
+  const baseline = fs.existsSync(BASELINE_FILE) ? JSON.parse(fs.readFileSync(BASELINE_FILE, 'utf8')) : null;
+  const comparable = baseline && baseline.fixture.files === options.files && baseline.fixture.lines === options.lines;
+  if (baseline && !comparable) {
+    console.log(`Baseline was recorded with --files ${baseline.fixture.files} --lines ${baseline.fixture.lines}; skipping comparison\n`);
+  }
+
+  const fixture = buildFixture(options);
+  const totalBytes = fixture.files.reduce((sum, file) => sum + file.content.length, 0);
+  console.log(`Fixture: ${fixture.files.length} files, ${(totalBytes / 1024 / 1024).toFixed(1)} MB (node ${process.version})\n`);
+
+  const results = {};
+  let regressions = 0;
+  names.forEach(name => {
+    const result = measure(BENCHMARKS[name](fixture), options.time);
+    results[name] = { msPerOp: Number(result.msPerOp.toFixed(4)) };
+
+    const previous = comparable ? baseline.results[name] : null;
+    const delta = formatDelta(result.msPerOp, previous && previous.msPerOp);
+    console.log(`${name.padEnd(22)} ${result.msPerOp.toFixed(3).padStart(10)} ms/op  ${String(result.runs).padStart(6)} runs  ${delta}`);
+
+    if (previous && options.maxRegression !== null &&
+      result.msPerOp > previous.msPerOp * (1 + options.maxRegression / 100)) {
+      regressions++;
+    }
+  });
+
+  if (options.save) {
+    // 일부만 실행했다면 나머지 기준값은 유지
+    const saved = {
+      node: process.version,
+      recordedAt: new Date().toISOString(),
+      fixture: { files: options.files, lines: options.lines },
+      results: { ...(comparable ? baseline.results : {}), ...results }
+    };
+    fs.mkdirSync(path.dirname(BASELINE_FILE), { recursive: true });
+    fs.writeFileSync(BASELINE_FILE, `${JSON.stringify(saved, null, 2)}\n`);
+    console.log(`\nSaved baseline to ${path.relative(process.cwd(), BASELINE_FILE)}`);
+  }
+
+  if (regressions > 0) {
+    console.log(`\n${regressions} benchmark(s) regressed by more than ${options.maxRegression}%`);
+    return 1;
+  }
+  return 0;
+}
+
+if (require.main === module) {
+  try {
+    process.exitCode = main(process.argv.slice(2));
+  } catch (error) {
+    console.error(error.message);
+    process.exitCode = 1;
+  }
+}
+
+module.exports = { BENCHMARKS, buildFixture, measure };
@@ -0,0 +1,300 @@
+#!/usr/bin/env node
+
+/**
+ * Parser Fuzz Test
+ * 신뢰할 수 없는 입력을 받는 파서에 변형된 입력을 대량으로 넣어 예외와 잘못된 결과를 찾는 스크립트
+ *
+ * 대상:
+ * - diff      FileAnalyzer.parseDiffOutput (포크 PR의 git diff --name-status 출력)
+ * - response  CodeReviewer.parseResponse (임의의 모델 출력 텍스트)
+ * - config    RepoConfig.parseYaml (PR에서 바꿀 수 있는 .claude-review.yml)
+ *
+ * 사용법:
+ *   npm run test:fuzz                                   # 모든 대상, 대상별 2000회
+ *   npm run test:fuzz -- --target response --iterations 50000 --seed 42
+ *
+ * 실패한 입력은 test/fixtures/fuzz/<대상>/에 저장되고, 이후 실행에서 먼저 재생되어 회귀를 막습니다.
+ */
+
+const fs = require('fs');
+const path = require('path');
+const crypto = require('crypto');
+const FileAnalyzer = require('../src/file-analyzer');
+const CodeReviewer = require('../src/code-reviewer');
+const RepoConfig = require('../src/repo-config');
+const { createRandom } = require('./random');
+
+const CORPUS_DIR = path.join(__dirname, 'fixtures', 'fuzz');
+
+// 입력 하나를 처리하는 데 허용하는 시간 (정규식 역추적 폭주 감지)
+const SLOW_INPUT_MS = 1000;
+
+// 변형에 끼워 넣을 토큰 (JSON/diff 구문 경계와 특수 문자)
+const TOKENS = [
+  '{', '}', '[', ']', '"', ':', ',', '\\', '\n', '\r\n', '\t', '```', '```json\n',
+  'null', 'true', '-1', '1e309', 'NaN', '"issues":', '"line":', '"severity":"critical"',
+  '"title":{}', '"issues":[null]', '"summary":[]', '\u0000', ' ', '한글', '🔴', '\uD800',
+  'M\t', 'A\t', 'D\t', 'R100\told\tnew', '\t\t', '../../etc/passwd'
+];
+
+const VALID_SEVERITIES = ['low', 'medium', 'high', 'critical'];
+const GIT_STATUSES = ['added', 'modified', 'renamed', 'copied', 'changed', 'updated'];
+
+/**
+ * 시드 입력 하나를 무작위로 변형
+ * @param {string} input - 원본 입력
+ * @param {Function} random - 난수 생성기
+ * @returns {string} 변형된 입력
+ */
+function mutate(input, random) {
+  let output = input;
+  const rounds = 1 + Math.floor(random() * 4);
+
+  for (let i = 0; i < rounds; i++) {
+    const position = Math.floor(random() * (output.length + 1));
+    const token = TOKENS[Math.floor(random() * TOKENS.length)];
+
+    switch (Math.floor(random() * 6)) {
+      case 0: // 토큰 삽입
+        output = output.slice(0, position) + token + output.slice(position);
+        break;
+      case 1: // 구간 삭제
+        output = output.slice(0, position) + output.slice(position + Math.floor(random() * 20));
+        break;
+      case 2: // 잘라내기 (모델 응답이 max_tokens에서 끊긴 상황)
+        output = output.slice(0, position);
+        break;
+      case 3: // 문자 교체
+        output = output.slice(0, position) + String.fromCharCode(Math.floor(random() * 0x3000)) + output.slice(position + 1);
+        break;
+      case 4: // 구간 반복
+        output = output.slice(0, position) + output.slice(position, position + 40).repeat(1 + Math.floor(random() * 50)) + output.slice(position);
+        break;
+      default: // 토큰으로 구간 교체
+        output = output.slice(0, position) + token + output.slice(position + token.length);
+    }
+  }
+
+  return output;
+}
+
+/**
+ * 결과 검증 (조건 위반 시 Error)
+ * @param {boolean} condition - 조건
+ * @param {string} message - 위반 메시지
+ */
+function check(condition, message) {
+  if (!condition) {
+    throw new Error(`invariant violated: ${message}`);
+  }
+}
+
+// 퍼징 대상: 시드 입력, 실행 함수, 결과 불변 조건
+const TARGETS = {
+  diff: {
+    seeds: [
+      'M\tsrc/index.js\nA\tsrc/new-file.ts\nD\tsrc/old.js\n',
+      'R100\tsrc/a.js\tsrc/b.js\nC75\tlib/x.go\tlib/y.go\n',
+      'M\tpath with spaces/파일.py\r\nT\tbin/tool\n\n',
+      ''
+    ],
+    run: input => FileAnalyzer.prototype.parseDiffOutput.call(FileAnalyzer.prototype, input),
+    verify: files => {
+      check(Array.isArray(files), 'result is not an array');
+      files.forEach(file => {
+        check(typeof file.filename === 'string' && file.filename.length > 0, 'empty filename');
+        check(GIT_STATUSES.includes(file.status), `unknown status ${file.status}`);
+      });
+    }
+  },
+
+  response: {
+    seeds: [
+      JSON.stringify({
+        summary: 'Adds caching',
+        issues: [
+          { line: 12, severity: 'high', type: 'bug', title: 'Race', description: 'Map written concurrently', suggestion: 'Use a mutex' },
+          { line: 40, severity: 'low', type: 'style', title: 'Naming', description: 'Unclear name', suggestion: 'Rename', code_example: 'const cache = new Map();' }
+        ],
+        overall_score: 7
+      }),
+      '```json\n{"summary":"ok","issues":[],"overall_score":9}\n```',
+      'Here is the review:\n{"summary":"partial","issues":[{"line":3,"severity":"critical","type":"security","title":"SQL injection","description":"Query built from input","suggestion":"Use parameters"},{"line":9,"sev',
+      '{summary: unquoted, issues: [], overall_score: 5,}',
+      '{"summary":"링크","issues":[{"line":1,"severity":"medium","type":"security","title":"XSS","description":"<script>","suggestion":"escape","why":"users","reference":"https://owasp.org/"}],"overall_score":4}'
+    ],
+    run: input => RESPONSE_PARSER.parseResponse(input),
+    verify: result => {
+      check(result && typeof result === 'object', 'result is not an object');
+      check(typeof result.summary === 'string', 'summary is not a string');
+      check(typeof result.overallScore === 'number' && Number.isFinite(result.overallScore), 'overallScore is not a finite number');
+      check(Array.isArray(result.issues), 'issues is not an array');
+      result.issues.forEach(issue => {
+        check(issue && typeof issue === 'object', 'issue is not an object');
+        check(typeof issue.title === 'string', 'issue title is not a string');
+        check(typeof issue.description === 'string', 'issue description is not a string');
+        check(typeof issue.suggestion === 'string', 'issue suggestion is not a string');
+        check(issue.line === null || Number.isInteger(issue.line), `issue line ${issue.line} is not an integer`);
+        check(VALID_SEVERITIES.includes(issue.severity), `issue severity ${issue.severity} is invalid`);
+        check(issue.codeExample === null || typeof issue.codeExample === 'string', 'issue codeExample is not a string');
+      });
+    }
+  },
+
+  config: {
+    seeds: [
+      'review_type: full\nseverity_filter: medium # 주석\nfile_patterns: ["src/**", \'lib/**\']\n',
+      'exclude_patterns:\n  - "**/dist/**"\n  - vendor/**\nlanguage:\n  alice: ja\n  default: ko\n',
+      'paths:\n  - path: "src/legacy/**"\n    severity_filter: high\n  - path: ["gen/**", "**/*.pb.go"]\n    skip: true\n',
+      'note: |\n  first # kept\n\n  second\nfolded: >-\n  a\n  b\n',
+      ''
+    ],
+    // 잘못된 YAML은 줄 번호가 있는 오류로 보고해야 하며, 그 밖의 예외는 실패
+    run: input => {
+      try {
+        return { value: RepoConfig.parseYaml(input) };
+      } catch (error) {
+        if (error.constructor === Error && /^line \d+: /.test(error.message)) {
+          return { error: error.message };
+        }
+        throw error;
+      }
+    },
+    verify: result => {
+      check(result && ('value' in result || 'error' in result), 'no value or error');
+      if ('value' in result) {
+        check(result.value === null || ['object', 'string', 'number', 'boolean'].includes(typeof result.value), `unexpected value type ${typeof result.value}`);
+      }
+    }
+  }
+};
+
+// parseResponse는 API 클라이언트를 사용하지 않으므로 가짜 키로 생성
+const RESPONSE_PARSER = new CodeReviewer('fuzz-test-key');
+
+/**
+ * 입력 하나 실행 및 검증
+ * @param {Object} target - 퍼징 대상
+ * @param {string} input - 입력
+ * @returns {string|null} 실패 사유 (성공 시 null)
+ */
+function execute(target, input) {
+  const startedAt = Date.now();
+  try {
+    target.verify(target.run(input));
+  } catch (error) {
+    // 불변 조건 위반은 메시지만, 예상치 못한 예외는 스택까지 출력
+    return error.message.startsWith('invariant violated') ? error.message : (error.stack || String(error));
+  }
+  const elapsed = Date.now() - startedAt;
+  return elapsed > SLOW_INPUT_MS ? `slow input: ${elapsed}ms` : null;
+}
+
+/**
+ * 실패 입력 저장
+ * @param {string} targetName - 대상 이름
+ * @param {string} input - 입력
+ * @returns {string} 저장 경로
+ */
+function saveCrasher(targetName, input) {
+  const dir = path.join(CORPUS_DIR, targetName);
+  fs.mkdirSync(dir, { recursive: true });
+  const file = path.join(dir, `crash-${crypto.createHash('sha256').update(input).digest('hex').substring(0, 16)}`);
+  fs.writeFileSync(file, input);
+  return file;
+}
+
+/**
+ * 저장된 실패 입력 목록
+ * @param {string} targetName - 대상 이름
+ * @returns {Array<{file: string, input: string}>} 입력 목록
+ */
+function loadCorpus(targetName) {
+  const dir = path.join(CORPUS_DIR, targetName);
+  if (!fs.existsSync(dir)) {
+    return [];
+  }
+  return fs.readdirSync(dir).sort().map(name => ({
+    file: path.join(dir, name),
+    input: fs.readFileSync(path.join(dir, name), 'utf8')
+  }));
+}
+
+/**
+ * 명령행 옵션 파싱
+ * @param {Array<string>} args - 명령행 인자
+ * @returns {Object} { target, iterations, seed }
+ */
+function parseArgs(args) {
+  const options = { target: null, iterations: 2000, seed: Date.now() % 2147483647 };
+  for (let i = 0; i < args.length; i++) {
+    if (args[i] === '--target') options.target = args[++i];
+    else if (args[i] === '--iterations') options.iterations = parseInt(args[++i], 10);
+    else if (args[i] === '--seed') options.seed = parseInt(args[++i], 10);
+  }
+  return options;
+}
+
+function main(args) {
+  const options = parseArgs(args);
+  const names = options.target ? [options.target] : Object.keys(TARGETS);
+  if (names.some(name => !TARGETS[name])) {
+    console.log(`Unknown target. Available: ${Object.keys(TARGETS).join(', ')}`);
+    return 1;
+  }
+
+  // 파서의 디버그 로그가 출력을 덮지 않도록 실행 중에는 콘솔 억제
+  const originalConsole = { log: console.log, error: console.error, warn: console.warn };
+  const print = originalConsole.log;
+  const silence = () => { console.log = console.error = console.warn = () => {}; };
+  const restore = () => Object.assign(console, originalConsole);
+
+  print(`seed ${options.seed}, ${options.iterations} iterations per target`);
+  let failures = 0;
+
+  names.forEach(name => {
+    const target = TARGETS[name];
+    const random = createRandom(options.seed);
+
+    // 1. 저장된 실패 입력 재생 (회귀 확인)
+    loadCorpus(name).forEach(({ file, input }) => {
+      silence();
+      const failure = execute(target, input);
+      restore();
+      if (failure) {
+        failures++;
+        print(`FAIL     ${name} ${path.relative(process.cwd(), file)}\n${failure}`);
+      }
+    });
+
+    // 2. 시드 변형 입력 실행 (새 실패는 첫 번째 것만 저장)
+    const inputs = [...target.seeds];
+    for (let i = 0; i < options.iterations; i++) {
+      inputs.push(mutate(target.seeds[Math.floor(random() * target.seeds.length)], random));
+    }
+
+    for (const input of inputs) {
+      silence();
+      const failure = execute(target, input);
+      restore();
+      if (failure) {
+        failures++;
+        print(`FAIL     ${name} saved ${path.relative(process.cwd(), saveCrasher(name, input))}\n${failure}`);
+        return;
+      }
+    }
+    print(`ok       ${name}`);
+  });
+
+  if (failures > 0) {
+    print(`\n${failures} failing input(s). Reproduce with --seed ${options.seed}; fix the parser and keep the saved input as a regression case.`);
+    return 1;
+  }
+  return 0;
+}
+
+if (require.main === module) {
+  process.exitCode = main(process.argv.slice(2));
+}
+
+module.exports = { mutate, TARGETS };
@@ -0,0 +1,316 @@
+#!/usr/bin/env node
+
+/**
+ * Synthetic PR Fixture Generator
+ * 크기, 언어 구성, 이슈 밀도를 지정해 재현 가능한 PR 입력을 만들어내는 스크립트
+ *
+ * 손으로 관리하는 샘플 대신 같은 시드로 언제든 같은 입력을 다시 만들 수 있어
+ * 성능 측정과 정확도 테스트에 사용합니다.
+ *
+ * 사용법:
+ *   npm run genfixtures -- --out /tmp/pr-fixture
+ *   npm run genfixtures -- --files 40 --lines 300 --languages js:3,py:1,go:1 --density 2 --seed 7
+ *
+ * 옵션:
+ *   --out <dir>          출력 디렉터리 (기본 test/fixtures/generated)
+ *   --files <n>          변경 파일 수 (기본 10)
+ *   --lines <n>          파일당 줄 수 (기본 120)
+ *   --languages <mix>    언어별 비중, 예: js:3,ts:1,py:1 (기본 js:1,ts:1,py:1,go:1,java:1,rs:1)
+ *   --density <n>        100줄당 심어 둘 이슈 수 (기본 1)
+ *   --seed <n>           시드 (기본 1)
+ *
+ * 출력:
+ *   event.json               pull_request 이벤트 페이로드 (GITHUB_EVENT_PATH로 사용)
+ *   repo/                    PR head 시점의 파일 (체크아웃된 작업 디렉터리 대용)
+ *   pulls-files.json         pulls.listFiles 응답 (patch 포함)
+ *   claude-responses.json    심어 둔 이슈를 보고하는 모의 Claude 응답 (mock-claude-server의 /__mock/enqueue 본문)
+ *   manifest.json            생성 옵션과 파일별로 심어 둔 이슈 (정답 데이터)
+ */
+
+const fs = require('fs');
+const path = require('path');
+const crypto = require('crypto');
+const { createRandom } = require('./random');
+
+const DEFAULT_OUT = path.join(__dirname, 'fixtures', 'generated');
+
+// 언어별 확장자, 디렉터리, 정상 코드 조각, 심어 둘 이슈 (at: 조각 안에서 이슈가 있는 줄, 기본 1)
+const LANGUAGES = {
+  js: {
+    dir: 'src',
+    ext: 'js',
+    filler: (name, i) => [`function ${name}${i}(items) {`, `  return items.filter(item => item.id !== ${i}).map(item => item.value);`, '}', ''],
+    issues: [
+      { type: 'security', severity: 'critical', title: 'Dynamic code execution', code: ['function run(input) {', '  return eval(input);', '}', ''] },
+      { type: 'security', severity: 'high', title: 'SQL built from input', code: ['function findUser(db, id) {', '  return db.query(`SELECT * FROM users WHERE id = ${id}`);', '}', ''] },
+      { type: 'bug', severity: 'medium', title: 'Swallowed error', code: ['async function load(fetcher) {', '  try { return await fetcher(); } catch (e) {}', '}', ''] }
+    ]
+  },
+  ts: {
+    dir: 'web',
+    ext: 'ts',
+    filler: (name, i) => [`export function ${name}${i}(values: number[]): number {`, `  return values.reduce((sum, value) => sum + value * ${i}, 0);`, '}', ''],
+    issues: [
+      { type: 'security', severity: 'high', title: 'Unsanitized HTML', code: ['export function render(el: HTMLElement, html: string): void {', '  el.innerHTML = html;', '}', ''] },
+      { type: 'maintainability', severity: 'low', title: 'Explicit any', at: 0, code: ['export function parse(raw: any): any {', '  return JSON.parse(raw);', '}', ''] }
+    ]
+  },
+  py: {
+    dir: 'app',
+    ext: 'py',
+    filler: (name, i) => [`def ${name}_${i}(items):`, `    return [item for item in items if item != ${i}]`, '', ''],
+    issues: [
+      { type: 'security', severity: 'critical', title: 'Shell command injection', code: ['def archive(name):', '    os.system("tar czf backup.tgz " + name)', '', ''] },
+      { type: 'bug', severity: 'medium', title: 'Mutable default argument', at: 0, code: ['def append(item, bucket=[]):', '    bucket.append(item)', '    return bucket', ''] }
+    ]
+  },
+  go: {
+    dir: 'internal',
+    ext: 'go',
+    filler: (name, i) => [`func ${name}${i}(values []int) int {`, `\treturn len(values) + ${i}`, '}', ''],
+    issues: [
+      { type: 'bug', severity: 'high', title: 'Ignored error', code: ['func readConfig(path string) []byte {', '\tdata, _ := os.ReadFile(path)', '\treturn data', '}', ''] },
+      { type: 'performance', severity: 'medium', title: 'String concatenation in loop', at: 2, code: ['func join(parts []string) string {', '\tout := ""', '\tfor _, p := range parts { out += p }', '\treturn out', '}', ''] }
+    ]
+  },
+  java: {
+    dir: 'service',
+    ext: 'java',
+    filler: (name, i) => [`    static int ${name}${i}(int value) {`, `        return value * ${i};`, '    }', ''],
+    issues: [
+      { type: 'security', severity: 'high', title: 'SQL built from input', code: ['    static String query(String id) {', '        return "SELECT * FROM orders WHERE id = " + id;', '    }', ''] },
+      { type: 'performance', severity: 'low', title: 'Boxed arithmetic', code: ['    static Long total(Long a, Long b) {', '        return a + b;', '    }', ''] }
+    ]
+  },
+  rs: {
+    dir: 'crates/core/src',
+    ext: 'rs',
+    filler: (name, i) => [`pub fn ${name}_${i}(values: &[u64]) -> u64 {`, `    values.iter().sum::<u64>() + ${i}`, '}', ''],
+    issues: [
+      { type: 'bug', severity: 'high', title: 'Unwrap on user input', code: ['pub fn port(raw: &str) -> u16 {', '    raw.parse().unwrap()', '}', ''] },
+      { type: 'security', severity: 'medium', title: 'Unchecked unsafe block', code: ['pub fn first(ptr: *const u8) -> u8 {', '    unsafe { *ptr }', '}', ''] }
+    ]
+  }
+};
+
+const NAMES = ['compute', 'collect', 'resolve', 'transform', 'validate', 'merge'];
+
+/**
+ * 명령행 옵션 파싱
+ * @param {Array<string>} args - 명령행 인자
+ * @returns {Object} 옵션
+ */
+function parseArgs(args) {
+  const options = { out: DEFAULT_OUT, files: 10, lines: 120, languages: 'js:1,ts:1,py:1,go:1,java:1,rs:1', density: 1, seed: 1 };
+  for (let i = 0; i < args.length; i += 2) {
+    const key = args[i].replace(/^--/, '');
+    if (!(key in options)) {
+      throw new Error(`Unknown option ${args[i]}`);
+    }
+    options[key] = typeof options[key] === 'number' ? Number(args[i + 1]) : args[i + 1];
+  }
+
+  options.languages = options.languages.split(',').map(entry => {
+    const [language, weight = '1'] = entry.split(':');
+    if (!LANGUAGES[language]) {
+      throw new Error(`Unknown language "${language}". Available: ${Object.keys(LANGUAGES).join(', ')}`);
+    }
+    return { language, weight: Number(weight) };
+  });
+  return options;
+}
+
+/**
+ * 비중에 따라 언어 선택
+ * @param {Array} mix - { language, weight } 목록
+ * @param {Function} random - 난수 생성기
+ * @returns {string} 언어
+ */
+function pickLanguage(mix, random) {
+  const total = mix.reduce((sum, entry) => sum + entry.weight, 0);
+  let roll = random() * total;
+  for (const entry of mix) {
+    roll -= entry.weight;
+    if (roll < 0) {
+      return entry.language;
+    }
+  }
+  return mix[mix.length - 1].language;
+}
+
+/**
+ * 파일 하나 생성 (정상 코드 사이에 이슈 조각을 밀도만큼 삽입)
+ * @param {string} language - 언어
+ * @param {number} index - 파일 번호
+ * @param {Object} options - 옵션
+ * @param {Function} random - 난수 생성기
+ * @returns {Object} { filename, lines, issues, changedFrom }
+ */
+function generateFile(language, index, options, random) {
+  const spec = LANGUAGES[language];
+  const name = NAMES[index % NAMES.length];
+  const lines = language === 'java' ? [`public class Generated${index} {`, ''] : [];
+  // 조각은 약 4줄이므로 파일에 들어갈 수 있는 조각 수를 넘지 않도록 제한
+  const slotCount = Math.max(1, Math.floor(options.lines / 4));
+  const issueCount = Math.min(slotCount, Math.round((options.lines / 100) * options.density * (0.5 + random())));
+  const issueSlots = new Set();
+  while (issueSlots.size < issueCount) {
+    issueSlots.add(Math.floor(random() * slotCount));
+  }
+
+  const issues = [];
+  for (let block = 0; lines.length < options.lines; block++) {
+    if (issueSlots.has(block)) {
+      const issue = spec.issues[Math.floor(random() * spec.issues.length)];
+      // 이슈 라인은 1부터 시작하는 번호
+      issues.push({ line: lines.length + (issue.at === undefined ? 1 : issue.at) + 1, type: issue.type, severity: issue.severity, title: issue.title });
+      lines.push(...issue.code);
+    } else {
+      lines.push(...spec.filler(name, block));
+    }
+  }
+  if (language === 'java') {
+    lines.push('}');
+  }
+
+  return {
+    filename: `${spec.dir}/generated_${index}.${spec.ext}`,
+    lines,
+    issues,
+    // 파일 뒤쪽 절반을 이번 PR에서 추가된 것으로 취급
+    changedFrom: Math.floor(lines.length / 2)
+  };
+}
+
+/**
+ * pulls.listFiles 응답 항목 생성
+ * @param {Object} file - generateFile() 결과
+ * @param {string} headSha - head 커밋 SHA
+ * @returns {Object} 파일 항목
+ */
+function toPullFile(file, headSha) {
+  const added = file.lines.slice(file.changedFrom);
+  const patch = [`@@ -${file.changedFrom},0 +${file.changedFrom + 1},${added.length} @@`, ...added.map(line => `+${line}`)].join('\n');
+  return {
+    sha: crypto.createHash('sha1').update(file.lines.join('\n')).digest('hex'),
+    filename: file.filename,
+    status: 'modified',
+    additions: added.length,
+    deletions: 0,
+    changes: added.length,
+    blob_url: `https://github.com/octo-org/generated/blob/${headSha}/${file.filename}`,
+    raw_url: `https://github.com/octo-org/generated/raw/${headSha}/${file.filename}`,
+    contents_url: `https://api.github.com/repos/octo-org/generated/contents/${file.filename}?ref=${headSha}`,
+    patch
+  };
+}
+
+/**
+ * 심어 둔 이슈를 보고하는 모의 모델 응답 생성
+ * @param {Object} file - generateFile() 결과
+ * @returns {Object} mock-claude-server 예약 항목
+ */
+function toClaudeResponse(file) {
+  return {
+    text: JSON.stringify({
+      summary: `Generated review for ${file.filename}`,
+      issues: file.issues.map(issue => ({
+        ...issue,
+        description: `Synthetic ${issue.type} finding`,
+        suggestion: 'Fix the seeded issue'
+      })),
+      overall_score: Math.max(1, 9 - file.issues.length)
+    })
+  };
+}
+
+/**
+ * pull_request 이벤트 페이로드 생성
+ * @param {Object} options - 옵션
+ * @param {Array} files - 생성된 파일 목록
+ * @param {string} headSha - head 커밋 SHA
+ * @returns {Object} 이벤트 페이로드
+ */
+function buildEvent(options, files, headSha) {
+  const additions = files.reduce((sum, file) => sum + file.lines.length - file.changedFrom, 0);
+  return {
+    action: 'synchronize',
+    number: 1000 + options.seed,
+    pull_request: {
+      number: 1000 + options.seed,
+      title: `Generated PR (${files.length} files, seed ${options.seed})`,
+      body: 'Synthetic pull request produced by test/genfixtures.js',
+      html_url: `https://github.com/octo-org/generated/pull/${1000 + options.seed}`,
+      user: { login: 'fixture-bot' },
+      draft: false,
+      additions,
+      deletions: 0,
+      changed_files: files.length,
+      head: { ref: `fixtures/seed-${options.seed}`, sha: headSha },
+      base: { ref: 'main', sha: crypto.createHash('sha1').update(`base-${options.seed}`).digest('hex') }
+    },
+    repository: {
+      name: 'generated',
+      full_name: 'octo-org/generated',
+      owner: { login: 'octo-org' },
+      default_branch: 'main'
+    },
+    sender: { login: 'fixture-bot' }
+  };
+}
+
+/**
+ * 파일 목록 생성 (디스크에 쓰지 않음)
+ * @param {Object} options - parseArgs() 결과
+ * @returns {Array} generateFile() 결과 목록
+ */
+function generateFiles(options) {
+  const random = createRandom(options.seed);
+  const files = [];
+  for (let i = 0; i < options.files; i++) {
+    files.push(generateFile(pickLanguage(options.languages, random), i, options, random));
+  }
+  return files;
+}
+
+/**
+ * 픽스처 생성
+ * @param {Object} options - parseArgs() 결과
+ * @returns {Object} manifest
+ */
+function generate(options) {
+  const headSha = crypto.createHash('sha1').update(`head-${options.seed}`).digest('hex');
+  const files = generateFiles(options);
+
+  fs.rmSync(options.out, { recursive: true, force: true });
+  files.forEach(file => {
+    const target = path.join(options.out, 'repo', file.filename);
+    fs.mkdirSync(path.dirname(target), { recursive: true });
+    fs.writeFileSync(target, `${file.lines.join('\n')}\n`);
+  });
+
+  const write = (name, data) => fs.writeFileSync(path.join(options.out, name), `${JSON.stringify(data, null, 2)}\n`);
+  const manifest = {
+    options: { ...options, out: undefined },
+    totalIssues: files.reduce((sum, file) => sum + file.issues.length, 0),
+    files: files.map(file => ({ filename: file.filename, lines: file.lines.length, issues: file.issues }))
+  };
+  write('event.json', buildEvent(options, files, headSha));
+  write('pulls-files.json', files.map(file => toPullFile(file, headSha)));
+  write('claude-responses.json', files.map(toClaudeResponse));
+  write('manifest.json', manifest);
+  return manifest;
+}
+
+if (require.main === module) {
+  try {
+    const options = parseArgs(process.argv.slice(2));
+    const manifest = generate(options);
+    console.log(`Generated ${manifest.files.length} files with ${manifest.totalIssues} seeded issues in ${path.relative(process.cwd(), options.out) || '.'}`);
+  } catch (error) {
+    console.error(error.message);
+    process.exitCode = 1;
+  }
+}
+
+module.exports = { generate, generateFiles, toPullFile, parseArgs, LANGUAGES };
@@ -0,0 +1,304 @@
+#!/usr/bin/env node
+
+/**
+ * GitHub Cassette Contract Test
+ * 녹화된 GitHub API 응답(카세트)을 재생해 GitHub 클라이언트 코드를 실제 API 없이 검증하는 스크립트
+ *
+ * @actions/github는 GITHUB_API_URL 환경 변수를 기본 URL로 사용하므로,
+ * 로컬 재생 서버를 띄우고 그 주소를 GITHUB_API_URL로 지정한 뒤 액션 모듈을 그대로 실행합니다.
+ *
+ * 사용법:
+ *   npm run test:github                                   # 카세트 재생 (CI용)
+ *   npm run test:github -- pull-files-pagination          # 특정 시나리오만 실행
+ *   GITHUB_TOKEN=... npm run test:github -- --record <시나리오>   # 실제 API 응답으로 카세트 다시 녹화
+ *
+ * 카세트 형식 (test/cassettes/<시나리오>.json):
+ *   { "interactions": [ { "request": { method, path, body? }, "response": { status, headers, body } } ] }
+ * - 요청은 순서대로 method + path(쿼리 포함)로 매칭하고, 카세트에 body가 있으면 JSON 본문도 비교합니다
+ *   (카세트 body에 적힌 키만 비교하므로 시각처럼 실행마다 달라지는 값은 생략할 수 있습니다)
+ * - GraphQL 요청(POST /graphql)도 본문의 query, variables로 같은 방식으로 매칭됩니다
+ *   (액션이 GitHub에 보내는 GraphQL 요청은 리뷰어 추천의 blame 조회뿐이고 mutation은 없으므로,
+ *   reviewer-blame-graphql 시나리오가 GraphQL 경로 전체를 다룹니다. mutation을 추가하면 시나리오도 함께 추가하세요)
+ * - 응답 헤더의 {{baseUrl}}은 재생 서버 주소로 바뀝니다 (페이지네이션 link 헤더용)
+ */
+
+const fs = require('fs');
+const http = require('http');
+const path = require('path');
+const assert = require('assert');
+
+const CASSETTES_DIR = path.join(__dirname, 'cassettes');
+const LIVE_API_URL = 'https://api.github.com';
+
+// 녹화 시 보존할 응답 헤더 (토큰, 쿠키 등은 저장하지 않음)
+const RECORDED_HEADERS = ['content-type', 'link', 'retry-after', 'x-ratelimit-limit', 'x-ratelimit-remaining', 'x-ratelimit-reset', 'x-ratelimit-resource'];
+
+const CONTEXT = {
+  repo: { owner: 'octo-org', repo: 'widgets' },
+  payload: { pull_request: { number: 42 } }
+};
+
+class CassetteServer {
+  /**
+   * CassetteServer 생성자
+   * @param {string} name - 시나리오 이름
+   * @param {Object} [options] - 옵션
+   * @param {boolean} [options.record] - true면 실제 API로 전달하고 응답을 녹화
+   * @param {string} [options.token] - 녹화 시 사용할 GitHub 토큰
+   */
+  constructor(name, options = {}) {
+    this.file = path.join(CASSETTES_DIR, `${name}.json`);
+    this.record = Boolean(options.record);
+    this.token = options.token || null;
+    this.interactions = this.record ? [] : JSON.parse(fs.readFileSync(this.file, 'utf8')).interactions;
+    this.position = 0;
+    this.errors = [];
+    this.baseUrl = null;
+  }
+
+  /**
+   * 재생 서버 시작
+   * @returns {Promise<string>} 기본 URL
+   */
+  listen() {
+    this.server = http.createServer((req, res) => {
+      this.handle(req, res).catch(error => {
+        this.errors.push(error.message);
+        res.writeHead(500, { 'content-type': 'application/json' });
+        res.end(JSON.stringify({ message: error.message }));
+      });
+    });
+    return new Promise(resolve => {
+      this.server.listen(0, '127.0.0.1', () => {
+        this.baseUrl = `http://127.0.0.1:${this.server.address().port}`;
+        resolve(this.baseUrl);
+      });
+    });
+  }
+
+  /**
+   * 요청 하나 처리 (재생 또는 녹화)
+   * @param {http.IncomingMessage} req - 요청
+   * @param {http.ServerResponse} res - 응답
+   */
+  async handle(req, res) {
+    const chunks = [];
+    for await (const chunk of req) {
+      chunks.push(chunk);
+    }
+    const rawBody = Buffer.concat(chunks).toString('utf8');
+    const body = rawBody ? JSON.parse(rawBody) : undefined;
+
+    const response = this.record
+      ? await this.forward(req.method, req.url, body)
+      : this.replay(req.method, req.url, body);
+
+    const headers = {};
+    for (const [name, value] of Object.entries(response.headers || {})) {
+      headers[name] = String(value).split('{{baseUrl}}').join(this.baseUrl);
+    }
+    res.writeHead(response.status, { 'content-type': 'application/json; charset=utf-8', ...headers });
+    res.end(response.body === undefined ? '' : JSON.stringify(response.body));
+  }
+
+  /**
+   * 카세트의 다음 상호작용과 비교해 응답 반환
+   * @param {string} method - HTTP 메서드
+   * @param {string} url - 경로와 쿼리
+   * @param {Object} [body] - 요청 본문
+   * @returns {Object} 응답
+   */
+  replay(method, url, body) {
+    const expected = this.interactions[this.position];
+    if (!expected) {
+      throw new Error(`Unexpected request ${method} ${url}: cassette has only ${this.interactions.length} interactions`);
+    }
+    if (expected.request.method !== method || expected.request.path !== url) {
+      throw new Error(`Request #${this.position + 1} mismatch: expected ${expected.request.method} ${expected.request.path}, got ${method} ${url}`);
+    }
+    if (expected.request.body !== undefined) {
+      if (!matchesSubset(body, expected.request.body)) {
+        throw new Error(`Request #${this.position + 1} body mismatch for ${method} ${url}:\n  expected ${JSON.stringify(expected.request.body)}\n  got      ${JSON.stringify(body)}`);
+      }
+    }
+    this.position++;
+    return expected.response;
+  }
+
+  /**
+   * 실제 API로 전달하고 상호작용 녹화
+   * @param {string} method - HTTP 메서드
+   * @param {string} url - 경로와 쿼리
+   * @param {Object} [body] - 요청 본문
+   * @returns {Promise<Object>} 응답
+   */
+  async forward(method, url, body) {
+    const response = await fetch(`${LIVE_API_URL}${url}`, {
+      method,
+      headers: {
+        accept: 'application/vnd.github+json',
+        authorization: `Bearer ${this.token}`,
+        'content-type': 'application/json',
+        'user-agent': 'claude-code-review-action-cassettes'
+      },
+      body: body === undefined ? undefined : JSON.stringify(body)
+    });
+
+    const headers = {};
+    RECORDED_HEADERS.forEach(name => {
+      if (response.headers.has(name)) {
+        headers[name] = response.headers.get(name).split(LIVE_API_URL).join('{{baseUrl}}');
+      }
+    });
+    const text = await response.text();
+    const recorded = { status: response.status, headers, body: text ? JSON.parse(text) : undefined };
+
+    this.interactions.push({ request: { method, path: url, body }, response: recorded });
+    return recorded;
+  }
+
+  /**
+   * 서버 종료 (녹화 모드면 카세트 저장, 재생 모드면 사용하지 않은 상호작용 확인)
+   * @returns {Promise<void>}
+   */
+  async close() {
+    await new Promise(resolve => this.server.close(() => resolve()));
+    if (this.record) {
+      fs.writeFileSync(this.file, `${JSON.stringify({ interactions: this.interactions }, null, 2)}\n`);
+    } else if (this.position < this.interactions.length) {
+      this.errors.push(`${this.interactions.length - this.position} recorded interaction(s) were never requested`);
+    }
+  }
+}
+
+/**
+ * 실제 값이 기대값의 모든 키를 같은 값으로 포함하는지 확인 (객체는 재귀, 배열과 원시값은 완전 일치)
+ * @param {*} actual - 실제 값
+ * @param {*} expected - 기대 값
+ * @returns {boolean} 일치 여부
+ */
+function matchesSubset(actual, expected) {
+  if (expected && typeof expected === 'object' && !Array.isArray(expected)) {
+    return Boolean(actual) && typeof actual === 'object' &&
+      Object.keys(expected).every(key => matchesSubset(actual[key], expected[key]));
+  }
+  return JSON.stringify(actual) === JSON.stringify(expected);
+}
+
+// 시나리오: 카세트 이름 → 액션 모듈을 실행하고 결과를 검증하는 함수
+const SCENARIOS = {
+  // PR 파일 목록 페이지네이션 (100개 초과 PR에서 뒤 페이지가 누락되지 않는지)
+  'pull-files-pagination': async () => {
+    const FileAnalyzer = require('../src/file-analyzer');
+    const analyzer = new FileAnalyzer({ filePatterns: '**/*.js', excludePatterns: '', maxFiles: 10, githubToken: 'cassette-token' });
+    const files = await analyzer.getPullRequestFiles(CONTEXT);
+    assert.deepStrictEqual(files.map(file => file.filename), ['src/app.js', 'src/util.js', 'src/late-page.js']);
+  },
+
+  // 리뷰 댓글이 두 번째 페이지에 있을 때 이슈 블록 메모 추가
+  'annotate-finding-pagination': async () => {
+    const CommentManager = require('../src/comment-manager');
+    const manager = new CommentManager('cassette-token', CONTEXT);
+    const updated = await manager.annotateFinding(42, 'abc123', '🔕 Dismissed by @octocat');
+    assert.strictEqual(updated, 1);
+  },
+
+  // 억제 파일이 없을 때 빈 저장소로 시작하고 새 파일로 커밋
+  'suppressions-create': async () => {
+    const SuppressionStore = require('../src/suppression-store');
+    const location = { owner: 'octo-org', repo: 'widgets', branch: 'main' };
+    const store = await SuppressionStore.loadFromRepo(github().getOctokit('cassette-token'), location);
+    assert.strictEqual(store.suppressions.length, 0);
+    assert.strictEqual(store.sha, null);
+
+    store.upsert({ fingerprint: 'abc123', status: 'dismissed', by: 'octocat', at: '2026-01-01T00:00:00.000Z' });
+    await store.saveToRepo(github().getOctokit('cassette-token'), location, 'Dismiss finding abc123');
+    assert.strictEqual(store.sha, '3d21ec53a331a6f037a91c368710b99387d012c1');
+  },
+
+  // GraphQL blame 조회: PR base 커밋 기준 줄 범위별 마지막 수정자 (GitHub 계정이 없는 작성자는 제외)
+  'reviewer-blame-graphql': async () => {
+    const ReviewerSuggester = require('../src/reviewer-suggester');
+    const context = {
+      ...CONTEXT,
+      payload: { pull_request: { number: 42, base: { sha: '9f2c1e4b7a8d3f6e5c0b1a2d3e4f5a6b7c8d9e0f' }, user: { login: 'author' } } }
+    };
+    const suggester = new ReviewerSuggester({ octokit: github().getOctokit('cassette-token'), context, routes: [], codeowners: null });
+    const ranges = await suggester.blame('src/auth.js');
+    assert.deepStrictEqual(ranges, [
+      { start: 1, end: 12, login: 'octocat' },
+      { start: 21, end: 48, login: 'hubot' }
+    ]);
+  },
+
+  // 보조 속도 제한(403)에 걸리면 댓글 작성 오류로 드러나는지
+  'rate-limited-comment': async () => {
+    const CommentManager = require('../src/comment-manager');
+    const manager = new CommentManager('cassette-token', CONTEXT);
+    await assert.rejects(
+      manager.postPullRequestComment('## review'),
+      error => error.message.startsWith('Failed to post PR comment') && error.message.includes('rate limit')
+    );
+  }
+};
+
+/**
+ * @actions/github 지연 로드 (GITHUB_API_URL 설정 후 로드해야 재생 서버를 사용)
+ * @returns {Object} @actions/github 모듈
+ */
+function github() {
+  return require('@actions/github');
+}
+
+async function main(args) {
+  const record = args.includes('--record');
+  const only = args.filter(arg => !arg.startsWith('-'));
+  const names = Object.keys(SCENARIOS).filter(name => only.length === 0 || only.includes(name));
+
+  if (record && (!process.env.GITHUB_TOKEN || only.length !== 1)) {
+    console.log('Recording needs GITHUB_TOKEN and exactly one scenario name');
+    return 1;
+  }
+
+  let failed = 0;
+  for (const name of names) {
+    const server = new CassetteServer(name, { record, token: process.env.GITHUB_TOKEN });
+    // @actions/github는 로드 시점의 GITHUB_API_URL을 기본 URL로 고정하므로
+    // 시나리오마다 캐시를 비워 새 재생 서버 주소로 다시 로드
+    process.env.GITHUB_API_URL = await server.listen();
+    Object.keys(require.cache).forEach(key => delete require.cache[key]);
+
+    let failure = null;
+    try {
+      await SCENARIOS[name]();
+    } catch (error) {
+      failure = error.message;
+    }
+    await server.close();
+
+    const problems = [failure, ...server.errors].filter(Boolean);
+    if (problems.length === 0) {
+      console.log(`${record ? 'recorded' : 'ok      '} ${name}`);
+    } else {
+      failed++;
+      console.log(`FAIL     ${name}\n${problems.map(problem => `    ${problem.split('\n').join('\n    ')}`).join('\n')}`);
+    }
+  }
+
+  if (failed > 0) {
+    console.log(`\n${failed} of ${names.length} GitHub contract scenario(s) failed`);
+    return 1;
+  }
+  return 0;
+}
+
+if (require.main === module) {
+  main(process.argv.slice(2)).then(code => {
+    process.exitCode = code;
+  }, error => {
+    console.log(error.stack);
+    process.exitCode = 1;
+  });
+}
+
+module.exports = CassetteServer;
@@ -0,0 +1,53 @@
+/**
+ * Golden File Helper
+ * 렌더링 결과를 저장된 기대값 파일과 비교하는 공통 함수 (prompt-golden, render-snapshots에서 사용)
+ */
+
+const fs = require('fs');
+
+/**
+ * 첫 번째로 다른 줄 설명
+ * @param {string} expected - 기대값
+ * @param {string} actual - 실제값
+ * @returns {string} 차이 설명
+ */
+function describeDifference(expected, actual) {
+  const expectedLines = expected.split('\n');
+  const actualLines = actual.split('\n');
+  const length = Math.max(expectedLines.length, actualLines.length);
+
+  for (let i = 0; i < length; i++) {
+    if (expectedLines[i] !== actualLines[i]) {
+      return [
+        `    first difference at line ${i + 1}:`,
+        `    - ${expectedLines[i] === undefined ? '(end of file)' : expectedLines[i]}`,
+        `    + ${actualLines[i] === undefined ? '(end of file)' : actualLines[i]}`
+      ].join('\n');
+    }
+  }
+  return '    (no line difference)';
+}
+
+/**
+ * 기대값 파일과 비교하거나 갱신
+ * @param {string} goldenPath - 기대값 파일 경로
+ * @param {string} actual - 실제 렌더링 결과
+ * @param {boolean} update - true면 기대값 파일을 실제값으로 갱신
+ * @returns {{status: string, detail?: string}} status: updated | ok | missing | differs
+ */
+function compareGolden(goldenPath, actual, update) {
+  if (update) {
+    fs.writeFileSync(goldenPath, actual);
+    return { status: 'updated' };
+  }
+  if (!fs.existsSync(goldenPath)) {
+    return { status: 'missing' };
+  }
+
+  const expected = fs.readFileSync(goldenPath, 'utf8');
+  return expected === actual
+    ? { status: 'ok' }
+    : { status: 'differs', detail: describeDifference(expected, actual) };
+}
+
+module.exports = { describeDifference, compareGolden };
@@ -0,0 +1,311 @@
+#!/usr/bin/env node
+
+/**
+ * Mock Claude Server
+ * 실제 API 키와 비용 없이 전체 리뷰 파이프라인을 실행하기 위한 Anthropic API 모의 서버
+ *
+ * 액션이 사용하는 Messages API 범위만 구현합니다.
+ * - POST /v1/messages            일반 응답 및 stream: true 일 때 SSE 스트리밍
+ * - GET  /v1/models              API 키 확인용 모델 목록 (doctor 점검)
+ * - POST /__mock/enqueue         다음 요청들에 돌려줄 응답/오류 예약
+ * - GET  /__mock/requests        지금까지 받은 요청 목록
+ * - POST /__mock/reset           예약된 응답과 요청 기록 초기화
+ *
+ * 사용법:
+ *   npm run mock:claude                      # 기본 포트 4010
+ *   ANTHROPIC_BASE_URL=http://localhost:4010 node src/index.js
+ *
+ * SDK는 ANTHROPIC_BASE_URL 환경 변수를 읽으므로 액션 코드 변경 없이 모의 서버로 연결됩니다.
+ *
+ * 예약 항목 형식 (/__mock/enqueue 본문은 항목 하나 또는 배열):
+ *   { "text": "{\"summary\":...}" }                         ← 응답 본문 텍스트
+ *   { "error": "overloaded_error", "status": 529 }         ← 오류 응답
+ *   { "error": "rate_limit_error", "status": 429, "retryAfter": 1 }
+ *   { "delayMs": 2000, "text": "..." }                     ← 지연 후 응답 (타임아웃 테스트용)
+ *   { "text": "...", "disconnectAfter": 300 }              ← 스트리밍 중 텍스트 300자를 보낸 뒤 연결 끊기
+ */
+
+const http = require('http');
+const crypto = require('crypto');
+
+// 예약된 응답이 없을 때 돌려줄 기본 리뷰
+const DEFAULT_REVIEW = {
+  summary: 'Mock review',
+  issues: [{
+    line: 1,
+    severity: 'medium',
+    type: 'maintainability',
+    title: 'Mock finding',
+    description: 'Returned by the mock Claude server',
+    suggestion: 'No action needed'
+  }],
+  overall_score: 7
+};
+
+// 오류 타입별 기본 상태 코드 (Anthropic API 오류 형식과 동일)
+const ERROR_STATUS = {
+  invalid_request_error: 400,
+  authentication_error: 401,
+  permission_error: 403,
+  not_found_error: 404,
+  request_too_large: 413,
+  rate_limit_error: 429,
+  api_error: 500,
+  overloaded_error: 529
+};
+
+// 스트리밍 시 텍스트를 나누는 크기
+const STREAM_CHUNK_SIZE = 64;
+
+class MockClaudeServer {
+  /**
+   * MockClaudeServer 생성자
+   * @param {Object} [options] - 옵션
+   * @param {string} [options.apiKey] - 지정하면 x-api-key 헤더 검증
+   * @param {string} [options.defaultText] - 예약이 없을 때의 응답 텍스트
+   */
+  constructor(options = {}) {
+    this.apiKey = options.apiKey || null;
+    this.defaultText = options.defaultText || JSON.stringify(DEFAULT_REVIEW);
+    this.queue = [];
+    this.requests = [];
+    this.server = null;
+  }
+
+  /**
+   * 응답/오류 예약
+   * @param {Object|Array<Object>} entries - 예약 항목
+   */
+  enqueue(entries) {
+    this.queue.push(...[].concat(entries));
+  }
+
+  /**
+   * 예약과 요청 기록 초기화
+   */
+  reset() {
+    this.queue = [];
+    this.requests = [];
+  }
+
+  /**
+   * HTTP 요청 처리
+   * @param {http.IncomingMessage} req - 요청
+   * @param {http.ServerResponse} res - 응답
+   */
+  async handleRequest(req, res) {
+    const rawBody = await readBody(req);
+    const url = new URL(req.url, 'http://localhost');
+
+    if (url.pathname === '/__mock/enqueue' && req.method === 'POST') {
+      this.enqueue(JSON.parse(rawBody || '[]'));
+      return sendJson(res, 200, { queued: this.queue.length });
+    }
+    if (url.pathname === '/__mock/requests' && req.method === 'GET') {
+      return sendJson(res, 200, this.requests);
+    }
+    if (url.pathname === '/__mock/reset' && req.method === 'POST') {
+      this.reset();
+      return sendJson(res, 200, { ok: true });
+    }
+    if (url.pathname === '/v1/models' && req.method === 'GET') {
+      if (this.apiKey && req.headers['x-api-key'] !== this.apiKey) {
+        return this.sendError(res, 'authentication_error', 'invalid x-api-key');
+      }
+      return sendJson(res, 200, { data: [{ type: 'model', id: 'claude-sonnet-4-20250514', display_name: 'Mock model' }], has_more: false });
+    }
+    if (url.pathname !== '/v1/messages' || req.method !== 'POST') {
+      return this.sendError(res, 'not_found_error', `Unknown endpoint: ${req.method} ${url.pathname}`);
+    }
+
+    return this.handleMessages(req, res, rawBody);
+  }
+
+  /**
+   * Messages API 요청 처리
+   * @param {http.IncomingMessage} req - 요청
+   * @param {http.ServerResponse} res - 응답
+   * @param {string} rawBody - 요청 본문
+   */
+  async handleMessages(req, res, rawBody) {
+    if (this.apiKey && req.headers['x-api-key'] !== this.apiKey) {
+      return this.sendError(res, 'authentication_error', 'invalid x-api-key');
+    }
+    if (!req.headers['anthropic-version']) {
+      return this.sendError(res, 'invalid_request_error', 'anthropic-version header is required');
+    }
+
+    let body;
+    try {
+      body = JSON.parse(rawBody);
+    } catch (error) {
+      return this.sendError(res, 'invalid_request_error', `Invalid JSON body: ${error.message}`);
+    }
+    if (!body.model || !body.max_tokens || !Array.isArray(body.messages)) {
+      return this.sendError(res, 'invalid_request_error', 'model, max_tokens and messages are required');
+    }
+
+    this.requests.push({ headers: req.headers, body });
+
+    const entry = this.queue.shift() || { text: this.defaultText };
+    if (entry.delayMs) {
+      await new Promise(resolve => setTimeout(resolve, entry.delayMs));
+    }
+    if (entry.error) {
+      return this.sendError(res, entry.error, entry.message || `Injected ${entry.error}`, entry);
+    }
+
+    const message = buildMessage(body, entry.text);
+    if (body.stream) {
+      return this.streamMessage(res, message, entry.disconnectAfter);
+    }
+    return sendJson(res, 200, message, { 'request-id': requestId() });
+  }
+
+  /**
+   * SSE 스트리밍 응답 (Messages API 스트림 이벤트 순서)
+   * @param {http.ServerResponse} res - 응답
+   * @param {Object} message - 완성된 메시지
+   * @param {number} [disconnectAfter] - 이 글자 수만큼 보낸 뒤 연결을 끊음 (스트림 중단 테스트용)
+   */
+  streamMessage(res, message, disconnectAfter) {
+    res.writeHead(200, {
+      'content-type': 'text/event-stream',
+      'cache-control': 'no-cache',
+      'request-id': requestId()
+    });
+
+    const send = (event, data) => res.write(`event: ${event}\ndata: ${JSON.stringify({ type: event, ...data })}\n\n`);
+    const text = message.content[0].text;
+
+    send('message_start', { message: { ...message, content: [], stop_reason: null, usage: { ...message.usage, output_tokens: 0 } } });
+    send('content_block_start', { index: 0, content_block: { type: 'text', text: '' } });
+    for (let offset = 0; offset < text.length; offset += STREAM_CHUNK_SIZE) {
+      if (disconnectAfter !== undefined && offset >= disconnectAfter) {
+        // 이미 쓴 청크는 보낸 뒤 종료 청크 없이 소켓을 닫아 SDK에는 본문이 잘린 것으로 보이게 함
+        res.socket.end();
+        return;
+      }
+      send('content_block_delta', { index: 0, delta: { type: 'text_delta', text: text.slice(offset, offset + STREAM_CHUNK_SIZE) } });
+    }
+    send('content_block_stop', { index: 0 });
+    send('message_delta', { delta: { stop_reason: message.stop_reason, stop_sequence: null }, usage: { output_tokens: message.usage.output_tokens } });
+    send('message_stop', {});
+    res.end();
+  }
+
+  /**
+   * Anthropic 형식 오류 응답
+   * @param {http.ServerResponse} res - 응답
+   * @param {string} type - 오류 타입
+   * @param {string} message - 오류 메시지
+   * @param {Object} [entry] - 예약 항목 (status, retryAfter)
+   */
+  sendError(res, type, message, entry = {}) {
+    const headers = { 'request-id': requestId() };
+    if (entry.retryAfter !== undefined) {
+      headers['retry-after'] = String(entry.retryAfter);
+    }
+    // SDK가 재시도하지 않도록 하려면 예약 항목에 shouldRetry: false 지정
+    if (entry.shouldRetry !== undefined) {
+      headers['x-should-retry'] = String(entry.shouldRetry);
+    }
+    sendJson(res, entry.status || ERROR_STATUS[type] || 500, { type: 'error', error: { type, message } }, headers);
+  }
+
+  /**
+   * 서버 시작
+   * @param {number} [port] - 수신 포트 (0이면 임의 포트)
+   * @returns {Promise<string>} 기본 URL (ANTHROPIC_BASE_URL로 사용)
+   */
+  listen(port = 0) {
+    this.server = http.createServer((req, res) => {
+      this.handleRequest(req, res).catch(error => {
+        if (!res.headersSent) {
+          this.sendError(res, 'api_error', error.message);
+        }
+      });
+    });
+
+    return new Promise(resolve => {
+      this.server.listen(port, '127.0.0.1', () => resolve(`http://127.0.0.1:${this.server.address().port}`));
+    });
+  }
+
+  /**
+   * 서버 종료
+   * @returns {Promise<void>}
+   */
+  close() {
+    return new Promise(resolve => (this.server ? this.server.close(() => resolve()) : resolve()));
+  }
+}
+
+/**
+ * 요청 본문 읽기
+ * @param {http.IncomingMessage} req - 요청
+ * @returns {Promise<string>} 본문
+ */
+function readBody(req) {
+  return new Promise((resolve, reject) => {
+    const chunks = [];
+    req.on('data', chunk => chunks.push(chunk));
+    req.on('end', () => resolve(Buffer.concat(chunks).toString('utf8')));
+    req.on('error', reject);
+  });
+}
+
+/**
+ * JSON 응답 전송
+ * @param {http.ServerResponse} res - 응답
+ * @param {number} status - 상태 코드
+ * @param {Object} data - 본문
+ * @param {Object} [headers] - 추가 헤더
+ */
+function sendJson(res, status, data, headers = {}) {
+  res.writeHead(status, { 'content-type': 'application/json', ...headers });
+  res.end(JSON.stringify(data));
+}
+
+/**
+ * Messages API 응답 객체 생성
+ * @param {Object} body - 요청 본문
+ * @param {string} text - 응답 텍스트
+ * @returns {Object} 메시지
+ */
+function buildMessage(body, text) {
+  // 토큰 수는 대략 4자 = 1토큰으로 추정
+  const inputChars = JSON.stringify(body.messages).length + JSON.stringify(body.system || '').length;
+  return {
+    id: `msg_mock_${crypto.randomBytes(8).toString('hex')}`,
+    type: 'message',
+    role: 'assistant',
+    model: body.model,
+    content: [{ type: 'text', text }],
+    stop_reason: 'end_turn',
+    stop_sequence: null,
+    usage: {
+      input_tokens: Math.ceil(inputChars / 4),
+      output_tokens: Math.ceil(text.length / 4)
+    }
+  };
+}
+
+/**
+ * 모의 request-id 생성
+ * @returns {string} request-id
+ */
+function requestId() {
+  return `req_mock_${crypto.randomBytes(8).toString('hex')}`;
+}
+
+// 직접 실행 시 독립 서버로 동작
+if (require.main === module) {
+  const server = new MockClaudeServer({ apiKey: process.env.MOCK_CLAUDE_API_KEY });
+  server.listen(parseInt(process.env.PORT || '4010', 10)).then(url => {
+    console.log(`Mock Claude server listening on ${url}`);
+  });
+}
+
+module.exports = MockClaudeServer;
+module.exports.DEFAULT_REVIEW = DEFAULT_REVIEW;
@@ -0,0 +1,145 @@
+#!/usr/bin/env node
+
+/**
+ * Prompt Golden Test
+ * 고정된 입력(fixture)으로 리뷰 프롬프트를 렌더링해 저장된 기대값(golden)과 비교하는 스크립트
+ *
+ * 프롬프트 리팩터링이 모델에 보내는 내용을 몰래 바꾸지 못하도록 합니다.
+ *
+ * 사용법:
+ *   npm run test:prompts               # golden과 비교 (다르면 exit 1)
+ *   npm run test:prompts -- --update   # 현재 렌더링 결과로 golden 갱신
+ *   npm run test:prompts -- full-en    # 특정 케이스만 실행
+ *
+ * 케이스 구조 (test/fixtures/prompts/<케이스>/):
+ * - case.json     filename, reviewType, language, maxIssuesPerFile, options, glossary, memory (과거 사례 목록),
+ *                 candidates (리뷰 패스 모드의 중재 후보 이슈), i18n (i18n 리뷰의 프레임워크와 카탈로그),
+ *                 workspace (모노레포 패키지 목록 packages와 변경 파일 changedFiles),
+ *                 templates (저장소 프롬프트 템플릿 { system, user }),
+ *                 changedFiles (PR의 변경 파일 목록, 테스트 누락 리뷰의 변경 테스트 파일 안내용),
+ *                 license (컴플라이언스 리뷰의 저장소 라이선스 { license, source, header }),
+ *                 customTypes (저장소에 정의한 리뷰 타입 { 이름: 프롬프트 }),
+ *                 instructions (설정 파일 paths 섹션의 팀별 리뷰 규칙)
+ * - content.txt   파일 내용
+ * - diff.patch    Git diff (선택)
+ * - prompt.golden 기대 프롬프트 (--update로 생성)
+ */
+
+const fs = require('fs');
+const path = require('path');
+const CodeReviewer = require('../src/code-reviewer');
+const Glossary = require('../src/glossary');
+const ReviewMemory = require('../src/review-memory');
+const I18nCatalog = require('../src/i18n-catalog');
+const WorkspacePackages = require('../src/workspace-packages');
+const PromptTemplates = require('../src/prompt-templates');
+const LicensePolicy = require('../src/license-policy');
+const CustomReviewTypes = require('../src/custom-review-types');
+const { compareGolden } = require('./golden');
+
+const FIXTURES_DIR = path.join(__dirname, 'fixtures', 'prompts');
+const GOLDEN_FILE = 'prompt.golden';
+
+/**
+ * 케이스의 시스템 프롬프트와 사용자 프롬프트 렌더링
+ * @param {string} caseDir - 케이스 디렉터리
+ * @returns {string} 렌더링 결과
+ */
+function renderCase(caseDir) {
+  const config = JSON.parse(fs.readFileSync(path.join(caseDir, 'case.json'), 'utf8'));
+  const content = fs.readFileSync(path.join(caseDir, 'content.txt'), 'utf8');
+  const diffPath = path.join(caseDir, 'diff.patch');
+  const diff = fs.existsSync(diffPath) ? fs.readFileSync(diffPath, 'utf8') : '';
+
+  const options = { ...config.options };
+  if (config.glossary) {
+    options.glossary = new Glossary(config.glossary);
+  }
+  if (config.memory) {
+    options.memory = new ReviewMemory(config.memory);
+  }
+  if (config.i18n) {
+    options.i18nCatalog = new I18nCatalog(config.i18n);
+  }
+  if (config.workspace) {
+    options.packageScope = new WorkspacePackages(config.workspace.packages);
+    options.packageScope.setChangedFiles(config.workspace.changedFiles || []);
+  }
+  if (config.templates) {
+    options.promptTemplates = new PromptTemplates(config.templates);
+  }
+  if (config.license) {
+    options.licensePolicy = new LicensePolicy(config.license);
+  }
+  if (config.customTypes) {
+    options.customTypes = new CustomReviewTypes(config.customTypes);
+  }
+  const reviewer = new CodeReviewer('golden-test-key', config.language || 'en', config.maxIssuesPerFile || 3, options);
+  if (config.changedFiles) {
+    reviewer.setChangedFiles(config.changedFiles);
+  }
+
+  const sections = [`=== system ===\n${reviewer.getSystemPrompt()}`];
+
+  // 리뷰 패스 모드는 패스별 프롬프트와 후보 이슈(candidates)로 만든 중재 프롬프트를 렌더링
+  if (reviewer.passes.length > 0) {
+    reviewer.passes.forEach(pass => {
+      const prompt = reviewer.buildPrompt(config.filename, content, diff, config.reviewType || 'full', null, pass, config.instructions);
+      sections.push(`=== user (pass: ${pass}) ===\n${prompt}`);
+    });
+    sections.push(`=== user (arbitration) ===\n${reviewer.buildArbitrationPrompt(config.filename, content, config.candidates || [])}`);
+    return `${sections.join('\n\n')}\n`;
+  }
+
+  // 멀티 에이전트 모드는 페르소나마다 프롬프트를 따로 보내므로 모두 렌더링
+  const personas = reviewer.personas.length > 0 ? reviewer.personas : [null];
+  personas.forEach(persona => {
+    const prompt = reviewer.buildPrompt(config.filename, content, diff, config.reviewType || 'full', persona, null, config.instructions);
+    sections.push(`=== user${persona ? ` (persona: ${persona})` : ''} ===\n${prompt}`);
+  });
+
+  return `${sections.join('\n\n')}\n`;
+}
+
+function main(args) {
+  const update = args.includes('--update') || args.includes('-update');
+  const only = args.filter(arg => !arg.startsWith('-'));
+  const cases = fs.readdirSync(FIXTURES_DIR)
+    .filter(name => fs.statSync(path.join(FIXTURES_DIR, name)).isDirectory())
+    .filter(name => only.length === 0 || only.includes(name))
+    .sort();
+
+  let failed = 0;
+  cases.forEach(name => {
+    const caseDir = path.join(FIXTURES_DIR, name);
+    const result = compareGolden(path.join(caseDir, GOLDEN_FILE), renderCase(caseDir), update);
+
+    if (result.status === 'updated') {
+      console.log(`updated  ${name}`);
+    } else if (result.status === 'ok') {
+      console.log(`ok       ${name}`);
+    } else if (result.status === 'missing') {
+      failed++;
+      console.log(`MISSING  ${name} (run with --update to create ${GOLDEN_FILE})`);
+    } else {
+      failed++;
+      console.log(`FAIL     ${name}\n${result.detail}`);
+    }
+  });
+
+  if (cases.length === 0) {
+    console.log('No prompt fixtures matched');
+    return 1;
+  }
+  if (failed > 0) {
+    console.log(`\n${failed} of ${cases.length} prompt goldens differ. If the change is intended, rerun with --update and review the golden diff.`);
+    return 1;
+  }
+  return 0;
+}
+
+if (require.main === module) {
+  process.exitCode = main(process.argv.slice(2));
+}
+
+module.exports = { renderCase };
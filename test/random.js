@@ -0,0 +1,22 @@
+/**
+ * Seeded Random
+ * 테스트 스크립트가 같은 시드로 같은 입력을 재현하기 위한 난수 생성기
+ */
+
+/**
+ * 시드 기반 난수 생성기 (mulberry32)
+ * @param {number} seed - 시드
+ * @returns {Function} 0 이상 1 미만 난수를 반환하는 함수
+ */
+function createRandom(seed) {
+  let state = seed >>> 0;
+  return () => {
+    state = (state + 0x6D2B79F5) >>> 0;
+    let t = state;
+    t = Math.imul(t ^ (t >>> 15), t | 1);
+    t ^= t + Math.imul(t ^ (t >>> 7), t | 61);
+    return ((t ^ (t >>> 14)) >>> 0) / 4294967296;
+  };
+}
+
+module.exports = { createRandom };
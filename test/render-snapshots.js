@@ -0,0 +1,177 @@
+#!/usr/bin/env node
+
+/**
+ * Renderer Snapshot Test
+ * 같은 리뷰 결과를 모든 출력 형식(PR 댓글, 인라인 댓글, JSON 리포트, 텍스트, 이메일, 채팅 알림)으로 렌더링해
+ * 저장된 스냅샷과 비교하는 스크립트
+ *
+ * 사용법:
+ *   npm run test:renders                    # 스냅샷과 비교 (다르면 exit 1)
+ *   npm run test:renders -- --update        # 현재 렌더링 결과로 스냅샷 갱신
+ *   npm run test:renders -- zero-findings   # 특정 케이스만 실행
+ *
+ * 케이스 구조 (test/fixtures/renders/<케이스>/):
+ * - case.json        results (파일별 리뷰 결과), overallScore, runMetadata, reviewTypes (경로별 리뷰 타입의 파일 수),
+ *                    commits (커밋별 리뷰의 [{ sha, subject }], 이슈의 commit 값으로 커밋 섹션 구성)
+ *                    risk (RiskScorer.score() 결과 { score, level, factors }, 댓글 헤더의 위험도)
+ * - <렌더러>.snap    렌더러별 스냅샷 (--update로 생성)
+ */
+
+const fs = require('fs');
+const path = require('path');
+const CommentManager = require('../src/comment-manager');
+const EmailNotifier = require('../src/email-notifier');
+const SlackNotifier = require('../src/slack-notifier');
+const TeamsNotifier = require('../src/teams-notifier');
+const DiscordNotifier = require('../src/discord-notifier');
+const { buildReviewSummary } = require('../src/review-summary');
+const { buildJsonReport, buildFindingsList } = require('../src/json-report');
+const { buildSarifReport } = require('../src/sarif-report');
+const { buildJobSummary } = require('../src/job-summary');
+const { buildHtmlReport } = require('../src/html-report');
+const { buildJunitReport } = require('../src/junit-report');
+const { renderPlainText } = require('../src/plain-text-renderer');
+const { compareGolden } = require('./golden');
+
+const FIXTURES_DIR = path.join(__dirname, 'fixtures', 'renders');
+
+// 렌더러에 포함되는 현재 시각을 고정 (스냅샷이 실행 시각에 따라 바뀌지 않도록)
+const FIXED_NOW = '2026-01-15T09:30:00.000Z';
+
+const CONTEXT = {
+  eventName: 'pull_request',
+  sha: '9f2c1e7a4b5d6c8e0f1a2b3c4d5e6f708192a3b4',
+  repo: { owner: 'octo-org', repo: 'widgets' },
+  payload: { pull_request: { number: 42, title: 'Add checkout flow', html_url: 'https://github.com/octo-org/widgets/pull/42' } }
+};
+
+const WEBHOOK_URL = 'https://hooks.example.com/snapshot';
+
+// 렌더러: 스냅샷 파일 이름 → 렌더링 함수
+const RENDERERS = {
+  'comment-full.md': (results, summary, config) => comment(results, config, 'full'),
+  'comment-top.md': (results, summary, config) => comment(results, config, 'top'),
+  'comment-summary.md': (results, summary, config) => comment(results, config, 'summary'),
+  'inline.json': results => json(new CommentManager('snapshot-token', CONTEXT).buildInlineComments(results)),
+  'report.json': (results, summary) => json(buildJsonReport(summary)),
+  'findings.json': (results, summary) => json(buildFindingsList(summary)),
+  'sarif.json': (results, summary) => json(buildSarifReport(summary)),
+  'job-summary.md': (results, summary, config) => buildJobSummary({ summary, filesReviewed: config.totalFiles || results.length }),
+  'report.html': (results, summary) => buildHtmlReport(summary, { generatedAt: FIXED_NOW }),
+  'junit.xml': (results, summary) => buildJunitReport(summary),
+  'plain.txt': (results, summary) => renderPlainText(summary),
+  'email.html': (results, summary) => new EmailNotifier({ smtp: null, from: 'review@example.com', to: ['team@example.com'] }).buildHtml(summary),
+  'slack.json': (results, summary) => json(new SlackNotifier({ webhookUrl: WEBHOOK_URL, interactive: true }).buildMessage(summary)),
+  'teams.json': (results, summary) => json(new TeamsNotifier({ webhookUrl: WEBHOOK_URL }).buildMessage(summary)),
+  'discord.json': (results, summary) => json(new DiscordNotifier({ webhookUrl: WEBHOOK_URL }).buildMessage(summary))
+};
+
+/**
+ * PR 댓글 렌더링
+ * @param {Array} results - 리뷰 결과
+ * @param {Object} config - 케이스 설정
+ * @param {string} verbosity - 댓글 상세도
+ * @returns {string} 댓글 본문
+ */
+function comment(results, config, verbosity) {
+  return new CommentManager('snapshot-token', CONTEXT).buildCommentBody(results, {
+    totalFiles: config.totalFiles || results.length,
+    totalIssues: results.reduce((sum, result) => sum + result.issues.length, 0),
+    reviewType: 'full',
+    reviewTypes: config.reviewTypes || {},
+    runMetadata: config.runMetadata || null,
+    overallScore: config.overallScore,
+    risk: config.risk || null,
+    commitReviews: (config.commits || []).map(commit => ({
+      ...commit,
+      results: results
+        .map(result => ({ ...result, issues: result.issues.filter(issue => issue.commit === commit.sha) }))
+        .filter(result => result.issues.length > 0)
+    })),
+    verbosity
+  });
+}
+
+/**
+ * JSON 직렬화 (스냅샷 비교용)
+ * @param {Object} data - 데이터
+ * @returns {string} 들여쓰기된 JSON
+ */
+function json(data) {
+  return JSON.stringify(data, null, 2);
+}
+
+/**
+ * 현재 시각을 고정한 채로 함수 실행
+ * @param {Function} fn - 실행할 함수
+ * @returns {*} 함수 결과
+ */
+function withFixedTime(fn) {
+  const RealDate = Date;
+  global.Date = class extends RealDate {
+    constructor(...args) {
+      super(...(args.length > 0 ? args : [FIXED_NOW]));
+    }
+
+    static now() {
+      return new RealDate(FIXED_NOW).getTime();
+    }
+  };
+  try {
+    return fn();
+  } finally {
+    global.Date = RealDate;
+  }
+}
+
+function main(args) {
+  const update = args.includes('--update');
+  const only = args.filter(arg => !arg.startsWith('-'));
+  const cases = fs.readdirSync(FIXTURES_DIR)
+    .filter(name => fs.statSync(path.join(FIXTURES_DIR, name)).isDirectory())
+    .filter(name => only.length === 0 || only.includes(name))
+    .sort();
+
+  let checked = 0;
+  let failed = 0;
+  cases.forEach(name => {
+    const caseDir = path.join(FIXTURES_DIR, name);
+    const config = JSON.parse(fs.readFileSync(path.join(caseDir, 'case.json'), 'utf8'));
+    const summary = buildReviewSummary(config.results, CONTEXT, config.runMetadata || null);
+
+    Object.entries(RENDERERS).forEach(([file, render]) => {
+      checked++;
+      const actual = `${withFixedTime(() => render(config.results, summary, config))}\n`;
+      const result = compareGolden(path.join(caseDir, `${file}.snap`), actual, update);
+      const label = `${name}/${file}`;
+
+      if (result.status === 'updated') {
+        console.log(`updated  ${label}`);
+      } else if (result.status === 'ok') {
+        console.log(`ok       ${label}`);
+      } else if (result.status === 'missing') {
+        failed++;
+        console.log(`MISSING  ${label} (run with --update to create the snapshot)`);
+      } else {
+        failed++;
+        console.log(`FAIL     ${label}\n${result.detail}`);
+      }
+    });
+  });
+
+  if (cases.length === 0) {
+    console.log('No render fixtures matched');
+    return 1;
+  }
+  if (failed > 0) {
+    console.log(`\n${failed} of ${checked} snapshots differ. If the output change is intended, rerun with --update and review the snapshot diff.`);
+    return 1;
+  }
+  return 0;
+}
+
+if (require.main === module) {
+  process.exitCode = main(process.argv.slice(2));
+}
+
+module.exports = { RENDERERS };
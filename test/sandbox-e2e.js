@@ -0,0 +1,287 @@
+#!/usr/bin/env node
+
+/**
+ * Sandbox Repository End-to-End Test
+ * 임시 저장소를 만들어 픽스처 파일로 PR을 열고, 액션을 실행해 실제로 작성된 댓글을 검증한 뒤 저장소를 삭제하는 스크립트
+ *
+ * 모의 객체로는 확인할 수 없는 GitHub 쪽 동작(권한, 댓글 작성, 페이로드 형식)을 자동으로 검증합니다.
+ * 실제 GitHub 저장소를 만들고 지우므로 CI에서는 수동 실행(workflow_dispatch)이나 야간 작업으로만 사용하세요.
+ *
+ * 사용법:
+ *   GITHUB_TOKEN=... npm run test:e2e                         # 토큰 사용자 계정에 임시 저장소 생성
+ *   GITHUB_TOKEN=... npm run test:e2e -- --owner my-sandbox-org
+ *   GITHUB_TOKEN=... ANTHROPIC_API_KEY=... npm run test:e2e -- --live-claude
+ *
+ * 옵션:
+ *   --owner <org>          저장소를 만들 조직 (기본: 토큰 사용자)
+ *   --live-claude          모의 서버 대신 실제 Claude API 사용 (ANTHROPIC_API_KEY 필요)
+ *   --keep                 검증 후 저장소를 삭제하지 않음 (실패 분석용)
+ *   --input <key=value>    액션 입력값 (여러 번 지정 가능)
+ *
+ * 토큰 권한: repo, delete_repo 스코프 (조직에 만들 때는 해당 조직의 저장소 생성 권한)
+ * GitHub Enterprise Server는 GITHUB_API_URL, GITHUB_SERVER_URL 환경 변수로 지정합니다.
+ */
+
+const fs = require('fs');
+const os = require('os');
+const path = require('path');
+const assert = require('assert');
+const crypto = require('crypto');
+const { execFileSync } = require('child_process');
+const MockClaudeServer = require('./mock-claude-server');
+const { generateFiles, parseArgs: parseFixtureArgs } = require('./genfixtures');
+const { readInputDefaults } = require('../scripts/simulate');
+
+const API_URL = (process.env.GITHUB_API_URL || 'https://api.github.com').replace(/\/+$/, '');
+const SERVER_URL = (process.env.GITHUB_SERVER_URL || 'https://github.com').replace(/\/+$/, '');
+const SUMMARY_MARKER = '<!-- claude-review:summary -->';
+const HEAD_BRANCH = 'sandbox/fixtures';
+
+/**
+ * 명령행 옵션 파싱
+ * @param {Array<string>} args - 명령행 인자
+ * @returns {Object} 옵션
+ */
+function parseArgs(args) {
+  const options = { owner: null, liveClaude: false, keep: false, inputs: {} };
+  for (let i = 0; i < args.length; i++) {
+    switch (args[i]) {
+      case '--owner': options.owner = args[++i]; break;
+      case '--live-claude': options.liveClaude = true; break;
+      case '--keep': options.keep = true; break;
+      case '--input': {
+        const value = args[++i] || '';
+        const separator = value.indexOf('=');
+        if (separator === -1) {
+          throw new Error(`Invalid --input "${value}" (expected key=value)`);
+        }
+        options.inputs[value.substring(0, separator)] = value.substring(separator + 1);
+        break;
+      }
+      default:
+        throw new Error(`Unknown option ${args[i]}`);
+    }
+  }
+  return options;
+}
+
+/**
+ * GitHub REST API 요청
+ * @param {string} token - GitHub 토큰
+ * @param {string} method - HTTP 메서드
+ * @param {string} apiPath - API 경로
+ * @param {Object} [body] - 요청 본문
+ * @returns {Promise<*>} 응답 본문
+ */
+async function api(token, method, apiPath, body) {
+  const response = await fetch(`${API_URL}${apiPath}`, {
+    method,
+    headers: {
+      accept: 'application/vnd.github+json',
+      authorization: `Bearer ${token}`,
+      'content-type': 'application/json',
+      'user-agent': 'claude-code-review-action-e2e'
+    },
+    body: body === undefined ? undefined : JSON.stringify(body)
+  });
+  const text = await response.text();
+  if (!response.ok) {
+    throw new Error(`${method} ${apiPath} failed (HTTP ${response.status}): ${text.substring(0, 300)}`);
+  }
+  return text ? JSON.parse(text) : null;
+}
+
+/**
+ * git 명령 실행 (토큰이 출력에 드러나지 않도록 오류 메시지에서 가림)
+ * @param {string} cwd - 작업 디렉터리
+ * @param {Array<string>} args - git 인자
+ * @param {string} token - 가릴 토큰
+ */
+function git(cwd, args, token) {
+  try {
+    execFileSync('git', args, { cwd, stdio: ['ignore', 'pipe', 'pipe'] });
+  } catch (error) {
+    const message = String(error.stderr || error.message).split(token).join('***');
+    throw new Error(`git ${args[0]} failed: ${message}`);
+  }
+}
+
+/**
+ * 로컬 체크아웃 준비 후 기본 브랜치와 픽스처 브랜치 푸시
+ * 액션은 작업 디렉터리에서 파일을 읽고 HEAD~1..HEAD diff를 사용하므로 픽스처를 커밋 하나로 만듭니다.
+ * @param {string} dir - 체크아웃 디렉터리
+ * @param {Object} repo - 생성된 저장소
+ * @param {string} token - GitHub 토큰
+ * @returns {Array<string>} 픽스처 파일 경로
+ */
+function pushFixtures(dir, repo, token) {
+  const remote = `${SERVER_URL.replace('://', `://x-access-token:${token}@`)}/${repo.full_name}.git`;
+  const identity = ['-c', 'user.name=claude-review-e2e', '-c', 'user.email=e2e@example.com'];
+
+  git(dir, ['init', '--initial-branch', repo.default_branch || 'main'], token);
+  fs.writeFileSync(path.join(dir, 'README.md'), '# Sandbox\n\nTemporary repository created by test/sandbox-e2e.js\n');
+  git(dir, ['add', '-A'], token);
+  git(dir, [...identity, 'commit', '-m', 'Initial commit'], token);
+  git(dir, ['push', remote, 'HEAD'], token);
+
+  const files = generateFiles(parseFixtureArgs(['--files', '3', '--lines', '40', '--languages', 'js:2,py:1', '--density', '3', '--seed', '11']));
+  git(dir, ['checkout', '-b', HEAD_BRANCH], token);
+  files.forEach(file => {
+    fs.mkdirSync(path.dirname(path.join(dir, file.filename)), { recursive: true });
+    fs.writeFileSync(path.join(dir, file.filename), `${file.lines.join('\n')}\n`);
+  });
+  git(dir, ['add', '-A'], token);
+  git(dir, [...identity, 'commit', '-m', 'Add fixture files'], token);
+  git(dir, ['push', remote, HEAD_BRANCH], token);
+  return files.map(file => file.filename);
+}
+
+/**
+ * 액션 실행 (액션 모듈은 환경 변수를 로드 시점에 읽으므로 설정 후 로드)
+ * @param {Object} options - 옵션
+ * @param {Object} repo - 저장소
+ * @param {Object} pull - PR
+ * @param {string} checkout - 체크아웃 디렉터리
+ * @param {string} token - GitHub 토큰
+ * @param {string|null} mockUrl - 모의 Claude 서버 주소
+ */
+async function runAction(options, repo, pull, checkout, token, mockUrl) {
+  const tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'claude-review-e2e-run-'));
+  const eventPath = path.join(tempDir, 'event.json');
+  fs.writeFileSync(eventPath, JSON.stringify({ action: 'opened', number: pull.number, pull_request: pull, repository: repo, sender: pull.user }));
+
+  Object.assign(process.env, {
+    GITHUB_API_URL: API_URL,
+    GITHUB_SERVER_URL: SERVER_URL,
+    GITHUB_EVENT_PATH: eventPath,
+    GITHUB_EVENT_NAME: 'pull_request',
+    GITHUB_REPOSITORY: repo.full_name,
+    GITHUB_SHA: pull.head.sha,
+    GITHUB_RUN_ID: 'sandbox-e2e',
+    GITHUB_OUTPUT: path.join(tempDir, 'outputs'),
+    GITHUB_STEP_SUMMARY: path.join(tempDir, 'step-summary.md'),
+    RUNNER_TEMP: tempDir
+  });
+  fs.writeFileSync(process.env.GITHUB_OUTPUT, '');
+  fs.writeFileSync(process.env.GITHUB_STEP_SUMMARY, '');
+  if (mockUrl) {
+    process.env.ANTHROPIC_BASE_URL = mockUrl;
+  }
+
+  const inputs = {
+    ...readInputDefaults(),
+    github_token: token,
+    anthropic_api_key: mockUrl ? 'sandbox-e2e-key' : process.env.ANTHROPIC_API_KEY,
+    ...options.inputs
+  };
+  Object.entries(inputs).forEach(([name, value]) => {
+    process.env[`INPUT_${name.replace(/ /g, '_').toUpperCase()}`] = value;
+  });
+
+  const previousDir = process.cwd();
+  process.chdir(checkout);
+  try {
+    await require('../src/index')();
+  } finally {
+    process.chdir(previousDir);
+  }
+  if (process.exitCode) {
+    throw new Error('The action reported a failure (see the log above)');
+  }
+}
+
+/**
+ * PR에 작성된 댓글 검증
+ * @param {string} token - GitHub 토큰
+ * @param {Object} repo - 저장소
+ * @param {Object} pull - PR
+ * @param {Array<string>} filenames - 픽스처 파일 경로
+ * @param {MockClaudeServer|null} mock - 모의 서버
+ */
+async function assertComments(token, repo, pull, filenames, mock) {
+  const comments = await api(token, 'GET', `/repos/${repo.full_name}/issues/${pull.number}/comments?per_page=100`);
+  const summaries = comments.filter(comment => comment.body.includes(SUMMARY_MARKER));
+  assert.strictEqual(summaries.length, 1, `expected exactly one summary comment, found ${summaries.length}`);
+
+  const body = summaries[0].body;
+  filenames.forEach(filename => assert.ok(body.includes(filename), `summary comment does not mention ${filename}`));
+  if (mock) {
+    assert.strictEqual(mock.requests.length, filenames.length, `expected one Claude request per file, got ${mock.requests.length}`);
+    assert.ok(body.includes('Mock finding'), 'summary comment does not include the mock finding');
+  }
+}
+
+async function main(args) {
+  const options = parseArgs(args);
+  const token = process.env.GITHUB_TOKEN;
+  if (!token) {
+    console.log('GITHUB_TOKEN with repo and delete_repo scopes is required');
+    return 1;
+  }
+  if (options.liveClaude && !process.env.ANTHROPIC_API_KEY) {
+    console.log('--live-claude requires ANTHROPIC_API_KEY');
+    return 1;
+  }
+
+  const name = `claude-review-e2e-${Date.now()}-${crypto.randomBytes(3).toString('hex')}`;
+  const checkout = fs.mkdtempSync(path.join(os.tmpdir(), 'claude-review-e2e-'));
+  const mock = options.liveClaude ? null : new MockClaudeServer();
+  let repo = null;
+  let failure = null;
+
+  try {
+    const mockUrl = mock ? await mock.listen() : null;
+    repo = await api(token, 'POST', options.owner ? `/orgs/${options.owner}/repos` : '/user/repos', {
+      name,
+      private: true,
+      description: 'Temporary repository for claude-code-review-action end-to-end tests',
+      auto_init: false
+    });
+    console.log(`▶️  Created ${repo.html_url}`);
+
+    const filenames = pushFixtures(checkout, repo, token);
+    const pull = await api(token, 'POST', `/repos/${repo.full_name}/pulls`, {
+      title: 'Sandbox fixture PR',
+      head: HEAD_BRANCH,
+      base: repo.default_branch || 'main',
+      body: 'Opened by test/sandbox-e2e.js'
+    });
+    console.log(`▶️  Opened ${pull.html_url} with ${filenames.length} files`);
+
+    await runAction(options, repo, pull, checkout, token, mockUrl);
+    await assertComments(token, repo, pull, filenames, mock);
+  } catch (error) {
+    failure = error;
+  } finally {
+    if (mock) {
+      await mock.close();
+    }
+    if (repo && !options.keep) {
+      try {
+        await api(token, 'DELETE', `/repos/${repo.full_name}`);
+        console.log(`🧹 Deleted ${repo.full_name}`);
+      } catch (error) {
+        console.log(`⚠️  Could not delete ${repo.html_url}, delete it manually: ${error.message}`);
+      }
+    } else if (repo) {
+      console.log(`📌 Kept ${repo.html_url}`);
+    }
+    fs.rmSync(checkout, { recursive: true, force: true });
+  }
+
+  if (failure) {
+    console.log(`\n❌ Sandbox end-to-end test failed: ${failure.message}`);
+    return 1;
+  }
+  console.log('\n✅ Sandbox end-to-end test passed');
+  return 0;
+}
+
+if (require.main === module) {
+  main(process.argv.slice(2)).then(code => {
+    process.exitCode = code;
+  }, error => {
+    console.log(error.stack);
+    process.exitCode = 1;
+  });
+}
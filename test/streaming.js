@@ -0,0 +1,91 @@
+#!/usr/bin/env node
+
+/**
+ * Streaming Test
+ * 모의 Claude 서버로 스트리밍 응답 경로(CodeReviewer.streamMessage)를 실제 SDK와 함께 실행해 확인하는 스크립트
+ *
+ * 시나리오:
+ * - complete      SSE 이벤트로 메시지를 조립하고 토큰 사용량과 request-id를 기록
+ * - disconnect    스트림 중간에 연결이 끊겨도 완성된 이슈와 사용량은 복구
+ * - overloaded    스트림 시작 전 과부하 오류는 대체 모델로 재시도
+ *
+ * 사용법:
+ *   npm run test:streaming
+ */
+
+const assert = require('assert');
+const MockClaudeServer = require('./mock-claude-server');
+const CodeReviewer = require('../src/code-reviewer');
+
+// 스트리밍 청크(64자)보다 충분히 긴 응답 (이슈 3개, 모델처럼 json 코드 블록으로 감쌈)
+const REVIEW = {
+  summary: 'Streaming review',
+  issues: [1, 2, 3].map(line => ({
+    line,
+    severity: 'high',
+    type: 'bug',
+    title: `Streamed finding ${line}`,
+    description: 'Returned in several SSE chunks by the mock server',
+    suggestion: 'No action needed'
+  })),
+  overall_score: 6
+};
+const REVIEW_TEXT = '```json\n' + JSON.stringify(REVIEW) + '\n```';
+
+const SCENARIOS = {
+  async complete(server, reviewer) {
+    server.enqueue({ text: REVIEW_TEXT });
+    const review = await reviewer.requestReview('src/app.js', 'Review this file');
+    assert.deepStrictEqual(review.issues.map(issue => issue.title), REVIEW.issues.map(issue => issue.title));
+    assert.strictEqual(server.requests[0].body.stream, true, 'request should use stream: true');
+    assert.ok(reviewer.usage.inputTokens > 0 && reviewer.usage.outputTokens > 0, 'usage should come from message_start and message_delta');
+  },
+
+  async disconnect(server, reviewer) {
+    // 두 번째 이슈 중간에서 연결을 끊음
+    const cut = REVIEW_TEXT.indexOf('Streamed finding 2') + 64;
+    server.enqueue({ text: REVIEW_TEXT, disconnectAfter: cut - (cut % 64) });
+    const review = await reviewer.requestReview('src/app.js', 'Review this file');
+    assert.deepStrictEqual(review.issues.map(issue => issue.title), ['Streamed finding 1']);
+    assert.ok(reviewer.usage.inputTokens > 0, 'salvaged stream should record usage from message_start');
+  },
+
+  async overloaded(server, reviewer) {
+    // SDK 자체 재시도(maxRetries 2)까지 모두 과부하로 응답
+    server.enqueue([1, 2, 3].map(() => ({ error: 'overloaded_error', status: 529, retryAfter: 0 })));
+    server.enqueue({ text: REVIEW_TEXT });
+    reviewer.fallbackModels = ['claude-3-5-haiku-20241022'];
+    const review = await reviewer.requestReview('src/app.js', 'Review this file');
+    assert.strictEqual(review.issues.length, REVIEW.issues.length);
+    assert.deepStrictEqual(reviewer.fallbacks.map(fallback => fallback.reason), ['overloaded']);
+  }
+};
+
+async function main() {
+  const server = new MockClaudeServer();
+  process.env.ANTHROPIC_BASE_URL = await server.listen();
+  let failed = 0;
+
+  try {
+    for (const [name, scenario] of Object.entries(SCENARIOS)) {
+      server.reset();
+      const reviewer = new CodeReviewer('sk-ant-mock', 'en', 3);
+      try {
+        await scenario(server, reviewer);
+        console.log(`ok       ${name}`);
+      } catch (error) {
+        failed++;
+        console.log(`FAIL     ${name}\n${error.stack}`);
+      }
+    }
+  } finally {
+    await server.close();
+  }
+  return failed > 0 ? 1 : 0;
+}
+
+if (require.main === module) {
+  main().then(code => {
+    process.exitCode = code;
+  });
+}